@@ -0,0 +1,85 @@
+// Package xAuth 已认证主体（Principal）及其在上下文中的传递。
+//
+// 认证中间件（如 xMiddle.Auth）校验令牌后将 [Principal] 写入请求上下文，之后：
+//   - 处理器通过 [FromContext] 读取，*gin.Context 与 c.Request.Context() 均可
+//   - xAsync 启动的异步任务继承父上下文中的 Principal
+//   - 日志自动附带 subject 字段，便于按用户检索
+//
+// 该包位于 common 层，插件无需依赖 major 即可读取当前主体。
+package xAuth
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	xCtx "github.com/bamboo-services/bamboo-base-go/defined/context"
+)
+
+// Principal 已认证主体
+//
+// 由认证中间件构造后只读，请勿在处理器中修改；需要派生时先 [Principal.Clone]。
+type Principal struct {
+	Subject   string         // 主体标识，通常为用户 ID
+	TokenID   string         // 令牌唯一标识（jti），用于吊销
+	Issuer    string         // 签发方
+	Audience  []string       // 受众
+	Roles     []string       // 角色
+	Data      map[string]any // 自定义声明
+	IssuedAt  time.Time      // 签发时间
+	ExpiresAt time.Time      // 过期时间
+}
+
+// HasRole 判断主体是否拥有指定角色
+func (p *Principal) HasRole(role string) bool {
+	return p != nil && slices.Contains(p.Roles, role)
+}
+
+// Clone 返回主体的深拷贝
+func (p *Principal) Clone() *Principal {
+	if p == nil {
+		return nil
+	}
+	clone := *p
+	clone.Audience = slices.Clone(p.Audience)
+	clone.Roles = slices.Clone(p.Roles)
+	if p.Data != nil {
+		clone.Data = make(map[string]any, len(p.Data))
+		for k, v := range p.Data {
+			clone.Data[k] = v
+		}
+	}
+	return &clone
+}
+
+// WithPrincipal 返回携带主体的子上下文，principal 为 nil 时原样返回 ctx
+func WithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	if principal == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, xCtx.PrincipalKey, principal)
+}
+
+// FromContext 返回 ctx 中的已认证主体
+//
+// 同时支持以字符串键存储值的上下文（如 *gin.Context 通过 c.Set 写入）。
+func FromContext(ctx context.Context) (*Principal, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+	if p, ok := ctx.Value(xCtx.PrincipalKey).(*Principal); ok && p != nil {
+		return p, true
+	}
+	if p, ok := ctx.Value(xCtx.PrincipalKey.String()).(*Principal); ok && p != nil {
+		return p, true
+	}
+	return nil, false
+}
+
+// Subject 返回 ctx 中已认证主体的标识，未认证时返回空字符串
+func Subject(ctx context.Context) string {
+	if p, ok := FromContext(ctx); ok {
+		return p.Subject
+	}
+	return ""
+}
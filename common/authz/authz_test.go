@@ -0,0 +1,189 @@
+package xAuthz
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	xAuth "github.com/bamboo-services/bamboo-base-go/common/auth"
+)
+
+// memoryCache 测试用判定缓存
+type memoryCache struct {
+	mu    sync.Mutex
+	items map[string]Verdict
+}
+
+func (c *memoryCache) Get(_ context.Context, key string) (Verdict, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	verdict, ok := c.items[key]
+	return verdict, ok, nil
+}
+
+func (c *memoryCache) Set(_ context.Context, key string, verdict Verdict, _ time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.items[key] = verdict
+	return nil
+}
+
+var testPolicy = Policy{Roles: []Role{
+	{Name: "viewer", Rules: []Rule{{Resource: "order:**", Actions: []string{"read"}}}},
+	{Name: "editor", Inherits: []string{"viewer"}, Rules: []Rule{
+		{Resource: "order:*", Actions: []string{"update"}, Condition: "owner"},
+	}},
+	{Name: "admin", Rules: []Rule{
+		{Resource: "**", Actions: []string{"*"}},
+		{Resource: "audit:**", Actions: []string{"delete"}, Effect: EffectDeny},
+	}},
+}}
+
+// owner 测试条件：资源 id 与主体一致
+func owner(_ context.Context, req Request) bool {
+	return req.Attributes["id"] == req.Principal.Subject
+}
+
+// TestMatchResource 验证单段与多段通配。
+func TestMatchResource(t *testing.T) {
+	cases := []struct {
+		pattern, resource string
+		want              bool
+	}{
+		{"order:*", "order:1", true},
+		{"order:*", "order", false},
+		{"order:*", "order:1:item", false},
+		{"order:**", "order", true},
+		{"order:**", "order:1:item", true},
+		{"order:*:item", "order:1:item", true},
+		{"**:item", "order:1:item", true},
+		{"**", "anything:at:all", true},
+		{"order:1", "order:2", false},
+	}
+	for _, c := range cases {
+		if got := MatchResource(c.pattern, c.resource); got != c.want {
+			t.Errorf("MatchResource(%q, %q) = %v, want %v", c.pattern, c.resource, got, c.want)
+		}
+	}
+}
+
+// TestPolicy_Validate 验证未知继承、继承环与非法模式。
+func TestPolicy_Validate(t *testing.T) {
+	if err := testPolicy.Validate(); err != nil {
+		t.Fatalf("合法策略校验失败: %v", err)
+	}
+	invalid := []Policy{
+		{Roles: []Role{{Name: "a", Inherits: []string{"missing"}}}},
+		{Roles: []Role{{Name: "a", Inherits: []string{"b"}}, {Name: "b", Inherits: []string{"a"}}}},
+		{Roles: []Role{{Name: "a", Rules: []Rule{{Resource: "order:x*", Actions: []string{"read"}}}}}},
+		{Roles: []Role{{Name: "a", Rules: []Rule{{Resource: "order", Actions: []string{"read"}, Effect: "maybe"}}}}},
+		{Roles: []Role{{Name: "a"}, {Name: "a"}}},
+	}
+	for i, p := range invalid {
+		if err := p.Validate(); err == nil {
+			t.Errorf("第 %d 个非法策略应校验失败", i+1)
+		}
+	}
+
+	if _, err := New(context.Background(), Static(testPolicy)); err == nil {
+		t.Error("引用未注册条件的策略应加载失败")
+	}
+}
+
+// TestEnforcer_Authorize 验证角色继承、条件、拒绝优先与错误类型。
+func TestEnforcer_Authorize(t *testing.T) {
+	ctx := context.Background()
+	enforcer, err := New(ctx, Static(testPolicy), WithCondition("owner", owner))
+	if err != nil {
+		t.Fatal(err)
+	}
+	editor := &xAuth.Principal{Subject: "7", Roles: []string{"editor"}}
+	admin := &xAuth.Principal{Subject: "1", Roles: []string{"admin"}}
+
+	cases := map[string]struct {
+		principal *xAuth.Principal
+		need      Requirement
+		attrs     map[string]string
+		want      error
+	}{
+		"inherited read":   {editor, Require("order:{id}", "read"), map[string]string{"id": "9"}, nil},
+		"owner update":     {editor, Require("order:{id}", "update"), map[string]string{"id": "7"}, nil},
+		"not owner":        {editor, Require("order:{id}", "update"), map[string]string{"id": "9"}, ErrResourceDenied},
+		"no grant":         {editor, Require("order:{id}", "delete"), map[string]string{"id": "7"}, ErrPermissionDenied},
+		"admin wildcard":   {admin, Require("user:1", "delete"), nil, nil},
+		"admin deny":       {admin, Require("audit:log:1", "delete"), nil, ErrResourceDenied},
+		"inherited role":   {editor, RequireRole("viewer"), nil, nil},
+		"missing role":     {editor, RequireRole("admin"), nil, ErrRoleDenied},
+		"unauthenticated":  {nil, Require("order:1", "read"), nil, ErrUnauthenticated},
+		"undeclared role":  {&xAuth.Principal{Roles: []string{"vip"}}, RequireRole("vip"), nil, nil},
+		"undeclared perms": {&xAuth.Principal{Roles: []string{"vip"}}, Require("order:1", "read"), nil, ErrPermissionDenied},
+	}
+	for name, c := range cases {
+		if err := enforcer.Authorize(ctx, c.principal, c.need, c.attrs); !errors.Is(err, c.want) || (c.want == nil && err != nil) {
+			t.Errorf("%s: Authorize = %v, want %v", name, err, c.want)
+		}
+	}
+}
+
+// TestEnforcer_Cache 验证仅缓存不依赖条件的结果，且策略变更后版本变化。
+func TestEnforcer_Cache(t *testing.T) {
+	ctx := context.Background()
+	cache := &memoryCache{items: map[string]Verdict{}}
+	policy := testPolicy
+	enforcer, err := New(ctx, SourceFunc(func(context.Context) (Policy, error) { return policy, nil }),
+		WithCondition("owner", owner), WithDecisionCache(cache, time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	editor := &xAuth.Principal{Subject: "7", Roles: []string{"editor"}}
+
+	_ = enforcer.Authorize(ctx, editor, Require("order:1", "read"), nil)
+	_ = enforcer.Authorize(ctx, editor, Require("order:{id}", "update"), map[string]string{"id": "7"})
+	if len(cache.items) != 1 {
+		t.Fatalf("应仅缓存无条件判定，实际 %d 条", len(cache.items))
+	}
+
+	version := enforcer.Version()
+	policy = Policy{Roles: []Role{{Name: "editor"}}}
+	if err = enforcer.Reload(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if enforcer.Version() == version {
+		t.Fatal("策略变更后版本应变化")
+	}
+	if err = enforcer.Authorize(ctx, editor, Require("order:1", "read"), nil); !errors.Is(err, ErrPermissionDenied) {
+		t.Errorf("策略变更后不应命中旧缓存，实际 %v", err)
+	}
+}
+
+// TestEnforcer_PlaceholderInjection 验证属性值不能通过 ':' 或 '*' 改变资源分段以绕过拒绝规则。
+func TestEnforcer_PlaceholderInjection(t *testing.T) {
+	ctx := context.Background()
+	policy := Policy{Roles: []Role{{Name: "user", Rules: []Rule{
+		{Resource: "order:**", Actions: []string{"read"}},
+		{Resource: "order:42", Actions: []string{"read"}, Effect: EffectDeny},
+	}}}}
+	enforcer, err := New(ctx, Static(policy))
+	if err != nil {
+		t.Fatal(err)
+	}
+	user := &xAuth.Principal{Subject: "7", Roles: []string{"user"}}
+	need := Require("order:{id}", "read")
+
+	if err = enforcer.Authorize(ctx, user, need, map[string]string{"id": "42"}); !errors.Is(err, ErrResourceDenied) {
+		t.Fatalf("order:42 应命中拒绝规则，实际 %v", err)
+	}
+	for _, id := range []string{"42:x", "42:", "*", ""} {
+		if err = enforcer.Authorize(ctx, user, need, map[string]string{"id": id}); !errors.Is(err, ErrResourceDenied) {
+			t.Errorf("id=%q 应被拒绝，实际 %v", id, err)
+		}
+	}
+	if err = enforcer.Authorize(ctx, user, need, nil); !errors.Is(err, ErrResourceDenied) {
+		t.Errorf("缺失占位符属性应被拒绝，实际 %v", err)
+	}
+	if err = enforcer.Authorize(ctx, user, need, map[string]string{"id": "43"}); err != nil {
+		t.Errorf("order:43 应允许: %v", err)
+	}
+}
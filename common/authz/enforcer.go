@@ -0,0 +1,404 @@
+package xAuthz
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	xAuth "github.com/bamboo-services/bamboo-base-go/common/auth"
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xLog "github.com/bamboo-services/bamboo-base-go/common/log"
+)
+
+// 判定错误，均可通过 errors.Is 判断，[ErrorCode] 给出对应的错误码
+var (
+	ErrUnauthenticated  = errors.New("未认证")
+	ErrRoleDenied       = errors.New("角色被拒绝")
+	ErrPermissionDenied = errors.New("权限不足")
+	ErrResourceDenied   = errors.New("资源访问被拒绝")
+)
+
+// DefaultDecisionTTL 默认判定结果缓存时间
+const DefaultDecisionTTL = 5 * time.Minute
+
+// ErrorCode 将判定错误映射为错误码，未知错误返回 xError.ServerInternalError。
+func ErrorCode(err error) *xError.ErrorCode {
+	switch {
+	case errors.Is(err, ErrUnauthenticated):
+		return xError.Unauthorized
+	case errors.Is(err, ErrRoleDenied):
+		return xError.RoleDenied
+	case errors.Is(err, ErrPermissionDenied):
+		return xError.PermissionDenied
+	case errors.Is(err, ErrResourceDenied):
+		return xError.ResourceDenied
+	default:
+		return xError.ServerInternalError
+	}
+}
+
+// Request 一次授权判定的输入，传递给 [Condition]
+type Request struct {
+	Principal  *xAuth.Principal  // 已认证主体
+	Resource   string            // 已解析占位符的资源
+	Action     string            // 动作
+	Attributes map[string]string // 请求属性，如 HTTP 路由参数；可能为 nil
+}
+
+// Condition 具名属性条件，返回 true 表示规则对本次请求生效
+type Condition func(ctx context.Context, req Request) bool
+
+// Requirement 路由或服务方法声明的访问要求
+//
+// Roles 与 Resource/Action 可单独或同时声明，同时声明时先校验角色再校验权限。
+type Requirement struct {
+	Roles    []string // 需拥有其中任一角色（含继承得到的角色）
+	Resource string   // 资源，可包含 {name} 占位符，由请求属性中的同名值替换
+	Action   string   // 动作
+}
+
+// Require 声明对资源执行动作的权限要求，如 Require("order:{id}", "delete")。
+func Require(resource, action string) Requirement {
+	return Requirement{Resource: resource, Action: action}
+}
+
+// RequireRole 声明需拥有 roles 中任一角色。
+func RequireRole(roles ...string) Requirement {
+	return Requirement{Roles: roles}
+}
+
+// resolve 以请求属性替换资源中的 {name} 占位符
+//
+// 被引用的属性缺失、为空或包含 ':'、'*' 时返回 false：这类值会改变资源的分段结构，
+// 如 id 为 "42:x" 时 "order:{id}" 不再命中 "order:42" 上的拒绝规则，却仍命中 "order:**" 的允许规则。
+func (r Requirement) resolve(attrs map[string]string) (string, bool) {
+	if !strings.Contains(r.Resource, "{") {
+		return r.Resource, true
+	}
+	pairs := make([]string, 0, len(attrs)*2)
+	for k, v := range attrs {
+		placeholder := "{" + k + "}"
+		if !strings.Contains(r.Resource, placeholder) {
+			continue
+		}
+		if v == "" || strings.ContainsAny(v, ":*") {
+			return "", false
+		}
+		pairs = append(pairs, placeholder, v)
+	}
+	resource := strings.NewReplacer(pairs...).Replace(r.Resource)
+	return resource, !strings.Contains(resource, "{")
+}
+
+// Verdict 可缓存的权限判定结果
+type Verdict uint8
+
+const (
+	VerdictAllow            Verdict = iota + 1 // 允许
+	VerdictPermissionDenied                    // 无规则授予该动作
+	VerdictResourceDenied                      // 拒绝规则命中或条件不满足
+)
+
+// err 返回判定结果对应的错误，允许时为 nil
+func (v Verdict) err() error {
+	switch v {
+	case VerdictAllow:
+		return nil
+	case VerdictResourceDenied:
+		return ErrResourceDenied
+	default:
+		return ErrPermissionDenied
+	}
+}
+
+// DecisionCache 判定结果缓存，键已包含策略版本，策略变更后旧结果自然失效
+type DecisionCache interface {
+	Get(ctx context.Context, key string) (Verdict, bool, error)
+	Set(ctx context.Context, key string, verdict Verdict, ttl time.Duration) error
+}
+
+// Option 是 [New] 的函数式选项。
+type Option func(*Enforcer)
+
+// WithCondition 注册具名条件，策略中 Rule.Condition 引用的条件必须先注册。
+func WithCondition(name string, condition Condition) Option {
+	return func(e *Enforcer) { e.conditions[name] = condition }
+}
+
+// WithDecisionCache 缓存不依赖条件的判定结果，ttl 小于等于 0 时使用 [DefaultDecisionTTL]。
+//
+// 通常传入 xCache.DecisionCacheOf 构造的缓存，使用 Redis 后端时多实例共享判定结果。
+func WithDecisionCache(cache DecisionCache, ttl time.Duration) Option {
+	return func(e *Enforcer) {
+		e.cache = cache
+		if ttl > 0 {
+			e.cacheTTL = ttl
+		}
+	}
+}
+
+// compiled 编译后的策略
+type compiled struct {
+	version  string
+	closures map[string][]string // 角色 -> 自身及全部继承角色
+	rules    map[string][]Rule   // 角色 -> 自身规则
+}
+
+// Enforcer 授权判定器，并发安全
+type Enforcer struct {
+	source     Source
+	conditions map[string]Condition
+	cache      DecisionCache
+	cacheTTL   time.Duration
+	state      atomic.Pointer[compiled]
+	log        *xLog.LogNamedLogger
+}
+
+// New 构造授权判定器并立即从 source 加载策略，加载或校验失败时返回错误。
+//
+// 使用示例：
+//
+//	enforcer, err := xAuthz.New(ctx, xAuthzSource.FromConfig(cfg, "authz"),
+//	    xAuthz.WithCondition("owner", func(ctx context.Context, req xAuthz.Request) bool {
+//	        return req.Attributes["id"] == req.Principal.Subject
+//	    }),
+//	    xAuthz.WithDecisionCache(xCache.DecisionCacheOf(cacheManager, "authz"), 0),
+//	)
+func New(ctx context.Context, source Source, opts ...Option) (*Enforcer, error) {
+	if source == nil {
+		return nil, errors.New("xAuthz: 策略来源不能为 nil")
+	}
+	e := &Enforcer{
+		source:     source,
+		conditions: make(map[string]Condition),
+		cacheTTL:   DefaultDecisionTTL,
+		log:        xLog.WithName(xLog.NamedPERM),
+	}
+	for _, o := range opts {
+		if o != nil {
+			o(e)
+		}
+	}
+	if err := e.Reload(ctx); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// Reload 重新从来源加载策略并原子替换；失败时保留当前策略。
+//
+// 策略版本为策略内容的摘要，内容不变时版本不变，已缓存的判定结果继续有效。
+func (e *Enforcer) Reload(ctx context.Context) error {
+	policy, err := e.source.Load(ctx)
+	if err != nil {
+		return fmt.Errorf("加载授权策略失败: %w", err)
+	}
+	if err = policy.Validate(); err != nil {
+		return fmt.Errorf("授权策略校验失败: %w", err)
+	}
+	state, err := e.compile(policy)
+	if err != nil {
+		return fmt.Errorf("授权策略校验失败: %w", err)
+	}
+	e.state.Store(state)
+	e.log.Info(ctx, "授权策略已加载", slog.String("version", state.version), slog.Int("roles", len(policy.Roles)))
+	return nil
+}
+
+// Version 返回当前策略版本
+func (e *Enforcer) Version() string {
+	return e.state.Load().version
+}
+
+// compile 展开角色继承并计算策略版本
+func (e *Enforcer) compile(policy Policy) (*compiled, error) {
+	state := &compiled{
+		closures: make(map[string][]string, len(policy.Roles)),
+		rules:    make(map[string][]Rule, len(policy.Roles)),
+	}
+	inherits := make(map[string][]string, len(policy.Roles))
+	var errs []error
+	for _, role := range policy.Roles {
+		inherits[role.Name] = role.Inherits
+		state.rules[role.Name] = role.Rules
+		for _, rule := range role.Rules {
+			if _, ok := e.conditions[rule.Condition]; rule.Condition != "" && !ok {
+				errs = append(errs, fmt.Errorf("角色 %q 引用了未注册的条件 %q", role.Name, rule.Condition))
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	// Validate 已保证继承无环
+	var expand func(name string, seen map[string]bool)
+	expand = func(name string, seen map[string]bool) {
+		if seen[name] {
+			return
+		}
+		seen[name] = true
+		for _, parent := range inherits[name] {
+			expand(parent, seen)
+		}
+	}
+	for name := range inherits {
+		seen := make(map[string]bool)
+		expand(name, seen)
+		closure := make([]string, 0, len(seen))
+		for role := range seen {
+			closure = append(closure, role)
+		}
+		sort.Strings(closure)
+		state.closures[name] = closure
+	}
+
+	content, err := json.Marshal(policy)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(content)
+	state.version = hex.EncodeToString(sum[:8])
+	return state, nil
+}
+
+// Authorize 判定主体是否满足访问要求，满足时返回 nil。
+//
+// 判定顺序：
+//   - principal 为 nil：[ErrUnauthenticated]
+//   - 声明了 Roles 且主体（含继承）不具备其中任一角色：[ErrRoleDenied]
+//   - 命中拒绝规则，或仅有的允许规则条件不满足：[ErrResourceDenied]
+//   - 没有规则授予该动作：[ErrPermissionDenied]
+//   - 资源占位符引用的属性缺失、为空或包含 ':'、'*'：[ErrResourceDenied]
+//
+// attrs 用于替换 Requirement.Resource 中的占位符并传递给条件。被拒绝的请求以 PERM 日志记录审计信息。
+func (e *Enforcer) Authorize(ctx context.Context, principal *xAuth.Principal, need Requirement, attrs map[string]string) error {
+	resource, valid := need.resolve(attrs)
+	if principal == nil {
+		return e.deny(ctx, principal, nil, resource, need.Action, ErrUnauthenticated)
+	}
+
+	state := e.state.Load()
+	roles := state.expand(principal.Roles)
+	if len(need.Roles) > 0 && !slices.ContainsFunc(need.Roles, func(role string) bool { return slices.Contains(roles, role) }) {
+		return e.deny(ctx, principal, roles, resource, need.Action, ErrRoleDenied)
+	}
+	if need.Resource == "" && need.Action == "" {
+		return nil
+	}
+	if !valid {
+		return e.deny(ctx, principal, roles, need.Resource, need.Action, ErrResourceDenied)
+	}
+
+	req := Request{Principal: principal, Resource: resource, Action: need.Action, Attributes: attrs}
+	verdict := e.decide(ctx, state, roles, req)
+	if err := verdict.err(); err != nil {
+		return e.deny(ctx, principal, roles, resource, need.Action, err)
+	}
+	return nil
+}
+
+// decide 优先读取缓存，未命中时求值并写回可缓存的结果；缓存异常仅记录告警
+func (e *Enforcer) decide(ctx context.Context, state *compiled, roles []string, req Request) Verdict {
+	if e.cache == nil {
+		verdict, _ := state.evaluate(ctx, e.conditions, roles, req)
+		return verdict
+	}
+
+	sum := sha256.Sum256([]byte(strings.Join([]string{state.version, strings.Join(roles, ","), req.Resource, req.Action}, "|")))
+	key := hex.EncodeToString(sum[:16])
+	if verdict, ok, err := e.cache.Get(ctx, key); err != nil {
+		e.log.Warn(ctx, "读取授权判定缓存失败", slog.Any("error", err))
+	} else if ok {
+		return verdict
+	}
+
+	verdict, cacheable := state.evaluate(ctx, e.conditions, roles, req)
+	if cacheable {
+		if err := e.cache.Set(ctx, key, verdict, e.cacheTTL); err != nil {
+			e.log.Warn(ctx, "写入授权判定缓存失败", slog.Any("error", err))
+		}
+	}
+	return verdict
+}
+
+// deny 记录审计日志并返回判定错误
+func (e *Enforcer) deny(ctx context.Context, principal *xAuth.Principal, roles []string, resource, action string, err error) error {
+	var subject string
+	if principal != nil {
+		subject = principal.Subject
+	}
+	e.log.Warn(ctx, "访问被拒绝",
+		slog.String("subject", subject),
+		slog.Any("roles", roles),
+		slog.String("resource", resource),
+		slog.String("action", action),
+		slog.String("reason", err.Error()),
+	)
+	return err
+}
+
+// expand 返回主体角色及其继承角色的有序去重集合，策略中未声明的角色保留自身
+func (s *compiled) expand(roles []string) []string {
+	seen := make(map[string]bool, len(roles))
+	for _, role := range roles {
+		if closure, ok := s.closures[role]; ok {
+			for _, r := range closure {
+				seen[r] = true
+			}
+			continue
+		}
+		seen[role] = true
+	}
+	result := make([]string, 0, len(seen))
+	for role := range seen {
+		result = append(result, role)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// evaluate 对角色规则求值，cacheable 表示结果不依赖条件
+func (s *compiled) evaluate(ctx context.Context, conditions map[string]Condition, roles []string, req Request) (verdict Verdict, cacheable bool) {
+	var allowed, denied, conditional, conditionFailed bool
+	for _, role := range roles {
+		for _, rule := range s.rules[role] {
+			if !rule.matches(req.Resource, req.Action) {
+				continue
+			}
+			if rule.Condition != "" {
+				conditional = true
+				if !conditions[rule.Condition](ctx, req) {
+					conditionFailed = conditionFailed || rule.Effect != EffectDeny
+					continue
+				}
+			}
+			if rule.Effect == EffectDeny {
+				denied = true
+			} else {
+				allowed = true
+			}
+		}
+	}
+
+	switch {
+	case denied:
+		verdict = VerdictResourceDenied
+	case allowed:
+		verdict = VerdictAllow
+	case conditionFailed:
+		verdict = VerdictResourceDenied
+	default:
+		verdict = VerdictPermissionDenied
+	}
+	return verdict, !conditional
+}
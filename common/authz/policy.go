@@ -0,0 +1,201 @@
+// Package xAuthz 基于角色（RBAC）与属性条件（ABAC）的授权策略引擎。
+//
+// 策略由若干 [Role] 组成，每个角色包含一组 [Rule]，规则描述对某类资源的某些动作允许或拒绝，
+// 并可引用具名 [Condition] 基于主体与请求属性做细粒度判定；角色可继承其他角色的规则。
+//
+// 资源以冒号分段，如 "order:1001"、"article:draft:42"，规则中的资源模式支持：
+//   - "*"：匹配恰好一段，如 "order:*" 匹配 "order:1001"，不匹配 "order" 与 "order:1001:item"
+//   - "**"：匹配零或多段，如 "order:**" 匹配 "order"、"order:1001" 与 "order:1001:item"；单独的 "**" 匹配全部资源
+//
+// 动作为任意字符串，"*" 匹配全部动作。判定时拒绝规则优先于允许规则。
+//
+// 策略通过 [Source] 加载，可来自代码（[Static]）、配置文件或数据库（见 xAuthzSource），
+// 由 [Enforcer] 编译后供 xMiddle.Authorize 与 gRPC Authorize 拦截器使用。
+//
+// 该包位于 common 层，插件无需依赖 major 即可完成授权判定。
+package xAuthz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Effect 规则效果
+type Effect string
+
+const (
+	EffectAllow Effect = "allow" // 允许，规则未声明效果时的默认值
+	EffectDeny  Effect = "deny"  // 拒绝，优先于允许
+)
+
+// Rule 授权规则，描述对匹配资源的动作允许或拒绝
+type Rule struct {
+	Resource  string   `json:"resource" config:"resource"`             // 资源模式，支持 "*" 与 "**" 通配
+	Actions   []string `json:"actions" config:"actions"`               // 动作列表，"*" 表示全部
+	Effect    Effect   `json:"effect,omitempty" config:"effect"`       // 规则效果，为空时视为 EffectAllow
+	Condition string   `json:"condition,omitempty" config:"condition"` // 具名条件，需通过 WithCondition 注册；为空表示无条件
+}
+
+// Role 角色，拥有自身规则并继承 Inherits 中角色的全部规则
+type Role struct {
+	Name     string   `json:"name" config:"name"`                   // 角色名称，与 xAuth.Principal.Roles 对应
+	Inherits []string `json:"inherits,omitempty" config:"inherits"` // 继承的角色名称
+	Rules    []Rule   `json:"rules,omitempty" config:"rules"`       // 角色规则
+}
+
+// Policy 授权策略
+//
+// 配置文件示例（YAML）：
+//
+//	authz:
+//	  roles:
+//	    - name: viewer
+//	      rules:
+//	        - { resource: "order:**", actions: [read] }
+//	    - name: editor
+//	      inherits: [viewer]
+//	      rules:
+//	        - { resource: "order:*", actions: [update], condition: owner }
+//	    - name: admin
+//	      rules:
+//	        - { resource: "**", actions: ["*"] }
+//	        - { resource: "audit:**", actions: [delete], effect: deny }
+type Policy struct {
+	Roles []Role `json:"roles" config:"roles"`
+}
+
+// Validate 校验策略：角色名称唯一、继承的角色存在且无环、资源模式与规则效果合法。
+func (p Policy) Validate() error {
+	roles := make(map[string]Role, len(p.Roles))
+	var errs []error
+	for _, role := range p.Roles {
+		if role.Name == "" {
+			errs = append(errs, errors.New("角色名称不能为空"))
+			continue
+		}
+		if _, ok := roles[role.Name]; ok {
+			errs = append(errs, fmt.Errorf("角色 %q 重复声明", role.Name))
+			continue
+		}
+		roles[role.Name] = role
+		for i, rule := range role.Rules {
+			if err := rule.validate(); err != nil {
+				errs = append(errs, fmt.Errorf("角色 %q 的第 %d 条规则: %w", role.Name, i+1, err))
+			}
+		}
+	}
+
+	// 深度优先检测未知继承与继承环
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := make(map[string]int, len(roles))
+	var visit func(name string, path []string)
+	visit = func(name string, path []string) {
+		switch state[name] {
+		case visiting:
+			errs = append(errs, fmt.Errorf("角色继承存在环: %s", strings.Join(append(path, name), " -> ")))
+			return
+		case visited:
+			return
+		}
+		state[name] = visiting
+		for _, parent := range roles[name].Inherits {
+			if _, ok := roles[parent]; !ok {
+				errs = append(errs, fmt.Errorf("角色 %q 继承了未声明的角色 %q", name, parent))
+				continue
+			}
+			visit(parent, append(path, name))
+		}
+		state[name] = visited
+	}
+	for _, role := range p.Roles {
+		if _, ok := roles[role.Name]; ok {
+			visit(role.Name, nil)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// validate 校验单条规则
+func (r Rule) validate() error {
+	if r.Resource == "" {
+		return errors.New("资源模式不能为空")
+	}
+	for _, segment := range strings.Split(r.Resource, ":") {
+		if segment == "" {
+			return fmt.Errorf("资源模式 %q 包含空段", r.Resource)
+		}
+		if strings.Contains(segment, "*") && segment != "*" && segment != "**" {
+			return fmt.Errorf("资源模式 %q 的通配符必须独占一段", r.Resource)
+		}
+	}
+	if len(r.Actions) == 0 {
+		return errors.New("动作列表不能为空")
+	}
+	if r.Effect != "" && r.Effect != EffectAllow && r.Effect != EffectDeny {
+		return fmt.Errorf("未知的规则效果 %q", r.Effect)
+	}
+	return nil
+}
+
+// matches 判断规则是否覆盖 resource 上的 action
+func (r Rule) matches(resource, action string) bool {
+	actionMatched := false
+	for _, a := range r.Actions {
+		if a == "*" || a == action {
+			actionMatched = true
+			break
+		}
+	}
+	return actionMatched && MatchResource(r.Resource, resource)
+}
+
+// MatchResource 判断资源是否匹配模式，通配规则见包文档。
+func MatchResource(pattern, resource string) bool {
+	return matchSegments(strings.Split(pattern, ":"), strings.Split(resource, ":"))
+}
+
+// matchSegments 逐段匹配，"**" 回溯尝试吞掉零或多段
+func matchSegments(pattern, resource []string) bool {
+	for len(pattern) > 0 {
+		switch pattern[0] {
+		case "**":
+			for i := 0; i <= len(resource); i++ {
+				if matchSegments(pattern[1:], resource[i:]) {
+					return true
+				}
+			}
+			return false
+		case "*":
+			if len(resource) == 0 {
+				return false
+			}
+		default:
+			if len(resource) == 0 || pattern[0] != resource[0] {
+				return false
+			}
+		}
+		pattern, resource = pattern[1:], resource[1:]
+	}
+	return len(resource) == 0
+}
+
+// Source 策略来源
+type Source interface {
+	Load(ctx context.Context) (Policy, error)
+}
+
+// SourceFunc 将普通函数适配为 [Source]
+type SourceFunc func(ctx context.Context) (Policy, error)
+
+// Load 实现 [Source]
+func (f SourceFunc) Load(ctx context.Context) (Policy, error) { return f(ctx) }
+
+// Static 返回固定策略的来源，适用于代码内声明或测试
+func Static(policy Policy) Source {
+	return SourceFunc(func(context.Context) (Policy, error) { return policy, nil })
+}
@@ -47,7 +47,7 @@ func NewError(ctx context.Context, err *ErrorCode, errorMessage ErrMessage, thro
 // 参数 errorMessage 表示自定义错误消息，补充描述具体错误。
 // 参数 throw 表示是否抛出错误日志，为 true 时记录错误日志。
 // 参数 getErr 表示实际错误对象，用于包装具体错误。
-// 参数 data 表示错误相关的上下文数据，可选。
+// 参数 data 表示错误相关的上下文数据，可选；记录日志时按 xLog 的脱敏规则处理，不影响返回的 Data。
 //
 // 返回值为 *Error 类型，包含错误码、自定义消息、原始错误以及上下文数据。
 func NewErrorHasData(ctx context.Context, err *ErrorCode, errorMessage ErrMessage, throw bool, getErr error, data ...interface{}) *Error {
@@ -69,7 +69,7 @@ func NewErrorHasData(ctx context.Context, err *ErrorCode, errorMessage ErrMessag
 			slog.Int("code", int(err.Code)),
 			slog.String("message", newErr.ErrorMessage.String()),
 			slog.String("error", newErr.error.Error()),
-			slog.Any("data", newErr.Data),
+			slog.Any("data", xLog.Redacted(newErr.Data)),
 		)
 	}
 	return newErr
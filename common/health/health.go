@@ -0,0 +1,279 @@
+// Package xHealth 健康检查，汇总各组件的检查结果生成健康报告。
+//
+// 组件通过实现 [Checker]（或借助 [CheckFunc]）参与检查，由 [Registry] 统一调度：
+//   - 每项检查有独立的超时时间，超时或 panic 视为失败
+//   - 检查结果在 cacheTTL 内复用，避免探针高频请求压垮数据库与 Redis
+//   - 可选检查失败时报告为 degraded，不影响就绪状态
+//
+// HTTP 的 /healthz、/readyz 与 gRPC 健康服务共享同一个 [Registry]，保证两侧结论一致。
+// 该包位于 common 层，插件无需依赖 major 即可接入。
+package xHealth
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	xLifecycle "github.com/bamboo-services/bamboo-base-go/common/lifecycle"
+)
+
+const (
+	// DefaultTimeout 默认单项检查超时时间
+	DefaultTimeout = 3 * time.Second
+
+	// DefaultCacheTTL 默认检查结果缓存时间
+	DefaultCacheTTL = 2 * time.Second
+)
+
+// Checker 健康检查接口，检查失败时返回错误
+//
+// 注册节点返回的组件实例若实现了 Checker，会被自动登记为该节点的健康检查，参见 xRegNode.OnCheck。
+type Checker interface {
+	Check(ctx context.Context) error
+}
+
+// CheckFunc 函数形式的 [Checker]
+type CheckFunc func(ctx context.Context) error
+
+// Check 实现 [Checker]
+func (f CheckFunc) Check(ctx context.Context) error {
+	return f(ctx)
+}
+
+// Status 检查状态
+type Status string
+
+const (
+	StatusUp       Status = "up"       // 全部检查通过
+	StatusDegraded Status = "degraded" // 仅可选检查失败
+	StatusDown     Status = "down"     // 存在必需检查失败
+)
+
+// Result 单项检查结果
+type Result struct {
+	Name     string `json:"name"`               // 检查名称（通常为节点 ContextKey）
+	Status   Status `json:"status"`             // up / down
+	Optional bool   `json:"optional,omitempty"` // 是否为可选检查
+	Error    string `json:"error,omitempty"`    // 失败原因
+	Latency  string `json:"latency"`            // 检查耗时
+}
+
+// Report 健康报告
+type Report struct {
+	Status    Status    `json:"status"`     // 汇总状态
+	Ready     bool      `json:"ready"`      // 是否可接收流量：进程就绪且无必需检查失败
+	CheckedAt time.Time `json:"checked_at"` // 检查时间，命中缓存时为缓存生成时间
+	Checks    []Result  `json:"checks"`     // 各项检查结果，按注册顺序排列
+}
+
+// CheckOption 单项检查的配置
+type CheckOption func(check *check)
+
+// Optional 将检查标记为可选，失败时报告为 degraded 而不影响就绪状态
+func Optional() CheckOption {
+	return func(check *check) {
+		check.optional = true
+	}
+}
+
+// WithCheckTimeout 设置单项检查的超时时间，<= 0 时使用 [Registry] 的默认超时
+func WithCheckTimeout(timeout time.Duration) CheckOption {
+	return func(check *check) {
+		if timeout > 0 {
+			check.timeout = timeout
+		}
+	}
+}
+
+// check 已注册的检查项
+type check struct {
+	name     string
+	checker  Checker
+	optional bool
+	timeout  time.Duration
+}
+
+// Option [Registry] 的配置
+type Option func(registry *Registry)
+
+// WithTimeout 设置单项检查的默认超时时间，<= 0 时保持原值
+func WithTimeout(timeout time.Duration) Option {
+	return func(registry *Registry) {
+		if timeout > 0 {
+			registry.timeout = timeout
+		}
+	}
+}
+
+// WithCacheTTL 设置检查结果缓存时间，0 表示每次请求都重新检查
+func WithCacheTTL(ttl time.Duration) Option {
+	return func(registry *Registry) {
+		registry.cacheTTL = max(ttl, 0)
+	}
+}
+
+// Registry 健康检查注册表
+type Registry struct {
+	timeout  time.Duration
+	cacheTTL time.Duration
+
+	mu     sync.RWMutex
+	checks []check
+	cached *Report
+
+	runMu sync.Mutex // 串行化检查，并发请求共享同一次检查结果
+}
+
+// New 创建健康检查注册表
+func New(opts ...Option) *Registry {
+	registry := &Registry{timeout: DefaultTimeout, cacheTTL: DefaultCacheTTL}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(registry)
+		}
+	}
+	return registry
+}
+
+// Register 注册检查项，checker 为 nil 时忽略；同名检查项会被替换
+func (r *Registry) Register(name string, checker Checker, opts ...CheckOption) {
+	if checker == nil {
+		return
+	}
+	item := check{name: name, checker: checker}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&item)
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cached = nil
+	for i := range r.checks {
+		if r.checks[i].name == name {
+			r.checks[i] = item
+			return
+		}
+	}
+	r.checks = append(r.checks, item)
+}
+
+// Names 返回已注册的检查项名称，按注册顺序排列
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.checks))
+	for _, item := range r.checks {
+		names = append(names, item.name)
+	}
+	return names
+}
+
+// Check 执行全部检查并返回报告，cacheTTL 内重复调用直接返回缓存结果
+//
+// 各检查项并行执行，互不阻塞；Ready 同时要求进程处于就绪状态（xLifecycle.Ready）。
+// 检查结果会被缓存并共享给其他调用方，因此不继承 ctx 的取消，仅受各检查项超时约束，
+// 避免某个探针提前断开时把失败结果缓存给所有调用方。
+func (r *Registry) Check(ctx context.Context) Report {
+	if report, ok := r.fromCache(); ok {
+		return report
+	}
+
+	r.runMu.Lock()
+	defer r.runMu.Unlock()
+	if report, ok := r.fromCache(); ok {
+		return report
+	}
+
+	r.mu.RLock()
+	checks := append([]check(nil), r.checks...)
+	r.mu.RUnlock()
+
+	runCtx := context.WithoutCancel(ctx)
+	results := make([]Result, len(checks))
+	var wg sync.WaitGroup
+	for i, item := range checks {
+		wg.Add(1)
+		go func(i int, item check) {
+			defer wg.Done()
+			results[i] = r.run(runCtx, item)
+		}(i, item)
+	}
+	wg.Wait()
+
+	report := Report{Status: StatusUp, CheckedAt: time.Now(), Checks: results}
+	for _, result := range results {
+		if result.Status == StatusUp {
+			continue
+		}
+		if !result.Optional {
+			report.Status = StatusDown
+			break
+		}
+		report.Status = StatusDegraded
+	}
+
+	r.mu.Lock()
+	r.cached = &report
+	r.mu.Unlock()
+
+	report.Ready = report.Status != StatusDown && xLifecycle.Ready()
+	return report
+}
+
+// Ready 判断是否可接收流量：进程处于就绪状态且无必需检查失败
+func (r *Registry) Ready(ctx context.Context) bool {
+	if !xLifecycle.Ready() {
+		return false
+	}
+	return r.Check(ctx).Ready
+}
+
+// fromCache 返回未过期的缓存报告，Ready 按当前进程就绪状态重新计算
+func (r *Registry) fromCache() (Report, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.cached == nil || r.cacheTTL <= 0 || time.Since(r.cached.CheckedAt) >= r.cacheTTL {
+		return Report{}, false
+	}
+	report := *r.cached
+	report.Ready = report.Status != StatusDown && xLifecycle.Ready()
+	return report, true
+}
+
+// run 在超时时间内执行单项检查，panic 视为失败
+func (r *Registry) run(ctx context.Context, item check) Result {
+	timeout := item.timeout
+	if timeout <= 0 {
+		timeout = r.timeout
+	}
+	checkCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if rec := recover(); rec != nil {
+				done <- fmt.Errorf("检查 panic: %v", rec)
+			}
+		}()
+		done <- item.checker.Check(checkCtx)
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-checkCtx.Done():
+		err = fmt.Errorf("检查超时: %w", checkCtx.Err())
+	}
+
+	result := Result{Name: item.name, Status: StatusUp, Optional: item.optional, Latency: time.Since(start).String()}
+	if err != nil {
+		result.Status = StatusDown
+		result.Error = err.Error()
+	}
+	return result
+}
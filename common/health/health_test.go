@@ -0,0 +1,96 @@
+package xHealth
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	xLifecycle "github.com/bamboo-services/bamboo-base-go/common/lifecycle"
+)
+
+// TestCheck_Status 验证必需检查失败为 down、仅可选检查失败为 degraded。
+func TestCheck_Status(t *testing.T) {
+	xLifecycle.SetReady(true)
+	defer xLifecycle.SetReady(false)
+
+	registry := New(WithCacheTTL(0))
+	registry.Register("db", CheckFunc(func(context.Context) error { return nil }))
+	registry.Register("email", CheckFunc(func(context.Context) error { return errors.New("smtp down") }), Optional())
+
+	report := registry.Check(context.Background())
+	if report.Status != StatusDegraded || !report.Ready {
+		t.Fatalf("可选检查失败应为 degraded 且就绪: %+v", report)
+	}
+
+	registry.Register("db", CheckFunc(func(context.Context) error { return errors.New("ping failed") }))
+	report = registry.Check(context.Background())
+	if report.Status != StatusDown || report.Ready {
+		t.Fatalf("必需检查失败应为 down 且未就绪: %+v", report)
+	}
+	if len(report.Checks) != 2 || report.Checks[0].Error != "ping failed" {
+		t.Errorf("同名检查应被替换并保持注册顺序: %+v", report.Checks)
+	}
+}
+
+// TestCheck_Timeout 验证单项检查超时与 panic 视为失败。
+func TestCheck_Timeout(t *testing.T) {
+	registry := New(WithTimeout(time.Second), WithCacheTTL(0))
+	registry.Register("slow", CheckFunc(func(ctx context.Context) error {
+		<-ctx.Done()
+		time.Sleep(time.Second)
+		return nil
+	}), WithCheckTimeout(20*time.Millisecond))
+	registry.Register("panic", CheckFunc(func(context.Context) error { panic("boom") }))
+
+	start := time.Now()
+	report := registry.Check(context.Background())
+	if time.Since(start) > 500*time.Millisecond {
+		t.Errorf("超时检查应在单项超时后返回: %v", time.Since(start))
+	}
+	for _, result := range report.Checks {
+		if result.Status != StatusDown {
+			t.Errorf("检查 %s 应失败: %+v", result.Name, result)
+		}
+	}
+}
+
+// TestCheck_Cache 验证 cacheTTL 内复用检查结果，并按当前进程状态计算 Ready。
+func TestCheck_Cache(t *testing.T) {
+	var calls atomic.Int32
+	registry := New(WithCacheTTL(time.Minute))
+	registry.Register("db", CheckFunc(func(context.Context) error {
+		calls.Add(1)
+		return nil
+	}))
+
+	xLifecycle.SetReady(true)
+	registry.Check(context.Background())
+	xLifecycle.SetReady(false)
+	report := registry.Check(context.Background())
+	if calls.Load() != 1 {
+		t.Errorf("缓存期内不应重复检查: calls=%d", calls.Load())
+	}
+	if report.Ready {
+		t.Error("进程未就绪时 Ready 应为 false")
+	}
+}
+
+// TestCheck_CallerCancel 验证调用方取消 ctx 不会使检查失败并被缓存给其他调用方。
+func TestCheck_CallerCancel(t *testing.T) {
+	registry := New(WithCacheTTL(time.Minute))
+	registry.Register("db", CheckFunc(func(ctx context.Context) error {
+		time.Sleep(20 * time.Millisecond)
+		return ctx.Err()
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if report := registry.Check(ctx); report.Status != StatusUp {
+		t.Fatalf("调用方取消不应影响检查结果: %+v", report)
+	}
+	if report := registry.Check(context.Background()); report.Status != StatusUp {
+		t.Errorf("缓存的检查结果不应为失败: %+v", report)
+	}
+}
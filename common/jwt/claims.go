@@ -0,0 +1,76 @@
+package xJwt
+
+import (
+	"encoding/json"
+	"slices"
+	"time"
+
+	xAuth "github.com/bamboo-services/bamboo-base-go/common/auth"
+)
+
+// TokenType 令牌类型
+type TokenType string
+
+const (
+	TokenTypeAccess  TokenType = "access"  // 访问令牌，随每次请求携带
+	TokenTypeRefresh TokenType = "refresh" // 刷新令牌，仅用于换发新的令牌对
+)
+
+// Audience 受众，序列化时单个值输出为字符串，反序列化同时接受字符串与数组
+type Audience []string
+
+// MarshalJSON 实现 json.Marshaler
+func (a Audience) MarshalJSON() ([]byte, error) {
+	if len(a) == 1 {
+		return json.Marshal(a[0])
+	}
+	return json.Marshal([]string(a))
+}
+
+// UnmarshalJSON 实现 json.Unmarshaler
+func (a *Audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = Audience{single}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	*a = list
+	return nil
+}
+
+// Claims 令牌声明，时间字段为 Unix 秒（NumericDate）
+type Claims struct {
+	Issuer    string         `json:"iss,omitempty"`        // 签发方
+	Subject   string         `json:"sub,omitempty"`        // 主体标识，通常为用户 ID
+	Audience  Audience       `json:"aud,omitempty"`        // 受众
+	ExpiresAt int64          `json:"exp,omitempty"`        // 过期时间
+	NotBefore int64          `json:"nbf,omitempty"`        // 生效时间
+	IssuedAt  int64          `json:"iat,omitempty"`        // 签发时间
+	ID        string         `json:"jti,omitempty"`        // 令牌唯一标识，用于吊销
+	Type      TokenType      `json:"token_type,omitempty"` // 令牌类型
+	Roles     []string       `json:"roles,omitempty"`      // 角色
+	Data      map[string]any `json:"data,omitempty"`       // 自定义声明
+}
+
+// Principal 将声明转换为已认证主体
+func (c *Claims) Principal() *xAuth.Principal {
+	p := &xAuth.Principal{
+		Subject:  c.Subject,
+		TokenID:  c.ID,
+		Issuer:   c.Issuer,
+		Audience: slices.Clone([]string(c.Audience)),
+		Roles:    slices.Clone(c.Roles),
+		Data:     c.Data,
+	}
+	if c.IssuedAt > 0 {
+		p.IssuedAt = time.Unix(c.IssuedAt, 0)
+	}
+	if c.ExpiresAt > 0 {
+		p.ExpiresAt = time.Unix(c.ExpiresAt, 0)
+	}
+	return p
+}
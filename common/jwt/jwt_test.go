@@ -0,0 +1,208 @@
+package xJwt
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// memoryDenyList 测试用吊销列表
+type memoryDenyList struct {
+	mu  sync.Mutex
+	ids map[string]time.Time
+}
+
+func (d *memoryDenyList) Deny(_ context.Context, id string, until time.Time) (bool, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if _, ok := d.ids[id]; ok {
+		return false, nil
+	}
+	d.ids[id] = until
+	return true, nil
+}
+
+func (d *memoryDenyList) Denied(_ context.Context, id string) (bool, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	_, ok := d.ids[id]
+	return ok, nil
+}
+
+var testSecret = []byte("0123456789abcdef0123456789abcdef")
+
+// TestManager_Algorithms 验证三种算法的签发与校验。
+func TestManager_Algorithms(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, key := range []Key{HMACKey("hs", testSecret), RSAKey("rs", rsaKey), EdDSAKey("ed", edKey)} {
+		keys, err := NewKeySet(key)
+		if err != nil {
+			t.Fatalf("%s: %v", key.Algorithm(), err)
+		}
+		manager := New(keys, WithIssuer("bamboo"), WithAudience("api"))
+		token, err := manager.Issue(context.Background(), Claims{Subject: "1001", Roles: []string{"admin"}})
+		if err != nil {
+			t.Fatalf("%s: 签发失败: %v", key.Algorithm(), err)
+		}
+		claims, err := manager.Verify(context.Background(), token)
+		if err != nil {
+			t.Fatalf("%s: 校验失败: %v", key.Algorithm(), err)
+		}
+		principal := claims.Principal()
+		if principal.Subject != "1001" || !principal.HasRole("admin") || principal.TokenID == "" {
+			t.Errorf("%s: 主体不匹配: %+v", key.Algorithm(), principal)
+		}
+
+		tampered := token[:len(token)-2] + "AA"
+		if _, err := manager.Verify(context.Background(), tampered); !errors.Is(err, ErrTokenInvalid) {
+			t.Errorf("%s: 篡改签名应返回 ErrTokenInvalid，实际 %v", key.Algorithm(), err)
+		}
+	}
+}
+
+// TestManager_Rotation 验证轮换后旧令牌仍可校验，移除旧密钥后失效。
+func TestManager_Rotation(t *testing.T) {
+	keys, _ := NewKeySet(HMACKey("v1", testSecret))
+	manager := New(keys)
+	old, _ := manager.Issue(context.Background(), Claims{Subject: "1"})
+
+	if err := keys.Rotate(HMACKey("v2", []byte("fedcba9876543210fedcba9876543210"))); err != nil {
+		t.Fatal(err)
+	}
+	if keys.Current().ID() != "v2" {
+		t.Fatalf("当前密钥应为 v2，实际 %s", keys.Current().ID())
+	}
+	if _, err := manager.Verify(context.Background(), old); err != nil {
+		t.Fatalf("轮换后旧令牌应仍可校验: %v", err)
+	}
+	if err := keys.Retire("v2"); err == nil {
+		t.Error("不应允许移除当前签发密钥")
+	}
+	_ = keys.Retire("v1")
+	if _, err := manager.Verify(context.Background(), old); !errors.Is(err, ErrTokenInvalid) {
+		t.Errorf("移除旧密钥后应返回 ErrTokenInvalid，实际 %v", err)
+	}
+}
+
+// TestManager_Validation 验证过期、算法混淆、类型与签发方校验。
+func TestManager_Validation(t *testing.T) {
+	keys, _ := NewKeySet(HMACKey("hs", testSecret))
+	manager := New(keys, WithIssuer("bamboo"), WithLeeway(0))
+	ctx := context.Background()
+
+	manager.now = func() time.Time { return time.Now().Add(-time.Hour) }
+	expired, _ := manager.Issue(ctx, Claims{Subject: "1"})
+	manager.now = time.Now
+	if _, err := manager.Verify(ctx, expired); !errors.Is(err, ErrTokenExpired) {
+		t.Errorf("过期令牌应返回 ErrTokenExpired，实际 %v", err)
+	}
+
+	if _, err := manager.Verify(ctx, ""); !errors.Is(err, ErrTokenMissing) {
+		t.Errorf("空令牌应返回 ErrTokenMissing，实际 %v", err)
+	}
+
+	token, _ := manager.Issue(ctx, Claims{Subject: "1"})
+	parts := strings.Split(token, ".")
+	none := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none","kid":"hs"}`)) + "." + parts[1] + "."
+	if _, err := manager.Verify(ctx, none); !errors.Is(err, ErrTokenInvalid) {
+		t.Errorf("alg=none 应返回 ErrTokenInvalid，实际 %v", err)
+	}
+
+	pair, _ := manager.IssuePair(ctx, Claims{Subject: "1"})
+	if _, err := manager.Verify(ctx, pair.RefreshToken); !errors.Is(err, ErrTokenInvalid) {
+		t.Errorf("刷新令牌不应作为访问令牌使用，实际 %v", err)
+	}
+
+	other := New(keys, WithIssuer("other"))
+	if _, err := other.Verify(ctx, token); !errors.Is(err, ErrTokenInvalid) {
+		t.Errorf("签发方不匹配应返回 ErrTokenInvalid，实际 %v", err)
+	}
+}
+
+// TestManager_RefreshAndRevoke 验证刷新令牌一次有效与访问令牌吊销。
+func TestManager_RefreshAndRevoke(t *testing.T) {
+	keys, _ := NewKeySet(HMACKey("hs", testSecret))
+	manager := New(keys, WithDenyList(&memoryDenyList{ids: map[string]time.Time{}}))
+	ctx := context.Background()
+
+	pair, err := manager.IssuePair(ctx, Claims{Subject: "1", Data: map[string]any{"tenant": "t1"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	next, err := manager.Refresh(ctx, pair.RefreshToken)
+	if err != nil {
+		t.Fatalf("刷新失败: %v", err)
+	}
+	if _, err := manager.Refresh(ctx, pair.RefreshToken); !errors.Is(err, ErrTokenInvalid) {
+		t.Errorf("重放刷新令牌应返回 ErrTokenInvalid，实际 %v", err)
+	}
+
+	claims, err := manager.Verify(ctx, next.AccessToken)
+	if err != nil {
+		t.Fatalf("新访问令牌校验失败: %v", err)
+	}
+	if claims.Subject != "1" || claims.Data["tenant"] != "t1" {
+		t.Errorf("刷新后声明不匹配: %+v", claims)
+	}
+	if err := manager.Revoke(ctx, claims); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := manager.Verify(ctx, next.AccessToken); !errors.Is(err, ErrTokenInvalid) {
+		t.Errorf("吊销后应返回 ErrTokenInvalid，实际 %v", err)
+	}
+}
+
+// TestManager_ConcurrentRefresh 验证并发重放同一刷新令牌时只有一个请求换发成功。
+func TestManager_ConcurrentRefresh(t *testing.T) {
+	keys, _ := NewKeySet(HMACKey("hs", testSecret))
+	manager := New(keys, WithDenyList(&memoryDenyList{ids: map[string]time.Time{}}))
+	ctx := context.Background()
+	pair, err := manager.IssuePair(ctx, Claims{Subject: "1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const workers = 16
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		successes int
+	)
+	start := make(chan struct{})
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			_, err := manager.Refresh(ctx, pair.RefreshToken)
+			if err != nil && !errors.Is(err, ErrTokenInvalid) {
+				t.Errorf("并发刷新返回非预期错误: %v", err)
+			}
+			if err == nil {
+				mu.Lock()
+				successes++
+				mu.Unlock()
+			}
+		}()
+	}
+	close(start)
+	wg.Wait()
+	if successes != 1 {
+		t.Errorf("并发刷新应恰好成功一次，实际 %d 次", successes)
+	}
+}
@@ -0,0 +1,213 @@
+// Package xJwt JSON Web Token 的签发、校验、刷新与吊销。
+//
+// 支持 HS256、RS256 与 EdDSA(Ed25519) 三种签名算法，仅依赖标准库：
+//   - [KeySet] 持有一把签发密钥与若干校验密钥，令牌头部携带 kid，轮换时旧令牌在过期前仍可校验
+//   - [Manager] 签发访问令牌 / 刷新令牌对，校验时检查签名、算法、有效期、签发方与受众
+//   - 刷新令牌一次有效，[Manager.Refresh] 换发新令牌对时吊销旧的刷新令牌
+//   - 吊销通过 [DenyList] 记录令牌 jti，major 层的 xCache.DenyListOf 提供基于缓存的实现
+//
+// 校验通过的令牌可经 [Claims.Principal] 转为 xAuth.Principal，由认证中间件写入请求上下文。
+package xJwt
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Algorithm 签名算法
+type Algorithm string
+
+const (
+	HS256 Algorithm = "HS256" // HMAC-SHA256，对称密钥
+	RS256 Algorithm = "RS256" // RSASSA-PKCS1-v1_5 + SHA256，非对称密钥
+	EdDSA Algorithm = "EdDSA" // Ed25519，非对称密钥
+)
+
+// minHMACSecret HS256 密钥的最小长度（字节），与 SHA256 输出长度一致
+const minHMACSecret = 32
+
+// Key 签名密钥
+//
+// 私钥（或 HMAC 密钥）可同时用于签发与校验；仅持有公钥的 Key 只能用于校验，
+// 适合由其他服务签发、本服务仅校验的场景。
+type Key struct {
+	id     string
+	alg    Algorithm
+	signer any
+	public any
+	err    error
+}
+
+// HMACKey 构造 HS256 密钥，secret 至少 32 字节
+func HMACKey(id string, secret []byte) Key {
+	key := Key{id: id, alg: HS256, signer: secret, public: secret}
+	if len(secret) < minHMACSecret {
+		key.err = fmt.Errorf("密钥 %s: HS256 密钥长度不能少于 %d 字节", id, minHMACSecret)
+	}
+	return key
+}
+
+// RSAKey 构造 RS256 签发密钥
+func RSAKey(id string, private *rsa.PrivateKey) Key {
+	if private == nil {
+		return Key{id: id, alg: RS256, err: fmt.Errorf("密钥 %s: RSA 私钥不能为 nil", id)}
+	}
+	return Key{id: id, alg: RS256, signer: private, public: &private.PublicKey}
+}
+
+// RSAPublicKey 构造仅用于校验的 RS256 密钥
+func RSAPublicKey(id string, public *rsa.PublicKey) Key {
+	if public == nil {
+		return Key{id: id, alg: RS256, err: fmt.Errorf("密钥 %s: RSA 公钥不能为 nil", id)}
+	}
+	return Key{id: id, alg: RS256, public: public}
+}
+
+// EdDSAKey 构造 EdDSA(Ed25519) 签发密钥
+func EdDSAKey(id string, private ed25519.PrivateKey) Key {
+	if len(private) != ed25519.PrivateKeySize {
+		return Key{id: id, alg: EdDSA, err: fmt.Errorf("密钥 %s: Ed25519 私钥长度错误", id)}
+	}
+	return Key{id: id, alg: EdDSA, signer: private, public: private.Public()}
+}
+
+// EdDSAPublicKey 构造仅用于校验的 EdDSA(Ed25519) 密钥
+func EdDSAPublicKey(id string, public ed25519.PublicKey) Key {
+	if len(public) != ed25519.PublicKeySize {
+		return Key{id: id, alg: EdDSA, err: fmt.Errorf("密钥 %s: Ed25519 公钥长度错误", id)}
+	}
+	return Key{id: id, alg: EdDSA, public: public}
+}
+
+// ID 返回密钥标识（kid）
+func (k Key) ID() string { return k.id }
+
+// Algorithm 返回签名算法
+func (k Key) Algorithm() Algorithm { return k.alg }
+
+// CanSign 判断密钥是否可用于签发
+func (k Key) CanSign() bool { return k.signer != nil }
+
+// sign 对签名输入计算签名
+func (k Key) sign(input []byte) ([]byte, error) {
+	switch k.alg {
+	case HS256:
+		mac := hmac.New(sha256.New, k.signer.([]byte))
+		mac.Write(input)
+		return mac.Sum(nil), nil
+	case RS256:
+		digest := sha256.Sum256(input)
+		return rsa.SignPKCS1v15(rand.Reader, k.signer.(*rsa.PrivateKey), crypto.SHA256, digest[:])
+	case EdDSA:
+		return ed25519.Sign(k.signer.(ed25519.PrivateKey), input), nil
+	default:
+		return nil, fmt.Errorf("不支持的签名算法: %s", k.alg)
+	}
+}
+
+// verify 校验签名，失败时返回 false
+func (k Key) verify(input, signature []byte) bool {
+	switch k.alg {
+	case HS256:
+		mac := hmac.New(sha256.New, k.public.([]byte))
+		mac.Write(input)
+		return hmac.Equal(mac.Sum(nil), signature)
+	case RS256:
+		digest := sha256.Sum256(input)
+		return rsa.VerifyPKCS1v15(k.public.(*rsa.PublicKey), crypto.SHA256, digest[:], signature) == nil
+	case EdDSA:
+		return ed25519.Verify(k.public.(ed25519.PublicKey), input, signature)
+	default:
+		return false
+	}
+}
+
+// KeySet 密钥集合，持有当前签发密钥与仍被接受的历史密钥
+//
+// 轮换流程：[KeySet.Rotate] 切换签发密钥，旧密钥继续用于校验；
+// 待旧密钥签发的令牌全部过期后调用 [KeySet.Retire] 移除。并发安全。
+type KeySet struct {
+	mu      sync.RWMutex
+	current string
+	keys    map[string]Key
+}
+
+// NewKeySet 以 current 为签发密钥构造密钥集合，others 为仅参与校验的密钥（如上一轮密钥或其他服务的公钥）
+func NewKeySet(current Key, others ...Key) (*KeySet, error) {
+	s := &KeySet{keys: make(map[string]Key, len(others)+1)}
+	for _, key := range others {
+		if err := s.add(key); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.Rotate(current); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Rotate 将 next 设为签发密钥，原签发密钥保留用于校验
+func (s *KeySet) Rotate(next Key) error {
+	if !next.CanSign() && next.err == nil {
+		return fmt.Errorf("密钥 %s: 签发密钥必须包含私钥", next.id)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.addLocked(next); err != nil {
+		return err
+	}
+	s.current = next.id
+	return nil
+}
+
+// Retire 移除不再接受的历史密钥，当前签发密钥不可移除
+func (s *KeySet) Retire(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if id == s.current {
+		return fmt.Errorf("密钥 %s: 不能移除当前签发密钥", id)
+	}
+	delete(s.keys, id)
+	return nil
+}
+
+// Current 返回当前签发密钥
+func (s *KeySet) Current() Key {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.keys[s.current]
+}
+
+// Lookup 按 kid 查找密钥
+func (s *KeySet) Lookup(id string) (Key, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	key, ok := s.keys[id]
+	return key, ok
+}
+
+// add 加锁后登记密钥
+func (s *KeySet) add(key Key) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.addLocked(key)
+}
+
+// addLocked 校验并登记密钥，同一 kid 重复登记时覆盖
+func (s *KeySet) addLocked(key Key) error {
+	if key.err != nil {
+		return key.err
+	}
+	if key.id == "" {
+		return errors.New("密钥标识 kid 不能为空")
+	}
+	s.keys[key.id] = key
+	return nil
+}
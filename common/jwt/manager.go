@@ -0,0 +1,352 @@
+package xJwt
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// 校验错误，均可通过 errors.Is 判断；具体原因以包装后的错误描述给出
+var (
+	ErrTokenMissing = errors.New("令牌缺失")
+	ErrTokenInvalid = errors.New("令牌无效")
+	ErrTokenExpired = errors.New("令牌已过期")
+)
+
+const (
+	// DefaultAccessTTL 默认访问令牌有效期
+	DefaultAccessTTL = 15 * time.Minute
+
+	// DefaultRefreshTTL 默认刷新令牌有效期
+	DefaultRefreshTTL = 7 * 24 * time.Hour
+
+	// DefaultLeeway 默认校验 exp / nbf 时允许的时钟偏差
+	DefaultLeeway = 30 * time.Second
+)
+
+// DenyList 令牌吊销列表，按 jti 记录，until 之后条目可自动清除
+//
+// Deny 须以原子的「不存在才写入」语义实现，added 为 false 表示 id 已被吊销；
+// 刷新令牌据此保证并发重放时只有一个请求能换发成功。
+type DenyList interface {
+	Deny(ctx context.Context, id string, until time.Time) (added bool, err error)
+	Denied(ctx context.Context, id string) (bool, error)
+}
+
+// TokenPair 访问令牌与刷新令牌对
+type TokenPair struct {
+	AccessToken      string `json:"access_token"`       // 访问令牌
+	RefreshToken     string `json:"refresh_token"`      // 刷新令牌，通过 X-Refresh-Token 请求头换发新令牌对
+	TokenType        string `json:"token_type"`         // 固定为 Bearer
+	ExpiresIn        int64  `json:"expires_in"`         // 访问令牌有效期（秒）
+	RefreshExpiresIn int64  `json:"refresh_expires_in"` // 刷新令牌有效期（秒）
+}
+
+// header 令牌头部
+type header struct {
+	Alg Algorithm `json:"alg"`
+	Typ string    `json:"typ,omitempty"`
+	Kid string    `json:"kid,omitempty"`
+}
+
+// Manager 令牌管理器，负责签发、校验、刷新与吊销
+type Manager struct {
+	keys       *KeySet
+	issuer     string
+	audience   Audience
+	accessTTL  time.Duration
+	refreshTTL time.Duration
+	leeway     time.Duration
+	denyList   DenyList
+	now        func() time.Time
+}
+
+// Option 是 [Manager] 的函数式选项
+type Option func(*Manager)
+
+// WithIssuer 设置签发方，校验时要求 iss 一致
+func WithIssuer(issuer string) Option {
+	return func(m *Manager) { m.issuer = issuer }
+}
+
+// WithAudience 设置受众，签发时写入 aud，校验时要求 aud 至少包含其中之一
+func WithAudience(audience ...string) Option {
+	return func(m *Manager) { m.audience = audience }
+}
+
+// WithAccessTTL 设置访问令牌有效期，默认 [DefaultAccessTTL]
+func WithAccessTTL(ttl time.Duration) Option {
+	return func(m *Manager) { m.accessTTL = ttl }
+}
+
+// WithRefreshTTL 设置刷新令牌有效期，默认 [DefaultRefreshTTL]
+func WithRefreshTTL(ttl time.Duration) Option {
+	return func(m *Manager) { m.refreshTTL = ttl }
+}
+
+// WithLeeway 设置校验 exp / nbf 时允许的时钟偏差，默认 [DefaultLeeway]
+func WithLeeway(leeway time.Duration) Option {
+	return func(m *Manager) { m.leeway = leeway }
+}
+
+// WithDenyList 设置吊销列表，未设置时 [Manager.Revoke] 返回错误，刷新令牌也无法保证一次有效
+func WithDenyList(denyList DenyList) Option {
+	return func(m *Manager) { m.denyList = denyList }
+}
+
+// New 构造令牌管理器
+//
+// 使用示例:
+//
+//	keys, _ := xJwt.NewKeySet(xJwt.HMACKey("2026-01", secret))
+//	manager := xJwt.New(keys,
+//	    xJwt.WithIssuer("bamboo"),
+//	    xJwt.WithDenyList(xCache.DenyListOf(cacheManager, "jwt")),
+//	)
+//	pair, _ := manager.IssuePair(ctx, xJwt.Claims{Subject: "1001", Roles: []string{"admin"}})
+func New(keys *KeySet, opts ...Option) *Manager {
+	m := &Manager{
+		keys:       keys,
+		accessTTL:  DefaultAccessTTL,
+		refreshTTL: DefaultRefreshTTL,
+		leeway:     DefaultLeeway,
+		now:        time.Now,
+	}
+	for _, o := range opts {
+		if o != nil {
+			o(m)
+		}
+	}
+	return m
+}
+
+// Keys 返回密钥集合，可用于运行期轮换
+func (m *Manager) Keys() *KeySet { return m.keys }
+
+// IssuePair 以 claims 为模板签发访问令牌与刷新令牌
+//
+// 模板中的 Subject、Roles、Data 会写入两枚令牌；iss、aud、iat、exp、jti 与令牌类型由管理器填充。
+func (m *Manager) IssuePair(ctx context.Context, claims Claims) (*TokenPair, error) {
+	access, err := m.issue(claims, TokenTypeAccess, m.accessTTL)
+	if err != nil {
+		return nil, err
+	}
+	refresh, err := m.issue(claims, TokenTypeRefresh, m.refreshTTL)
+	if err != nil {
+		return nil, err
+	}
+	return &TokenPair{
+		AccessToken:      access,
+		RefreshToken:     refresh,
+		TokenType:        "Bearer",
+		ExpiresIn:        int64(m.accessTTL.Seconds()),
+		RefreshExpiresIn: int64(m.refreshTTL.Seconds()),
+	}, nil
+}
+
+// Issue 签发单枚访问令牌，适合无需刷新的短期凭据
+func (m *Manager) Issue(ctx context.Context, claims Claims) (string, error) {
+	return m.issue(claims, TokenTypeAccess, m.accessTTL)
+}
+
+// issue 填充标准声明后签名
+func (m *Manager) issue(claims Claims, typ TokenType, ttl time.Duration) (string, error) {
+	id, err := newTokenID()
+	if err != nil {
+		return "", err
+	}
+	now := m.now()
+	claims.Issuer = m.issuer
+	if len(claims.Audience) == 0 {
+		claims.Audience = m.audience
+	}
+	claims.IssuedAt = now.Unix()
+	claims.ExpiresAt = now.Add(ttl).Unix()
+	claims.ID = id
+	claims.Type = typ
+	return m.Sign(claims)
+}
+
+// Sign 使用当前签发密钥对 claims 原样签名，不填充任何声明
+func (m *Manager) Sign(claims Claims) (string, error) {
+	key := m.keys.Current()
+	head, err := json.Marshal(header{Alg: key.alg, Typ: "JWT", Kid: key.id})
+	if err != nil {
+		return "", err
+	}
+	body, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	input := base64.RawURLEncoding.EncodeToString(head) + "." + base64.RawURLEncoding.EncodeToString(body)
+	signature, err := key.sign([]byte(input))
+	if err != nil {
+		return "", err
+	}
+	return input + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// Verify 校验访问令牌并返回声明
+//
+// 依次检查格式、kid 与算法、签名、有效期、签发方、受众、令牌类型与吊销状态。
+// 令牌无效返回包装 [ErrTokenInvalid] 的错误，过期返回包装 [ErrTokenExpired] 的错误；
+// 吊销列表读取失败时返回包装后的读取错误（不属于以上两类）。
+func (m *Manager) Verify(ctx context.Context, token string) (*Claims, error) {
+	return m.verify(ctx, token, TokenTypeAccess)
+}
+
+// Refresh 校验刷新令牌并换发新的令牌对
+//
+// 旧刷新令牌在换发前以原子操作吊销，重放（包括并发重放）同一刷新令牌只有一次成功，其余返回 [ErrTokenInvalid]；
+// 未配置吊销列表时不具备重放保护。新令牌对沿用原令牌的主体、角色与自定义声明。
+func (m *Manager) Refresh(ctx context.Context, refreshToken string) (*TokenPair, error) {
+	claims, err := m.verify(ctx, refreshToken, TokenTypeRefresh)
+	if err != nil {
+		return nil, err
+	}
+	if m.denyList != nil {
+		added, err := m.deny(ctx, claims)
+		if err != nil {
+			return nil, err
+		}
+		if !added {
+			return nil, fmt.Errorf("%w: 令牌已吊销", ErrTokenInvalid)
+		}
+	}
+	return m.IssuePair(ctx, Claims{
+		Subject:  claims.Subject,
+		Audience: claims.Audience,
+		Roles:    claims.Roles,
+		Data:     claims.Data,
+	})
+}
+
+// Revoke 吊销令牌，吊销记录保留到令牌过期；重复吊销不视为错误
+func (m *Manager) Revoke(ctx context.Context, claims *Claims) error {
+	if m.denyList == nil {
+		return errors.New("未配置令牌吊销列表")
+	}
+	_, err := m.deny(ctx, claims)
+	return err
+}
+
+// deny 将令牌加入吊销列表，added 为 false 表示已被吊销
+func (m *Manager) deny(ctx context.Context, claims *Claims) (bool, error) {
+	if claims == nil || claims.ID == "" {
+		return false, fmt.Errorf("%w: 缺少 jti，无法吊销", ErrTokenInvalid)
+	}
+	return m.denyList.Deny(ctx, claims.ID, time.Unix(claims.ExpiresAt, 0).Add(m.leeway))
+}
+
+// verify 校验令牌并要求令牌类型一致
+func (m *Manager) verify(ctx context.Context, token string, typ TokenType) (*Claims, error) {
+	claims, err := m.Parse(token)
+	if err != nil {
+		return nil, err
+	}
+	if claims.Type != typ {
+		return nil, fmt.Errorf("%w: 令牌类型应为 %s", ErrTokenInvalid, typ)
+	}
+	if m.denyList != nil && claims.ID != "" {
+		denied, err := m.denyList.Denied(ctx, claims.ID)
+		if err != nil {
+			return nil, fmt.Errorf("读取令牌吊销列表失败: %w", err)
+		}
+		if denied {
+			return nil, fmt.Errorf("%w: 令牌已吊销", ErrTokenInvalid)
+		}
+	}
+	return claims, nil
+}
+
+// Parse 校验令牌签名与标准声明并返回声明，不检查令牌类型与吊销状态
+func (m *Manager) Parse(token string) (*Claims, error) {
+	if token == "" {
+		return nil, ErrTokenMissing
+	}
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: 格式错误", ErrTokenInvalid)
+	}
+
+	var head header
+	if err := decodeSegment(parts[0], &head); err != nil {
+		return nil, fmt.Errorf("%w: 头部解析失败", ErrTokenInvalid)
+	}
+	// 未携带 kid 的令牌按当前签发密钥校验，兼容单密钥部署
+	key, ok := m.keys.Current(), true
+	if head.Kid != "" {
+		key, ok = m.keys.Lookup(head.Kid)
+	}
+	if !ok {
+		return nil, fmt.Errorf("%w: 未知的密钥 %q", ErrTokenInvalid, head.Kid)
+	}
+	// 算法必须与密钥绑定的算法一致，防止 alg=none 或以公钥作 HMAC 密钥的算法混淆攻击
+	if head.Alg != key.alg {
+		return nil, fmt.Errorf("%w: 签名算法 %q 与密钥不匹配", ErrTokenInvalid, head.Alg)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || !key.verify([]byte(parts[0]+"."+parts[1]), signature) {
+		return nil, fmt.Errorf("%w: 签名校验失败", ErrTokenInvalid)
+	}
+
+	var claims Claims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("%w: 声明解析失败", ErrTokenInvalid)
+	}
+	if err := m.validate(&claims); err != nil {
+		return nil, err
+	}
+	return &claims, nil
+}
+
+// validate 校验有效期、签发方与受众
+func (m *Manager) validate(claims *Claims) error {
+	now := m.now()
+	if claims.ExpiresAt == 0 {
+		return fmt.Errorf("%w: 缺少过期时间", ErrTokenInvalid)
+	}
+	if now.After(time.Unix(claims.ExpiresAt, 0).Add(m.leeway)) {
+		return ErrTokenExpired
+	}
+	if claims.NotBefore != 0 && now.Add(m.leeway).Before(time.Unix(claims.NotBefore, 0)) {
+		return fmt.Errorf("%w: 令牌尚未生效", ErrTokenInvalid)
+	}
+	if m.issuer != "" && claims.Issuer != m.issuer {
+		return fmt.Errorf("%w: 签发方不匹配", ErrTokenInvalid)
+	}
+	if len(m.audience) > 0 && !slices.ContainsFunc(claims.Audience, func(aud string) bool {
+		return slices.Contains(m.audience, aud)
+	}) {
+		return fmt.Errorf("%w: 受众不匹配", ErrTokenInvalid)
+	}
+	return nil
+}
+
+// decodeSegment 解码 base64url 段并反序列化 JSON，数字保留为 json.Number 以免精度丢失
+func decodeSegment(segment string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
+
+// newTokenID 生成 128 位随机 jti
+func newTokenID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b[:]), nil
+}
@@ -0,0 +1,127 @@
+// Package xLifecycle 进程生命周期协调，提供就绪状态与按阶段的优雅关闭。
+//
+// xMain.Runner 在收到退出信号后依次执行:
+//
+//	标记未就绪 → 等待摘流 → PhaseHTTP → PhaseGRPC → PhaseCron → PhaseAsync → 取消运行期上下文
+//
+// 各插件（gRPC、Cron、xAsync）通过 [Join] 加入对应阶段，在 [Member.Stopping] 关闭后停止接收新任务、
+// 完成在途任务并调用 [Member.Leave]；Runner 通过 [Stop] 等待某一阶段的全部成员退出。
+// 该包位于 common 层，插件无需依赖 major 即可接入关闭流程。
+package xLifecycle
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+)
+
+// Phase 关闭阶段
+type Phase string
+
+const (
+	PhaseHTTP  Phase = "http"  // HTTP 服务（主服务与附加服务）
+	PhaseGRPC  Phase = "grpc"  // gRPC 服务
+	PhaseCron  Phase = "cron"  // 定时任务
+	PhaseAsync Phase = "async" // xAsync 异步任务
+)
+
+// Phases 返回 xMain.Runner 执行关闭的阶段顺序
+func Phases() []Phase {
+	return []Phase{PhaseHTTP, PhaseGRPC, PhaseCron, PhaseAsync}
+}
+
+var (
+	ready  atomic.Bool
+	mu     sync.Mutex
+	phases = make(map[Phase]*phaseState)
+)
+
+// phaseState 单个阶段的成员计数与关闭信号
+type phaseState struct {
+	stopping chan struct{} // Stop 时关闭
+	stopped  bool          // stopping 是否已关闭
+	members  int           // 尚未 Leave 的成员数
+	left     chan struct{} // 每次有成员 Leave 时关闭并替换，用于唤醒 Stop
+}
+
+// Ready 判断服务是否就绪（可接收流量），供 /readyz 等健康检查使用
+func Ready() bool {
+	return ready.Load()
+}
+
+// SetReady 设置就绪状态
+//
+// xMain.Runner 在全部 HTTP 服务开始监听后置为 true，收到退出信号后第一时间置为 false，
+// 使负载均衡在摘流期内停止转发新请求。
+func SetReady(value bool) {
+	ready.Store(value)
+}
+
+// Member 某一关闭阶段的成员
+type Member struct {
+	state *phaseState
+	once  sync.Once
+}
+
+// Join 加入指定关闭阶段，返回的成员须在退出时调用 [Member.Leave]
+func Join(phase Phase) *Member {
+	mu.Lock()
+	defer mu.Unlock()
+	state := phaseOf(phase)
+	state.members++
+	return &Member{state: state}
+}
+
+// Stopping 返回在所属阶段开始关闭时关闭的通道；阶段已在关闭中时返回已关闭的通道
+func (m *Member) Stopping() <-chan struct{} {
+	return m.state.stopping
+}
+
+// Leave 声明成员已退出，可重复调用
+func (m *Member) Leave() {
+	m.once.Do(func() {
+		mu.Lock()
+		defer mu.Unlock()
+		m.state.members--
+		close(m.state.left)
+		m.state.left = make(chan struct{})
+	})
+}
+
+// Stop 通知指定阶段的全部成员开始关闭，并等待它们退出
+//
+// ctx 结束时仍未退出的成员数会体现在返回的错误中。没有成员的阶段立即返回 nil，重复调用只会等待。
+func Stop(ctx context.Context, phase Phase) error {
+	mu.Lock()
+	state := phaseOf(phase)
+	if !state.stopped {
+		state.stopped = true
+		close(state.stopping)
+	}
+	mu.Unlock()
+
+	for {
+		mu.Lock()
+		members, left := state.members, state.left
+		mu.Unlock()
+		if members <= 0 {
+			return nil
+		}
+		select {
+		case <-left:
+		case <-ctx.Done():
+			return fmt.Errorf("阶段 %s 仍有 %d 个成员未退出: %w", phase, members, ctx.Err())
+		}
+	}
+}
+
+// phaseOf 返回阶段状态，不存在时创建；调用方需持有 mu
+func phaseOf(phase Phase) *phaseState {
+	state, ok := phases[phase]
+	if !ok {
+		state = &phaseState{stopping: make(chan struct{}), left: make(chan struct{})}
+		phases[phase] = state
+	}
+	return state
+}
@@ -0,0 +1,62 @@
+package xLifecycle
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// TestStop_WaitsForMembers 验证 Stop 通知成员并等待其退出。
+func TestStop_WaitsForMembers(t *testing.T) {
+	phase := Phase("test-wait")
+	member := Join(phase)
+	go func() {
+		<-member.Stopping()
+		time.Sleep(20 * time.Millisecond)
+		member.Leave()
+		member.Leave()
+	}()
+
+	if err := Stop(context.Background(), phase); err != nil {
+		t.Fatalf("Stop 返回错误: %v", err)
+	}
+	select {
+	case <-Join(phase).Stopping():
+	default:
+		t.Error("阶段关闭后加入的成员应立即收到关闭信号")
+	}
+}
+
+// TestStop_Timeout 验证成员未退出时 Stop 在 ctx 结束后返回错误。
+func TestStop_Timeout(t *testing.T) {
+	phase := Phase("test-timeout")
+	member := Join(phase)
+	defer member.Leave()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := Stop(ctx, phase); err == nil {
+		t.Fatal("成员未退出时应返回错误")
+	}
+}
+
+// TestStop_Empty 验证没有成员的阶段立即返回。
+func TestStop_Empty(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := Stop(ctx, Phase("test-empty")); err != nil {
+		t.Fatalf("空阶段应立即返回: %v", err)
+	}
+}
+
+// TestReady 验证就绪状态切换。
+func TestReady(t *testing.T) {
+	SetReady(true)
+	if !Ready() {
+		t.Error("SetReady(true) 后应就绪")
+	}
+	SetReady(false)
+	if Ready() {
+		t.Error("SetReady(false) 后应未就绪")
+	}
+}
@@ -0,0 +1,179 @@
+package xLog
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// OverflowPolicy 异步队列已满时的处理策略
+type OverflowPolicy string
+
+const (
+	// OverflowBlock 队列满时阻塞调用方，直至有空位（不丢日志）
+	OverflowBlock OverflowPolicy = "block"
+
+	// OverflowDrop 队列满时丢弃新日志，并在队列空闲时输出丢弃条数汇总
+	OverflowDrop OverflowPolicy = "drop"
+)
+
+// defaultQueueSize 异步队列默认容量
+const defaultQueueSize = 1024
+
+// AsyncConfig 异步写入配置
+type AsyncConfig struct {
+	QueueSize int            // 队列容量，<= 0 时默认 1024
+	Policy    OverflowPolicy // 队列满时的处理策略，默认 OverflowBlock
+}
+
+// ParseOverflowPolicy 解析 block/drop 形式的溢出策略（大小写不敏感）
+func ParseOverflowPolicy(text string) (OverflowPolicy, bool) {
+	switch OverflowPolicy(strings.ToLower(strings.TrimSpace(text))) {
+	case OverflowBlock:
+		return OverflowBlock, true
+	case OverflowDrop:
+		return OverflowDrop, true
+	}
+	return "", false
+}
+
+// queueItem 队列元素，entry 与 flushed 二选一
+type queueItem struct {
+	entry   *Entry
+	flushed chan error // 非 nil 表示刷新请求，写完之前的所有日志后回传结果
+}
+
+// asyncQueue 有界异步队列，由唯一的后台协程依次写入所有输出目标
+type asyncQueue struct {
+	items    chan queueItem
+	policy   OverflowPolicy
+	sinks    []Sink
+	dropped  atomic.Int64
+	stopped  chan struct{} // 关闭后后台协程退出，新日志被丢弃
+	stopOnce sync.Once
+}
+
+// newAsyncQueue 创建异步队列并启动后台写入协程
+func newAsyncQueue(sinks []Sink, config AsyncConfig) *asyncQueue {
+	size := config.QueueSize
+	if size <= 0 {
+		size = defaultQueueSize
+	}
+	policy := config.Policy
+	if policy == "" {
+		policy = OverflowBlock
+	}
+
+	q := &asyncQueue{
+		items:   make(chan queueItem, size),
+		policy:  policy,
+		sinks:   sinks,
+		stopped: make(chan struct{}),
+	}
+	go q.run()
+	return q
+}
+
+// push 入队一条日志，按溢出策略决定阻塞或丢弃
+func (q *asyncQueue) push(entry *Entry) {
+	if q.policy == OverflowDrop {
+		select {
+		case q.items <- queueItem{entry: entry}:
+		default:
+			q.dropped.Add(1)
+		}
+		return
+	}
+	select {
+	case q.items <- queueItem{entry: entry}:
+	case <-q.stopped:
+	}
+}
+
+// flush 等待此前入队的日志全部写出并刷新输出目标
+//
+// 刷新请求与普通日志走同一队列，保证顺序；ctx 到期时返回 ctx.Err()，队列已关闭时直接返回 nil。
+func (q *asyncQueue) flush(ctx context.Context) error {
+	done := make(chan error, 1)
+	select {
+	case q.items <- queueItem{flushed: done}:
+	case <-q.stopped:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	select {
+	case err := <-done:
+		return err
+	case <-q.stopped:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// stop 停止后台协程，尚未写出与之后入队的日志被丢弃；可重复调用
+func (q *asyncQueue) stop() {
+	q.stopOnce.Do(func() { close(q.stopped) })
+}
+
+// run 后台写入循环
+func (q *asyncQueue) run() {
+	for {
+		var item queueItem
+		select {
+		case item = <-q.items:
+		case <-q.stopped:
+			return
+		}
+		// 与 stop 同时就绪时 select 随机选择，此处再次确认，保证停止后不再写出
+		select {
+		case <-q.stopped:
+			return
+		default:
+		}
+		if item.entry != nil {
+			writeSinks(q.sinks, item.entry)
+		}
+		// 队列空闲或收到刷新请求时汇报丢弃条数，避免高峰期额外写入
+		if len(q.items) == 0 || item.flushed != nil {
+			q.reportDropped()
+		}
+		if item.flushed != nil {
+			item.flushed <- flushSinks(q.sinks)
+		}
+	}
+}
+
+// reportDropped 输出自上次汇报以来丢弃的日志条数
+func (q *asyncQueue) reportDropped() {
+	n := q.dropped.Swap(0)
+	if n == 0 {
+		return
+	}
+	writeSinks(q.sinks, &Entry{
+		Time:    time.Now(),
+		Level:   slog.LevelWarn,
+		Logger:  NamedCORE,
+		Message: fmt.Sprintf("日志队列已满，已丢弃 %d 条日志", n),
+		Attrs:   []slog.Attr{slog.Int64("dropped", n)},
+	})
+}
+
+// Flush 刷新全局默认 logger 的输出
+//
+// 默认 Handler 为 [LogHandler] 时等待异步队列写出并刷新输出目标，否则直接返回 nil。
+// xMain.Runner 在退出前调用，避免丢失关闭阶段的日志。
+func Flush(ctx context.Context) error {
+	if f, ok := slog.Default().Handler().(interface {
+		Flush(ctx context.Context) error
+	}); ok {
+		return f.Flush(ctx)
+	}
+	return nil
+}
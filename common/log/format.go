@@ -0,0 +1,270 @@
+package xLog
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Format 日志输出格式
+//
+// 每个输出目标（控制台、文件）可独立选择格式，便于控制台保持人类可读，
+// 文件或容器标准输出输出机器可解析的记录。
+type Format string
+
+const (
+	// FormatText 彩色文本格式，仅适用于控制台
+	FormatText Format = "text"
+
+	// FormatJSON 每行一个 JSON 对象，字段名固定
+	FormatJSON Format = "json"
+
+	// FormatLogfmt 每行一条 key=value 记录，字段名与 JSON 一致
+	FormatLogfmt Format = "logfmt"
+)
+
+// 结构化输出的固定字段名
+const (
+	FieldTime    = "time"     // 记录时间 (RFC3339Nano)
+	FieldLevel   = "level"    // 日志级别 (DEBUG/INFO/NOTICE/WARN/ERROR)
+	FieldLogger  = "logger"   // logger 名称，即 WithName 的 name
+	FieldOptions = "options"  // WithName 的 optionName 列表
+	FieldTrace   = "trace"    // 请求追踪 ID
+	FieldTraceID = "trace_id" // W3C 链路 ID
+	FieldSpanID  = "span_id"  // W3C span ID
+	FieldSubject = "subject"  // 已认证主体标识
+	FieldMessage = "message"  // 日志消息
+	FieldAttrs   = "attrs"    // 其余属性
+)
+
+// ParseFormat 解析 text/json/logfmt 形式的输出格式（大小写不敏感）
+func ParseFormat(text string) (Format, bool) {
+	switch Format(strings.ToLower(strings.TrimSpace(text))) {
+	case FormatText:
+		return FormatText, true
+	case FormatJSON:
+		return FormatJSON, true
+	case FormatLogfmt:
+		return FormatLogfmt, true
+	}
+	return "", false
+}
+
+// LevelName 返回日志级别的固定名称，NOTICE 为 INFO+1
+func LevelName(level slog.Level) string {
+	switch level {
+	case slog.LevelDebug:
+		return "DEBUG"
+	case slog.LevelInfo:
+		return "INFO"
+	case slog.LevelInfo + 1:
+		return "NOTICE"
+	case slog.LevelWarn:
+		return "WARN"
+	case slog.LevelError:
+		return "ERROR"
+	default:
+		return level.String()
+	}
+}
+
+// Entry 单条日志的结构化视图，由各输出格式与 [Sink] 共享
+//
+// Entry 在 Handle 时构建完毕且不再修改，可安全地跨协程传递给异步队列。
+type Entry struct {
+	Time    time.Time   // 记录时间
+	Level   slog.Level  // 日志级别
+	Logger  string      // logger 名称
+	Options []string    // WithName 的 optionName 列表
+	Trace   string      // 请求追踪 ID
+	TraceID string      // W3C 链路 ID，跨服务一致
+	SpanID  string      // W3C span ID
+	Subject string      // 已认证主体标识（xAuth.Principal.Subject）
+	Message string      // 日志消息
+	Attrs   []slog.Attr // 预设属性在前、记录属性在后
+	Stack   string      // ERROR 及以上级别的调用堆栈，仅彩色文本格式输出
+}
+
+// Encode 按指定格式编码日志条目，末尾带换行
+func (e *Entry) Encode(format Format) []byte {
+	switch format {
+	case FormatText:
+		return e.appendText(nil)
+	case FormatLogfmt:
+		return e.appendLogfmt(nil)
+	default:
+		return e.appendJSON(nil)
+	}
+}
+
+// appendJSON 编码为单行 JSON，字段顺序固定
+func (e *Entry) appendJSON(buf []byte) []byte {
+	buf = append(buf, '{')
+	buf = appendJSONField(buf, FieldTime, e.Time.Format(time.RFC3339Nano), false)
+	buf = appendJSONField(buf, FieldLevel, LevelName(e.Level), true)
+	if e.Logger != "" {
+		buf = appendJSONField(buf, FieldLogger, e.Logger, true)
+	}
+	if len(e.Options) > 0 {
+		buf = append(buf, ',')
+		buf = strconv.AppendQuote(buf, FieldOptions)
+		buf = append(buf, ':')
+		buf = appendJSONValue(buf, e.Options)
+	}
+	if e.Trace != "" {
+		buf = appendJSONField(buf, FieldTrace, e.Trace, true)
+	}
+	if e.TraceID != "" {
+		buf = appendJSONField(buf, FieldTraceID, e.TraceID, true)
+		buf = appendJSONField(buf, FieldSpanID, e.SpanID, true)
+	}
+	if e.Subject != "" {
+		buf = appendJSONField(buf, FieldSubject, e.Subject, true)
+	}
+	buf = appendJSONField(buf, FieldMessage, e.Message, true)
+	if len(e.Attrs) > 0 {
+		buf = append(buf, ',')
+		buf = strconv.AppendQuote(buf, FieldAttrs)
+		buf = append(buf, ':')
+		buf = appendJSONAttrs(buf, e.Attrs)
+	}
+	return append(buf, '}', '\n')
+}
+
+// appendLogfmt 编码为单行 logfmt，分组属性以 "attrs.group.key" 展开
+func (e *Entry) appendLogfmt(buf []byte) []byte {
+	buf = appendLogfmtPair(buf, FieldTime, e.Time.Format(time.RFC3339Nano))
+	buf = appendLogfmtPair(buf, FieldLevel, LevelName(e.Level))
+	if e.Logger != "" {
+		buf = appendLogfmtPair(buf, FieldLogger, e.Logger)
+	}
+	if len(e.Options) > 0 {
+		buf = appendLogfmtPair(buf, FieldOptions, strings.Join(e.Options, ","))
+	}
+	if e.Trace != "" {
+		buf = appendLogfmtPair(buf, FieldTrace, e.Trace)
+	}
+	if e.TraceID != "" {
+		buf = appendLogfmtPair(buf, FieldTraceID, e.TraceID)
+		buf = appendLogfmtPair(buf, FieldSpanID, e.SpanID)
+	}
+	if e.Subject != "" {
+		buf = appendLogfmtPair(buf, FieldSubject, e.Subject)
+	}
+	buf = appendLogfmtPair(buf, FieldMessage, e.Message)
+	buf = appendLogfmtAttrs(buf, FieldAttrs, e.Attrs)
+	if len(buf) > 0 && buf[0] == ' ' {
+		buf = buf[1:]
+	}
+	return append(buf, '\n')
+}
+
+// appendJSONField 追加 "key":"value" 字符串字段
+func appendJSONField(buf []byte, key, value string, comma bool) []byte {
+	if comma {
+		buf = append(buf, ',')
+	}
+	buf = appendJSONValue(buf, key)
+	buf = append(buf, ':')
+	return appendJSONValue(buf, value)
+}
+
+// appendJSONAttrs 按属性顺序编码为 JSON 对象，分组属性编码为嵌套对象
+func appendJSONAttrs(buf []byte, attrs []slog.Attr) []byte {
+	buf = append(buf, '{')
+	first := true
+	for _, a := range attrs {
+		a.Value = a.Value.Resolve()
+		if a.Equal(slog.Attr{}) {
+			continue
+		}
+		if !first {
+			buf = append(buf, ',')
+		}
+		first = false
+		buf = appendJSONValue(buf, a.Key)
+		buf = append(buf, ':')
+		if a.Value.Kind() == slog.KindGroup {
+			buf = appendJSONAttrs(buf, a.Value.Group())
+			continue
+		}
+		buf = appendJSONValue(buf, attrValue(a.Value))
+	}
+	return append(buf, '}')
+}
+
+// appendJSONValue 追加任意值的 JSON 编码，无法编码时退化为字符串
+func appendJSONValue(buf []byte, v any) []byte {
+	data, err := json.Marshal(v)
+	if err != nil {
+		data, _ = json.Marshal(fmt.Sprintf("%+v", v))
+	}
+	return append(buf, data...)
+}
+
+// attrValue 将 slog.Value 转换为适合编码的值
+//
+// error 取 Error() 文本，time.Duration 取可读字符串，避免 JSON 输出 {} 或纳秒整数。
+func attrValue(v slog.Value) any {
+	switch v.Kind() {
+	case slog.KindDuration:
+		return v.Duration().String()
+	case slog.KindTime:
+		return v.Time().Format(time.RFC3339Nano)
+	case slog.KindAny:
+		if err, ok := v.Any().(error); ok {
+			return err.Error()
+		}
+	}
+	return v.Any()
+}
+
+// appendLogfmtAttrs 以 prefix.key 形式追加属性
+func appendLogfmtAttrs(buf []byte, prefix string, attrs []slog.Attr) []byte {
+	for _, a := range attrs {
+		a.Value = a.Value.Resolve()
+		if a.Equal(slog.Attr{}) {
+			continue
+		}
+		key := prefix + "." + a.Key
+		if a.Value.Kind() == slog.KindGroup {
+			buf = appendLogfmtAttrs(buf, key, a.Value.Group())
+			continue
+		}
+		value := attrValue(a.Value)
+		text, ok := value.(string)
+		if !ok {
+			text = fmt.Sprintf("%v", value)
+		}
+		buf = appendLogfmtPair(buf, key, text)
+	}
+	return buf
+}
+
+// appendLogfmtPair 追加 " key=value"，含空白、引号或等号的值加引号转义
+func appendLogfmtPair(buf []byte, key, value string) []byte {
+	buf = append(buf, ' ')
+	buf = append(buf, key...)
+	buf = append(buf, '=')
+	if needsQuote(value) {
+		return strconv.AppendQuote(buf, value)
+	}
+	return append(buf, value...)
+}
+
+// needsQuote 判断 logfmt 值是否需要加引号
+func needsQuote(value string) bool {
+	if value == "" {
+		return true
+	}
+	for _, r := range value {
+		if r <= ' ' || r == '=' || r == '"' || r == utf8.RuneError {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,118 @@
+package xLog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	xAuth "github.com/bamboo-services/bamboo-base-go/common/auth"
+	xTrace "github.com/bamboo-services/bamboo-base-go/common/trace"
+)
+
+// TestHandler_JSONFormat 验证 JSON 输出的固定字段与属性嵌套。
+func TestHandler_JSONFormat(t *testing.T) {
+	var console, file bytes.Buffer
+	handler := NewLogHandler(HandlerConfig{
+		Console:       &console,
+		File:          &file,
+		Level:         slog.LevelDebug,
+		ConsoleFormat: FormatJSON,
+	})
+	logger := slog.New(handler.WithGroup(NamedCORE).WithAttrs([]slog.Attr{
+		slog.String("option_name_1", "DB"),
+		slog.String("service", "demo"),
+	}))
+
+	logger.LogAttrs(context.Background(), slog.LevelInfo+1, "启动完成",
+		slog.Int("port", 8080),
+		slog.Any("err", errors.New("boom")),
+		slog.Group("req", slog.String("path", "/ping")),
+	)
+
+	if console.String() != file.String() {
+		t.Fatalf("控制台与文件同为 JSON 时输出应一致\nconsole=%s\nfile=%s", console.String(), file.String())
+	}
+
+	var got map[string]any
+	if err := json.Unmarshal(file.Bytes(), &got); err != nil {
+		t.Fatalf("输出不是合法 JSON: %v, raw=%s", err, file.String())
+	}
+	if got[FieldLevel] != "NOTICE" || got[FieldLogger] != NamedCORE || got[FieldMessage] != "启动完成" {
+		t.Errorf("固定字段不匹配: %v", got)
+	}
+	if options, _ := got[FieldOptions].([]any); len(options) != 1 || options[0] != "DB" {
+		t.Errorf("options 不匹配: %v", got[FieldOptions])
+	}
+	attrs, _ := got[FieldAttrs].(map[string]any)
+	if attrs["service"] != "demo" || attrs["port"] != float64(8080) || attrs["err"] != "boom" {
+		t.Errorf("attrs 不匹配: %v", attrs)
+	}
+	if req, _ := attrs["req"].(map[string]any); req["path"] != "/ping" {
+		t.Errorf("分组属性应为嵌套对象: %v", attrs["req"])
+	}
+	if _, ok := attrs["option_name_1"]; ok {
+		t.Error("option_name_<number> 不应出现在 attrs 中")
+	}
+}
+
+// TestHandler_LogfmtFormat 验证 logfmt 输出的字段顺序与转义。
+func TestHandler_LogfmtFormat(t *testing.T) {
+	var console bytes.Buffer
+	handler := NewLogHandler(HandlerConfig{
+		Console:       &console,
+		ConsoleFormat: FormatLogfmt,
+	})
+	slog.New(handler.WithGroup(NamedHTTP)).Warn("slow request", "cost", "1.2 s", slog.Group("req", "id", 7))
+
+	line := strings.TrimSuffix(console.String(), "\n")
+	if !strings.Contains(line, " level=WARN logger=HTTP message=\"slow request\" attrs.cost=\"1.2 s\" attrs.req.id=7") {
+		t.Errorf("logfmt 输出不匹配: %s", line)
+	}
+	if !strings.HasPrefix(line, "time=") {
+		t.Errorf("logfmt 应以 time 开头: %s", line)
+	}
+}
+
+// TestHandler_TraceContext 验证 context 中的 W3C 链路上下文写入 trace_id 与 span_id 字段。
+func TestHandler_TraceContext(t *testing.T) {
+	var console bytes.Buffer
+	handler := NewLogHandler(HandlerConfig{Console: &console, ConsoleFormat: FormatJSON})
+	span, _ := xTrace.ParseTraceParent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+	slog.New(handler).InfoContext(xTrace.WithContext(context.Background(), span), "链路")
+
+	var got map[string]any
+	if err := json.Unmarshal(console.Bytes(), &got); err != nil {
+		t.Fatalf("输出不是合法 JSON: %v, raw=%s", err, console.String())
+	}
+	if got[FieldTraceID] != "4bf92f3577b34da6a3ce929d0e0e4736" || got[FieldSpanID] != "00f067aa0ba902b7" {
+		t.Errorf("链路字段不匹配: %v", got)
+	}
+}
+
+// TestHandler_Principal 验证 context 中的已认证主体写入 subject 字段。
+func TestHandler_Principal(t *testing.T) {
+	var console bytes.Buffer
+	handler := NewLogHandler(HandlerConfig{Console: &console, ConsoleFormat: FormatLogfmt})
+	ctx := xAuth.WithPrincipal(context.Background(), &xAuth.Principal{Subject: "user-42"})
+
+	slog.New(handler).InfoContext(ctx, "主体")
+
+	if !strings.Contains(console.String(), "subject=user-42") {
+		t.Errorf("缺少 subject 字段: %s", console.String())
+	}
+}
+
+// TestParseFormat 验证输出格式解析。
+func TestParseFormat(t *testing.T) {
+	if f, ok := ParseFormat(" JSON "); !ok || f != FormatJSON {
+		t.Errorf("ParseFormat(JSON) = %q, %v", f, ok)
+	}
+	if _, ok := ParseFormat("xml"); ok {
+		t.Error("不支持的格式应返回 false")
+	}
+}
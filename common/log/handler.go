@@ -2,7 +2,6 @@ package xLog
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"io"
 	"log/slog"
@@ -10,8 +9,9 @@ import (
 	"runtime"
 	"strings"
 	"sync"
-	"time"
 
+	xAuth "github.com/bamboo-services/bamboo-base-go/common/auth"
+	xTrace "github.com/bamboo-services/bamboo-base-go/common/trace"
 	xConsts "github.com/bamboo-services/bamboo-base-go/defined/context"
 )
 
@@ -36,22 +36,39 @@ func SetLogContextExtractor(e LogContextExtractor) {
 }
 
 // LogHandler 自定义 slog Handler，支持彩色控制台输出和 JSON 文件输出
+//
+// 每条记录先构建为 [Entry]，再扇出到所有 [Sink]（控制台、文件及额外输出目标）。
+// 配置 [AsyncConfig] 后写入由后台协程完成，Handle 仅负责入队。
 type LogHandler struct {
-	opts        slog.HandlerOptions
-	mu          *sync.Mutex
-	console     io.Writer
-	file        io.Writer
-	group       string // logger 名称（通过 WithGroup 设置）
-	attrs       []slog.Attr
-	isDebugMode bool
+	opts     slog.HandlerOptions
+	mu       *sync.Mutex
+	sinks    []Sink
+	levels   *LevelRegistry
+	sampler  *sampler    // 采样与重复抑制，nil 表示不启用
+	redactor *Redactor   // 敏感数据脱敏器，nil 表示使用全局脱敏器
+	queue    *asyncQueue // 异步队列，nil 表示同步写入
+	group    string      // logger 名称（通过 WithGroup 设置）
+	attrs    []slog.Attr
 }
 
 // HandlerConfig Handler 配置选项
 type HandlerConfig struct {
-	Console     io.Writer  // 控制台输出（可选，默认 os.Stdout）
-	File        io.Writer  // 文件输出（可选）
-	Level       slog.Level // 日志级别
-	IsDebugMode bool       // 是否调试模式
+	Console io.Writer    // 控制台输出（可选，默认 os.Stdout）
+	File    io.Writer    // 文件输出（可选）
+	Level   slog.Level   // 日志级别
+	Leveler slog.Leveler // 动态日志级别（可选，设置后优先于 Level，如 *slog.LevelVar）
+
+	ConsoleFormat Format // 控制台输出格式（可选，默认 FormatText）
+	FileFormat    Format // 文件输出格式（可选，默认 FormatJSON；FormatText 按 FormatJSON 处理）
+
+	Sinks []Sink       // 额外输出目标（可选），与控制台、文件一并扇出
+	Async *AsyncConfig // 异步写入配置（可选），nil 表示同步写入
+
+	Levels *LevelRegistry // 按 logger 名称的级别注册表（可选，默认以 Leveler/Level 为基础级别创建）
+
+	Sampling *SamplingPolicy // 按 logger 名称的采样与重复抑制策略（可选），nil 表示不启用
+
+	Redactor *Redactor // 敏感数据脱敏器（可选），nil 表示使用全局脱敏器（见 [SetRedactor]）
 }
 
 // NewLogHandler 创建自定义 slog Handler
@@ -67,47 +84,134 @@ func NewLogHandler(config HandlerConfig) slog.Handler {
 		console = os.Stdout
 	}
 
-	return &LogHandler{
+	var level slog.Leveler = config.Level
+	if config.Leveler != nil {
+		level = config.Leveler
+	}
+
+	consoleFormat := config.ConsoleFormat
+	if consoleFormat == "" {
+		consoleFormat = FormatText
+	}
+	fileFormat := config.FileFormat
+	if fileFormat == "" || fileFormat == FormatText {
+		fileFormat = FormatJSON
+	}
+
+	sinks := []Sink{NewWriterSink(console, consoleFormat)}
+	if config.File != nil {
+		sinks = append(sinks, NewWriterSink(config.File, fileFormat))
+	}
+	for _, sink := range config.Sinks {
+		if sink != nil {
+			sinks = append(sinks, sink)
+		}
+	}
+
+	levels := config.Levels
+	if levels == nil {
+		levels = NewLevelRegistry(level)
+	}
+
+	handler := &LogHandler{
 		opts: slog.HandlerOptions{
-			Level:     config.Level,
+			Level:     levels.base,
 			AddSource: false,
 		},
-		mu:          &sync.Mutex{},
-		console:     console,
-		file:        config.File,
-		isDebugMode: config.IsDebugMode,
-		attrs:       []slog.Attr{},
+		mu:       &sync.Mutex{},
+		levels:   levels,
+		sampler:  newSampler(config.Sampling),
+		redactor: config.Redactor,
+		sinks:    sinks,
+		attrs:    []slog.Attr{},
+	}
+	if config.Async != nil {
+		handler.queue = newAsyncQueue(sinks, *config.Async)
 	}
+	return handler
 }
 
 // Enabled 判断指定级别是否启用
+//
+// 当前 logger 名称在 [LevelRegistry] 中单独设置过级别时以其为准，否则使用基础级别。
 func (h *LogHandler) Enabled(_ context.Context, level slog.Level) bool {
-	minLevel := slog.LevelInfo
-	if h.opts.Level != nil {
-		minLevel = h.opts.Level.Level()
-	}
-	return level >= minLevel
+	return h.levels.Enabled(h.group, level)
+}
+
+// Levels 返回日志级别注册表，可在运行期按 logger 名称调整级别
+func (h *LogHandler) Levels() *LevelRegistry {
+	return h.levels
 }
 
 // Handle 处理日志记录
+//
+// 启用采样时先按 [SamplingPolicy] 过滤与重复抑制；
+// 同步模式下先在锁外完成编码，再持锁依次写入所有输出目标；异步模式下仅构建条目并入队。
 func (h *LogHandler) Handle(ctx context.Context, r slog.Record) error {
+	if h.sampler != nil && !h.sampler.allow(h.group, r) {
+		return nil
+	}
+
+	entry := h.newEntry(r, h.extractContextUUID(ctx))
+	if span, ok := xTrace.FromContext(ctx); ok {
+		entry.TraceID, entry.SpanID = span.TraceID.String(), span.SpanID.String()
+	}
+	entry.Subject = xAuth.Subject(ctx)
+	if h.sampler == nil {
+		h.write(entry)
+		return nil
+	}
+	for _, e := range h.sampler.dedup(entry) {
+		h.write(e)
+	}
+	return nil
+}
+
+// write 将条目交给异步队列或同步写入所有输出目标
+//
+// 同步模式下 [WriterSink] 的编码在锁外完成，锁内仅执行写入，避免并发日志在编码上排队。
+func (h *LogHandler) write(entry *Entry) {
+	if h.queue != nil {
+		h.queue.push(entry)
+		return
+	}
+
+	encoded := encodeSinks(h.sinks, entry)
 	h.mu.Lock()
 	defer h.mu.Unlock()
+	writeEncoded(h.sinks, entry, encoded)
+}
 
-	// 提取 contextUUID ID
-	contextUUID := h.extractContextUUID(ctx)
-
-	// 写入控制台（彩色格式）
-	if h.console != nil {
-		h.writeConsole(r, contextUUID)
+// Flush 等待已入队的日志全部写出，并刷新实现了 [Flusher] 的输出目标
+//
+// 尚未输出的重复汇总会先行写出。同步模式下直接刷新输出目标；
+// ctx 到期时返回 ctx.Err()，未写出的日志仍由后台协程继续处理。
+func (h *LogHandler) Flush(ctx context.Context) error {
+	if h.sampler != nil {
+		for _, e := range h.sampler.drain() {
+			h.write(e)
+		}
 	}
 
-	// 写入文件（JSON 格式）
-	if h.file != nil {
-		h.writeFile(r, contextUUID)
+	if h.queue != nil {
+		return h.queue.flush(ctx)
 	}
 
-	return nil
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return flushSinks(h.sinks)
+}
+
+// Close 写出尚未输出的日志并停止异步队列的后台协程
+//
+// 用于启动失败等需要释放日志器的场景；输出目标（如 [RotatingWriter]）由创建方负责关闭。
+// 关闭后异步模式下的新日志被丢弃，同步模式下仍直接写入输出目标。可重复调用。
+func (h *LogHandler) Close(ctx context.Context) error {
+	err := h.Flush(ctx)
+	if h.queue != nil {
+		h.queue.stop()
+	}
+	return err
 }
 
 // WithAttrs 添加属性
@@ -116,28 +220,16 @@ func (h *LogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
 	copy(newAttrs, h.attrs)
 	copy(newAttrs[len(h.attrs):], attrs)
 
-	return &LogHandler{
-		opts:        h.opts,
-		mu:          h.mu,
-		console:     h.console,
-		file:        h.file,
-		group:       h.group,
-		attrs:       newAttrs,
-		isDebugMode: h.isDebugMode,
-	}
+	clone := *h
+	clone.attrs = newAttrs
+	return &clone
 }
 
 // WithGroup 设置日志组名称（用作 logger name）
 func (h *LogHandler) WithGroup(name string) slog.Handler {
-	return &LogHandler{
-		opts:        h.opts,
-		mu:          h.mu,
-		console:     h.console,
-		file:        h.file,
-		group:       name,
-		attrs:       h.attrs,
-		isDebugMode: h.isDebugMode,
-	}
+	clone := *h
+	clone.group = name
+	return &clone
 }
 
 // extractContextUUID 从 context 中提取 trace ID
@@ -167,34 +259,8 @@ func (h *LogHandler) extractContextUUID(ctx context.Context) string {
 	return ""
 }
 
-// writeConsole 写入控制台（彩色格式）
-// 格式: 时间 [LEVEL] [trace] [NAME] 消息
-//
-//	变量（换行棕色显示）
-func (h *LogHandler) writeConsole(r slog.Record, contextUuid string) {
-	var buf strings.Builder
-
-	// 时间戳（灰色）
-	buf.WriteString("\033[90m")
-	buf.WriteString(r.Time.Format("2006-01-02 15:04:05.000"))
-	buf.WriteString("\033[0m")
-
-	// 日志级别
-	buf.WriteString(h.colorLevel(r.Level))
-
-	// Trace ID（如果有）
-	if contextUuid != "" {
-		buf.WriteString(" \033[34m[")
-		buf.WriteString(contextUuid)
-		buf.WriteString("]\033[0m")
-	}
-
-	// Logger 名称
-	if h.group != "" {
-		buf.WriteString(h.colorName(h.group))
-	}
-
-	// Option 名称
+// optionNames 返回 WithName 写入的 option_name_<number> 属性值
+func (h *LogHandler) optionNames() []string {
 	optionNames := make([]string, 0)
 	for _, attr := range h.attrs {
 		if !strings.HasPrefix(attr.Key, "option_name_") {
@@ -208,141 +274,109 @@ func (h *LogHandler) writeConsole(r slog.Record, contextUuid string) {
 			optionNames = append(optionNames, value)
 		}
 	}
-	for _, option := range optionNames {
-		buf.WriteString(" \033[96m[")
-		buf.WriteString(option)
-		buf.WriteString("]\033[0m")
-		buf.WriteString("\t")
-	}
-
-	// 消息
-	buf.WriteString(" >> ")
-	buf.WriteString(r.Message)
-
-	// 收集所有属性
-	var hasAttrs bool
-
-	// 额外属性（棕色，换行显示）
-	r.Attrs(func(a slog.Attr) bool {
-		if strings.HasPrefix(a.Key, "option_name_") {
-			return true
-		}
-		if !hasAttrs {
-			hasAttrs = true
-		}
-		buf.WriteString("\n    \033[38;5;130m")
-		buf.WriteString(a.Key)
-		buf.WriteString("\033[0m=\033[38;5;180m")
-		buf.WriteString(fmt.Sprintf("%v", a.Value.Any()))
-		buf.WriteString("\033[0m")
-		return true
-	})
+	return optionNames
+}
 
-	// 预设属性（棕色，换行显示）
-	for _, a := range h.attrs {
+// newEntry 构建结构化日志条目
+//
+// 属性顺序为预设属性在前、记录属性在后，option_name_<number> 归入 options 字段。
+// 属性值在此处求值（LogValuer）并按 [Redactor] 脱敏，ERROR 及以上级别同时采集当前协程堆栈，
+// 保证条目交给异步队列后不再依赖调用方状态。
+func (h *LogHandler) newEntry(r slog.Record, trace string) *Entry {
+	redactor := h.redactor
+	if redactor == nil {
+		redactor = DefaultRedactor()
+	}
+	attrs := make([]slog.Attr, 0, len(h.attrs)+r.NumAttrs())
+	appendAttr := func(a slog.Attr) {
 		if strings.HasPrefix(a.Key, "option_name_") {
-			continue
+			return
 		}
-		if !hasAttrs {
-			hasAttrs = true
+		if a, ok := redactor.Attr(resolveAttr(a)); ok {
+			attrs = append(attrs, a)
 		}
-		buf.WriteString("\n    \033[38;5;130m")
-		buf.WriteString(a.Key)
-		buf.WriteString("\033[0m=\033[38;5;180m")
-		buf.WriteString(fmt.Sprintf("%v", a.Value.Any()))
-		buf.WriteString("\033[0m")
-	}
-
-	buf.WriteString("\n")
-
-	// 错误级别添加堆栈
-	if r.Level >= slog.LevelError {
-		buf.WriteString(h.getStack())
-		buf.WriteString("\n")
-	}
-
-	_, _ = io.WriteString(h.console, buf.String())
-}
-
-// writeFile 写入文件（JSON 格式）
-func (h *LogHandler) writeFile(r slog.Record, trace string) {
-	entry := map[string]interface{}{
-		"time":    r.Time.Format(time.RFC3339Nano),
-		"level":   r.Level.String(),
-		"message": r.Message,
 	}
-
-	if trace != "" {
-		entry["trace"] = trace
-	}
-	if h.group != "" {
-		entry["logger"] = h.group
+	for _, a := range h.attrs {
+		appendAttr(a)
 	}
-
-	// 添加额外属性
 	r.Attrs(func(a slog.Attr) bool {
-		entry[a.Key] = a.Value.Any()
+		appendAttr(a)
 		return true
 	})
 
-	// 添加预设属性
-	for _, a := range h.attrs {
-		entry[a.Key] = a.Value.Any()
+	entry := &Entry{
+		Time:    r.Time,
+		Level:   r.Level,
+		Logger:  h.group,
+		Options: h.optionNames(),
+		Trace:   trace,
+		Message: r.Message,
+		Attrs:   attrs,
 	}
-
-	data, err := json.Marshal(entry)
-	if err == nil {
-		data = append(data, '\n')
-		_, _ = h.file.Write(data)
+	if r.Level >= slog.LevelError {
+		entry.Stack = getStack()
 	}
+	return entry
 }
 
-// colorLevel 返回带颜色的日志级别
-func (h *LogHandler) colorLevel(level slog.Level) string {
-	switch level {
-	case slog.LevelDebug:
-		return " \033[36m[DEBU]\033[0m" // 青色
-	case slog.LevelInfo:
-		return " \033[32m[INFO]\033[0m" // 绿色
-	case slog.LevelWarn:
-		return " \033[33m[WARN]\033[0m" // 黄色
-	case slog.LevelError:
-		return " \033[31m[ERRO]\033[0m" // 红色
-	default:
-		return " \033[32m[INFO]\033[0m"
+// resolveAttr 求值属性（含分组内属性）中的 LogValuer
+func resolveAttr(a slog.Attr) slog.Attr {
+	a.Value = a.Value.Resolve()
+	if a.Value.Kind() == slog.KindGroup {
+		group := a.Value.Group()
+		resolved := make([]slog.Attr, len(group))
+		for i, ga := range group {
+			resolved[i] = resolveAttr(ga)
+		}
+		a.Value = slog.GroupValue(resolved...)
 	}
+	return a
+}
+
+// writeSinks 将条目依次写入所有输出目标，单个目标失败不影响其他目标
+func writeSinks(sinks []Sink, entry *Entry) {
+	writeEncoded(sinks, entry, encodeSinks(sinks, entry))
 }
 
-// colorName 返回带颜色的 logger 名称
-func (h *LogHandler) colorName(name string) string {
-	if len(name) != 4 {
-		return fmt.Sprintf(" \033[96m[%s]\033[0m", name) // 亮青色
+// encodeSinks 为每个 [WriterSink] 预先编码条目，相同格式只编码一次；其他输出目标对应 nil
+func encodeSinks(sinks []Sink, entry *Entry) [][]byte {
+	encoded := make([][]byte, len(sinks))
+	for i, sink := range sinks {
+		ws, ok := sink.(*WriterSink)
+		if !ok {
+			continue
+		}
+		for j := range i {
+			if prev, ok := sinks[j].(*WriterSink); ok && prev.format == ws.format {
+				encoded[i] = encoded[j]
+				break
+			}
+		}
+		if encoded[i] == nil {
+			encoded[i] = entry.Encode(ws.format)
+		}
 	}
+	return encoded
+}
 
-	switch name {
-	// 核心服务类 - 蓝色
-	case NamedCONT, NamedSERV, NamedLOGC, NamedREPO, NamedCORE, NamedBASE, NamedMAIN:
-		return fmt.Sprintf(" \033[34m[%s]\033[0m", name)
-	// 路由网络类 - 黄色
-	case NamedROUT, NamedHTTP, NamedGRPC, NamedSOCK, NamedCONN, NamedLINK:
-		return fmt.Sprintf(" \033[33m[%s]\033[0m", name)
-	// 安全认证类 - 红色
-	case NamedAUTH, NamedUSER, NamedPERM, NamedROLE, NamedTOKN, NamedSIGN:
-		return fmt.Sprintf(" \033[31m[%s]\033[0m", name)
-	// 业务逻辑类 - 白色
-	case NamedBUSI, NamedPROC, NamedFLOW, NamedTASK, NamedJOBS:
-		return fmt.Sprintf(" \033[37m[%s]\033[0m", name)
-	// 其他已定义的常量 - 橙色
-	case NamedRECO, NamedUTIL, NamedFILT, NamedMIDE, NamedVALD, NamedINIT, NamedTHOW, NamedRESU:
-		return fmt.Sprintf(" \033[93m[%s]\033[0m", name)
-	default:
-		return fmt.Sprintf(" \033[35m[%s]\033[0m", name) // 紫色
+// writeEncoded 写入已编码的内容，未预先编码的输出目标调用 WriteEntry，单个目标失败不影响其他目标
+func writeEncoded(sinks []Sink, entry *Entry, encoded [][]byte) {
+	for i, sink := range sinks {
+		var err error
+		if ws, ok := sink.(*WriterSink); ok && encoded[i] != nil {
+			_, err = ws.w.Write(encoded[i])
+		} else {
+			err = sink.WriteEntry(entry)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "[LOG] 写入日志输出目标失败: %v\n", err)
+		}
 	}
 }
 
 // getStack 获取堆栈信息
-func (h *LogHandler) getStack() string {
+func getStack() string {
 	buf := make([]byte, 4096)
 	n := runtime.Stack(buf, false)
-	return "\033[31m" + string(buf[:n]) + "\033[0m"
+	return string(buf[:n])
 }
@@ -0,0 +1,25 @@
+package xLog
+
+import (
+	"log/slog"
+	"strings"
+)
+
+// ParseLevel 解析 debug/info/notice/warn/error 形式的日志级别（大小写不敏感）
+//
+// 无法识别时返回 slog.LevelInfo 与 false，由调用方决定是否回退默认值。
+func ParseLevel(text string) (slog.Level, bool) {
+	switch strings.ToLower(strings.TrimSpace(text)) {
+	case "debug":
+		return slog.LevelDebug, true
+	case "info":
+		return slog.LevelInfo, true
+	case "notice":
+		return slog.LevelInfo + 1, true
+	case "warn", "warning":
+		return slog.LevelWarn, true
+	case "error":
+		return slog.LevelError, true
+	}
+	return slog.LevelInfo, false
+}
@@ -0,0 +1,94 @@
+package xLog
+
+import (
+	"log/slog"
+	"maps"
+	"sync"
+	"sync/atomic"
+)
+
+// LevelRegistry 按 logger 名称（WithName 的 name，如 NamedREPO）管理日志级别
+//
+// 未单独设置的 logger 使用基础级别；单独设置后仅影响该 logger，
+// 便于在生产环境排障时只为 REPO 打开 debug 而不放大其他日志量。
+// 读取路径无锁（写时复制），可在每次 Enabled 判断时调用。
+type LevelRegistry struct {
+	base      slog.Leveler
+	mu        sync.Mutex // 串行化写操作
+	overrides atomic.Pointer[map[string]slog.Level]
+}
+
+// NewLevelRegistry 创建日志级别注册表
+//
+// 参数说明:
+//   - base: 基础级别，nil 时为 slog.LevelInfo；传入 *slog.LevelVar 时可通过 SetBase 调整
+func NewLevelRegistry(base slog.Leveler) *LevelRegistry {
+	if base == nil {
+		base = slog.LevelInfo
+	}
+	r := &LevelRegistry{base: base}
+	r.overrides.Store(&map[string]slog.Level{})
+	return r
+}
+
+// Base 返回基础级别
+func (r *LevelRegistry) Base() slog.Level {
+	return r.base.Level()
+}
+
+// SetBase 调整基础级别，基础级别不是 *slog.LevelVar 时返回 false
+func (r *LevelRegistry) SetBase(level slog.Level) bool {
+	v, ok := r.base.(*slog.LevelVar)
+	if ok {
+		v.Set(level)
+	}
+	return ok
+}
+
+// Level 返回指定 logger 的生效级别
+func (r *LevelRegistry) Level(name string) slog.Level {
+	if level, ok := (*r.overrides.Load())[name]; ok {
+		return level
+	}
+	return r.base.Level()
+}
+
+// Enabled 判断指定 logger 是否输出该级别
+func (r *LevelRegistry) Enabled(name string, level slog.Level) bool {
+	return level >= r.Level(name)
+}
+
+// Set 为指定 logger 单独设置级别
+func (r *LevelRegistry) Set(name string, level slog.Level) {
+	r.update(func(m map[string]slog.Level) { m[name] = level })
+}
+
+// Reset 清除指定 logger 的单独设置，恢复使用基础级别
+func (r *LevelRegistry) Reset(name string) {
+	r.update(func(m map[string]slog.Level) { delete(m, name) })
+}
+
+// Overrides 返回所有单独设置的级别副本
+func (r *LevelRegistry) Overrides() map[string]slog.Level {
+	return maps.Clone(*r.overrides.Load())
+}
+
+// update 写时复制更新单独设置
+func (r *LevelRegistry) update(fn func(map[string]slog.Level)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	next := maps.Clone(*r.overrides.Load())
+	fn(next)
+	r.overrides.Store(&next)
+}
+
+// Levels 返回全局默认 logger 的日志级别注册表
+//
+// 默认 Handler 不是 [LogHandler] 时返回 nil。
+func Levels() *LevelRegistry {
+	if h, ok := slog.Default().Handler().(interface{ Levels() *LevelRegistry }); ok {
+		return h.Levels()
+	}
+	return nil
+}
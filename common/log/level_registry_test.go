@@ -0,0 +1,52 @@
+package xLog
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+// TestLevelRegistry_PerLogger 验证单独设置仅影响对应 logger，Reset 后恢复基础级别。
+func TestLevelRegistry_PerLogger(t *testing.T) {
+	base := new(slog.LevelVar)
+	base.Set(slog.LevelInfo)
+	levels := NewLevelRegistry(base)
+
+	var console bytes.Buffer
+	handler := NewLogHandler(HandlerConfig{Console: &console, ConsoleFormat: FormatLogfmt, Levels: levels})
+	repo := slog.New(handler.WithGroup(NamedREPO))
+	http := slog.New(handler.WithGroup(NamedHTTP))
+
+	levels.Set(NamedREPO, slog.LevelDebug)
+	repo.Debug("repo-debug")
+	http.Debug("http-debug")
+	if !strings.Contains(console.String(), "repo-debug") || strings.Contains(console.String(), "http-debug") {
+		t.Errorf("仅 REPO 应输出 debug: %s", console.String())
+	}
+
+	levels.Reset(NamedREPO)
+	console.Reset()
+	repo.Debug("repo-debug")
+	if console.Len() != 0 {
+		t.Errorf("Reset 后应恢复基础级别: %s", console.String())
+	}
+
+	if !levels.SetBase(slog.LevelWarn) || levels.Level(NamedHTTP) != slog.LevelWarn {
+		t.Errorf("SetBase 应调整未单独设置的 logger: %v", levels.Level(NamedHTTP))
+	}
+	if len(levels.Overrides()) != 0 {
+		t.Errorf("Overrides 应为空: %v", levels.Overrides())
+	}
+}
+
+// TestLevelRegistry_FixedBase 验证基础级别不是 LevelVar 时 SetBase 返回 false。
+func TestLevelRegistry_FixedBase(t *testing.T) {
+	levels := NewLevelRegistry(slog.LevelError)
+	if levels.SetBase(slog.LevelDebug) {
+		t.Error("固定基础级别不应支持 SetBase")
+	}
+	if levels.Enabled(NamedCORE, slog.LevelWarn) {
+		t.Error("WARN 低于基础级别 ERROR，不应输出")
+	}
+}
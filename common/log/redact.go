@@ -0,0 +1,617 @@
+package xLog
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"path"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"unicode/utf8"
+)
+
+// 内置脱敏器名称，用于结构体标签 `log:"<name>"` 与 [RedactRule].Masker
+const (
+	MaskerOmit     = "-"        // 整体移除字段
+	MaskerDefault  = "mask"     // 保留首尾各 3 个字符，短值全部隐藏
+	MaskerPhone    = "phone"    // 手机号：保留前 3 后 4 位
+	MaskerEmail    = "email"    // 邮箱：保留用户名首字符与域名
+	MaskerIDCard   = "idcard"   // 身份证号：保留前 6 后 4 位
+	MaskerBankCard = "bankcard" // 银行卡号：保留前 4 后 4 位
+)
+
+// maskedAll 短值或无法按类型脱敏时的替换文本
+const maskedAll = "******"
+
+// maxRedactDepth 反射遍历的最大深度，超出部分不再展开，避免循环引用
+const maxRedactDepth = 16
+
+// Masker 将敏感值转换为脱敏后的文本
+type Masker func(value string) string
+
+// RedactRule 按属性名（日志属性键、JSON 字段名、map 键、请求头名）匹配的脱敏规则
+//
+// Pattern 忽略大小写与 '_'、'-'，支持 path.Match 通配语法，如 "*password*" 同时匹配
+// password、old_password 与 newPassword。Masker 为脱敏器名称，为空时使用 [MaskerDefault]。
+type RedactRule struct {
+	Pattern string
+	Masker  string
+}
+
+// DefaultRedactRules 默认脱敏规则，覆盖常见的凭证、手机号、邮箱、身份证与银行卡字段
+var DefaultRedactRules = []RedactRule{
+	{Pattern: "setcookie", Masker: MaskerOmit},
+	{Pattern: "*password*", Masker: MaskerDefault},
+	{Pattern: "passwd", Masker: MaskerDefault},
+	{Pattern: "*secret*", Masker: MaskerDefault},
+	{Pattern: "*token", Masker: MaskerDefault},
+	{Pattern: "*apikey", Masker: MaskerDefault},
+	{Pattern: "*authorization", Masker: MaskerDefault},
+	{Pattern: "cookie", Masker: MaskerDefault},
+	{Pattern: "*phone*", Masker: MaskerPhone},
+	{Pattern: "*mobile*", Masker: MaskerPhone},
+	{Pattern: "*email*", Masker: MaskerEmail},
+	{Pattern: "*idcard*", Masker: MaskerIDCard},
+	{Pattern: "*idno", Masker: MaskerIDCard},
+	{Pattern: "*bankcard*", Masker: MaskerBankCard},
+	{Pattern: "*cardno", Masker: MaskerBankCard},
+	{Pattern: "*cardnumber", Masker: MaskerBankCard},
+}
+
+// RedactPolicy 脱敏策略
+type RedactPolicy struct {
+	Rules           []RedactRule      // 额外规则，优先于默认规则匹配
+	DisableDefaults bool              // 是否不使用 [DefaultRedactRules]
+	Maskers         map[string]Masker // 自定义脱敏器，可覆盖同名内置脱敏器
+}
+
+// Redactor 敏感数据脱敏器
+//
+// 按属性名匹配 [RedactRule]，并识别结构体字段上的 `log` 标签：
+//
+//	type User struct {
+//	    Name     string `json:"name"`
+//	    Phone    string `json:"phone" log:"phone"`
+//	    Password string `json:"password" log:"-"`
+//	    Remark   string `json:"remark" log:"mask"`
+//	}
+//
+// 含敏感字段的结构体、map 与切片会被转换为以 JSON 字段名为键的 map[string]any / []any 副本，
+// 不含敏感字段时原样返回，不影响原值。可安全地并发使用。
+type Redactor struct {
+	rules   []RedactRule
+	maskers map[string]Masker
+	keys    sync.Map // 结构体字段名与日志属性名 -> string（脱敏器名称，空串表示不匹配），不缓存 map 键等外部输入
+}
+
+// NewRedactor 按策略创建脱敏器
+func NewRedactor(policy RedactPolicy) *Redactor {
+	rules := make([]RedactRule, 0, len(policy.Rules)+len(DefaultRedactRules))
+	for _, rule := range policy.Rules {
+		if pattern := normalizeRedactKey(rule.Pattern); pattern != "" {
+			rules = append(rules, RedactRule{Pattern: pattern, Masker: rule.Masker})
+		}
+	}
+	if !policy.DisableDefaults {
+		rules = append(rules, DefaultRedactRules...)
+	}
+	for i := range rules {
+		if rules[i].Masker == "" {
+			rules[i].Masker = MaskerDefault
+		}
+	}
+
+	maskers := map[string]Masker{
+		MaskerDefault:  MaskDefault,
+		MaskerPhone:    MaskPhone,
+		MaskerEmail:    MaskEmail,
+		MaskerIDCard:   MaskIDCard,
+		MaskerBankCard: MaskBankCard,
+	}
+	for name, masker := range policy.Maskers {
+		if masker != nil && name != MaskerOmit {
+			maskers[name] = masker
+		}
+	}
+	return &Redactor{rules: rules, maskers: maskers}
+}
+
+// globalRedactor 全局脱敏器
+var globalRedactor atomic.Pointer[Redactor]
+
+func init() {
+	globalRedactor.Store(NewRedactor(RedactPolicy{}))
+}
+
+// SetRedactor 设置全局脱敏器，nil 时恢复默认策略
+//
+// LogHandler、HTTP 请求日志、xResult 与 xError 均使用全局脱敏器。
+func SetRedactor(r *Redactor) {
+	if r == nil {
+		r = NewRedactor(RedactPolicy{})
+	}
+	globalRedactor.Store(r)
+}
+
+// DefaultRedactor 返回全局脱敏器
+func DefaultRedactor() *Redactor {
+	return globalRedactor.Load()
+}
+
+// Redact 使用全局脱敏器脱敏 v，见 [Redactor.Value]
+func Redact(v any) any {
+	return DefaultRedactor().Value(v)
+}
+
+// Redacted 返回延迟脱敏的 slog.LogValuer，日志实际输出时才遍历 v
+//
+// 使用示例:
+//
+//	log.Info(ctx, "创建用户", slog.Any("data", xLog.Redacted(user)))
+func Redacted(v any) slog.LogValuer {
+	return redactedValue{v: v}
+}
+
+// redactedValue 延迟脱敏的属性值
+type redactedValue struct {
+	v any
+}
+
+// LogValue 实现 slog.LogValuer
+func (r redactedValue) LogValue() slog.Value {
+	return slog.AnyValue(Redact(r.v))
+}
+
+// cachedMasker 返回属性名匹配的脱敏器名称并缓存结果，仅用于结构体字段名与日志属性名等由代码决定、数量有限的键
+func (r *Redactor) cachedMasker(key string) string {
+	if key == "" {
+		return ""
+	}
+	if cached, ok := r.keys.Load(key); ok {
+		return cached.(string)
+	}
+	name := r.masker(key)
+	r.keys.Store(key, name)
+	return name
+}
+
+// masker 返回属性名匹配的脱敏器名称，不匹配时返回空串；不缓存，用于 map 键、请求头名等可能由客户端控制的键
+func (r *Redactor) masker(key string) string {
+	if key == "" {
+		return ""
+	}
+	normalized := normalizeRedactKey(key)
+	for _, rule := range r.rules {
+		if matched, _ := path.Match(rule.Pattern, normalized); matched {
+			return rule.Masker
+		}
+	}
+	return ""
+}
+
+// mask 使用指定脱敏器处理字符串，未知脱敏器按 [MaskerDefault] 处理
+func (r *Redactor) mask(name, value string) string {
+	if masker, ok := r.maskers[name]; ok {
+		return masker(value)
+	}
+	return MaskDefault(value)
+}
+
+// String 按属性名脱敏单个字符串值（如请求头、表单字段），属性名可由客户端控制，匹配结果不缓存
+//
+// 返回 false 表示该字段应整体移除。
+func (r *Redactor) String(key, value string) (string, bool) {
+	switch name := r.masker(key); name {
+	case "":
+		return value, true
+	case MaskerOmit:
+		return "", false
+	default:
+		return r.mask(name, value), true
+	}
+}
+
+// Attr 脱敏日志属性，返回 false 表示该属性应整体移除
+//
+// 属性名匹配规则时按对应脱敏器处理属性值；否则展开分组与任意类型值，处理其中的敏感字段。
+func (r *Redactor) Attr(a slog.Attr) (slog.Attr, bool) {
+	name := r.cachedMasker(a.Key)
+	switch {
+	case name == MaskerOmit:
+		return a, false
+	case name != "":
+		a.Value = r.forceValue(a.Value, name)
+	case a.Value.Kind() == slog.KindGroup:
+		group := a.Value.Group()
+		attrs := make([]slog.Attr, 0, len(group))
+		for _, ga := range group {
+			if ga, ok := r.Attr(ga); ok {
+				attrs = append(attrs, ga)
+			}
+		}
+		a.Value = slog.GroupValue(attrs...)
+	case a.Value.Kind() == slog.KindAny:
+		if out, changed := r.walk(reflect.ValueOf(a.Value.Any()), "", 0); changed {
+			a.Value = slog.AnyValue(out)
+		}
+	}
+	return a, true
+}
+
+// forceValue 将属性值中的所有标量按脱敏器 name 处理，布尔、时长与时间保持原值
+func (r *Redactor) forceValue(v slog.Value, name string) slog.Value {
+	switch v.Kind() {
+	case slog.KindGroup:
+		group := v.Group()
+		attrs := make([]slog.Attr, len(group))
+		for i, ga := range group {
+			attrs[i] = slog.Attr{Key: ga.Key, Value: r.forceValue(ga.Value, name)}
+		}
+		return slog.GroupValue(attrs...)
+	case slog.KindAny:
+		out, _ := r.walk(reflect.ValueOf(v.Any()), name, 0)
+		return slog.AnyValue(out)
+	case slog.KindBool, slog.KindDuration, slog.KindTime:
+		return v
+	default:
+		return slog.StringValue(r.mask(name, v.String()))
+	}
+}
+
+// Value 脱敏任意值（结构体、map、切片、字符串等），不含敏感字段时原样返回
+func (r *Redactor) Value(v any) any {
+	if v == nil {
+		return nil
+	}
+	if out, changed := r.walk(reflect.ValueOf(v), "", 0); changed {
+		return out
+	}
+	return v
+}
+
+// JSON 脱敏 JSON 文本，无法解析时返回 false
+func (r *Redactor) JSON(data []byte) ([]byte, bool) {
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return data, false
+	}
+	out, err := json.Marshal(r.Value(v))
+	if err != nil {
+		return data, false
+	}
+	return out, true
+}
+
+var (
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+	jsonMarshalerType = reflect.TypeFor[json.Marshaler]()
+	stringerType      = reflect.TypeFor[fmt.Stringer]()
+	errorType         = reflect.TypeFor[error]()
+)
+
+// walk 遍历 v，force 非空时所有标量按该脱敏器处理
+//
+// changed 为 false 时 out 即原值，调用方应继续使用原值，保证普通数据的输出格式不变。
+func (r *Redactor) walk(v reflect.Value, force string, depth int) (out any, changed bool) {
+	if !v.IsValid() {
+		return nil, false
+	}
+	if depth > maxRedactDepth {
+		return plainValue(v), false
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() || (force == "" && isOpaque(v.Type())) {
+			return plainValue(v), false
+		}
+		if out, changed := r.walk(v.Elem(), force, depth+1); changed {
+			return out, true
+		}
+		return plainValue(v), false
+	case reflect.Struct:
+		if !isOpaque(v.Type()) {
+			return r.walkStruct(v, force, depth)
+		}
+		if force != "" {
+			return r.mask(force, fmt.Sprint(plainValue(v))), true
+		}
+		return plainValue(v), false
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String || v.IsNil() {
+			return plainValue(v), false
+		}
+		return r.walkMap(v, force, depth)
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 {
+			if force != "" && v.Len() > 0 {
+				return maskedAll, true
+			}
+			return plainValue(v), false
+		}
+		return r.walkSlice(v, force, depth)
+	case reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		if force != "" {
+			return r.mask(force, scalarString(v)), true
+		}
+		return plainValue(v), false
+	default:
+		return plainValue(v), false
+	}
+}
+
+// walkStruct 遍历结构体导出字段，含敏感字段时转换为 map[string]any
+func (r *Redactor) walkStruct(v reflect.Value, force string, depth int) (any, bool) {
+	info := cachedRedactType(v.Type())
+	changed := force != ""
+	out := make(map[string]any, len(info.fields))
+	for _, field := range info.fields {
+		fv, ok := fieldByIndex(v, field.index)
+		if !ok {
+			continue
+		}
+
+		name := force
+		if name == "" {
+			name = field.tag
+		}
+		if name == "" {
+			if name = r.cachedMasker(field.name); name == "" {
+				name = r.cachedMasker(field.goName)
+			}
+		}
+		if name == MaskerOmit {
+			changed = true
+			continue
+		}
+		if field.omitEmpty && fv.IsZero() {
+			continue
+		}
+
+		value, fieldChanged := r.walk(fv, name, depth+1)
+		changed = changed || fieldChanged
+		out[field.name] = value
+	}
+	if !changed {
+		return plainValue(v), false
+	}
+	return out, true
+}
+
+// walkMap 遍历 string 键 map，含敏感字段时复制为 map[string]any
+func (r *Redactor) walkMap(v reflect.Value, force string, depth int) (any, bool) {
+	changed := force != ""
+	out := make(map[string]any, v.Len())
+	iter := v.MapRange()
+	for iter.Next() {
+		key := iter.Key().String()
+		name := force
+		if name == "" {
+			name = r.masker(key)
+		}
+		if name == MaskerOmit {
+			changed = true
+			continue
+		}
+		value, valueChanged := r.walk(iter.Value(), name, depth+1)
+		changed = changed || valueChanged
+		out[key] = value
+	}
+	if !changed {
+		return plainValue(v), false
+	}
+	return out, true
+}
+
+// walkSlice 遍历切片与数组，含敏感元素时复制为 []any
+func (r *Redactor) walkSlice(v reflect.Value, force string, depth int) (any, bool) {
+	changed := force != ""
+	out := make([]any, v.Len())
+	for i := range out {
+		var elemChanged bool
+		out[i], elemChanged = r.walk(v.Index(i), force, depth+1)
+		changed = changed || elemChanged
+	}
+	if !changed {
+		return plainValue(v), false
+	}
+	return out, true
+}
+
+// scalarString 返回字符串或数值的文本形式，浮点数不使用科学计数法（JSON 解码的手机号等为 float64）
+func scalarString(v reflect.Value) string {
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64)
+	default:
+		return fmt.Sprint(plainValue(v))
+	}
+}
+
+// plainValue 返回 v 的原值；经由未导出嵌入结构体读取的只读字段按基础类型取值
+func plainValue(v reflect.Value) any {
+	if v.CanInterface() {
+		return v.Interface()
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return v.Uint()
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	default:
+		return nil
+	}
+}
+
+// isOpaque 判断类型是否自行决定输出形式（time.Time、error、实现了 Marshaler/Stringer 的类型），不再展开
+func isOpaque(t reflect.Type) bool {
+	if t.Kind() == reflect.Interface {
+		return false
+	}
+	return t.Implements(jsonMarshalerType) || t.Implements(textMarshalerType) ||
+		t.Implements(stringerType) || t.Implements(errorType)
+}
+
+// fieldByIndex 按索引路径读取字段，途经 nil 指针时返回 false
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
+
+// redactField 结构体字段的脱敏元信息
+type redactField struct {
+	index     []int
+	name      string // JSON 字段名
+	goName    string // Go 字段名
+	tag       string // log 标签指定的脱敏器
+	omitEmpty bool
+}
+
+// redactType 结构体类型的脱敏元信息
+type redactType struct {
+	fields []redactField
+}
+
+// redactTypes 结构体类型 -> *redactType 缓存
+var redactTypes sync.Map
+
+// cachedRedactType 解析结构体字段，匿名嵌入且无 JSON 名称的结构体字段按 encoding/json 规则展开
+func cachedRedactType(t reflect.Type) *redactType {
+	if cached, ok := redactTypes.Load(t); ok {
+		return cached.(*redactType)
+	}
+	info := &redactType{}
+	collectRedactFields(t, nil, info, 0)
+	actual, _ := redactTypes.LoadOrStore(t, info)
+	return actual.(*redactType)
+}
+
+// collectRedactFields 收集 t 的导出字段
+func collectRedactFields(t reflect.Type, index []int, info *redactType, depth int) {
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		jsonName, opts, _ := strings.Cut(sf.Tag.Get("json"), ",")
+		if jsonName == "-" && opts == "" {
+			continue
+		}
+
+		ft := sf.Type
+		if ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		fieldIndex := append(append([]int(nil), index...), i)
+		if sf.Anonymous && jsonName == "" && ft.Kind() == reflect.Struct && depth < maxRedactDepth {
+			collectRedactFields(ft, fieldIndex, info, depth+1)
+			continue
+		}
+		if !sf.IsExported() {
+			continue
+		}
+
+		field := redactField{
+			index:     fieldIndex,
+			name:      jsonName,
+			goName:    sf.Name,
+			tag:       strings.TrimSpace(sf.Tag.Get("log")),
+			omitEmpty: strings.Contains(opts, "omitempty"),
+		}
+		if field.name == "" {
+			field.name = sf.Name
+		}
+		info.fields = append(info.fields, field)
+	}
+}
+
+// normalizeRedactKey 转为小写并去除 '_'、'-'，使 old_password、newPassword、X-Api-Key 等写法统一
+func normalizeRedactKey(key string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '_' || r == '-' {
+			return -1
+		}
+		return toLowerASCII(r)
+	}, strings.TrimSpace(key))
+}
+
+// toLowerASCII ASCII 字母转小写
+func toLowerASCII(r rune) rune {
+	if 'A' <= r && r <= 'Z' {
+		return r + ('a' - 'A')
+	}
+	return r
+}
+
+// MaskDefault 保留首尾各最多 3 个字符，中间以 "..." 替换；不超过 6 个字符时全部替换为 ******
+//
+// 示例: "mySecretToken123" → "myS...123"，"abc" → "******"
+func MaskDefault(value string) string {
+	runes := []rune(value)
+	if len(runes) <= 6 {
+		return maskedAll
+	}
+	return string(runes[:3]) + "..." + string(runes[len(runes)-3:])
+}
+
+// MaskPhone 手机号脱敏，保留前 3 后 4 位
+//
+// 示例: "13812345678" → "138****5678"
+func MaskPhone(value string) string {
+	return maskMiddle(value, 3, 4)
+}
+
+// MaskEmail 邮箱脱敏，保留用户名首字符与完整域名
+//
+// 示例: "zhangsan@example.com" → "z***@example.com"
+func MaskEmail(value string) string {
+	local, domain, ok := strings.Cut(value, "@")
+	if !ok || local == "" {
+		return MaskDefault(value)
+	}
+	first, _ := utf8.DecodeRuneInString(local)
+	return string(first) + "***@" + domain
+}
+
+// MaskIDCard 身份证号脱敏，保留前 6 后 4 位
+//
+// 示例: "110101199003071234" → "110101********1234"
+func MaskIDCard(value string) string {
+	return maskMiddle(value, 6, 4)
+}
+
+// MaskBankCard 银行卡号脱敏，保留前 4 后 4 位
+//
+// 示例: "6222021234567890123" → "6222***********0123"
+func MaskBankCard(value string) string {
+	return maskMiddle(value, 4, 4)
+}
+
+// maskMiddle 保留前 left 后 right 个字符，中间逐字符替换为 '*'；长度不足时按 [MaskDefault] 处理
+func maskMiddle(value string, left, right int) string {
+	runes := []rune(value)
+	if len(runes) <= left+right {
+		return MaskDefault(value)
+	}
+	return string(runes[:left]) + strings.Repeat("*", len(runes)-left-right) + string(runes[len(runes)-right:])
+}
@@ -0,0 +1,107 @@
+package xLog
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"time"
+)
+
+// retainedFile 描述一个受保留策略管理的旧日志文件。
+type retainedFile struct {
+	path string
+	time time.Time
+}
+
+// archivePattern 匹配每日归档包 logger-YYYY-MM-DD.tar.gz 与 logger-YYYY-MM-DD.tar.zst。
+var archivePattern = regexp.MustCompile(`^logger-(\d{4}-\d{2}-\d{2})\.tar\.(?:gz|zst)$`)
+
+// prune 按 MaxAge / MaxBackups / MaxArchives 清理旧日志
+//
+// 管理范围:
+//   - 备份文件: log.N.log 切割文件与 log-YYYY-MM-DD(.N).log 日期文件，按修改时间排序
+//   - 归档文件: logger-YYYY-MM-DD.tar.gz / .tar.zst，按文件名中的日期排序
+//
+// 清理规则:
+//  1. 早于 MaxAge 天的文件直接删除
+//  2. 备份文件只保留最新的 MaxBackups 个，归档文件只保留最新的 MaxArchives 个
+//
+// 当前写入的 log.log 永远不会被清理。调用方需持有锁。
+func (w *RotatingWriter) prune() {
+	if w.maxAge <= 0 && w.maxBackups <= 0 && w.maxArchives <= 0 {
+		return
+	}
+
+	backups, archives, err := w.collectRetainedFiles()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "[LOG] 扫描日志目录失败: %v\n", err)
+		return
+	}
+
+	limits := []int{w.maxBackups, w.maxArchives}
+	for groupIndex, group := range [][]retainedFile{backups, archives} {
+		sort.Slice(group, func(i, j int) bool {
+			return group[i].time.After(group[j].time)
+		})
+
+		var cutoff time.Time
+		if w.maxAge > 0 {
+			cutoff = time.Now().AddDate(0, 0, -w.maxAge)
+		}
+		for index, file := range group {
+			expired := !cutoff.IsZero() && file.time.Before(cutoff)
+			overflow := limits[groupIndex] > 0 && index >= limits[groupIndex]
+			if !expired && !overflow {
+				continue
+			}
+			if err := os.Remove(file.path); err != nil && !os.IsNotExist(err) {
+				fmt.Fprintf(os.Stderr, "[LOG] 清理旧日志失败: %v\n", err)
+			}
+		}
+	}
+}
+
+// collectRetainedFiles 收集目录中的备份文件与归档文件
+func (w *RotatingWriter) collectRetainedFiles() (backups, archives []retainedFile, err error) {
+	entries, err := os.ReadDir(w.dir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rotatedPattern := regexp.MustCompile(
+		fmt.Sprintf(`^%s\.(\d+)%s$`, regexp.QuoteMeta(w.baseName), regexp.QuoteMeta(w.ext)),
+	)
+	datedPattern := regexp.MustCompile(
+		fmt.Sprintf(`^%s-(\d{4}-\d{2}-\d{2})(\.\d+)?%s$`,
+			regexp.QuoteMeta(w.baseName), regexp.QuoteMeta(w.ext)),
+	)
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		name := entry.Name()
+		path := filepath.Join(w.dir, name)
+
+		if matches := archivePattern.FindStringSubmatch(name); len(matches) == 2 {
+			date, parseErr := time.ParseInLocation("2006-01-02", matches[1], time.Local)
+			if parseErr != nil {
+				continue
+			}
+			// 归档包在启动时可能被批量创建，修改时间不可靠，以文件名日期的次日零点（即当日日志结束时刻）为准
+			archives = append(archives, retainedFile{path: path, time: date.AddDate(0, 0, 1)})
+			continue
+		}
+
+		if rotatedPattern.MatchString(name) || datedPattern.MatchString(name) {
+			info, infoErr := entry.Info()
+			if infoErr != nil {
+				continue
+			}
+			backups = append(backups, retainedFile{path: path, time: info.ModTime()})
+		}
+	}
+	return backups, archives, nil
+}
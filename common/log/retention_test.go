@@ -0,0 +1,103 @@
+package xLog
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// newPruneWriter 构造仅用于测试 prune 的写入器，不启动归档调度。
+func newPruneWriter(t *testing.T, maxAge, maxBackups int) *RotatingWriter {
+	t.Helper()
+	return &RotatingWriter{
+		dir:        t.TempDir(),
+		baseName:   "log",
+		ext:        ".log",
+		maxAge:     maxAge,
+		maxBackups: maxBackups,
+	}
+}
+
+// touch 创建文件并设置修改时间。
+func touch(t *testing.T, dir, name string, modTime time.Time) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte("x"), 0644); err != nil {
+		t.Fatalf("创建文件失败: %v", err)
+	}
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatalf("设置修改时间失败: %v", err)
+	}
+	return path
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// TestPrune_MaxBackups 验证切割文件只保留最新的 MaxBackups 个，当前文件不受影响。
+func TestPrune_MaxBackups(t *testing.T) {
+	w := newPruneWriter(t, 0, 2)
+	now := time.Now()
+	oldest := touch(t, w.dir, "log.0.log", now.Add(-3*time.Hour))
+	middle := touch(t, w.dir, "log.1.log", now.Add(-2*time.Hour))
+	newest := touch(t, w.dir, "log.2.log", now.Add(-1*time.Hour))
+	current := touch(t, w.dir, "log.log", now.Add(-4*time.Hour))
+
+	w.prune()
+
+	if exists(oldest) {
+		t.Error("最旧的切割文件应被清理")
+	}
+	if !exists(middle) || !exists(newest) {
+		t.Error("最新的两个切割文件应保留")
+	}
+	if !exists(current) {
+		t.Error("当前写入文件不应被清理")
+	}
+}
+
+// TestPrune_MaxAge 验证超过 MaxAge 天的切割文件与归档包被清理。
+func TestPrune_MaxAge(t *testing.T) {
+	w := newPruneWriter(t, 3, 0)
+	now := time.Now()
+	staleBackup := touch(t, w.dir, "log-2000-01-01.log", now.AddDate(0, 0, -10))
+	freshBackup := touch(t, w.dir, "log.0.log", now)
+	staleArchive := touch(t, w.dir, "logger-2000-01-01.tar.gz", now)
+	freshName := "logger-" + now.AddDate(0, 0, -1).Format("2006-01-02") + ".tar.gz"
+	freshArchive := touch(t, w.dir, freshName, now)
+	unrelated := touch(t, w.dir, "other.txt", now.AddDate(0, 0, -10))
+
+	w.prune()
+
+	if exists(staleBackup) || exists(staleArchive) {
+		t.Error("过期的切割文件与归档包应被清理")
+	}
+	if !exists(freshBackup) || !exists(freshArchive) {
+		t.Error("未过期的文件应保留")
+	}
+	if !exists(unrelated) {
+		t.Error("不受管理的文件不应被清理")
+	}
+}
+
+// TestPrune_MaxArchives 验证归档数量独立于切割文件数量限制。
+func TestPrune_MaxArchives(t *testing.T) {
+	w := newPruneWriter(t, 0, 5)
+	w.maxArchives = 1
+	now := time.Now()
+	oldArchive := touch(t, w.dir, "logger-"+now.AddDate(0, 0, -2).Format("2006-01-02")+".tar.gz", now)
+	newArchive := touch(t, w.dir, "logger-"+now.AddDate(0, 0, -1).Format("2006-01-02")+".tar.zst", now)
+	backup := touch(t, w.dir, "log.0.log", now.Add(-time.Hour))
+
+	w.prune()
+
+	if exists(oldArchive) {
+		t.Error("超出 MaxArchives 的旧归档应被清理")
+	}
+	if !exists(newArchive) || !exists(backup) {
+		t.Error("最新归档与切割文件应保留")
+	}
+}
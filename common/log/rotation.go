@@ -0,0 +1,130 @@
+package xLog
+
+import (
+	"strings"
+	"time"
+)
+
+// RotationState 当前日志文件的状态，供 [RotationPolicy] 判断是否需要切割
+type RotationState struct {
+	Size     int64     // 当前文件已写入的字节数
+	Incoming int       // 本次待写入的字节数
+	OpenedAt time.Time // 当前文件的起始时间（续写已有文件时取其修改时间）
+	Now      time.Time // 本次写入时间
+}
+
+// RotationPolicy 日志切割策略
+//
+// [RotatingWriter] 在每次写入前调用 ShouldRotate，返回 true 时先切割再写入。
+// 内置策略: [SizePolicy]、[HourlyPolicy]、[DailyPolicy]，可通过 [AnyPolicy] 组合。
+type RotationPolicy interface {
+	ShouldRotate(state RotationState) bool
+}
+
+// RotationPolicyFunc 函数形式的 [RotationPolicy]
+type RotationPolicyFunc func(state RotationState) bool
+
+// ShouldRotate 实现 [RotationPolicy]
+func (f RotationPolicyFunc) ShouldRotate(state RotationState) bool {
+	return f(state)
+}
+
+// SizePolicy 按大小切割，写入后超过 maxSize 字节时切割；maxSize <= 0 表示从不触发
+func SizePolicy(maxSize int64) RotationPolicy {
+	return RotationPolicyFunc(func(state RotationState) bool {
+		return maxSize > 0 && state.Size > 0 && state.Size+int64(state.Incoming) > maxSize
+	})
+}
+
+// HourlyPolicy 按小时切割，当前文件的起始时间与写入时间不在同一小时（本地时间）时切割
+func HourlyPolicy() RotationPolicy {
+	return RotationPolicyFunc(func(state RotationState) bool {
+		opened, now := state.OpenedAt.Local(), state.Now.Local()
+		return opened.Format("2006-01-02 15") != now.Format("2006-01-02 15")
+	})
+}
+
+// DailyPolicy 按天切割，当前文件的起始时间与写入时间不在同一天（本地时间）时切割
+func DailyPolicy() RotationPolicy {
+	return RotationPolicyFunc(func(state RotationState) bool {
+		return state.OpenedAt.Local().Format("2006-01-02") != state.Now.Local().Format("2006-01-02")
+	})
+}
+
+// AnyPolicy 组合多个策略，任意一个触发即切割；nil 策略会被跳过
+func AnyPolicy(policies ...RotationPolicy) RotationPolicy {
+	var list []RotationPolicy
+	for _, policy := range policies {
+		if policy != nil {
+			list = append(list, policy)
+		}
+	}
+	return RotationPolicyFunc(func(state RotationState) bool {
+		for _, policy := range list {
+			if policy.ShouldRotate(state) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// ParseRotationPolicy 解析逗号分隔的 size/hourly/daily 组合（大小写不敏感），如 "hourly,size"
+//
+// size 使用 maxSize 作为阈值。存在无法识别的项或结果为空时返回 false。
+func ParseRotationPolicy(text string, maxSize int64) (RotationPolicy, bool) {
+	var policies []RotationPolicy
+	for _, item := range strings.Split(text, ",") {
+		switch strings.ToLower(strings.TrimSpace(item)) {
+		case "":
+			continue
+		case "size":
+			policies = append(policies, SizePolicy(maxSize))
+		case "hourly":
+			policies = append(policies, HourlyPolicy())
+		case "daily":
+			policies = append(policies, DailyPolicy())
+		default:
+			return nil, false
+		}
+	}
+	if len(policies) == 0 {
+		return nil, false
+	}
+	return AnyPolicy(policies...), true
+}
+
+// Compression 归档压缩算法
+type Compression string
+
+const (
+	// CompressionGzip 归档为 tar.gz，默认值
+	CompressionGzip Compression = "gzip"
+
+	// CompressionZstd 归档为 tar.zst，压缩率与速度均优于 gzip
+	CompressionZstd Compression = "zstd"
+)
+
+// ParseCompression 解析 gzip/zstd 形式的压缩算法（大小写不敏感）
+func ParseCompression(text string) (Compression, bool) {
+	switch Compression(strings.ToLower(strings.TrimSpace(text))) {
+	case CompressionGzip:
+		return CompressionGzip, true
+	case CompressionZstd:
+		return CompressionZstd, true
+	}
+	return "", false
+}
+
+// archiveExt 返回归档文件扩展名
+func (c Compression) archiveExt() string {
+	if c == CompressionZstd {
+		return ".tar.zst"
+	}
+	return ".tar.gz"
+}
+
+// ArchiveHook 归档完成后的回调，archivePath 为新生成的归档文件路径
+//
+// 回调在独立协程中执行，可用于上传对象存储等耗时操作，不会阻塞日志写入。
+type ArchiveHook func(archivePath string)
@@ -0,0 +1,132 @@
+package xLog
+
+import (
+	"archive/tar"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// TestRotationPolicies 验证内置切割策略的触发条件。
+func TestRotationPolicies(t *testing.T) {
+	base := time.Date(2024, 1, 15, 10, 30, 0, 0, time.Local)
+	tests := []struct {
+		name   string
+		policy RotationPolicy
+		state  RotationState
+		want   bool
+	}{
+		{"size 未超限", SizePolicy(100), RotationState{Size: 50, Incoming: 50, OpenedAt: base, Now: base}, false},
+		{"size 超限", SizePolicy(100), RotationState{Size: 50, Incoming: 51, OpenedAt: base, Now: base}, true},
+		{"size 空文件不切割", SizePolicy(100), RotationState{Incoming: 500, OpenedAt: base, Now: base}, false},
+		{"hourly 同一小时", HourlyPolicy(), RotationState{OpenedAt: base, Now: base.Add(20 * time.Minute)}, false},
+		{"hourly 跨小时", HourlyPolicy(), RotationState{OpenedAt: base, Now: base.Add(40 * time.Minute)}, true},
+		{"daily 同一天", DailyPolicy(), RotationState{OpenedAt: base, Now: base.Add(5 * time.Hour)}, false},
+		{"daily 跨天", DailyPolicy(), RotationState{OpenedAt: base, Now: base.Add(14 * time.Hour)}, true},
+		{"组合任一触发", AnyPolicy(DailyPolicy(), SizePolicy(100)), RotationState{Size: 99, Incoming: 2, OpenedAt: base, Now: base}, true},
+	}
+	for _, tt := range tests {
+		if got := tt.policy.ShouldRotate(tt.state); got != tt.want {
+			t.Errorf("%s: got=%v want=%v", tt.name, got, tt.want)
+		}
+	}
+}
+
+// TestParseRotationPolicy 验证策略组合的解析。
+func TestParseRotationPolicy(t *testing.T) {
+	policy, ok := ParseRotationPolicy(" Hourly , size ", 10)
+	if !ok {
+		t.Fatal("hourly,size 应解析成功")
+	}
+	now := time.Now()
+	if !policy.ShouldRotate(RotationState{Size: 10, Incoming: 1, OpenedAt: now, Now: now}) {
+		t.Error("size 阈值应生效")
+	}
+	for _, text := range []string{"", "weekly", "size,monthly"} {
+		if _, ok := ParseRotationPolicy(text, 10); ok {
+			t.Errorf("%q 应解析失败", text)
+		}
+	}
+}
+
+// TestRotatingWriter_PolicyRotate 验证自定义策略触发时切割为 log.N.log。
+func TestRotatingWriter_PolicyRotate(t *testing.T) {
+	dir := t.TempDir()
+	writes := 0
+	w, err := NewRotatingWriter(RotatorConfig{
+		Dir: dir,
+		Policy: RotationPolicyFunc(func(RotationState) bool {
+			writes++
+			return writes == 2
+		}),
+	})
+	if err != nil {
+		t.Fatalf("创建写入器失败: %v", err)
+	}
+	defer w.Close()
+
+	for _, line := range []string{"first\n", "second\n"} {
+		if _, err := w.Write([]byte(line)); err != nil {
+			t.Fatalf("写入失败: %v", err)
+		}
+	}
+
+	rotated, err := os.ReadFile(filepath.Join(dir, "log.0.log"))
+	if err != nil || string(rotated) != "first\n" {
+		t.Errorf("切割文件内容不匹配: %q, err=%v", rotated, err)
+	}
+	current, _ := os.ReadFile(filepath.Join(dir, "log.log"))
+	if string(current) != "second\n" {
+		t.Errorf("当前文件内容不匹配: %q", current)
+	}
+}
+
+// TestRotatingWriter_ZstdArchive 验证 zstd 归档可被解压且触发归档回调。
+func TestRotatingWriter_ZstdArchive(t *testing.T) {
+	dir := t.TempDir()
+	yesterday := time.Now().AddDate(0, 0, -1)
+	touch(t, dir, "log.0.log", yesterday)
+	touch(t, dir, "log.log", time.Now())
+
+	archived := make(chan string, 1)
+	w, err := NewRotatingWriter(RotatorConfig{
+		Dir:         dir,
+		Compression: CompressionZstd,
+		OnArchive:   func(path string) { archived <- path },
+	})
+	if err != nil {
+		t.Fatalf("创建写入器失败: %v", err)
+	}
+	defer w.Close()
+
+	want := filepath.Join(dir, "logger-"+yesterday.Format("2006-01-02")+".tar.zst")
+	select {
+	case path := <-archived:
+		if path != want {
+			t.Errorf("归档路径不匹配: got=%q want=%q", path, want)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("未触发归档回调")
+	}
+
+	file, err := os.Open(want)
+	if err != nil {
+		t.Fatalf("打开归档失败: %v", err)
+	}
+	defer file.Close()
+	decoder, err := zstd.NewReader(file)
+	if err != nil {
+		t.Fatalf("创建 zstd 读取器失败: %v", err)
+	}
+	defer decoder.Close()
+	header, err := tar.NewReader(decoder).Next()
+	if err != nil || header.Name != "log.0.log" {
+		t.Errorf("归档内容不匹配: header=%v err=%v", header, err)
+	}
+	if exists(filepath.Join(dir, "log.0.log")) {
+		t.Error("已归档的切割文件应被删除")
+	}
+}
@@ -10,33 +10,52 @@ import (
 	"regexp"
 	"sort"
 	"strconv"
-	"strings"
 	"sync"
 	"time"
+
+	"github.com/klauspost/compress/zstd"
 )
 
 // RotatorConfig 日志切割器配置
 type RotatorConfig struct {
-	Dir      string // 日志目录
-	BaseName string // 基础文件名 (如 "log")
-	Ext      string // 扩展名 (如 ".log")
-	MaxSize  int64  // 最大文件大小 (字节)，默认 10MB
+	Dir             string // 日志目录
+	BaseName        string // 基础文件名 (如 "log")
+	Ext             string // 扩展名 (如 ".log")
+	MaxSize         int64  // 最大文件大小 (字节)，默认 10MB
+	MaxAge          int    // 切割文件与归档的最大保留天数，0 表示不按时间清理
+	MaxBackups      int    // 切割文件的最大保留数量，0 表示不限制
+	MaxArchives     int    // 归档的最大保留数量，0 表示沿用 MaxBackups
+	DisableCompress bool   // 为 true 时不打包归档，旧日志以原文件形式保留
+
+	Policy      RotationPolicy // 切割策略，默认 AnyPolicy(DailyPolicy(), SizePolicy(MaxSize))
+	Compression Compression    // 归档压缩算法，默认 gzip
+	OnArchive   ArchiveHook    // 每个归档生成后的回调，可选
 }
 
 // RotatingWriter 支持自动切割的日志写入器
 //
-// 实现 io.Writer 接口，当文件大小超过阈值时自动切割。
-// 切割后的文件命名格式: log.0.log, log.1.log, log.2.log ... (索引递增，数字越大越新)
-// 每天 00:00:05 自动将前一天的日志打包为 logger-yyyy-MM-dd.tar.gz
+// 实现 io.Writer 接口，[RotationPolicy] 触发时自动切割（默认跨天或文件大小超过阈值）。
+// 同一天内切割后的文件命名格式: log.0.log, log.1.log, log.2.log ... (索引递增，数字越大越新)，
+// 跨天切割的文件命名为 log-yyyy-MM-dd.log。
+// 每天 00:00:05 自动将前一天的日志打包为 logger-yyyy-MM-dd.tar.gz（zstd 为 .tar.zst）
+// 配置 MaxAge / MaxBackups / MaxArchives 后，切割、归档及每日调度时会清理超出保留策略的旧文件。
 type RotatingWriter struct {
 	mu          sync.Mutex
-	file        *os.File // 当前写入的文件
-	dir         string   // 日志目录
-	baseName    string   // 基础文件名
-	ext         string   // 扩展名
-	maxSize     int64    // 最大文件大小
-	currentSize int64    // 当前文件大小
-	currentDate string   // 当前日期 (用于判断是否跨天)
+	file        *os.File       // 当前写入的文件
+	dir         string         // 日志目录
+	baseName    string         // 基础文件名
+	ext         string         // 扩展名
+	maxSize     int64          // 最大文件大小
+	maxAge      int            // 最大保留天数
+	maxBackups  int            // 切割文件最大保留数量
+	maxArchives int            // 归档最大保留数量
+	compress    bool           // 是否打包归档
+	compression Compression    // 归档压缩算法
+	policy      RotationPolicy // 切割策略
+	onArchive   ArchiveHook    // 归档回调
+	currentSize int64          // 当前文件大小
+	currentDate string         // 当前日期 (用于判断是否跨天)
+	openedAt    time.Time      // 当前文件的起始时间
 }
 
 // NewRotatingWriter 创建日志切割写入器
@@ -61,6 +80,15 @@ func NewRotatingWriter(config RotatorConfig) (*RotatingWriter, error) {
 	if config.MaxSize <= 0 {
 		config.MaxSize = 10 * 1024 * 1024 // 默认 10MB
 	}
+	if config.MaxArchives <= 0 {
+		config.MaxArchives = config.MaxBackups
+	}
+	if config.Compression == "" {
+		config.Compression = CompressionGzip
+	}
+	if config.Policy == nil {
+		config.Policy = AnyPolicy(DailyPolicy(), SizePolicy(config.MaxSize))
+	}
 
 	// 创建日志目录
 	if err := os.MkdirAll(config.Dir, 0755); err != nil {
@@ -72,6 +100,13 @@ func NewRotatingWriter(config RotatorConfig) (*RotatingWriter, error) {
 		baseName:    config.BaseName,
 		ext:         config.Ext,
 		maxSize:     config.MaxSize,
+		maxAge:      config.MaxAge,
+		maxBackups:  config.MaxBackups,
+		maxArchives: config.MaxArchives,
+		compress:    !config.DisableCompress,
+		compression: config.Compression,
+		policy:      config.Policy,
+		onArchive:   config.OnArchive,
 		currentDate: time.Now().Format("2006-01-02"),
 	}
 
@@ -88,24 +123,21 @@ func NewRotatingWriter(config RotatorConfig) (*RotatingWriter, error) {
 
 // Write 写入数据到日志文件
 //
-// 实现 io.Writer 接口。支持以下自动切割条件:
-//  1. 检测到日期变化（跨天）
-//  2. 文件大小超过阈值
+// 实现 io.Writer 接口。写入前由切割策略判断是否切割:
+//  1. 已跨天 → 按旧日期重命名为 log-yyyy-MM-dd.log 并触发归档
+//  2. 同一天内 → 重命名为 log.N.log
 func (w *RotatingWriter) Write(p []byte) (n int, err error) {
 	w.mu.Lock()
 	defer w.mu.Unlock()
 
-	// 检查是否跨天
-	today := time.Now().Format("2006-01-02")
-	if w.currentDate != today {
-		if err := w.rotateForNewDay(today); err != nil {
-			return 0, fmt.Errorf("跨天切割日志失败: %w", err)
-		}
-	}
-
-	// 检查是否需要切割（大小）
-	if w.currentSize+int64(len(p)) > w.maxSize {
-		if err := w.rotate(); err != nil {
+	now := time.Now()
+	state := RotationState{Size: w.currentSize, Incoming: len(p), OpenedAt: w.openedAt, Now: now}
+	if w.policy.ShouldRotate(state) {
+		if today := now.Format("2006-01-02"); w.currentDate != today {
+			if err := w.rotateForNewDay(today); err != nil {
+				return 0, fmt.Errorf("跨天切割日志失败: %w", err)
+			}
+		} else if err := w.rotate(); err != nil {
 			return 0, fmt.Errorf("日志切割失败: %w", err)
 		}
 	}
@@ -145,6 +177,10 @@ func (w *RotatingWriter) openFile() error {
 
 	w.file = file
 	w.currentSize = info.Size()
+	w.openedAt = time.Now()
+	if info.Size() > 0 {
+		w.openedAt = info.ModTime()
+	}
 	return nil
 }
 
@@ -252,7 +288,11 @@ func (w *RotatingWriter) rotate() error {
 
 	// 创建新的日志文件
 	w.currentSize = 0
-	return w.openFile()
+	if err := w.openFile(); err != nil {
+		return err
+	}
+	w.prune()
+	return nil
 }
 
 // rotateForNewDay 跨天时切割日志
@@ -348,20 +388,23 @@ func (w *RotatingWriter) startArchiveScheduler() {
 
 		time.Sleep(duration)
 
-		// 执行归档
+		// 执行归档与保留清理
 		w.mu.Lock()
 		w.archiveYesterday()
+		w.prune()
 		w.mu.Unlock()
 	}
 }
 
 // archiveYesterday 归档前一天的日志文件
 //
-// 将所有切割文件打包为 logger-yyyy-MM-dd.tar.gz
+// 将所有切割文件打包为 logger-yyyy-MM-dd.tar.gz（或 .tar.zst）
 func (w *RotatingWriter) archiveYesterday() {
+	if !w.compress {
+		return
+	}
 	yesterday := time.Now().AddDate(0, 0, -1).Format("2006-01-02")
-	archiveName := fmt.Sprintf("logger-%s.tar.gz", yesterday)
-	archivePath := filepath.Join(w.dir, archiveName)
+	archivePath := w.archiveFilePath(yesterday)
 
 	// 检查归档文件是否已存在
 	if _, err := os.Stat(archivePath); err == nil {
@@ -374,8 +417,8 @@ func (w *RotatingWriter) archiveYesterday() {
 		return
 	}
 
-	// 创建 tar.gz 归档
-	if err := w.createTarGz(archivePath, files); err != nil {
+	// 创建归档
+	if err := w.createArchive(archivePath, files); err != nil {
 		fmt.Fprintf(os.Stderr, "[LOG] 创建归档失败: %v\n", err)
 		return
 	}
@@ -384,6 +427,29 @@ func (w *RotatingWriter) archiveYesterday() {
 	for _, file := range files {
 		os.Remove(file)
 	}
+	w.notifyArchived(archivePath)
+}
+
+// archiveFilePath 获取指定日期的归档文件路径
+//
+// 格式: {dir}/logger-{date}.tar.gz 或 {dir}/logger-{date}.tar.zst
+func (w *RotatingWriter) archiveFilePath(date string) string {
+	return filepath.Join(w.dir, "logger-"+date+w.compression.archiveExt())
+}
+
+// notifyArchived 在独立协程中调用归档回调
+func (w *RotatingWriter) notifyArchived(archivePath string) {
+	if w.onArchive == nil {
+		return
+	}
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				fmt.Fprintf(os.Stderr, "[LOG] 归档回调异常: %v\n", r)
+			}
+		}()
+		w.onArchive(archivePath)
+	}()
 }
 
 // collectFilesToArchive 收集需要归档的日志文件
@@ -412,8 +478,8 @@ func (w *RotatingWriter) collectFilesToArchive() []string {
 	return files
 }
 
-// createTarGz 创建 tar.gz 归档文件
-func (w *RotatingWriter) createTarGz(archivePath string, files []string) error {
+// createArchive 按压缩算法创建 tar.gz 或 tar.zst 归档文件
+func (w *RotatingWriter) createArchive(archivePath string, files []string) error {
 	// 创建归档文件
 	archiveFile, err := os.Create(archivePath)
 	if err != nil {
@@ -426,17 +492,26 @@ func (w *RotatingWriter) createTarGz(archivePath string, files []string) error {
 		}
 	}(archiveFile)
 
-	// 创建 gzip 写入器
-	gzWriter := gzip.NewWriter(archiveFile)
-	defer func(gzWriter *gzip.Writer) {
-		err := gzWriter.Close()
+	// 创建压缩写入器
+	var compressor io.WriteCloser
+	switch w.compression {
+	case CompressionZstd:
+		compressor, err = zstd.NewWriter(archiveFile)
 		if err != nil {
-			SugarWarn(nil, "关闭 gzip 写入器失败", "error", err)
+			return fmt.Errorf("创建 zstd 写入器失败: %w", err)
 		}
-	}(gzWriter)
+	default:
+		compressor = gzip.NewWriter(archiveFile)
+	}
+	defer func(compressor io.WriteCloser) {
+		err := compressor.Close()
+		if err != nil {
+			SugarWarn(nil, "关闭压缩写入器失败", "compression", w.compression, "error", err)
+		}
+	}(compressor)
 
 	// 创建 tar 写入器
-	tarWriter := tar.NewWriter(gzWriter)
+	tarWriter := tar.NewWriter(compressor)
 	defer func(tarWriter *tar.Writer) {
 		err := tarWriter.Close()
 		if err != nil {
@@ -489,7 +564,7 @@ func (w *RotatingWriter) addFileToTar(tarWriter *tar.Writer, filePath string) er
 // checkStartupState 启动时检查日志文件状态
 //
 // 检查内容:
-//  1. 当前日志文件大小是否超过限制 → 立即切割
+//  1. 当前日志文件是否已满足切割策略（如大小超限、跨小时） → 立即切割
 //  2. 当前日志文件日期是否为今天 → 按日期重命名后创建新文件
 //  3. 目录中是否存在旧日期日志文件 → 批量归档
 func (w *RotatingWriter) checkStartupState() error {
@@ -520,8 +595,9 @@ func (w *RotatingWriter) checkStartupState() error {
 			return err
 		}
 	} else {
-		// 今天的日志，检查大小是否超限
-		if info.Size() >= w.maxSize {
+		// 今天的日志，检查是否满足切割策略
+		state := RotationState{Size: info.Size(), OpenedAt: modTime, Now: time.Now()}
+		if w.policy.ShouldRotate(state) {
 			if err := w.rotate(); err != nil {
 				return fmt.Errorf("启动时切割日志失败: %w", err)
 			}
@@ -534,11 +610,16 @@ func (w *RotatingWriter) checkStartupState() error {
 	}
 
 	// 归档所有旧日期的日志文件
-	if err := w.archiveOldFiles(); err != nil {
-		// 归档失败仅记录警告，不阻断启动
-		fmt.Fprintf(os.Stderr, "[LOG] 启动时归档旧日志失败: %v\n", err)
+	if w.compress {
+		if err := w.archiveOldFiles(); err != nil {
+			// 归档失败仅记录警告，不阻断启动
+			fmt.Fprintf(os.Stderr, "[LOG] 启动时归档旧日志失败: %v\n", err)
+		}
 	}
 
+	// 清理超出保留策略的旧文件
+	w.prune()
+
 	return nil
 }
 
@@ -550,7 +631,7 @@ func (w *RotatingWriter) checkStartupState() error {
 //
 // 归档规则:
 //   - 按文件修改日期分组
-//   - 每个日期创建一个 tar.gz 归档
+//   - 每个日期创建一个 tar.gz（或 tar.zst）归档
 //   - 已存在归档的日期跳过
 func (w *RotatingWriter) archiveOldFiles() error {
 	today := time.Now().Format("2006-01-02")
@@ -579,7 +660,7 @@ func (w *RotatingWriter) archiveOldFiles() error {
 		name := entry.Name()
 
 		// 跳过当前日志文件和归档文件
-		if name == w.baseName+w.ext || strings.HasSuffix(name, ".tar.gz") {
+		if name == w.baseName+w.ext || archivePattern.MatchString(name) {
 			continue
 		}
 
@@ -606,15 +687,14 @@ func (w *RotatingWriter) archiveOldFiles() error {
 
 	// 按日期批量归档
 	for date, files := range filesByDate {
-		archiveName := fmt.Sprintf("logger-%s.tar.gz", date)
-		archivePath := filepath.Join(w.dir, archiveName)
+		archivePath := w.archiveFilePath(date)
 
 		// 跳过已存在的归档
 		if _, err := os.Stat(archivePath); err == nil {
 			continue
 		}
 
-		if err := w.createTarGz(archivePath, files); err != nil {
+		if err := w.createArchive(archivePath, files); err != nil {
 			return fmt.Errorf("创建 %s 归档失败: %w", date, err)
 		}
 
@@ -622,6 +702,7 @@ func (w *RotatingWriter) archiveOldFiles() error {
 		for _, file := range files {
 			os.Remove(file)
 		}
+		w.notifyArchived(archivePath)
 	}
 
 	return nil
@@ -0,0 +1,186 @@
+package xLog
+
+import (
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+)
+
+// SamplingConfig 单个 logger 的采样与重复抑制配置
+//
+// 采样按 message+level 计数：每个统计窗口内前 First 条全部输出，之后每 Thereafter 条输出 1 条。
+// 重复抑制比较相邻两条记录的级别、消息与属性（忽略时间与 trace），相同则只计数，
+// 在下一条不同记录到来或刷新时输出一条 "重复 K 次" 汇总。
+type SamplingConfig struct {
+	Interval   time.Duration // 统计窗口，默认 1s
+	First      int           // 每个窗口内前 N 条全部输出，0 表示不采样
+	Thereafter int           // 超出 First 后每 M 条输出 1 条，<= 0 表示全部丢弃
+	Dedup      bool          // 是否抑制相邻的相同记录
+	Levels     []slog.Level  // 仅对这些级别生效，为空表示全部级别
+}
+
+// SamplingPolicy 按 logger 名称的采样策略
+//
+// Loggers 中存在的名称使用对应配置，值为 nil 表示该 logger 永不采样（如 NamedINIT）；
+// 未出现的名称使用 Default，Default 为 nil 表示不采样。
+type SamplingPolicy struct {
+	Default *SamplingConfig
+	Loggers map[string]*SamplingConfig
+}
+
+// config 返回指定 logger 的生效配置
+func (p *SamplingPolicy) config(name string) *SamplingConfig {
+	if c, ok := p.Loggers[name]; ok {
+		return c
+	}
+	return p.Default
+}
+
+// sampler 按 logger 名称维护采样状态，由 LogHandler 及其克隆共享
+type sampler struct {
+	policy  SamplingPolicy
+	mu      sync.Mutex
+	loggers map[string]*loggerSampler
+}
+
+// loggerSampler 单个 logger 的采样状态
+type loggerSampler struct {
+	config      SamplingConfig
+	windowStart time.Time
+	counts      map[samplingKey]int
+
+	last        *Entry // 最近一条已输出的记录，用于重复比较
+	lastPrint   string // last 的指纹
+	repeated    int    // last 之后被抑制的相同记录数
+	repeatedEnd time.Time
+}
+
+// samplingKey 采样计数键
+type samplingKey struct {
+	level   slog.Level
+	message string
+}
+
+// newSampler 创建采样器，策略为空时返回 nil
+func newSampler(policy *SamplingPolicy) *sampler {
+	if policy == nil || (policy.Default == nil && len(policy.Loggers) == 0) {
+		return nil
+	}
+	return &sampler{policy: *policy, loggers: make(map[string]*loggerSampler)}
+}
+
+// logger 返回指定 logger 的采样状态，该 logger 不采样时返回 nil。调用方需持有锁
+func (s *sampler) logger(name string) *loggerSampler {
+	if ls, ok := s.loggers[name]; ok {
+		return ls
+	}
+	var ls *loggerSampler
+	if c := s.policy.config(name); c != nil && (c.First > 0 || c.Dedup) {
+		ls = &loggerSampler{config: *c, counts: make(map[samplingKey]int)}
+		if ls.config.Interval <= 0 {
+			ls.config.Interval = time.Second
+		}
+	}
+	s.loggers[name] = ls
+	return ls
+}
+
+// allow 判断记录是否通过采样，在构建 Entry 之前调用以节省开销
+func (s *sampler) allow(name string, r slog.Record) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ls := s.logger(name)
+	if ls == nil || ls.config.First <= 0 || !ls.applies(r.Level) {
+		return true
+	}
+
+	if r.Time.Sub(ls.windowStart) >= ls.config.Interval || r.Time.Before(ls.windowStart) {
+		ls.windowStart = r.Time
+		clear(ls.counts)
+	}
+	key := samplingKey{level: r.Level, message: r.Message}
+	ls.counts[key]++
+	n := ls.counts[key]
+	if n <= ls.config.First {
+		return true
+	}
+	return ls.config.Thereafter > 0 && (n-ls.config.First)%ls.config.Thereafter == 0
+}
+
+// dedup 对已通过采样的记录做重复抑制，返回需要写出的条目（可能包含前一条的重复汇总）
+func (s *sampler) dedup(entry *Entry) []*Entry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ls := s.logger(entry.Logger)
+	if ls == nil || !ls.config.Dedup || !ls.applies(entry.Level) {
+		return []*Entry{entry}
+	}
+
+	fp := fingerprint(entry)
+	if ls.last != nil && fp == ls.lastPrint && entry.Time.Sub(ls.last.Time) < ls.config.Interval {
+		ls.repeated++
+		ls.repeatedEnd = entry.Time
+		return nil
+	}
+
+	entries := make([]*Entry, 0, 2)
+	if summary := ls.summary(); summary != nil {
+		entries = append(entries, summary)
+	}
+	ls.last, ls.lastPrint = entry, fp
+	return append(entries, entry)
+}
+
+// drain 取出所有 logger 尚未输出的重复汇总，供刷新时调用
+func (s *sampler) drain() []*Entry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var entries []*Entry
+	for _, ls := range s.loggers {
+		if ls == nil {
+			continue
+		}
+		if summary := ls.summary(); summary != nil {
+			entries = append(entries, summary)
+		}
+	}
+	return entries
+}
+
+// applies 判断配置是否作用于该级别
+func (ls *loggerSampler) applies(level slog.Level) bool {
+	return len(ls.config.Levels) == 0 || slices.Contains(ls.config.Levels, level)
+}
+
+// summary 生成并重置重复汇总，没有被抑制的记录时返回 nil
+func (ls *loggerSampler) summary() *Entry {
+	if ls.repeated == 0 {
+		return nil
+	}
+	entry := &Entry{
+		Time:    ls.repeatedEnd,
+		Level:   ls.last.Level,
+		Logger:  ls.last.Logger,
+		Options: ls.last.Options,
+		Message: fmt.Sprintf("上一条日志重复 %d 次", ls.repeated),
+		Attrs: []slog.Attr{
+			slog.String("message", ls.last.Message),
+			slog.Int("repeated", ls.repeated),
+		},
+	}
+	ls.repeated = 0
+	return entry
+}
+
+// fingerprint 计算用于重复比较的指纹，忽略时间、trace 与堆栈
+func fingerprint(e *Entry) string {
+	buf := append([]byte(LevelName(e.Level)), 0)
+	buf = append(buf, e.Message...)
+	buf = append(buf, 0)
+	return string(appendJSONAttrs(buf, e.Attrs))
+}
@@ -0,0 +1,79 @@
+package xLog
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newSampledLogger 构造写入环形缓冲的 logger，便于检查实际输出。
+func newSampledLogger(policy *SamplingPolicy) (*LogHandler, *RingSink) {
+	ring := NewRingSink(100)
+	handler := NewLogHandler(HandlerConfig{
+		Console:  &strings.Builder{},
+		Sinks:    []Sink{ring},
+		Level:    slog.LevelDebug,
+		Sampling: policy,
+	}).(*LogHandler)
+	return handler, ring
+}
+
+// TestSampling_FirstThenEveryM 验证窗口内前 N 条全部输出，之后每 M 条输出 1 条，其他 logger 不受影响。
+func TestSampling_FirstThenEveryM(t *testing.T) {
+	handler, ring := newSampledLogger(&SamplingPolicy{
+		Default: &SamplingConfig{Interval: time.Minute, First: 2, Thereafter: 3},
+		Loggers: map[string]*SamplingConfig{NamedINIT: nil},
+	})
+	resu := slog.New(handler.WithGroup(NamedRESU))
+	initLog := slog.New(handler.WithGroup(NamedINIT))
+
+	for i := 0; i < 11; i++ {
+		resu.Warn("下游超时", "i", i)
+		initLog.Info("初始化")
+	}
+
+	var resuCount, initCount int
+	for _, e := range ring.Entries() {
+		switch e.Logger {
+		case NamedRESU:
+			resuCount++
+		case NamedINIT:
+			initCount++
+		}
+	}
+	// 第 1、2 条 + 第 5、8、11 条
+	if resuCount != 5 {
+		t.Errorf("RESU 采样后应输出 5 条，实际 %d", resuCount)
+	}
+	if initCount != 11 {
+		t.Errorf("INIT 永不采样，应输出 11 条，实际 %d", initCount)
+	}
+}
+
+// TestSampling_Dedup 验证相邻相同记录被抑制，并在不同记录到来或刷新时输出汇总。
+func TestSampling_Dedup(t *testing.T) {
+	handler, ring := newSampledLogger(&SamplingPolicy{
+		Default: &SamplingConfig{Interval: time.Minute, Dedup: true},
+	})
+	logger := slog.New(handler.WithGroup(NamedREPO))
+
+	for i := 0; i < 4; i++ {
+		logger.Error("连接失败", "host", "db")
+	}
+	logger.Error("连接失败", "host", "cache")
+	logger.Error("连接失败", "host", "cache")
+	if err := handler.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush 失败: %v", err)
+	}
+
+	var messages []string
+	for _, e := range ring.Entries() {
+		messages = append(messages, e.Message)
+	}
+	want := []string{"连接失败", "上一条日志重复 3 次", "连接失败", "上一条日志重复 1 次"}
+	if strings.Join(messages, "|") != strings.Join(want, "|") {
+		t.Errorf("输出不匹配\n got=%v\nwant=%v", messages, want)
+	}
+}
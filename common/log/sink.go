@@ -0,0 +1,112 @@
+package xLog
+
+import (
+	"errors"
+	"io"
+	"sync"
+)
+
+// Sink 日志输出目标
+//
+// LogHandler 将每条记录构建为 [Entry] 后扇出到所有 Sink，由 Sink 自行决定编码格式与写入方式。
+// 同步模式下 WriteEntry 在 Handler 锁内调用（[WriterSink] 例外：在锁外编码，锁内仅写入底层写入器）；
+// 异步模式下由唯一的后台协程调用，因此实现无需考虑来自 Handler 的并发写入。
+type Sink interface {
+	// WriteEntry 写入一条日志
+	WriteEntry(e *Entry) error
+}
+
+// Flusher 可刷新的输出目标，Flush 在 [LogHandler.Flush] 时调用
+type Flusher interface {
+	Flush() error
+}
+
+// flushSinks 刷新所有实现了 [Flusher] 的输出目标
+func flushSinks(sinks []Sink) error {
+	var errs []error
+	for _, sink := range sinks {
+		if f, ok := sink.(Flusher); ok {
+			if err := f.Flush(); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// WriterSink 基于 io.Writer 的输出目标，适用于控制台、文件（[RotatingWriter]）等
+type WriterSink struct {
+	w      io.Writer
+	format Format
+}
+
+// NewWriterSink 创建基于 io.Writer 的输出目标
+//
+// 参数说明:
+//   - w: 底层写入器
+//   - format: 输出格式，空值按 FormatJSON 处理
+func NewWriterSink(w io.Writer, format Format) *WriterSink {
+	if format == "" {
+		format = FormatJSON
+	}
+	return &WriterSink{w: w, format: format}
+}
+
+// WriteEntry 按配置的格式编码后写入底层写入器
+func (s *WriterSink) WriteEntry(e *Entry) error {
+	_, err := s.w.Write(e.Encode(s.format))
+	return err
+}
+
+// Flush 底层写入器实现了 Flush() error 时调用之
+func (s *WriterSink) Flush() error {
+	if f, ok := s.w.(Flusher); ok {
+		return f.Flush()
+	}
+	return nil
+}
+
+// RingSink 内存环形缓冲输出目标，保留最近的 size 条日志
+//
+// 适用于在管理接口或故障现场查看最近日志，超出容量时覆盖最旧的记录。
+type RingSink struct {
+	mu      sync.Mutex
+	entries []*Entry
+	next    int
+	full    bool
+}
+
+// NewRingSink 创建容量为 size 的环形缓冲输出目标，size <= 0 时默认 1000
+func NewRingSink(size int) *RingSink {
+	if size <= 0 {
+		size = 1000
+	}
+	return &RingSink{entries: make([]*Entry, size)}
+}
+
+// WriteEntry 写入一条日志，缓冲区满时覆盖最旧的记录
+func (s *RingSink) WriteEntry(e *Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.entries[s.next] = e
+	s.next++
+	if s.next == len(s.entries) {
+		s.next = 0
+		s.full = true
+	}
+	return nil
+}
+
+// Entries 按时间从旧到新返回缓冲区中的日志
+func (s *RingSink) Entries() []*Entry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.full {
+		return append([]*Entry(nil), s.entries[:s.next]...)
+	}
+	result := make([]*Entry, 0, len(s.entries))
+	result = append(result, s.entries[s.next:]...)
+	return append(result, s.entries[:s.next]...)
+}
@@ -0,0 +1,186 @@
+package xLog
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// blockingSink 在 release 关闭前阻塞写入，用于构造队列满的场景。
+type blockingSink struct {
+	release chan struct{}
+	mu      sync.Mutex
+	count   int
+}
+
+func (s *blockingSink) WriteEntry(_ *Entry) error {
+	<-s.release
+	s.mu.Lock()
+	s.count++
+	s.mu.Unlock()
+	return nil
+}
+
+// TestAsync_FanOutAndFlush 验证异步模式扇出到所有输出目标，Flush 后日志全部写出且顺序不变。
+func TestAsync_FanOutAndFlush(t *testing.T) {
+	var console bytes.Buffer
+	ring := NewRingSink(2)
+	handler := NewLogHandler(HandlerConfig{
+		Console:       &console,
+		ConsoleFormat: FormatLogfmt,
+		Sinks:         []Sink{ring},
+		Async:         &AsyncConfig{QueueSize: 8},
+	}).(*LogHandler)
+	logger := slog.New(handler)
+
+	for _, msg := range []string{"a", "b", "c"} {
+		logger.Info(msg)
+	}
+	if err := handler.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush 失败: %v", err)
+	}
+
+	if got := strings.Count(console.String(), "\n"); got != 3 {
+		t.Errorf("控制台应写出 3 条日志，实际 %d: %s", got, console.String())
+	}
+	entries := ring.Entries()
+	if len(entries) != 2 || entries[0].Message != "b" || entries[1].Message != "c" {
+		t.Errorf("环形缓冲应保留最近 2 条且按时间排序: %+v", entries)
+	}
+}
+
+// TestSync_FanOut 验证同步模式下相同格式的输出目标写出相同内容，自定义输出目标仍收到条目。
+func TestSync_FanOut(t *testing.T) {
+	var console, file bytes.Buffer
+	ring := NewRingSink(4)
+	logger := slog.New(NewLogHandler(HandlerConfig{
+		Console:       &console,
+		ConsoleFormat: FormatJSON,
+		File:          &file,
+		Sinks:         []Sink{ring},
+	}))
+
+	logger.Info("a", slog.Int("n", 1))
+	logger.Info("b")
+	if console.String() != file.String() || strings.Count(file.String(), "\n") != 2 {
+		t.Errorf("相同格式的输出应一致:\n%s\n%s", console.String(), file.String())
+	}
+	if entries := ring.Entries(); len(entries) != 2 || entries[1].Message != "b" {
+		t.Errorf("自定义输出目标应收到全部条目: %+v", entries)
+	}
+}
+
+// TestAsync_Close 验证 Close 写出已入队的日志并停止后台协程，之后的日志被丢弃且不阻塞调用方。
+func TestAsync_Close(t *testing.T) {
+	ring := NewRingSink(8)
+	handler := NewLogHandler(HandlerConfig{
+		Console: io.Discard,
+		Sinks:   []Sink{ring},
+		Async:   &AsyncConfig{QueueSize: 1},
+	}).(*LogHandler)
+	logger := slog.New(handler)
+
+	logger.Info("a")
+	logger.Info("b")
+	if err := handler.Close(context.Background()); err != nil {
+		t.Fatalf("Close 失败: %v", err)
+	}
+	if got := len(ring.Entries()); got != 2 {
+		t.Fatalf("Close 前入队的日志应全部写出，实际 %d 条", got)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		logger.Info("c")
+		logger.Info("d")
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("关闭后写日志不应阻塞")
+	}
+	if err := handler.Close(context.Background()); err != nil {
+		t.Fatalf("重复 Close 失败: %v", err)
+	}
+	if got := len(ring.Entries()); got != 2 {
+		t.Errorf("关闭后的日志应被丢弃，实际 %d 条", got)
+	}
+}
+
+// TestAsync_DropPolicy 验证 drop 策略在队列满时丢弃日志且不阻塞调用方，并输出丢弃汇总。
+func TestAsync_DropPolicy(t *testing.T) {
+	blocker := &blockingSink{release: make(chan struct{})}
+	ring := NewRingSink(10)
+	handler := NewLogHandler(HandlerConfig{
+		Console: &bytes.Buffer{},
+		Sinks:   []Sink{blocker, ring},
+		Async:   &AsyncConfig{QueueSize: 1, Policy: OverflowDrop},
+	}).(*LogHandler)
+	logger := slog.New(handler)
+
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < 10; i++ {
+			logger.Info("flood")
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("drop 策略下写入不应阻塞")
+	}
+
+	close(blocker.release)
+	if err := handler.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush 失败: %v", err)
+	}
+
+	entries := ring.Entries()
+	last := entries[len(entries)-1]
+	if last.Level != slog.LevelWarn || !strings.Contains(last.Message, "已丢弃") {
+		t.Errorf("最后一条应为丢弃汇总: %+v", last)
+	}
+	if len(entries) >= 11 {
+		t.Errorf("应有日志被丢弃，实际写出 %d 条", len(entries))
+	}
+}
+
+// TestSyslogSink_UDP 验证 syslog 输出 RFC 5424 格式的消息。
+func TestSyslogSink_UDP(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("无法监听 UDP: %v", err)
+	}
+	defer conn.Close()
+
+	sink, err := NewSyslogSink(SyslogConfig{Addr: conn.LocalAddr().String(), Tag: "demo"})
+	if err != nil {
+		t.Fatalf("创建 syslog sink 失败: %v", err)
+	}
+	defer sink.Close()
+
+	entry := &Entry{Time: time.Now(), Level: slog.LevelWarn, Logger: NamedHTTP, Message: "slow"}
+	if err := sink.WriteEntry(entry); err != nil {
+		t.Fatalf("写入失败: %v", err)
+	}
+
+	buf := make([]byte, 2048)
+	_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	n, _, err := conn.ReadFrom(buf)
+	if err != nil {
+		t.Fatalf("读取失败: %v", err)
+	}
+	msg := string(buf[:n])
+	// facility=1, severity=4 => PRI=12
+	if !strings.HasPrefix(msg, "<12>1 ") || !strings.Contains(msg, " demo ") || !strings.Contains(msg, " HTTP - {") {
+		t.Errorf("syslog 消息格式不匹配: %s", msg)
+	}
+}
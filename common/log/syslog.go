@@ -0,0 +1,147 @@
+package xLog
+
+import (
+	"fmt"
+	"log/slog"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// SyslogConfig syslog 输出目标配置
+type SyslogConfig struct {
+	Network     string        // 传输协议 udp/tcp，默认 udp
+	Addr        string        // 服务端地址，如 127.0.0.1:514
+	Tag         string        // APP-NAME，默认当前进程名
+	Facility    int           // syslog facility，默认 1 (user-level)
+	Format      Format        // 消息体格式，默认 FormatJSON
+	DialTimeout time.Duration // 连接超时，默认 5s
+}
+
+// SyslogSink 以 RFC 5424 格式发送日志到 TCP/UDP syslog 服务端
+//
+// 连接在首次写入时建立，写入失败会断开并在下一次写入时重连一次。
+// TCP 采用 octet-counting 分帧（RFC 6587），UDP 每条日志一个数据报。
+type SyslogSink struct {
+	mu       sync.Mutex
+	config   SyslogConfig
+	hostname string
+	conn     net.Conn
+}
+
+// NewSyslogSink 创建 syslog 输出目标
+//
+// 参数说明:
+//   - config: syslog 配置，Addr 必填
+//
+// 返回值:
+//   - *SyslogSink: 输出目标实例
+//   - error: 协议不受支持或地址为空时返回错误
+func NewSyslogSink(config SyslogConfig) (*SyslogSink, error) {
+	if config.Network == "" {
+		config.Network = "udp"
+	}
+	if config.Network != "udp" && config.Network != "tcp" {
+		return nil, fmt.Errorf("不支持的 syslog 协议: %s", config.Network)
+	}
+	if config.Addr == "" {
+		return nil, fmt.Errorf("syslog 地址不能为空")
+	}
+	if config.Tag == "" {
+		config.Tag = filepath.Base(os.Args[0])
+	}
+	if config.Facility <= 0 {
+		config.Facility = 1
+	}
+	if config.Format == "" || config.Format == FormatText {
+		config.Format = FormatJSON
+	}
+	if config.DialTimeout <= 0 {
+		config.DialTimeout = 5 * time.Second
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "-"
+	}
+	return &SyslogSink{config: config, hostname: hostname}, nil
+}
+
+// WriteEntry 发送一条日志，失败时重连重试一次
+func (s *SyslogSink) WriteEntry(e *Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	msg := s.message(e)
+	var err error
+	for attempt := 0; attempt < 2; attempt++ {
+		if s.conn == nil {
+			if s.conn, err = net.DialTimeout(s.config.Network, s.config.Addr, s.config.DialTimeout); err != nil {
+				return fmt.Errorf("连接 syslog 失败: %w", err)
+			}
+		}
+		if _, err = s.conn.Write(msg); err == nil {
+			return nil
+		}
+		_ = s.conn.Close()
+		s.conn = nil
+	}
+	return fmt.Errorf("发送 syslog 失败: %w", err)
+}
+
+// Close 关闭连接
+func (s *SyslogSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
+
+// message 构建 RFC 5424 消息: <PRI>1 TIMESTAMP HOSTNAME APP-NAME PROCID MSGID - MSG
+func (s *SyslogSink) message(e *Entry) []byte {
+	body := e.Encode(s.config.Format)
+	body = body[:len(body)-1] // 去掉换行
+
+	msgID := e.Logger
+	if msgID == "" {
+		msgID = "-"
+	}
+	header := fmt.Sprintf("<%d>1 %s %s %s %d %s - ",
+		s.config.Facility*8+syslogSeverity(e.Level),
+		e.Time.Format(time.RFC3339Nano),
+		s.hostname,
+		s.config.Tag,
+		os.Getpid(),
+		msgID,
+	)
+	msg := append([]byte(header), body...)
+
+	if s.config.Network == "tcp" {
+		return append([]byte(strconv.Itoa(len(msg))+" "), msg...)
+	}
+	return msg
+}
+
+// syslogSeverity 将 slog 级别映射为 syslog severity
+func syslogSeverity(level slog.Level) int {
+	switch {
+	case level >= slog.LevelError:
+		return 3 // err
+	case level >= slog.LevelWarn:
+		return 4 // warning
+	case level > slog.LevelInfo:
+		return 5 // notice
+	case level >= slog.LevelInfo:
+		return 6 // info
+	default:
+		return 7 // debug
+	}
+}
@@ -0,0 +1,121 @@
+package xLog
+
+import (
+	"fmt"
+	"log/slog"
+)
+
+// appendText 编码为彩色文本（控制台格式）
+// 格式: 时间 [LEVEL] [trace] [trace_id] [subject] [NAME] 消息
+//
+//	变量（换行棕色显示）
+func (e *Entry) appendText(buf []byte) []byte {
+	// 时间戳（灰色）
+	buf = append(buf, "\033[90m"...)
+	buf = append(buf, e.Time.Format("2006-01-02 15:04:05.000")...)
+	buf = append(buf, "\033[0m"...)
+
+	// 日志级别
+	buf = append(buf, colorLevel(e.Level)...)
+
+	// Trace ID（如果有）
+	if e.Trace != "" {
+		buf = append(buf, " \033[34m["...)
+		buf = append(buf, e.Trace...)
+		buf = append(buf, "]\033[0m"...)
+	}
+
+	// W3C 链路 ID（如果有）
+	if e.TraceID != "" {
+		buf = append(buf, " \033[90m["...)
+		buf = append(buf, e.TraceID...)
+		buf = append(buf, "]\033[0m"...)
+	}
+
+	// 已认证主体（如果有）
+	if e.Subject != "" {
+		buf = append(buf, " \033[35m["...)
+		buf = append(buf, e.Subject...)
+		buf = append(buf, "]\033[0m"...)
+	}
+
+	// Logger 名称
+	if e.Logger != "" {
+		buf = append(buf, colorName(e.Logger)...)
+	}
+
+	// Option 名称
+	for _, option := range e.Options {
+		buf = append(buf, " \033[96m["...)
+		buf = append(buf, option...)
+		buf = append(buf, "]\033[0m"...)
+		buf = append(buf, '\t')
+	}
+
+	// 消息
+	buf = append(buf, " >> "...)
+	buf = append(buf, e.Message...)
+
+	// 属性（棕色，换行显示）
+	for _, a := range e.Attrs {
+		buf = append(buf, "\n    \033[38;5;130m"...)
+		buf = append(buf, a.Key...)
+		buf = append(buf, "\033[0m=\033[38;5;180m"...)
+		buf = append(buf, fmt.Sprintf("%v", a.Value.Any())...)
+		buf = append(buf, "\033[0m"...)
+	}
+
+	buf = append(buf, '\n')
+
+	// 错误级别添加堆栈
+	if e.Stack != "" {
+		buf = append(buf, "\033[31m"...)
+		buf = append(buf, e.Stack...)
+		buf = append(buf, "\033[0m\n"...)
+	}
+
+	return buf
+}
+
+// colorLevel 返回带颜色的日志级别
+func colorLevel(level slog.Level) string {
+	switch level {
+	case slog.LevelDebug:
+		return " \033[36m[DEBU]\033[0m" // 青色
+	case slog.LevelInfo:
+		return " \033[32m[INFO]\033[0m" // 绿色
+	case slog.LevelWarn:
+		return " \033[33m[WARN]\033[0m" // 黄色
+	case slog.LevelError:
+		return " \033[31m[ERRO]\033[0m" // 红色
+	default:
+		return " \033[32m[INFO]\033[0m"
+	}
+}
+
+// colorName 返回带颜色的 logger 名称
+func colorName(name string) string {
+	if len(name) != 4 {
+		return fmt.Sprintf(" \033[96m[%s]\033[0m", name) // 亮青色
+	}
+
+	switch name {
+	// 核心服务类 - 蓝色
+	case NamedCONT, NamedSERV, NamedLOGC, NamedREPO, NamedCORE, NamedBASE, NamedMAIN:
+		return fmt.Sprintf(" \033[34m[%s]\033[0m", name)
+	// 路由网络类 - 黄色
+	case NamedROUT, NamedHTTP, NamedGRPC, NamedSOCK, NamedCONN, NamedLINK:
+		return fmt.Sprintf(" \033[33m[%s]\033[0m", name)
+	// 安全认证类 - 红色
+	case NamedAUTH, NamedUSER, NamedPERM, NamedROLE, NamedTOKN, NamedSIGN:
+		return fmt.Sprintf(" \033[31m[%s]\033[0m", name)
+	// 业务逻辑类 - 白色
+	case NamedBUSI, NamedPROC, NamedFLOW, NamedTASK, NamedJOBS:
+		return fmt.Sprintf(" \033[37m[%s]\033[0m", name)
+	// 其他已定义的常量 - 橙色
+	case NamedRECO, NamedUTIL, NamedFILT, NamedMIDE, NamedVALD, NamedINIT, NamedTHOW, NamedRESU:
+		return fmt.Sprintf(" \033[93m[%s]\033[0m", name)
+	default:
+		return fmt.Sprintf(" \033[35m[%s]\033[0m", name) // 紫色
+	}
+}
@@ -0,0 +1,122 @@
+package xMetrics
+
+import (
+	"bufio"
+	"io"
+	"math"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ContentType Prometheus 文本格式的 Content-Type
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// WriteText 以 Prometheus 文本格式写出全部指标，写出前依次执行 [Registry.OnScrape] 回调
+//
+// 指标族按声明顺序输出，同一指标族内的时间序列按标签值排序；尚无时间序列的指标族只输出 HELP/TYPE。
+func (r *Registry) WriteText(w io.Writer) error {
+	r.mu.RLock()
+	scrapes := slices.Clone(r.scrapes)
+	r.mu.RUnlock()
+	for _, hook := range scrapes {
+		hook.fn()
+	}
+
+	r.mu.RLock()
+	families := make([]*family, 0, len(r.order))
+	for _, name := range r.order {
+		families = append(families, r.families[name])
+	}
+	r.mu.RUnlock()
+
+	buf := bufio.NewWriter(w)
+	for _, f := range families {
+		writeFamily(buf, f)
+	}
+	return buf.Flush()
+}
+
+// Handler 返回以 Prometheus 文本格式导出 r 的 http.Handler
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", ContentType)
+		_ = r.WriteText(w)
+	})
+}
+
+// Handler 返回导出 [Default] 注册表的 http.Handler
+func Handler() http.Handler {
+	return defaultRegistry.Handler()
+}
+
+// writeFamily 写出单个指标族
+func writeFamily(w *bufio.Writer, f *family) {
+	w.WriteString("# HELP " + f.name + " " + escapeHelp(f.help) + "\n")
+	w.WriteString("# TYPE " + f.name + " " + string(f.kind) + "\n")
+	for _, s := range f.snapshot() {
+		if f.kind != KindHistogram {
+			writeSample(w, f.name, f.labels, s.values, "", s.value.load())
+			continue
+		}
+		var cumulative uint64
+		for i, bound := range f.buckets {
+			cumulative += s.counts[i].Load()
+			writeSample(w, f.name+"_bucket", f.labels, s.values, formatFloat(bound), float64(cumulative))
+		}
+		cumulative += s.counts[len(f.buckets)].Load()
+		writeSample(w, f.name+"_bucket", f.labels, s.values, "+Inf", float64(cumulative))
+		writeSample(w, f.name+"_sum", f.labels, s.values, "", s.sum.load())
+		writeSample(w, f.name+"_count", f.labels, s.values, "", float64(s.count.Load()))
+	}
+}
+
+// writeSample 写出一行样本，le 非空时追加直方图分桶标签
+func writeSample(w *bufio.Writer, name string, labels, values []string, le string, value float64) {
+	w.WriteString(name)
+	if len(labels) > 0 || le != "" {
+		w.WriteByte('{')
+		for i, label := range labels {
+			if i > 0 {
+				w.WriteByte(',')
+			}
+			w.WriteString(label + `="` + escapeLabel(values[i]) + `"`)
+		}
+		if le != "" {
+			if len(labels) > 0 {
+				w.WriteByte(',')
+			}
+			w.WriteString(`le="` + le + `"`)
+		}
+		w.WriteByte('}')
+	}
+	w.WriteByte(' ')
+	w.WriteString(formatFloat(value))
+	w.WriteByte('\n')
+}
+
+// formatFloat 按 Prometheus 约定格式化浮点数
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	default:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	}
+}
+
+var (
+	helpEscaper  = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+	labelEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+)
+
+// escapeHelp 转义 HELP 文本中的反斜杠与换行
+func escapeHelp(s string) string { return helpEscaper.Replace(s) }
+
+// escapeLabel 转义标签值中的反斜杠、换行与双引号
+func escapeLabel(s string) string { return labelEscaper.Replace(s) }
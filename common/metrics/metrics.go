@@ -0,0 +1,199 @@
+// Package xMetrics 指标注册表，提供计数器、仪表盘与直方图，并以 Prometheus 文本格式导出。
+//
+// 框架内置的埋点（HTTP、gRPC、GORM、缓存、Cron、异步任务）均写入 [Default] 注册表，
+// 通过 xOption.WithMetrics 挂载的路由或 [Handler] 对外暴露。
+// 业务侧可通过 [NewCounter] / [NewGauge] / [NewHistogram] 在同一注册表中追加自定义指标：
+//
+//	var orders = xMetrics.NewCounter("orders_created_total", "已创建订单数", "channel")
+//	orders.Inc("app")
+//
+// 同名指标重复声明时返回已存在的实例（类型或标签不一致时 panic），便于在多处按需获取。
+// 该包不依赖第三方库，位于 common 层，插件可直接埋点。
+package xMetrics
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Kind 指标类型
+type Kind string
+
+const (
+	KindCounter   Kind = "counter"   // 单调递增计数器
+	KindGauge     Kind = "gauge"     // 可增可减的瞬时值
+	KindHistogram Kind = "histogram" // 分桶统计的观测值分布
+)
+
+// DefaultBuckets 默认直方图分桶（秒），覆盖 5ms 到 10s 的常见请求耗时
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// family 同名指标族，按标签值组合持有各时间序列
+type family struct {
+	name    string
+	help    string
+	kind    Kind
+	labels  []string
+	buckets []float64
+
+	mu     sync.RWMutex
+	series map[string]*series
+}
+
+// series 单条时间序列
+type series struct {
+	values []string
+	value  atomicFloat // counter / gauge 的当前值
+
+	counts []atomic.Uint64 // histogram 各分桶计数（非累计），最后一个为 +Inf
+	sum    atomicFloat
+	count  atomic.Uint64
+}
+
+// with 返回标签值对应的时间序列，不存在时创建
+func (f *family) with(values []string) *series {
+	if len(values) != len(f.labels) {
+		panic(fmt.Sprintf("xMetrics: 指标 %s 需要 %d 个标签值，实际 %d 个", f.name, len(f.labels), len(values)))
+	}
+	key := strings.Join(values, "\xff")
+
+	f.mu.RLock()
+	s, ok := f.series[key]
+	f.mu.RUnlock()
+	if ok {
+		return s
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if s, ok = f.series[key]; ok {
+		return s
+	}
+	s = &series{values: append([]string(nil), values...)}
+	if f.kind == KindHistogram {
+		s.counts = make([]atomic.Uint64, len(f.buckets)+1)
+	}
+	f.series[key] = s
+	return s
+}
+
+// snapshot 返回按标签值排序的时间序列，保证输出稳定
+func (f *family) snapshot() []*series {
+	f.mu.RLock()
+	list := make([]*series, 0, len(f.series))
+	for _, s := range f.series {
+		list = append(list, s)
+	}
+	f.mu.RUnlock()
+	slices.SortFunc(list, func(a, b *series) int {
+		return slices.Compare(a.values, b.values)
+	})
+	return list
+}
+
+// Counter 计数器，只能递增
+type Counter struct{ family *family }
+
+// With 返回标签值对应的计数器，热点路径可缓存返回值避免重复查找
+func (c *Counter) With(values ...string) *CounterValue {
+	return &CounterValue{series: c.family.with(values)}
+}
+
+// Inc 将标签值对应的计数器加 1
+func (c *Counter) Inc(values ...string) { c.With(values...).Inc() }
+
+// Add 将标签值对应的计数器增加 v，v 为负数时忽略
+func (c *Counter) Add(v float64, values ...string) { c.With(values...).Add(v) }
+
+// CounterValue 单条计数器序列
+type CounterValue struct{ series *series }
+
+// Inc 加 1
+func (v *CounterValue) Inc() { v.series.value.add(1) }
+
+// Add 增加 delta，delta 为负数时忽略
+func (v *CounterValue) Add(delta float64) {
+	if delta > 0 {
+		v.series.value.add(delta)
+	}
+}
+
+// Value 返回当前值
+func (v *CounterValue) Value() float64 { return v.series.value.load() }
+
+// Gauge 仪表盘，可任意设置
+type Gauge struct{ family *family }
+
+// With 返回标签值对应的仪表盘
+func (g *Gauge) With(values ...string) *GaugeValue {
+	return &GaugeValue{series: g.family.with(values)}
+}
+
+// Set 设置标签值对应的仪表盘
+func (g *Gauge) Set(v float64, values ...string) { g.With(values...).Set(v) }
+
+// Add 将标签值对应的仪表盘增加 v（可为负数）
+func (g *Gauge) Add(v float64, values ...string) { g.With(values...).Add(v) }
+
+// GaugeValue 单条仪表盘序列
+type GaugeValue struct{ series *series }
+
+// Set 设置当前值
+func (v *GaugeValue) Set(value float64) { v.series.value.store(value) }
+
+// Add 增加 delta（可为负数）
+func (v *GaugeValue) Add(delta float64) { v.series.value.add(delta) }
+
+// Inc 加 1
+func (v *GaugeValue) Inc() { v.series.value.add(1) }
+
+// Dec 减 1
+func (v *GaugeValue) Dec() { v.series.value.add(-1) }
+
+// Value 返回当前值
+func (v *GaugeValue) Value() float64 { return v.series.value.load() }
+
+// Histogram 直方图
+type Histogram struct{ family *family }
+
+// With 返回标签值对应的直方图
+func (h *Histogram) With(values ...string) *HistogramValue {
+	return &HistogramValue{series: h.family.with(values), buckets: h.family.buckets}
+}
+
+// Observe 记录一次观测值
+func (h *Histogram) Observe(v float64, values ...string) { h.With(values...).Observe(v) }
+
+// HistogramValue 单条直方图序列
+type HistogramValue struct {
+	series  *series
+	buckets []float64
+}
+
+// Observe 记录一次观测值
+func (v *HistogramValue) Observe(value float64) {
+	index, _ := slices.BinarySearch(v.buckets, value)
+	v.series.counts[index].Add(1)
+	v.series.sum.add(value)
+	v.series.count.Add(1)
+}
+
+// atomicFloat 以原子方式读写的 float64
+type atomicFloat struct{ bits atomic.Uint64 }
+
+func (f *atomicFloat) load() float64 { return math.Float64frombits(f.bits.Load()) }
+
+func (f *atomicFloat) store(v float64) { f.bits.Store(math.Float64bits(v)) }
+
+func (f *atomicFloat) add(delta float64) {
+	for {
+		old := f.bits.Load()
+		if f.bits.CompareAndSwap(old, math.Float64bits(math.Float64frombits(old)+delta)) {
+			return
+		}
+	}
+}
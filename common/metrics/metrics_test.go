@@ -0,0 +1,86 @@
+package xMetrics
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestWriteText 验证计数器、仪表盘与直方图的 Prometheus 文本输出。
+func TestWriteText(t *testing.T) {
+	registry := NewRegistry()
+	requests := registry.Counter("http_requests_total", "HTTP 请求数", "method", "status")
+	requests.Inc("GET", "200")
+	requests.Add(2, "GET", "200")
+	requests.Inc("POST", "500")
+	requests.Add(-1, "POST", "500")
+
+	inFlight := registry.Gauge("http_requests_in_flight", "处理中的请求数")
+	registry.OnScrape(func() { inFlight.Set(3) })
+
+	latency := registry.Histogram("http_request_duration_seconds", "请求耗时", []float64{0.5, 0.1, 1}, "route")
+	latency.Observe(0.05, `/a"b`)
+	latency.Observe(0.3, `/a"b`)
+	latency.Observe(2, `/a"b`)
+
+	var out strings.Builder
+	if err := registry.WriteText(&out); err != nil {
+		t.Fatalf("WriteText 返回错误: %v", err)
+	}
+	want := `# HELP http_requests_total HTTP 请求数
+# TYPE http_requests_total counter
+http_requests_total{method="GET",status="200"} 3
+http_requests_total{method="POST",status="500"} 1
+# HELP http_requests_in_flight 处理中的请求数
+# TYPE http_requests_in_flight gauge
+http_requests_in_flight 3
+# HELP http_request_duration_seconds 请求耗时
+# TYPE http_request_duration_seconds histogram
+http_request_duration_seconds_bucket{route="/a\"b",le="0.1"} 1
+http_request_duration_seconds_bucket{route="/a\"b",le="0.5"} 2
+http_request_duration_seconds_bucket{route="/a\"b",le="1"} 2
+http_request_duration_seconds_bucket{route="/a\"b",le="+Inf"} 3
+http_request_duration_seconds_sum{route="/a\"b"} 2.35
+http_request_duration_seconds_count{route="/a\"b"} 3
+`
+	if out.String() != want {
+		t.Errorf("输出不匹配:\n%s\n期望:\n%s", out.String(), want)
+	}
+}
+
+// TestRegistry_Redeclare 验证同名同类型指标复用，类型冲突时 panic。
+func TestRegistry_Redeclare(t *testing.T) {
+	registry := NewRegistry()
+	registry.Counter("jobs_total", "任务数", "job").Inc("a")
+	registry.Counter("jobs_total", "任务数", "job").Inc("a")
+
+	var out strings.Builder
+	_ = registry.WriteText(&out)
+	if !strings.Contains(out.String(), `jobs_total{job="a"} 2`) {
+		t.Errorf("同名计数器应共享序列:\n%s", out.String())
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("类型冲突时应 panic")
+		}
+	}()
+	registry.Gauge("jobs_total", "任务数", "job")
+}
+
+// TestRegistry_OnScrapeUnregister 验证注销后的抓取回调不再执行，重复注销无副作用。
+func TestRegistry_OnScrapeUnregister(t *testing.T) {
+	registry := NewRegistry()
+	var first, second int
+	unregister := registry.OnScrape(func() { first++ })
+	registry.OnScrape(func() { second++ })
+
+	var out strings.Builder
+	_ = registry.WriteText(&out)
+	unregister()
+	unregister()
+	_ = registry.WriteText(&out)
+
+	if first != 1 || second != 2 {
+		t.Errorf("注销后回调仍被执行: first=%d second=%d", first, second)
+	}
+}
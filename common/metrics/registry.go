@@ -0,0 +1,127 @@
+package xMetrics
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sync"
+)
+
+// metricNamePattern Prometheus 指标与标签名称规则
+var metricNamePattern = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*$`)
+
+// Registry 指标注册表
+type Registry struct {
+	mu       sync.RWMutex
+	families map[string]*family
+	order    []string
+	scrapes  []*scrapeHook
+}
+
+// scrapeHook 包装抓取回调，以指针标识区分注销对象
+type scrapeHook struct {
+	fn func()
+}
+
+// NewRegistry 创建空的指标注册表
+func NewRegistry() *Registry {
+	return &Registry{families: make(map[string]*family)}
+}
+
+var defaultRegistry = NewRegistry()
+
+// Default 返回全局默认注册表，框架内置埋点均写入该注册表
+func Default() *Registry {
+	return defaultRegistry
+}
+
+// Counter 声明计数器，同名计数器已存在时直接返回
+func (r *Registry) Counter(name, help string, labels ...string) *Counter {
+	return &Counter{family: r.family(name, help, KindCounter, labels, nil)}
+}
+
+// Gauge 声明仪表盘，同名仪表盘已存在时直接返回
+func (r *Registry) Gauge(name, help string, labels ...string) *Gauge {
+	return &Gauge{family: r.family(name, help, KindGauge, labels, nil)}
+}
+
+// Histogram 声明直方图，buckets 为 nil 时使用 [DefaultBuckets]；同名直方图已存在时直接返回
+func (r *Registry) Histogram(name, help string, buckets []float64, labels ...string) *Histogram {
+	if buckets == nil {
+		buckets = DefaultBuckets
+	}
+	buckets = slices.Clone(buckets)
+	slices.Sort(buckets)
+	return &Histogram{family: r.family(name, help, KindHistogram, labels, slices.Compact(buckets))}
+}
+
+// OnScrape 注册导出前执行的回调，用于在抓取时刷新连接池状态等按需计算的仪表盘
+//
+// 回调应尽快返回；涉及网络 I/O 时需自行设置超时。
+// 返回的注销函数用于在数据源关闭时移除回调，可安全多次调用。
+func (r *Registry) OnScrape(fn func()) (unregister func()) {
+	if fn == nil {
+		return func() {}
+	}
+	hook := &scrapeHook{fn: fn}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.scrapes = append(r.scrapes, hook)
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.scrapes = slices.DeleteFunc(r.scrapes, func(h *scrapeHook) bool { return h == hook })
+	}
+}
+
+// family 按名称获取或创建指标族，名称非法或与已存在的指标类型、标签不一致时 panic
+func (r *Registry) family(name, help string, kind Kind, labels []string, buckets []float64) *family {
+	if !metricNamePattern.MatchString(name) {
+		panic(fmt.Sprintf("xMetrics: 非法的指标名称 %q", name))
+	}
+	for _, label := range labels {
+		if !metricNamePattern.MatchString(label) || label == "le" {
+			panic(fmt.Sprintf("xMetrics: 指标 %s 的标签名称 %q 非法", name, label))
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if existing, ok := r.families[name]; ok {
+		if existing.kind != kind || !slices.Equal(existing.labels, labels) {
+			panic(fmt.Sprintf("xMetrics: 指标 %s 已声明为 %s%v，与 %s%v 冲突", name, existing.kind, existing.labels, kind, labels))
+		}
+		return existing
+	}
+	f := &family{
+		name:    name,
+		help:    help,
+		kind:    kind,
+		labels:  slices.Clone(labels),
+		buckets: buckets,
+		series:  make(map[string]*series),
+	}
+	r.families[name] = f
+	r.order = append(r.order, name)
+	return f
+}
+
+// NewCounter 在 [Default] 注册表中声明计数器
+func NewCounter(name, help string, labels ...string) *Counter {
+	return defaultRegistry.Counter(name, help, labels...)
+}
+
+// NewGauge 在 [Default] 注册表中声明仪表盘
+func NewGauge(name, help string, labels ...string) *Gauge {
+	return defaultRegistry.Gauge(name, help, labels...)
+}
+
+// NewHistogram 在 [Default] 注册表中声明直方图
+func NewHistogram(name, help string, buckets []float64, labels ...string) *Histogram {
+	return defaultRegistry.Histogram(name, help, buckets, labels...)
+}
+
+// OnScrape 在 [Default] 注册表中注册导出前回调，返回注销函数
+func OnScrape(fn func()) (unregister func()) {
+	return defaultRegistry.OnScrape(fn)
+}
@@ -0,0 +1,219 @@
+// Package xRateLimit 限流算法与限流器接口。
+//
+// 提供令牌桶（[TokenBucket]）与滑动窗口（[SlidingWindow]）两种算法的纯计算实现，
+// 状态的存取由后端负责：
+//   - 内存后端（单实例）：xCacheMemory 在分片锁内调用 [Limit.AllowTokenBucket] / [Limit.AllowSlidingWindow]
+//   - Redis 后端（集群）：xCacheRedis 以 Lua 脚本实现相同的算法，保证多实例下的原子性
+//
+// 业务侧通常通过 xCache.LimiterOf 获取 [Limiter]，再交给 xMiddle.RateLimit 或
+// gRPC 限流拦截器使用。该包位于 common 层，插件无需依赖 major 即可接入。
+package xRateLimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// Algorithm 限流算法
+type Algorithm string
+
+const (
+	TokenBucket   Algorithm = "token_bucket"   // 令牌桶：按固定速率补充令牌，允许不超过 Burst 的突发
+	SlidingWindow Algorithm = "sliding_window" // 滑动窗口：按上一窗口的剩余权重加权计数，平滑窗口边界的突刺
+)
+
+// Limiter 限流器接口
+//
+// key 为限流维度（如客户端 IP、用户 ID、路由），由调用方拼接；后端错误时返回 error，
+// 是否放行由调用方决定。
+type Limiter interface {
+	Allow(ctx context.Context, key string) (Result, error)
+}
+
+// Result 单次限流判定结果
+type Result struct {
+	Allowed    bool          // 是否放行
+	Limit      int           // 额度上限（令牌桶为桶容量，滑动窗口为窗口内请求数）
+	Remaining  int           // 本次判定后的剩余额度
+	ResetAfter time.Duration // 额度完全恢复所需时间
+	RetryAfter time.Duration // 被拒绝时建议的重试等待时间，放行时为 0
+}
+
+// Limit 限流规则
+type Limit struct {
+	Algorithm Algorithm     // 限流算法，为空时视为 [TokenBucket]
+	Rate      int           // 每个 Period 允许的请求数
+	Period    time.Duration // 统计周期
+	Burst     int           // 令牌桶容量，<= 0 时取 Rate；滑动窗口忽略该字段
+}
+
+// PerSecond 每秒 rate 次的令牌桶规则
+func PerSecond(rate int) Limit {
+	return Limit{Algorithm: TokenBucket, Rate: rate, Period: time.Second}
+}
+
+// PerMinute 每分钟 rate 次的令牌桶规则
+func PerMinute(rate int) Limit {
+	return Limit{Algorithm: TokenBucket, Rate: rate, Period: time.Minute}
+}
+
+// PerHour 每小时 rate 次的令牌桶规则
+func PerHour(rate int) Limit {
+	return Limit{Algorithm: TokenBucket, Rate: rate, Period: time.Hour}
+}
+
+// WithBurst 返回设置了令牌桶容量的规则副本
+func (l Limit) WithBurst(burst int) Limit {
+	l.Burst = burst
+	return l
+}
+
+// Sliding 返回改用滑动窗口算法的规则副本
+func (l Limit) Sliding() Limit {
+	l.Algorithm = SlidingWindow
+	return l
+}
+
+// Validate 校验规则是否合法
+func (l Limit) Validate() error {
+	var errs []error
+	switch l.Algorithm {
+	case "", TokenBucket, SlidingWindow:
+	default:
+		errs = append(errs, fmt.Errorf("不支持的限流算法: %s", l.Algorithm))
+	}
+	if l.Rate <= 0 {
+		errs = append(errs, fmt.Errorf("限流速率必须大于 0，实际为 %d", l.Rate))
+	}
+	if l.Period < time.Millisecond {
+		errs = append(errs, fmt.Errorf("限流周期不能小于 1ms，实际为 %s", l.Period))
+	}
+	return errors.Join(errs...)
+}
+
+// Capacity 返回额度上限：令牌桶为桶容量，滑动窗口为 Rate
+func (l Limit) Capacity() int {
+	if l.Algorithm != SlidingWindow && l.Burst > 0 {
+		return l.Burst
+	}
+	return l.Rate
+}
+
+// TTL 返回限流状态需保留的时长，超过该时长未访问的状态可安全丢弃
+//
+// 令牌桶为桶从空到满的时间，滑动窗口为两个周期。
+func (l Limit) TTL() time.Duration {
+	if l.Algorithm == SlidingWindow {
+		return 2 * l.Period
+	}
+	return time.Duration(math.Ceil(float64(l.Capacity()) * float64(l.Interval())))
+}
+
+// Interval 返回令牌桶补充一个令牌的间隔
+func (l Limit) Interval() time.Duration {
+	return l.Period / time.Duration(l.Rate)
+}
+
+// BucketState 令牌桶状态
+type BucketState struct {
+	Tokens float64   // 当前令牌数
+	Last   time.Time // 上次补充令牌的时间
+}
+
+// AllowTokenBucket 按令牌桶算法判定一次请求，返回新的状态与判定结果。
+//
+// state 为 nil 表示首次访问，桶视为满。时钟回拨时不补充令牌，也不回退 Last。
+func (l Limit) AllowTokenBucket(state *BucketState, now time.Time) (BucketState, Result) {
+	capacity := float64(l.Capacity())
+	interval := float64(l.Interval())
+
+	next := BucketState{Tokens: capacity, Last: now}
+	if state != nil {
+		next = *state
+		if elapsed := now.Sub(state.Last); elapsed > 0 {
+			next.Tokens = math.Min(capacity, state.Tokens+float64(elapsed)/interval)
+			next.Last = now
+		}
+	}
+
+	result := Result{Limit: int(capacity)}
+	if next.Tokens >= 1 {
+		next.Tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = time.Duration(math.Ceil((1 - next.Tokens) * interval))
+	}
+	result.Remaining = int(math.Floor(next.Tokens))
+	result.ResetAfter = time.Duration(math.Ceil((capacity - next.Tokens) * interval))
+	return next, result
+}
+
+// WindowState 滑动窗口状态
+type WindowState struct {
+	Start    int64 // 当前窗口起点（Unix 毫秒，按 Period 对齐）
+	Previous int   // 上一窗口的请求数
+	Current  int   // 当前窗口的请求数
+}
+
+// AllowSlidingWindow 按滑动窗口算法判定一次请求，返回新的状态与判定结果。
+//
+// 窗口按 Unix 毫秒对齐，估算值为「上一窗口计数 × 剩余权重 + 当前窗口计数」；
+// 被拒绝的请求不计数。state 为 nil 表示首次访问。
+func (l Limit) AllowSlidingWindow(state *WindowState, now time.Time) (WindowState, Result) {
+	period := l.Period.Milliseconds()
+	nowMs := now.UnixMilli()
+	start := nowMs - nowMs%period
+
+	next := WindowState{Start: start}
+	if state != nil {
+		switch state.Start {
+		case start:
+			next = *state
+		case start - period:
+			next.Previous = state.Current
+		}
+	}
+
+	elapsed := float64(nowMs - start)
+	estimate := float64(next.Previous)*(1-elapsed/float64(period)) + float64(next.Current)
+	result := Result{Limit: l.Rate}
+	if estimate+1 <= float64(l.Rate) {
+		next.Current++
+		estimate++
+		result.Allowed = true
+	} else {
+		result.RetryAfter = windowRetryAfter(l.Rate, period, elapsed, next)
+	}
+	result.Remaining = max(0, int(math.Floor(float64(l.Rate)-estimate)))
+	result.ResetAfter = windowResetAfter(period, elapsed, next)
+	return next, result
+}
+
+// windowRetryAfter 计算估算值回落到可再放行一次所需的时间（毫秒精度）
+func windowRetryAfter(rate int, period int64, elapsed float64, state WindowState) time.Duration {
+	p := float64(period)
+	var wait float64
+	if state.Current >= rate {
+		// 当前窗口已满，需等到下一窗口且本窗口计数衰减到 rate-1 以下
+		wait = p - elapsed + p*(1-float64(rate-1)/float64(state.Current))
+	} else {
+		wait = p*(1-float64(rate-1-state.Current)/float64(state.Previous)) - elapsed
+	}
+	return time.Duration(math.Ceil(max(wait, 1))) * time.Millisecond
+}
+
+// windowResetAfter 计算估算值衰减到 0 所需的时间（毫秒精度）
+func windowResetAfter(period int64, elapsed float64, state WindowState) time.Duration {
+	p := float64(period)
+	switch {
+	case state.Current > 0:
+		return time.Duration(math.Ceil(2*p-elapsed)) * time.Millisecond
+	case state.Previous > 0:
+		return time.Duration(math.Ceil(p-elapsed)) * time.Millisecond
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,99 @@
+package xRateLimit
+
+import (
+	"testing"
+	"time"
+)
+
+// TestAllowTokenBucket 验证令牌桶的突发容量、补充速率与重试时间。
+func TestAllowTokenBucket(t *testing.T) {
+	limit := PerSecond(2).WithBurst(3)
+	now := time.Unix(1_700_000_000, 0)
+
+	var state *BucketState
+	for i := 0; i < 3; i++ {
+		next, result := limit.AllowTokenBucket(state, now)
+		if !result.Allowed {
+			t.Fatalf("第 %d 次请求应在突发容量内放行", i+1)
+		}
+		if result.Limit != 3 || result.Remaining != 2-i {
+			t.Fatalf("第 %d 次请求 Limit/Remaining = %d/%d", i+1, result.Limit, result.Remaining)
+		}
+		state = &next
+	}
+
+	next, result := limit.AllowTokenBucket(state, now)
+	if result.Allowed {
+		t.Fatal("令牌耗尽后应拒绝")
+	}
+	if result.RetryAfter != 500*time.Millisecond {
+		t.Errorf("RetryAfter = %s，期望 500ms", result.RetryAfter)
+	}
+	if result.ResetAfter != 1500*time.Millisecond {
+		t.Errorf("ResetAfter = %s，期望 1.5s", result.ResetAfter)
+	}
+	state = &next
+
+	if _, result = limit.AllowTokenBucket(state, now.Add(500*time.Millisecond)); !result.Allowed {
+		t.Error("补充一个令牌后应放行")
+	}
+	if _, result = limit.AllowTokenBucket(state, now.Add(-time.Second)); result.Allowed {
+		t.Error("时钟回拨时不应补充令牌")
+	}
+}
+
+// TestAllowSlidingWindow 验证滑动窗口的计数、跨窗口加权与重试时间。
+func TestAllowSlidingWindow(t *testing.T) {
+	limit := PerSecond(4).Sliding()
+	start := time.UnixMilli(1_700_000_000_000)
+
+	var state *WindowState
+	for i := 0; i < 4; i++ {
+		next, result := limit.AllowSlidingWindow(state, start.Add(100*time.Millisecond))
+		if !result.Allowed || result.Remaining != 3-i {
+			t.Fatalf("第 %d 次请求 Allowed/Remaining = %v/%d", i+1, result.Allowed, result.Remaining)
+		}
+		state = &next
+	}
+
+	next, result := limit.AllowSlidingWindow(state, start.Add(100*time.Millisecond))
+	if result.Allowed {
+		t.Fatal("窗口已满时应拒绝")
+	}
+	if next.Current != 4 {
+		t.Errorf("被拒绝的请求不应计数，Current = %d", next.Current)
+	}
+	// 下一窗口需等到上一窗口权重衰减到 3/4 以下：0.9s 到窗口末尾 + 0.25s
+	if result.RetryAfter != 1150*time.Millisecond {
+		t.Errorf("RetryAfter = %s，期望 1.15s", result.RetryAfter)
+	}
+
+	// 下一窗口过半时估算值为 4*0.5 = 2，放行后剩余 1 次
+	_, result = limit.AllowSlidingWindow(state, start.Add(1500*time.Millisecond))
+	if !result.Allowed || result.Remaining != 1 {
+		t.Errorf("跨窗口 Allowed/Remaining = %v/%d，期望 true/1", result.Allowed, result.Remaining)
+	}
+
+	// 间隔超过两个窗口后状态清零
+	next, result = limit.AllowSlidingWindow(state, start.Add(3*time.Second))
+	if !result.Allowed || next.Previous != 0 || next.Current != 1 {
+		t.Errorf("过期状态未清零: %+v", next)
+	}
+}
+
+// TestLimitValidate 验证非法规则被拒绝。
+func TestLimitValidate(t *testing.T) {
+	if err := PerMinute(10).Validate(); err != nil {
+		t.Errorf("合法规则返回错误: %v", err)
+	}
+	invalid := []Limit{
+		{Rate: 0, Period: time.Second},
+		{Rate: 1, Period: 0},
+		{Algorithm: "leaky", Rate: 1, Period: time.Second},
+	}
+	for _, limit := range invalid {
+		if err := limit.Validate(); err == nil {
+			t.Errorf("非法规则 %+v 未返回错误", limit)
+		}
+	}
+}
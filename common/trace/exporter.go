@@ -0,0 +1,154 @@
+package xTrace
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// WriterExporter 将 span 以每行一个 JSON 对象写入 io.Writer（标准输出或文件）
+//
+// 字段: trace_id、span_id、parent_id、name、kind、start、end、duration_ms、status、status_message、attrs。
+type WriterExporter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewWriterExporter 创建写入 w 的导出器，w 为 nil 时写入标准输出
+func NewWriterExporter(w io.Writer) *WriterExporter {
+	if w == nil {
+		w = os.Stdout
+	}
+	return &WriterExporter{w: w}
+}
+
+// NewFileExporter 创建追加写入 path 的导出器，目录不存在时自动创建
+func NewFileExporter(path string) (*WriterExporter, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("创建链路文件目录失败: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("打开链路文件失败: %w", err)
+	}
+	return &WriterExporter{w: file}, nil
+}
+
+// spanRecord WriterExporter 的单行输出结构
+type spanRecord struct {
+	TraceID       string         `json:"trace_id"`
+	SpanID        string         `json:"span_id"`
+	ParentID      string         `json:"parent_id,omitempty"`
+	Name          string         `json:"name"`
+	Kind          string         `json:"kind"`
+	Start         string         `json:"start"`
+	End           string         `json:"end"`
+	DurationMs    float64        `json:"duration_ms"`
+	Status        string         `json:"status"`
+	StatusMessage string         `json:"status_message,omitempty"`
+	Attrs         map[string]any `json:"attrs,omitempty"`
+}
+
+// Export 实现 [Exporter]
+func (e *WriterExporter) Export(_ context.Context, spans []SpanData) error {
+	buf := make([]byte, 0, 256*len(spans))
+	for _, span := range spans {
+		record := spanRecord{
+			TraceID:       span.SpanContext.TraceID.String(),
+			SpanID:        span.SpanContext.SpanID.String(),
+			Name:          span.Name,
+			Kind:          span.Kind.String(),
+			Start:         span.StartTime.Format(time.RFC3339Nano),
+			End:           span.EndTime.Format(time.RFC3339Nano),
+			DurationMs:    float64(span.Duration().Microseconds()) / 1e3,
+			Status:        span.Status.String(),
+			StatusMessage: span.StatusMessage,
+		}
+		if span.SpanContext.ParentID.IsValid() {
+			record.ParentID = span.SpanContext.ParentID.String()
+		}
+		if attrs := flattenAttrs(span.Attrs); len(attrs) > 0 {
+			record.Attrs = make(map[string]any, len(attrs))
+			for _, a := range attrs {
+				record.Attrs[a.Key] = attrValue(a.Value)
+			}
+		}
+		data, err := json.Marshal(record)
+		if err != nil {
+			return fmt.Errorf("编码 span 失败: %w", err)
+		}
+		buf = append(append(buf, data...), '\n')
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	_, err := e.w.Write(buf)
+	return err
+}
+
+// Shutdown 实现 [Exporter]，写入目标为文件时关闭文件
+func (e *WriterExporter) Shutdown(_ context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if file, ok := e.w.(*os.File); ok && file != os.Stdout && file != os.Stderr {
+		return file.Close()
+	}
+	return nil
+}
+
+// flattenAttrs 展开分组属性为 "group.key" 形式，同名属性保留最后一次写入的值
+func flattenAttrs(attrs []slog.Attr) []slog.Attr {
+	flat := make([]slog.Attr, 0, len(attrs))
+	index := make(map[string]int, len(attrs))
+	var walk func(prefix string, attrs []slog.Attr)
+	walk = func(prefix string, attrs []slog.Attr) {
+		for _, a := range attrs {
+			a.Value = a.Value.Resolve()
+			if a.Key == "" && a.Value.Kind() != slog.KindGroup {
+				continue
+			}
+			key := a.Key
+			if prefix != "" && key != "" {
+				key = prefix + "." + key
+			} else if key == "" {
+				key = prefix
+			}
+			if a.Value.Kind() == slog.KindGroup {
+				walk(key, a.Value.Group())
+				continue
+			}
+			if i, ok := index[key]; ok {
+				flat[i].Value = a.Value
+				continue
+			}
+			index[key] = len(flat)
+			flat = append(flat, slog.Attr{Key: key, Value: a.Value})
+		}
+	}
+	walk("", attrs)
+	return flat
+}
+
+// attrValue 将 slog.Value 转换为适合 JSON 编码的值
+func attrValue(v slog.Value) any {
+	switch v.Kind() {
+	case slog.KindDuration:
+		return v.Duration().String()
+	case slog.KindTime:
+		return v.Time().Format(time.RFC3339Nano)
+	case slog.KindAny:
+		if err, ok := v.Any().(error); ok {
+			return err.Error()
+		}
+		if s, ok := v.Any().(fmt.Stringer); ok {
+			return s.String()
+		}
+	}
+	return v.Any()
+}
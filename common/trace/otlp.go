@@ -0,0 +1,194 @@
+package xTrace
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// otlpTracesPath OTLP/HTTP 的 traces 接收路径
+const otlpTracesPath = "/v1/traces"
+
+// OTLPConfig OTLP/HTTP 导出器配置
+type OTLPConfig struct {
+	Endpoint      string            // 收集器地址，如 http://127.0.0.1:4318；未包含路径时自动追加 /v1/traces
+	Headers       map[string]string // 额外请求头（如鉴权）
+	Timeout       time.Duration     // 单次请求超时，<= 0 时默认 10s
+	ServiceName   string            // resource 的 service.name，默认 "unknown_service"
+	ResourceAttrs []slog.Attr       // 其余 resource 属性（如 service.version）
+	Client        *http.Client      // 自定义 HTTP 客户端（可选）
+}
+
+// OTLPExporter 以 OTLP/HTTP JSON 编码将 span 发送到收集器（OpenTelemetry Collector、Jaeger、Tempo 等）
+type OTLPExporter struct {
+	url      string
+	headers  map[string]string
+	client   *http.Client
+	resource []otlpKeyValue
+}
+
+// NewOTLPExporter 创建 OTLP/HTTP 导出器
+func NewOTLPExporter(config OTLPConfig) (*OTLPExporter, error) {
+	endpoint := strings.TrimRight(strings.TrimSpace(config.Endpoint), "/")
+	if endpoint == "" {
+		return nil, fmt.Errorf("OTLP 收集器地址不能为空")
+	}
+	if !strings.HasPrefix(endpoint, "http://") && !strings.HasPrefix(endpoint, "https://") {
+		endpoint = "http://" + endpoint
+	}
+	if rest := endpoint[strings.Index(endpoint, "://")+3:]; !strings.Contains(rest, "/") {
+		endpoint += otlpTracesPath
+	}
+
+	client := config.Client
+	if client == nil {
+		timeout := config.Timeout
+		if timeout <= 0 {
+			timeout = exportTimeout
+		}
+		client = &http.Client{Timeout: timeout}
+	}
+
+	serviceName := config.ServiceName
+	if serviceName == "" {
+		serviceName = "unknown_service"
+	}
+	resource := append([]slog.Attr{slog.String("service.name", serviceName)}, config.ResourceAttrs...)
+
+	return &OTLPExporter{
+		url:      endpoint,
+		headers:  config.Headers,
+		client:   client,
+		resource: otlpAttributes(resource),
+	}, nil
+}
+
+// OTLP JSON 编码结构，字段名遵循 OTLP/JSON 映射（ID 为十六进制，64 位整数为字符串）
+type (
+	otlpRequest struct {
+		ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+	}
+	otlpResourceSpans struct {
+		Resource   otlpResource     `json:"resource"`
+		ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+	}
+	otlpResource struct {
+		Attributes []otlpKeyValue `json:"attributes"`
+	}
+	otlpScopeSpans struct {
+		Scope otlpScope  `json:"scope"`
+		Spans []otlpSpan `json:"spans"`
+	}
+	otlpScope struct {
+		Name string `json:"name"`
+	}
+	otlpSpan struct {
+		TraceID           string         `json:"traceId"`
+		SpanID            string         `json:"spanId"`
+		ParentSpanID      string         `json:"parentSpanId,omitempty"`
+		TraceState        string         `json:"traceState,omitempty"`
+		Name              string         `json:"name"`
+		Kind              int            `json:"kind"`
+		StartTimeUnixNano string         `json:"startTimeUnixNano"`
+		EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+		Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+		Status            otlpStatus     `json:"status"`
+	}
+	otlpStatus struct {
+		Code    int    `json:"code,omitempty"`
+		Message string `json:"message,omitempty"`
+	}
+	otlpKeyValue struct {
+		Key   string         `json:"key"`
+		Value map[string]any `json:"value"`
+	}
+)
+
+// Export 实现 [Exporter]
+func (e *OTLPExporter) Export(ctx context.Context, spans []SpanData) error {
+	otlpSpans := make([]otlpSpan, 0, len(spans))
+	for _, span := range spans {
+		s := otlpSpan{
+			TraceID:           span.SpanContext.TraceID.String(),
+			SpanID:            span.SpanContext.SpanID.String(),
+			TraceState:        span.SpanContext.TraceState,
+			Name:              span.Name,
+			Kind:              int(span.Kind),
+			StartTimeUnixNano: strconv.FormatInt(span.StartTime.UnixNano(), 10),
+			EndTimeUnixNano:   strconv.FormatInt(span.EndTime.UnixNano(), 10),
+			Attributes:        otlpAttributes(span.Attrs),
+			Status:            otlpStatus{Code: int(span.Status), Message: span.StatusMessage},
+		}
+		if span.SpanContext.ParentID.IsValid() {
+			s.ParentSpanID = span.SpanContext.ParentID.String()
+		}
+		otlpSpans = append(otlpSpans, s)
+	}
+
+	body, err := json.Marshal(otlpRequest{ResourceSpans: []otlpResourceSpans{{
+		Resource:   otlpResource{Attributes: e.resource},
+		ScopeSpans: []otlpScopeSpans{{Scope: otlpScope{Name: "bamboo-base-go"}, Spans: otlpSpans}},
+	}}})
+	if err != nil {
+		return fmt.Errorf("编码 OTLP 请求失败: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("创建 OTLP 请求失败: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range e.headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("发送 OTLP 请求失败: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("OTLP 收集器返回 %d: %s", resp.StatusCode, strings.TrimSpace(string(detail)))
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return nil
+}
+
+// Shutdown 实现 [Exporter]，关闭空闲连接
+func (e *OTLPExporter) Shutdown(_ context.Context) error {
+	e.client.CloseIdleConnections()
+	return nil
+}
+
+// otlpAttributes 将属性转换为 OTLP AnyValue 列表，分组属性展开为 "group.key"
+func otlpAttributes(attrs []slog.Attr) []otlpKeyValue {
+	flat := flattenAttrs(attrs)
+	kvs := make([]otlpKeyValue, 0, len(flat))
+	for _, a := range flat {
+		var value map[string]any
+		switch a.Value.Kind() {
+		case slog.KindString:
+			value = map[string]any{"stringValue": a.Value.String()}
+		case slog.KindBool:
+			value = map[string]any{"boolValue": a.Value.Bool()}
+		case slog.KindInt64:
+			value = map[string]any{"intValue": strconv.FormatInt(a.Value.Int64(), 10)}
+		case slog.KindUint64:
+			value = map[string]any{"intValue": strconv.FormatUint(a.Value.Uint64(), 10)}
+		case slog.KindFloat64:
+			value = map[string]any{"doubleValue": a.Value.Float64()}
+		default:
+			value = map[string]any{"stringValue": fmt.Sprint(attrValue(a.Value))}
+		}
+		kvs = append(kvs, otlpKeyValue{Key: a.Key, Value: value})
+	}
+	return kvs
+}
@@ -0,0 +1,251 @@
+package xTrace
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	xCtx "github.com/bamboo-services/bamboo-base-go/defined/context"
+)
+
+// SpanKind span 类型，取值与 OTLP 一致
+type SpanKind int
+
+const (
+	KindInternal SpanKind = iota + 1 // 服务内部处理（异步任务、定时任务）
+	KindServer                       // 处理上游请求（HTTP、gRPC 服务端）
+	KindClient                       // 调用下游依赖（gRPC 客户端、数据库、Redis）
+	KindProducer                     // 发送消息
+	KindConsumer                     // 消费消息
+)
+
+// String 返回 span 类型名称
+func (k SpanKind) String() string {
+	switch k {
+	case KindServer:
+		return "server"
+	case KindClient:
+		return "client"
+	case KindProducer:
+		return "producer"
+	case KindConsumer:
+		return "consumer"
+	default:
+		return "internal"
+	}
+}
+
+// StatusCode span 状态，取值与 OTLP 一致
+type StatusCode int
+
+const (
+	StatusUnset StatusCode = iota // 未设置，视为成功
+	StatusOK                      // 显式标记成功
+	StatusError                   // 处理失败
+)
+
+// String 返回状态名称
+func (c StatusCode) String() string {
+	switch c {
+	case StatusOK:
+		return "ok"
+	case StatusError:
+		return "error"
+	default:
+		return "unset"
+	}
+}
+
+// SpanData 已结束 span 的只读快照，交由 [Exporter] 导出
+type SpanData struct {
+	Name          string
+	Kind          SpanKind
+	SpanContext   SpanContext
+	StartTime     time.Time
+	EndTime       time.Time
+	Attrs         []slog.Attr
+	Status        StatusCode
+	StatusMessage string
+}
+
+// Duration 返回 span 耗时
+func (d SpanData) Duration() time.Duration {
+	return d.EndTime.Sub(d.StartTime)
+}
+
+// Span 一次计时的处理单元，由 [Start] 创建，调用 End 后交由当前 [Tracer] 导出
+//
+// 所有方法对 nil 接收者安全，调用方无需判断 [SpanFromContext] 的返回值。
+type Span struct {
+	mu     sync.Mutex
+	tracer *Tracer
+	data   SpanData
+	ended  bool
+}
+
+// spanConfig Start 的可选配置
+type spanConfig struct {
+	kind      SpanKind
+	attrs     []slog.Attr
+	parent    SpanContext
+	startTime time.Time
+}
+
+// SpanOption [Start] 的可选配置
+type SpanOption func(*spanConfig)
+
+// WithKind 设置 span 类型，默认 KindInternal
+func WithKind(kind SpanKind) SpanOption {
+	return func(c *spanConfig) { c.kind = kind }
+}
+
+// WithAttrs 设置 span 的初始属性
+func WithAttrs(attrs ...slog.Attr) SpanOption {
+	return func(c *spanConfig) { c.attrs = append(c.attrs, attrs...) }
+}
+
+// WithParent 显式指定父 span（如从 traceparent 解析出的上游 span），优先于 ctx 中的链路
+func WithParent(parent SpanContext) SpanOption {
+	return func(c *spanConfig) { c.parent = parent }
+}
+
+// WithStartTime 指定开始时间，默认 time.Now()
+func WithStartTime(t time.Time) SpanOption {
+	return func(c *spanConfig) { c.startTime = t }
+}
+
+// Start 创建 span 并写入返回的 context
+//
+// 父 span 依次取 [WithParent]、ctx 中的链路上下文，均不存在时创建新的根 span。
+// 返回的 context 中 [FromContext] 读取到的是新 span 的链路上下文，日志因此携带新的 span_id。
+// 调用方必须在处理结束时调用 End，通常写作：
+//
+//	ctx, span := xTrace.Start(ctx, "order.create")
+//	defer span.End()
+func Start(ctx context.Context, name string, opts ...SpanOption) (context.Context, *Span) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	cfg := spanConfig{kind: KindInternal}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&cfg)
+		}
+	}
+	if cfg.startTime.IsZero() {
+		cfg.startTime = time.Now()
+	}
+
+	parent := cfg.parent
+	if !parent.IsValid() {
+		parent, _ = FromContext(ctx)
+	}
+	sc := NewRoot()
+	if parent.IsValid() {
+		sc = parent.Child()
+	}
+
+	span := &Span{
+		tracer: DefaultTracer(),
+		data: SpanData{
+			Name:        name,
+			Kind:        cfg.kind,
+			SpanContext: sc,
+			StartTime:   cfg.startTime,
+			Attrs:       cfg.attrs,
+		},
+	}
+	ctx = WithContext(ctx, sc)
+	return context.WithValue(ctx, xCtx.SpanKey, span), span
+}
+
+// SpanFromContext 返回 ctx 中当前的 span，不存在时返回 nil
+//
+// 与 [FromContext] 一样支持以字符串键存储值的上下文（如 *gin.Context）。
+func SpanFromContext(ctx context.Context) *Span {
+	if ctx == nil {
+		return nil
+	}
+	if span, ok := ctx.Value(xCtx.SpanKey).(*Span); ok {
+		return span
+	}
+	if span, ok := ctx.Value(xCtx.SpanKey.String()).(*Span); ok {
+		return span
+	}
+	return nil
+}
+
+// Context 返回 span 的链路上下文
+func (s *Span) Context() SpanContext {
+	if s == nil {
+		return SpanContext{}
+	}
+	return s.data.SpanContext
+}
+
+// SetName 修改 span 名称（如路由匹配后改为路由模板）
+func (s *Span) SetName(name string) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.ended {
+		s.data.Name = name
+	}
+}
+
+// SetAttrs 追加属性，同名属性以后写入的为准
+func (s *Span) SetAttrs(attrs ...slog.Attr) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.ended {
+		s.data.Attrs = append(s.data.Attrs, attrs...)
+	}
+}
+
+// SetStatus 设置状态，状态只升不降（StatusError 不会被 StatusOK 覆盖）
+func (s *Span) SetStatus(code StatusCode, message string) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.ended || code < s.data.Status {
+		return
+	}
+	s.data.Status = code
+	s.data.StatusMessage = message
+}
+
+// SetError 将 span 标记为失败，err 为 nil 时不做处理
+func (s *Span) SetError(err error) {
+	if err == nil {
+		return
+	}
+	s.SetStatus(StatusError, err.Error())
+}
+
+// End 结束 span，重复调用只生效一次
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+		return
+	}
+	s.ended = true
+	s.data.EndTime = time.Now()
+	data := s.data
+	s.mu.Unlock()
+
+	if s.tracer != nil && data.SpanContext.IsSampled() {
+		s.tracer.enqueue(data)
+	}
+}
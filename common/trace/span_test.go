@@ -0,0 +1,205 @@
+package xTrace
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordExporter 记录导出的 span，供测试断言
+type recordExporter struct {
+	mu       sync.Mutex
+	spans    []SpanData
+	shutdown bool
+}
+
+func (e *recordExporter) Export(_ context.Context, spans []SpanData) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.spans = append(e.spans, spans...)
+	return nil
+}
+
+func (e *recordExporter) Shutdown(_ context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.shutdown = true
+	return nil
+}
+
+// TestStart 验证 Start 的父子关系与 context 写入。
+func TestStart(t *testing.T) {
+	ctx, root := Start(context.Background(), "root")
+	if !root.Context().IsValid() || root.Context().ParentID.IsValid() {
+		t.Fatalf("无父 span 时应创建根 span: %+v", root.Context())
+	}
+	if SpanFromContext(ctx) != root {
+		t.Error("SpanFromContext 应返回当前 span")
+	}
+	if sc, _ := FromContext(ctx); sc != root.Context() {
+		t.Error("FromContext 应返回当前 span 的链路上下文")
+	}
+
+	_, child := Start(ctx, "child")
+	if child.Context().TraceID != root.Context().TraceID || child.Context().ParentID != root.Context().SpanID {
+		t.Errorf("子 span 应继承 TraceID 并以父 SpanID 为 ParentID: %+v", child.Context())
+	}
+
+	upstream, _ := ParseTraceParent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	_, remote := Start(context.Background(), "server", WithParent(upstream), WithKind(KindServer))
+	if remote.Context().TraceID != upstream.TraceID || remote.Context().ParentID != upstream.SpanID {
+		t.Errorf("WithParent 应以上游 span 为父: %+v", remote.Context())
+	}
+
+	var nilSpan *Span
+	nilSpan.SetAttrs(slog.String("k", "v"))
+	nilSpan.SetError(errors.New("boom"))
+	nilSpan.End()
+}
+
+// TestTracerExport 验证结束的 span 经 Tracer 按批导出，且状态、属性与父子关系完整。
+func TestTracerExport(t *testing.T) {
+	exporter := &recordExporter{}
+	SetTracer(NewTracer(TracerConfig{Exporter: exporter, Interval: time.Hour}))
+	t.Cleanup(func() { SetTracer(nil) })
+
+	ctx, parent := Start(context.Background(), "parent", WithAttrs(slog.String("http.route", "/ping")))
+	_, child := Start(ctx, "child", WithKind(KindClient))
+	child.SetError(errors.New("boom"))
+	child.SetStatus(StatusOK, "")
+	child.End()
+	child.End()
+	parent.End()
+
+	if err := Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown 失败: %v", err)
+	}
+	if !exporter.shutdown {
+		t.Error("Shutdown 应关闭导出器")
+	}
+	if len(exporter.spans) != 2 {
+		t.Fatalf("应导出 2 个 span，实际 %d", len(exporter.spans))
+	}
+
+	got := exporter.spans[0]
+	if got.Name != "child" || got.Kind != KindClient || got.Status != StatusError || got.StatusMessage != "boom" {
+		t.Errorf("子 span 数据不匹配: %+v", got)
+	}
+	if got.SpanContext.ParentID != exporter.spans[1].SpanContext.SpanID {
+		t.Error("子 span 的 ParentID 应为父 span 的 SpanID")
+	}
+	if len(exporter.spans[1].Attrs) != 1 || exporter.spans[1].Attrs[0].Key != "http.route" {
+		t.Errorf("父 span 属性不匹配: %+v", exporter.spans[1].Attrs)
+	}
+
+	_, late := Start(context.Background(), "late")
+	late.End()
+	if len(exporter.spans) != 2 {
+		t.Error("Tracer 关闭后结束的 span 不应导出")
+	}
+}
+
+// TestWriterExporter 验证 JSON 行输出格式。
+func TestWriterExporter(t *testing.T) {
+	var buf bytes.Buffer
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	sc := NewRoot().Child()
+	err := NewWriterExporter(&buf).Export(context.Background(), []SpanData{{
+		Name:        "GET /ping",
+		Kind:        KindServer,
+		SpanContext: sc,
+		StartTime:   start,
+		EndTime:     start.Add(1500 * time.Microsecond),
+		Attrs:       []slog.Attr{slog.Int("http.response.status_code", 200), slog.Group("db", slog.String("system", "mysql"))},
+		Status:      StatusOK,
+	}})
+	if err != nil {
+		t.Fatalf("Export 失败: %v", err)
+	}
+
+	var record map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("输出应为 JSON 行: %v (%s)", err, buf.String())
+	}
+	if record["trace_id"] != sc.TraceID.String() || record["parent_id"] != sc.ParentID.String() ||
+		record["kind"] != "server" || record["status"] != "ok" || record["duration_ms"] != 1.5 {
+		t.Errorf("输出字段不匹配: %v", record)
+	}
+	attrs, _ := record["attrs"].(map[string]any)
+	if attrs["http.response.status_code"] != float64(200) || attrs["db.system"] != "mysql" {
+		t.Errorf("属性不匹配: %v", attrs)
+	}
+}
+
+// TestOTLPExporter 验证 OTLP/HTTP 请求路径、请求头与 JSON 编码。
+func TestOTLPExporter(t *testing.T) {
+	var (
+		path   string
+		header string
+		body   []byte
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		header = r.Header.Get("Authorization")
+		body, _ = io.ReadAll(r.Body)
+	}))
+	defer server.Close()
+
+	exporter, err := NewOTLPExporter(OTLPConfig{
+		Endpoint:    strings.TrimPrefix(server.URL, "http://"),
+		Headers:     map[string]string{"Authorization": "Bearer token"},
+		ServiceName: "demo",
+	})
+	if err != nil {
+		t.Fatalf("NewOTLPExporter 失败: %v", err)
+	}
+
+	sc := NewRoot().Child()
+	start := time.Unix(1, 0)
+	err = exporter.Export(context.Background(), []SpanData{{
+		Name:        "redis GET",
+		Kind:        KindClient,
+		SpanContext: sc,
+		StartTime:   start,
+		EndTime:     start.Add(time.Millisecond),
+		Attrs:       []slog.Attr{slog.Bool("cache.hit", true)},
+		Status:      StatusError,
+	}})
+	if err != nil {
+		t.Fatalf("Export 失败: %v", err)
+	}
+	if path != "/v1/traces" || header != "Bearer token" {
+		t.Errorf("请求路径或请求头不匹配: path=%s auth=%s", path, header)
+	}
+
+	var req otlpRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		t.Fatalf("请求体应为 OTLP JSON: %v", err)
+	}
+	resource := req.ResourceSpans[0].Resource.Attributes
+	if resource[0].Key != "service.name" || resource[0].Value["stringValue"] != "demo" {
+		t.Errorf("resource 属性不匹配: %+v", resource)
+	}
+	span := req.ResourceSpans[0].ScopeSpans[0].Spans[0]
+	if span.TraceID != sc.TraceID.String() || span.ParentSpanID != sc.ParentID.String() ||
+		span.Kind != int(KindClient) || span.Status.Code != int(StatusError) ||
+		span.StartTimeUnixNano != "1000000000" || span.EndTimeUnixNano != "1001000000" {
+		t.Errorf("span 编码不匹配: %+v", span)
+	}
+
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	})
+	if err := exporter.Export(context.Background(), nil); err == nil {
+		t.Error("收集器返回非 2xx 时应返回错误")
+	}
+}
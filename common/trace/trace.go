@@ -0,0 +1,240 @@
+package xTrace
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+
+	xCtx "github.com/bamboo-services/bamboo-base-go/defined/context"
+)
+
+const (
+	// HeaderTraceParent W3C Trace Context 的 traceparent 头（HTTP 头与 gRPC 元数据通用）
+	HeaderTraceParent = "traceparent"
+
+	// HeaderTraceState W3C Trace Context 的 tracestate 头（HTTP 头与 gRPC 元数据通用）
+	HeaderTraceState = "tracestate"
+)
+
+// FlagSampled traceparent 中的 sampled 标志位
+const FlagSampled byte = 0x01
+
+// maxTraceStateLen tracestate 最大长度，超出时按规范整体丢弃
+const maxTraceStateLen = 512
+
+// TraceID 16 字节的链路 ID
+type TraceID [16]byte
+
+// SpanID 8 字节的 span ID
+type SpanID [8]byte
+
+// IsValid 判断 TraceID 是否非全零
+func (t TraceID) IsValid() bool {
+	return t != TraceID{}
+}
+
+// String 返回 32 位小写十六进制表示
+func (t TraceID) String() string {
+	return hex.EncodeToString(t[:])
+}
+
+// IsValid 判断 SpanID 是否非全零
+func (s SpanID) IsValid() bool {
+	return s != SpanID{}
+}
+
+// String 返回 16 位小写十六进制表示
+func (s SpanID) String() string {
+	return hex.EncodeToString(s[:])
+}
+
+// SpanContext W3C Trace Context 中的链路上下文
+//
+// TraceID 在整条调用链中保持不变；SpanID 标识当前服务内的处理单元，
+// ParentID 为上游（或父任务）的 SpanID，根 span 时为空。
+type SpanContext struct {
+	TraceID    TraceID // 链路 ID
+	SpanID     SpanID  // 当前 span ID
+	ParentID   SpanID  // 父 span ID
+	Flags      byte    // trace-flags，目前仅使用 FlagSampled
+	TraceState string  // 原样透传的 tracestate
+}
+
+// IsValid 判断 TraceID 与 SpanID 均有效
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID.IsValid() && sc.SpanID.IsValid()
+}
+
+// IsSampled 判断是否设置了 sampled 标志
+func (sc SpanContext) IsSampled() bool {
+	return sc.Flags&FlagSampled != 0
+}
+
+// TraceParent 返回 version 00 的 traceparent 头值，无效时返回空字符串
+func (sc SpanContext) TraceParent() string {
+	if !sc.IsValid() {
+		return ""
+	}
+	buf := make([]byte, 0, 55)
+	buf = append(buf, "00-"...)
+	buf = hex.AppendEncode(buf, sc.TraceID[:])
+	buf = append(buf, '-')
+	buf = hex.AppendEncode(buf, sc.SpanID[:])
+	buf = append(buf, '-')
+	buf = hex.AppendEncode(buf, []byte{sc.Flags})
+	return string(buf)
+}
+
+// Child 在同一链路下创建子 span，当前 SpanID 成为子 span 的 ParentID
+func (sc SpanContext) Child() SpanContext {
+	if !sc.TraceID.IsValid() {
+		return NewRoot()
+	}
+	return SpanContext{
+		TraceID:    sc.TraceID,
+		SpanID:     newSpanID(),
+		ParentID:   sc.SpanID,
+		Flags:      sc.Flags,
+		TraceState: sc.TraceState,
+	}
+}
+
+// NewRoot 创建新的根 span，默认设置 sampled 标志
+func NewRoot() SpanContext {
+	var traceID TraceID
+	for !traceID.IsValid() {
+		_, _ = rand.Read(traceID[:])
+	}
+	return SpanContext{
+		TraceID: traceID,
+		SpanID:  newSpanID(),
+		Flags:   FlagSampled,
+	}
+}
+
+// newSpanID 生成非全零的随机 SpanID
+func newSpanID() SpanID {
+	var spanID SpanID
+	for !spanID.IsValid() {
+		_, _ = rand.Read(spanID[:])
+	}
+	return spanID
+}
+
+// ParseTraceParent 解析 traceparent 头
+//
+// 遵循 W3C Trace Context 规范：version 为 ff、ID 全零、十六进制含大写字母时视为无效；
+// 高于 00 的版本只解析前 55 个字符，其后必须以 '-' 分隔。
+// 返回的 SpanContext 中 SpanID 为上游的 parent-id。
+func ParseTraceParent(value string) (SpanContext, bool) {
+	value = strings.TrimSpace(value)
+	if len(value) < 55 || value[2] != '-' || value[35] != '-' || value[52] != '-' {
+		return SpanContext{}, false
+	}
+
+	var version [1]byte
+	if !decodeLowerHex(version[:], value[0:2]) || version[0] == 0xff {
+		return SpanContext{}, false
+	}
+	if version[0] == 0 && len(value) != 55 {
+		return SpanContext{}, false
+	}
+	if len(value) > 55 && value[55] != '-' {
+		return SpanContext{}, false
+	}
+
+	var sc SpanContext
+	var flags [1]byte
+	if !decodeLowerHex(sc.TraceID[:], value[3:35]) ||
+		!decodeLowerHex(sc.SpanID[:], value[36:52]) ||
+		!decodeLowerHex(flags[:], value[53:55]) {
+		return SpanContext{}, false
+	}
+	sc.Flags = flags[0]
+	if !sc.IsValid() {
+		return SpanContext{}, false
+	}
+	return sc, true
+}
+
+// decodeLowerHex 解码小写十六进制，长度不符或含大写字母时返回 false
+func decodeLowerHex(dst []byte, src string) bool {
+	if len(src) != len(dst)*2 || strings.ToLower(src) != src {
+		return false
+	}
+	_, err := hex.Decode(dst, []byte(src))
+	return err == nil
+}
+
+// ExtractParent 解析上游传入的 traceparent/tracestate，返回上游 span 的链路上下文
+//
+// traceparent 无效或缺失时返回 false，此时按规范忽略 tracestate；超长的 tracestate 整体丢弃。
+// 返回值可直接传给 [WithParent] 创建当前服务的 span。
+func ExtractParent(traceParent, traceState string) (SpanContext, bool) {
+	parent, ok := ParseTraceParent(traceParent)
+	if !ok {
+		return SpanContext{}, false
+	}
+	traceState = strings.TrimSpace(traceState)
+	if len(traceState) > maxTraceStateLen {
+		traceState = ""
+	}
+	parent.TraceState = traceState
+	return parent, true
+}
+
+// Extract 根据上游传入的 traceparent/tracestate 创建当前服务的 span
+//
+// traceparent 有效时沿用其 TraceID 与 Flags 并创建子 span，tracestate 原样透传；
+// 无效或缺失时创建新的根 span，此时按规范忽略 tracestate。
+func Extract(traceParent, traceState string) SpanContext {
+	if parent, ok := ExtractParent(traceParent, traceState); ok {
+		return parent.Child()
+	}
+	return NewRoot()
+}
+
+// Inject 通过 set 写出 traceparent 与 tracestate，tracestate 为空时不写出
+func Inject(sc SpanContext, set func(key, value string)) {
+	traceParent := sc.TraceParent()
+	if traceParent == "" {
+		return
+	}
+	set(HeaderTraceParent, traceParent)
+	if sc.TraceState != "" {
+		set(HeaderTraceState, sc.TraceState)
+	}
+}
+
+// WithContext 将 SpanContext 写入 context
+func WithContext(ctx context.Context, sc SpanContext) context.Context {
+	return context.WithValue(ctx, xCtx.TraceKey, sc)
+}
+
+// FromContext 从 context 中读取 SpanContext
+//
+// 同时尝试以字符串键读取，使 *gin.Context 等以 string 键存储值的上下文
+// （通过 c.Set(xCtx.TraceKey.String(), sc) 注入）无需框架依赖即可读取。
+func FromContext(ctx context.Context) (SpanContext, bool) {
+	if ctx == nil {
+		return SpanContext{}, false
+	}
+	if sc, ok := ctx.Value(xCtx.TraceKey).(SpanContext); ok && sc.IsValid() {
+		return sc, true
+	}
+	if sc, ok := ctx.Value(xCtx.TraceKey.String()).(SpanContext); ok && sc.IsValid() {
+		return sc, true
+	}
+	return SpanContext{}, false
+}
+
+// Detach 为脱离原请求的后台任务（异步任务、定时任务）创建 span
+//
+// ctx 中存在链路上下文时创建其子 span，保证同一 TraceID；否则创建新的根 span。
+func Detach(ctx context.Context) SpanContext {
+	if sc, ok := FromContext(ctx); ok {
+		return sc.Child()
+	}
+	return NewRoot()
+}
@@ -0,0 +1,94 @@
+package xTrace
+
+import (
+	"context"
+	"testing"
+)
+
+// TestParseTraceParent 验证 traceparent 的合法与非法取值。
+func TestParseTraceParent(t *testing.T) {
+	sc, ok := ParseTraceParent("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	if !ok {
+		t.Fatal("合法的 traceparent 应解析成功")
+	}
+	if sc.TraceID.String() != "4bf92f3577b34da6a3ce929d0e0e4736" || sc.SpanID.String() != "00f067aa0ba902b7" || !sc.IsSampled() {
+		t.Errorf("解析结果不匹配: %+v", sc)
+	}
+	if sc.TraceParent() != "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01" {
+		t.Errorf("编码结果不匹配: %s", sc.TraceParent())
+	}
+
+	if _, ok := ParseTraceParent("cc-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra"); !ok {
+		t.Error("更高版本应兼容解析前 55 个字符")
+	}
+
+	invalid := []string{
+		"",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra",
+		"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01",
+		"00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736_00f067aa0ba902b7-01",
+	}
+	for _, value := range invalid {
+		if _, ok := ParseTraceParent(value); ok {
+			t.Errorf("非法的 traceparent 不应解析成功: %q", value)
+		}
+	}
+}
+
+// TestExtract 验证沿用上游链路与缺失时创建根 span。
+func TestExtract(t *testing.T) {
+	sc := Extract("00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", "vendor=abc")
+	if sc.TraceID.String() != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("应沿用上游 TraceID，实际为 %s", sc.TraceID)
+	}
+	if sc.ParentID.String() != "00f067aa0ba902b7" || sc.SpanID == sc.ParentID {
+		t.Errorf("应创建上游 span 的子 span: %+v", sc)
+	}
+	if sc.TraceState != "vendor=abc" {
+		t.Errorf("tracestate 应原样透传，实际为 %q", sc.TraceState)
+	}
+
+	root := Extract("invalid", "vendor=abc")
+	if !root.IsValid() || root.ParentID.IsValid() || root.TraceState != "" {
+		t.Errorf("无效 traceparent 应创建不含 tracestate 的根 span: %+v", root)
+	}
+}
+
+// TestInject 验证写出的头部。
+func TestInject(t *testing.T) {
+	sc := NewRoot()
+	sc.TraceState = "vendor=abc"
+
+	headers := make(map[string]string)
+	Inject(sc, func(key, value string) { headers[key] = value })
+	if headers[HeaderTraceParent] != sc.TraceParent() || headers[HeaderTraceState] != "vendor=abc" {
+		t.Errorf("写出的头部不匹配: %v", headers)
+	}
+
+	headers = make(map[string]string)
+	Inject(SpanContext{}, func(key, value string) { headers[key] = value })
+	if len(headers) != 0 {
+		t.Errorf("无效链路不应写出头部: %v", headers)
+	}
+}
+
+// TestFromContext 验证 context 读写与字符串键回退。
+func TestFromContext(t *testing.T) {
+	if _, ok := FromContext(context.Background()); ok {
+		t.Error("空 context 不应包含链路上下文")
+	}
+
+	sc := NewRoot()
+	got, ok := FromContext(WithContext(context.Background(), sc))
+	if !ok || got != sc {
+		t.Errorf("应读取到写入的链路上下文: %+v", got)
+	}
+
+	child := Detach(WithContext(context.Background(), sc))
+	if child.TraceID != sc.TraceID || child.ParentID != sc.SpanID {
+		t.Errorf("Detach 应创建子 span: %+v", child)
+	}
+}
@@ -0,0 +1,194 @@
+package xTrace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	defaultTracerQueueSize = 2048
+	defaultTracerBatchSize = 512
+	defaultTracerInterval  = 5 * time.Second
+	exportTimeout          = 10 * time.Second
+)
+
+// Exporter span 导出器，由 [Tracer] 的后台协程按批调用
+type Exporter interface {
+	// Export 导出一批已结束的 span
+	Export(ctx context.Context, spans []SpanData) error
+	// Shutdown 释放导出器持有的资源（文件、连接等）
+	Shutdown(ctx context.Context) error
+}
+
+// TracerConfig [Tracer] 配置
+type TracerConfig struct {
+	Exporter  Exporter      // span 导出器（必填）
+	QueueSize int           // 待导出队列容量，<= 0 时默认 2048，队列满时丢弃新 span
+	BatchSize int           // 单批最大 span 数，<= 0 时默认 512
+	Interval  time.Duration // 定时导出间隔，<= 0 时默认 5s
+}
+
+// tracerItem 队列元素，span 与 flushed 二选一
+type tracerItem struct {
+	span    SpanData
+	flushed chan error // 非 nil 表示刷新请求，导出之前的所有 span 后回传结果
+}
+
+// Tracer 收集已结束的 span 并按批交给 [Exporter]
+//
+// 导出在后台协程中完成，span.End 只负责入队；队列满时丢弃 span 并在下次导出时汇报，
+// 保证链路追踪不会阻塞业务请求。
+type Tracer struct {
+	exporter  Exporter
+	items     chan tracerItem
+	batchSize int
+	interval  time.Duration
+	dropped   atomic.Int64
+	stopped   atomic.Bool
+	done      chan struct{}
+}
+
+// NewTracer 创建 Tracer 并启动后台导出协程
+func NewTracer(config TracerConfig) *Tracer {
+	queueSize := config.QueueSize
+	if queueSize <= 0 {
+		queueSize = defaultTracerQueueSize
+	}
+	batchSize := config.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultTracerBatchSize
+	}
+	interval := config.Interval
+	if interval <= 0 {
+		interval = defaultTracerInterval
+	}
+
+	t := &Tracer{
+		exporter:  config.Exporter,
+		items:     make(chan tracerItem, queueSize),
+		batchSize: batchSize,
+		interval:  interval,
+		done:      make(chan struct{}),
+	}
+	go t.run()
+	return t
+}
+
+// enqueue 入队一个已结束的 span，队列满或已关闭时丢弃
+func (t *Tracer) enqueue(span SpanData) {
+	if t.stopped.Load() {
+		return
+	}
+	select {
+	case t.items <- tracerItem{span: span}:
+	default:
+		t.dropped.Add(1)
+	}
+}
+
+// Flush 导出此前入队的所有 span，ctx 到期时返回 ctx.Err()
+func (t *Tracer) Flush(ctx context.Context) error {
+	if t.stopped.Load() {
+		return nil
+	}
+	done := make(chan error, 1)
+	select {
+	case t.items <- tracerItem{flushed: done}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Shutdown 导出剩余 span 并关闭导出器，之后结束的 span 将被丢弃
+func (t *Tracer) Shutdown(ctx context.Context) error {
+	flushErr := t.Flush(ctx)
+	if !t.stopped.CompareAndSwap(false, true) {
+		return nil
+	}
+	close(t.done)
+	return errors.Join(flushErr, t.exporter.Shutdown(ctx))
+}
+
+// run 后台导出循环
+func (t *Tracer) run() {
+	ticker := time.NewTicker(t.interval)
+	defer ticker.Stop()
+
+	batch := make([]SpanData, 0, t.batchSize)
+	for {
+		select {
+		case item := <-t.items:
+			if item.flushed != nil {
+				item.flushed <- t.export(batch)
+				batch = batch[:0]
+				continue
+			}
+			batch = append(batch, item.span)
+			if len(batch) >= t.batchSize {
+				_ = t.export(batch)
+				batch = batch[:0]
+			}
+		case <-ticker.C:
+			_ = t.export(batch)
+			batch = batch[:0]
+		case <-t.done:
+			return
+		}
+	}
+}
+
+// export 导出一批 span，失败时输出到标准错误
+func (t *Tracer) export(batch []SpanData) error {
+	if n := t.dropped.Swap(0); n > 0 {
+		fmt.Fprintf(os.Stderr, "[TRACE] 链路队列已满，已丢弃 %d 个 span\n", n)
+	}
+	if len(batch) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), exportTimeout)
+	defer cancel()
+	spans := make([]SpanData, len(batch))
+	copy(spans, batch)
+	if err := t.exporter.Export(ctx, spans); err != nil {
+		fmt.Fprintf(os.Stderr, "[TRACE] 导出 span 失败: %v\n", err)
+		return err
+	}
+	return nil
+}
+
+// globalTracer 全局 Tracer，nil 表示不导出 span
+var globalTracer atomic.Pointer[Tracer]
+
+// SetTracer 设置全局 Tracer，传入 nil 关闭导出
+//
+// 已创建的 span 仍由创建时的 Tracer 导出。
+func SetTracer(t *Tracer) {
+	globalTracer.Store(t)
+}
+
+// DefaultTracer 返回全局 Tracer，未设置时返回 nil
+func DefaultTracer() *Tracer {
+	return globalTracer.Load()
+}
+
+// Shutdown 关闭全局 Tracer，导出剩余 span，未设置时直接返回 nil
+//
+// xMain.Runner 在退出前调用。
+func Shutdown(ctx context.Context) error {
+	if t := globalTracer.Swap(nil); t != nil {
+		return t.Shutdown(ctx)
+	}
+	return nil
+}
@@ -7,6 +7,9 @@ const (
 	Exec             ContextKey = "special_execution"       // 特殊执行
 	RegNodeKey       ContextKey = "context_reg_node"        // 上下文注册节点
 	RequestKey       ContextKey = "context_request_key"     // 上下文请求键
+	TraceKey         ContextKey = "context_trace"           // 上下文 W3C 链路追踪（xTrace.SpanContext）
+	SpanKey          ContextKey = "context_span"            // 上下文当前 span（*xTrace.Span）
+	PrincipalKey     ContextKey = "context_principal"       // 上下文已认证主体（*xAuth.Principal）
 	ErrorCodeKey     ContextKey = "context_error_code"      // 上下文请求错误码
 	ErrorMessageKey  ContextKey = "context_error_message"   // 上下文请求错误描述
 	UserStartTimeKey ContextKey = "context_user_start_time" // 上下文用户请求开始时间
@@ -15,6 +18,8 @@ const (
 	SnowflakeNodeKey ContextKey = "context_snowflake_node"  // 上下文雪花算法节点
 	EmailClientKey   ContextKey = "context_email_client"    // 上下文邮件客户端
 	CacheManagerKey  ContextKey = "context_cache_manager"   // 上下文缓存管理器
+	StartupReportKey ContextKey = "context_startup_report"  // 上下文启动报告
+	ConfigKey        ContextKey = "context_config"          // 上下文配置中心
 )
 
 // String 返回 ContextKey 的字符串表示形式。
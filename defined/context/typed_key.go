@@ -0,0 +1,66 @@
+package xCtx
+
+import "context"
+
+// TypedKey 是携带值类型信息的 ContextKey。
+//
+// TypedKey 在底层仍以 ContextKey 存储，因此与 [ContextNodeList]、context.Value 以及按
+// ContextKey 读取的历史辅助函数完全兼容；区别在于注册与读取两端都绑定了类型 T，
+// 生产者与消费者的类型不一致会在编译期暴露，而不是运行期得到 nil。
+//
+// 使用示例:
+//
+//	var ConfigKey = xCtx.NewTypedKey[*Config]("context_config")
+//	xRegNode.UseTyped(rn, ConfigKey, loadConfig)
+//	cfg, ok := ConfigKey.Value(ctx)
+type TypedKey[T any] struct {
+	key ContextKey
+}
+
+// NewTypedKey 基于 ContextKey 创建带类型的键。
+//
+// 同一个 ContextKey 可同时以 TypedKey 与普通 ContextKey 访问，便于渐进迁移。
+func NewTypedKey[T any](key ContextKey) TypedKey[T] {
+	return TypedKey[T]{key: key}
+}
+
+// Key 返回底层的 ContextKey。
+func (k TypedKey[T]) Key() ContextKey {
+	return k.key
+}
+
+// String 返回底层 ContextKey 的字符串表示形式。
+func (k TypedKey[T]) String() string {
+	return k.key.String()
+}
+
+// From 从 ContextNodeList 中读取类型为 T 的值。
+func (k TypedKey[T]) From(list ContextNodeList) (T, bool) {
+	var zero T
+	if value := list.Get(k.key); value != nil {
+		if typed, ok := value.(T); ok {
+			return typed, true
+		}
+	}
+	return zero, false
+}
+
+// Value 从上下文中读取类型为 T 的值。
+//
+// 读取顺序与框架的组件获取函数一致：优先 RegNodeKey 聚合的 ContextNodeList，
+// 未命中再回退到 ctx.Value(key)。值不存在或类型不匹配时返回零值与 false。
+func (k TypedKey[T]) Value(ctx context.Context) (T, bool) {
+	var zero T
+	if ctx == nil {
+		return zero, false
+	}
+	if list, ok := ctx.Value(RegNodeKey).(ContextNodeList); ok {
+		if typed, ok := k.From(list); ok {
+			return typed, true
+		}
+	}
+	if typed, ok := ctx.Value(k.key).(T); ok {
+		return typed, true
+	}
+	return zero, false
+}
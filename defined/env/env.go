@@ -15,10 +15,35 @@ const (
 	Host  EnvKey = "XLF_HOST"  // 监听地址
 	Port  EnvKey = "XLF_PORT"  // 监听端口
 
+	UnixSocket        EnvKey = "XLF_UNIX_SOCKET"         // Unix 套接字路径，设置后替代 XLF_HOST/XLF_PORT
+	ListenFD          EnvKey = "XLF_LISTEN_FD"           // 继承的监听文件描述符（套接字激活，如 3）
+	ReadTimeout       EnvKey = "XLF_READ_TIMEOUT"        // 读取请求超时 (Go Duration，如 15s，0 表示不限制)
+	ReadHeaderTimeout EnvKey = "XLF_READ_HEADER_TIMEOUT" // 读取请求头超时 (Go Duration)
+	WriteTimeout      EnvKey = "XLF_WRITE_TIMEOUT"       // 写出响应超时 (Go Duration，0 表示不限制)
+	IdleTimeout       EnvKey = "XLF_IDLE_TIMEOUT"        // keep-alive 空闲超时 (Go Duration)
+	ShutdownTimeout   EnvKey = "XLF_SHUTDOWN_TIMEOUT"    // 优雅关闭超时 (Go Duration，默认 30s)
+	MaxHeaderBytes    EnvKey = "XLF_MAX_HEADER_BYTES"    // 请求头最大字节数 (0 表示默认 1MB)
+	TLSCert           EnvKey = "XLF_TLS_CERT"            // TLS 证书文件路径，变更后自动重新加载
+	TLSKey            EnvKey = "XLF_TLS_KEY"             // TLS 私钥文件路径
+	H2C               EnvKey = "XLF_H2C"                 // 是否在明文连接上启用 HTTP/2 (true/false)
+	DrainPeriod       EnvKey = "XLF_DRAIN_PERIOD"        // 退出时标记未就绪后等待摘流的时长 (Go Duration，默认 0)
+	PhaseTimeout      EnvKey = "XLF_PHASE_TIMEOUT"       // 关闭 gRPC / Cron / 异步任务各阶段的超时 (Go Duration，默认 30s)
+
 	GrpcPort       EnvKey = "GRPC_PORT"
 	GrpcReflection EnvKey = "GRPC_REFLECTION"
 )
 
+// ============================== 跨域配置 ==============================
+
+const (
+	CorsAllowOrigins     EnvKey = "CORS_ALLOW_ORIGINS"     // 允许的跨域来源，逗号分隔，支持 * 与 https://*.example.com
+	CorsAllowMethods     EnvKey = "CORS_ALLOW_METHODS"     // 允许的跨域方法，逗号分隔
+	CorsAllowHeaders     EnvKey = "CORS_ALLOW_HEADERS"     // 允许的跨域请求头，逗号分隔，* 表示回显预检声明的请求头
+	CorsExposeHeaders    EnvKey = "CORS_EXPOSE_HEADERS"    // 暴露给浏览器的响应头，逗号分隔
+	CorsAllowCredentials EnvKey = "CORS_ALLOW_CREDENTIALS" // 是否允许携带 Cookie 等凭据 (true/false)
+	CorsMaxAge           EnvKey = "CORS_MAX_AGE"           // 预检结果缓存时间 (Go Duration，如 10m)
+)
+
 // ============================== 数据库配置 ==============================
 
 const (
@@ -65,11 +90,40 @@ const (
 
 const (
 	LogLevel      EnvKey = "LOG_LEVEL"       // 日志级别 (debug/info/warn/error)
+	LogLevels     EnvKey = "LOG_LEVELS"      // 按 logger 名称的日志级别，如 REPO=debug,HTTP=warn
 	LogPath       EnvKey = "LOG_PATH"        // 日志文件路径
 	LogMaxSize    EnvKey = "LOG_MAX_SIZE"    // 日志文件最大大小（MB）
 	LogMaxAge     EnvKey = "LOG_MAX_AGE"     // 日志文件最大保留天数
 	LogMaxBackups EnvKey = "LOG_MAX_BACKUPS" // 日志文件最大备份数
 	LogCompress   EnvKey = "LOG_COMPRESS"    // 是否压缩日志文件
+
+	LogMaxArchives EnvKey = "LOG_MAX_ARCHIVES" // 日志归档最大保留数量，0 表示沿用 LOG_MAX_BACKUPS
+	LogCompression EnvKey = "LOG_COMPRESSION"  // 日志归档压缩算法 (gzip/zstd)
+	LogRotate      EnvKey = "LOG_ROTATE"       // 日志切割策略，逗号分隔的 size/hourly/daily 组合
+
+	LogConsoleFormat EnvKey = "LOG_CONSOLE_FORMAT" // 控制台输出格式 (text/json/logfmt)
+	LogFileFormat    EnvKey = "LOG_FILE_FORMAT"    // 文件输出格式 (json/logfmt)
+
+	LogAsyncQueueSize EnvKey = "LOG_ASYNC_QUEUE_SIZE" // 异步写入队列容量，0 表示同步写入
+	LogAsyncPolicy    EnvKey = "LOG_ASYNC_POLICY"     // 异步队列满时的策略 (block/drop)
+	LogSyslogAddr     EnvKey = "LOG_SYSLOG_ADDR"      // syslog 输出地址，如 udp://127.0.0.1:514
+
+	LogSampleFirst      EnvKey = "LOG_SAMPLE_FIRST"      // 采样窗口内同一消息全部输出的条数，0 表示不采样
+	LogSampleThereafter EnvKey = "LOG_SAMPLE_THEREAFTER" // 超出后每 M 条输出 1 条
+	LogSampleInterval   EnvKey = "LOG_SAMPLE_INTERVAL"   // 采样窗口 (Go Duration，如 1s)
+	LogSampleDedup      EnvKey = "LOG_SAMPLE_DEDUP"      // 是否抑制相邻的相同日志
+	LogSampleExclude    EnvKey = "LOG_SAMPLE_EXCLUDE"    // 永不采样的 logger 名称，逗号分隔，如 INIT,MAIN
+	LogRedactKeys       EnvKey = "LOG_REDACT_KEYS"       // 额外的脱敏字段规则，逗号分隔的 pattern[=masker]，如 *address*,real_name=mask
+)
+
+// ============================== 链路追踪配置 ==============================
+
+const (
+	TraceExporter     EnvKey = "TRACE_EXPORTER"      // span 导出器 (none/stdout/file/otlp)
+	TraceFile         EnvKey = "TRACE_FILE"          // file 导出器的输出文件路径
+	TraceOTLPEndpoint EnvKey = "TRACE_OTLP_ENDPOINT" // OTLP/HTTP 收集器地址，如 http://127.0.0.1:4318
+	TraceOTLPHeaders  EnvKey = "TRACE_OTLP_HEADERS"  // OTLP 额外请求头，如 Authorization=Bearer xxx,X-Tenant=demo
+	TraceServiceName  EnvKey = "TRACE_SERVICE_NAME"  // 上报的服务名，默认使用 APP_NAME
 )
 
 // ============================== 第三方服务配置 ==============================
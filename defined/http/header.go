@@ -29,12 +29,17 @@ const (
 	HeaderUserAgent         Header = "User-Agent"          // 用户代理
 	HeaderRequestUUID       Header = "X-Request-UUID"      // 请求唯一标识符的响应头字段名，用于跟踪请求的唯一性和溯源性
 	HeaderRefreshToken      Header = "X-Refresh-Token"     // 刷新令牌的请求头字段名，通常用于获取新的访问令牌
+	HeaderTraceParent       Header = "Traceparent"         // W3C Trace Context 链路标识
+	HeaderTraceState        Header = "Tracestate"          // W3C Trace Context 厂商扩展状态
 	HeaderXForwardedFor     Header = "X-Forwarded-For"     // 代理转发 IP
 	HeaderXForwardedHost    Header = "X-Forwarded-Host"    // 代理转发 Host
 	HeaderXForwardedProto   Header = "X-Forwarded-Proto"   // 代理转发协议
 	HeaderXRealIP           Header = "X-Real-IP"           // 真实客户端 IP
 	HeaderXRequestedWith    Header = "X-Requested-With"    // Ajax 请求标识
 
+	HeaderAccessControlRequestHeaders Header = "Access-Control-Request-Headers" // CORS 预检声明的请求头
+	HeaderAccessControlRequestMethod  Header = "Access-Control-Request-Method"  // CORS 预检声明的请求方法
+
 	// 常见响应头
 	HeaderAccessControlAllowCredentials Header = "Access-Control-Allow-Credentials" // CORS 允许携带凭据
 	HeaderAccessControlAllowHeaders     Header = "Access-Control-Allow-Headers"     // CORS 允许的请求头
@@ -58,6 +63,20 @@ const (
 	HeaderWWWAuthenticate               Header = "WWW-Authenticate"                 // 认证挑战
 )
 
+// 限流响应头
+const (
+	HeaderRateLimitLimit     Header = "X-RateLimit-Limit"     // 限流窗口内允许的请求数
+	HeaderRateLimitRemaining Header = "X-RateLimit-Remaining" // 限流窗口内剩余的请求数
+	HeaderRateLimitReset     Header = "X-RateLimit-Reset"     // 限流额度完全恢复所需的秒数
+	HeaderRetryAfter         Header = "Retry-After"           // 被限流后建议的重试等待秒数
+)
+
+// 幂等请求头
+const (
+	HeaderIdempotencyKey     Header = "Idempotency-Key"     // 客户端为非幂等请求生成的唯一键，重试时保持不变
+	HeaderIdempotentReplayed Header = "Idempotent-Replayed" // 响应为重放的已存储结果时为 "true"
+)
+
 // String 返回 Header 的字符串形式表示。
 func (h Header) String() string {
 	return string(h)
@@ -0,0 +1,115 @@
+// Package xAuthzSource 提供 xAuthz 策略的配置文件与数据库来源。
+//
+// 两种来源均在每次 xAuthz.Enforcer.Reload 时重新读取，配合配置中心订阅或定时任务即可热更新策略。
+package xAuthzSource
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	xAuthz "github.com/bamboo-services/bamboo-base-go/common/authz"
+	xConfig "github.com/bamboo-services/bamboo-base-go/major/config"
+	xModels "github.com/bamboo-services/bamboo-base-go/major/models"
+	"gorm.io/gorm"
+)
+
+// ConfigKey 配置文件中授权策略所在的段。
+const ConfigKey = "authz"
+
+// FromConfig 从配置中心的 key 段（通常为 [ConfigKey]）读取策略，结构见 xAuthz.Policy。
+//
+// 配置中心需已 Load。配置文件变更后可订阅该段重新加载：
+//
+//	cfg.Subscribe(xAuthzSource.ConfigKey, func(ctx context.Context, _ *xConfig.Config) {
+//	    _ = enforcer.Reload(ctx)
+//	})
+func FromConfig(cfg *xConfig.Config, key string) xAuthz.Source {
+	return xAuthz.SourceFunc(func(context.Context) (xAuthz.Policy, error) {
+		var policy xAuthz.Policy
+		if cfg == nil {
+			return policy, fmt.Errorf("配置中心未装配")
+		}
+		if err := cfg.Bind(key, &policy); err != nil {
+			return policy, fmt.Errorf("读取授权策略配置失败: %w", err)
+		}
+		return policy, nil
+	})
+}
+
+// RoleEntity 角色表，Inherits 为逗号分隔的继承角色名称
+type RoleEntity struct {
+	xModels.BaseEntity
+	Name     string `json:"name" gorm:"type:varchar(64);not null;uniqueIndex;comment:角色名称"`
+	Inherits string `json:"inherits" gorm:"type:varchar(512);not null;default:'';comment:继承的角色，逗号分隔"`
+}
+
+// TableName 指定表名
+func (RoleEntity) TableName() string { return "authz_role" }
+
+// RuleEntity 规则表，Actions 为逗号分隔的动作列表
+type RuleEntity struct {
+	xModels.BaseEntity
+	Role      string `json:"role" gorm:"type:varchar(64);not null;index;comment:所属角色"`
+	Resource  string `json:"resource" gorm:"type:varchar(255);not null;comment:资源模式"`
+	Actions   string `json:"actions" gorm:"type:varchar(255);not null;comment:动作，逗号分隔"`
+	Effect    string `json:"effect" gorm:"type:varchar(8);not null;default:'allow';comment:规则效果 allow/deny"`
+	Condition string `json:"condition" gorm:"type:varchar(64);not null;default:'';comment:具名条件"`
+}
+
+// TableName 指定表名
+func (RuleEntity) TableName() string { return "authz_rule" }
+
+// FromDatabase 从 authz_role 与 authz_rule 表读取策略，表结构见 [RoleEntity] 与 [RuleEntity]。
+//
+// 仅出现在规则表中的角色视为无继承的角色。表需由业务侧迁移：
+//
+//	db.AutoMigrate(&xAuthzSource.RoleEntity{}, &xAuthzSource.RuleEntity{})
+func FromDatabase(db *gorm.DB) xAuthz.Source {
+	return xAuthz.SourceFunc(func(ctx context.Context) (xAuthz.Policy, error) {
+		var policy xAuthz.Policy
+		if db == nil {
+			return policy, fmt.Errorf("数据库未装配")
+		}
+		var roles []RoleEntity
+		if err := db.WithContext(ctx).Select("name", "inherits").Order("id").Find(&roles).Error; err != nil {
+			return policy, fmt.Errorf("读取角色表失败: %w", err)
+		}
+		var rules []RuleEntity
+		if err := db.WithContext(ctx).Select("role", "resource", "actions", "effect", "condition").Order("id").Find(&rules).Error; err != nil {
+			return policy, fmt.Errorf("读取规则表失败: %w", err)
+		}
+
+		index := make(map[string]int, len(roles))
+		for _, role := range roles {
+			index[role.Name] = len(policy.Roles)
+			policy.Roles = append(policy.Roles, xAuthz.Role{Name: role.Name, Inherits: splitList(role.Inherits)})
+		}
+		for _, rule := range rules {
+			i, ok := index[rule.Role]
+			if !ok {
+				i = len(policy.Roles)
+				index[rule.Role] = i
+				policy.Roles = append(policy.Roles, xAuthz.Role{Name: rule.Role})
+			}
+			policy.Roles[i].Rules = append(policy.Roles[i].Rules, xAuthz.Rule{
+				Resource:  rule.Resource,
+				Actions:   splitList(rule.Actions),
+				Effect:    xAuthz.Effect(rule.Effect),
+				Condition: rule.Condition,
+			})
+		}
+		return policy, nil
+	})
+}
+
+// splitList 拆分逗号分隔的列表，去除空白与空项
+func splitList(value string) []string {
+	var result []string
+	for _, part := range strings.Split(value, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			result = append(result, part)
+		}
+	}
+	return result
+}
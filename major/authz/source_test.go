@@ -0,0 +1,87 @@
+package xAuthzSource
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	xAuth "github.com/bamboo-services/bamboo-base-go/common/auth"
+	xAuthz "github.com/bamboo-services/bamboo-base-go/common/authz"
+	xConfig "github.com/bamboo-services/bamboo-base-go/major/config"
+	"github.com/libtnb/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// assertPolicy 验证两种来源加载出的策略语义一致：editor 继承 viewer，admin 的拒绝规则优先
+func assertPolicy(t *testing.T, source xAuthz.Source) {
+	t.Helper()
+	ctx := context.Background()
+	enforcer, err := xAuthz.New(ctx, source)
+	if err != nil {
+		t.Fatalf("加载策略失败: %v", err)
+	}
+	editor := &xAuth.Principal{Subject: "7", Roles: []string{"editor"}}
+	admin := &xAuth.Principal{Subject: "1", Roles: []string{"admin"}}
+	if err = enforcer.Authorize(ctx, editor, xAuthz.Require("order:1", "read"), nil); err != nil {
+		t.Errorf("editor 应继承 viewer 的读权限: %v", err)
+	}
+	if err = enforcer.Authorize(ctx, editor, xAuthz.Require("order:1", "delete"), nil); !errors.Is(err, xAuthz.ErrPermissionDenied) {
+		t.Errorf("editor 删除订单应返回 ErrPermissionDenied，实际 %v", err)
+	}
+	if err = enforcer.Authorize(ctx, admin, xAuthz.Require("audit:1", "delete"), nil); !errors.Is(err, xAuthz.ErrResourceDenied) {
+		t.Errorf("admin 删除审计日志应返回 ErrResourceDenied，实际 %v", err)
+	}
+}
+
+// TestFromConfig 验证从配置文件读取策略。
+func TestFromConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	content := `
+authz:
+  roles:
+    - name: viewer
+      rules:
+        - { resource: "order:**", actions: [read] }
+    - name: editor
+      inherits: [viewer]
+    - name: admin
+      rules:
+        - { resource: "**", actions: ["*"] }
+        - { resource: "audit:**", actions: [delete], effect: deny }
+`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg := xConfig.New(xConfig.WithFile(path))
+	if err := cfg.Load(); err != nil {
+		t.Fatal(err)
+	}
+	assertPolicy(t, FromConfig(cfg, ConfigKey))
+}
+
+// TestFromDatabase 验证从角色表与规则表读取策略，规则表中的角色无需在角色表声明。
+func TestFromDatabase(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("打开数据库失败: %v", err)
+	}
+	if err = db.AutoMigrate(&RoleEntity{}, &RuleEntity{}); err != nil {
+		t.Fatalf("建表失败: %v", err)
+	}
+	roles := []RoleEntity{{Name: "viewer"}, {Name: "editor", Inherits: "viewer"}}
+	rules := []RuleEntity{
+		{Role: "viewer", Resource: "order:**", Actions: "read", Effect: "allow"},
+		{Role: "admin", Resource: "**", Actions: "*", Effect: "allow"},
+		{Role: "admin", Resource: "audit:**", Actions: "delete", Effect: "deny"},
+	}
+	if err = db.Create(&roles).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err = db.Create(&rules).Error; err != nil {
+		t.Fatal(err)
+	}
+	assertPolicy(t, FromDatabase(db))
+}
@@ -0,0 +1,55 @@
+package xCache
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	xAuthz "github.com/bamboo-services/bamboo-base-go/common/authz"
+)
+
+var _ xAuthz.DecisionCache = (*DecisionCache)(nil)
+
+// DecisionCache 基于缓存的授权判定结果缓存，实现 xAuthz.DecisionCache。
+//
+// 判定键已包含策略版本，策略变更后旧条目不再命中，由 TTL 自动清除；
+// Redis 后端在集群内共享，Memory 后端仅单实例生效。
+type DecisionCache struct {
+	kc     KeyCache[string, xAuthz.Verdict]
+	prefix string
+}
+
+// DecisionCacheOf 返回基于当前后端的判定结果缓存，底层 key 为 "authz:{name}:{key}"。
+//
+// 后端未装配时返回 nil，对 nil 调用方法返回错误。
+//
+// 使用示例：
+//
+//	enforcer, err := xAuthz.New(ctx, source, xAuthz.WithDecisionCache(xCache.DecisionCacheOf(cacheManager, "authz"), 0))
+func DecisionCacheOf(m *Manager, name string) *DecisionCache {
+	kc := KeyCacheOf[string, xAuthz.Verdict](m)
+	if kc == nil {
+		return nil
+	}
+	return &DecisionCache{kc: kc, prefix: "authz:" + name + ":"}
+}
+
+// Get 读取判定结果，未命中时 ok 为 false。
+func (d *DecisionCache) Get(ctx context.Context, key string) (xAuthz.Verdict, bool, error) {
+	if d == nil {
+		return 0, false, errors.New("判定缓存的缓存后端未装配")
+	}
+	verdict, ok, err := d.kc.Get(ctx, d.prefix+key)
+	if err != nil || !ok || verdict == nil {
+		return 0, false, err
+	}
+	return *verdict, true, nil
+}
+
+// Set 写入判定结果。
+func (d *DecisionCache) Set(ctx context.Context, key string, verdict xAuthz.Verdict, ttl time.Duration) error {
+	if d == nil {
+		return errors.New("判定缓存的缓存后端未装配")
+	}
+	return d.kc.Set(ctx, d.prefix+key, &verdict, WithTTL(ttl))
+}
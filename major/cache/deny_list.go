@@ -0,0 +1,69 @@
+package xCache
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	xJwt "github.com/bamboo-services/bamboo-base-go/common/jwt"
+)
+
+var _ xJwt.DenyList = (*DenyList)(nil)
+
+// DenyList 基于缓存的吊销列表，实现 xJwt.DenyList。
+//
+// 每个被吊销的标识（如令牌 jti）以 SET NX 语义原子写入为一个带 TTL 的键，过期后由缓存后端自动清除；
+// Redis 后端在集群内共享，Memory 后端仅单实例生效。
+type DenyList struct {
+	m      *Manager
+	prefix string
+}
+
+// DenyListOf 返回基于当前后端的吊销列表，底层 key 为 "denylist:{name}:{id}"。
+//
+// 后端未装配时返回 nil，对 nil 调用方法返回错误。
+//
+// 使用示例：
+//
+//	manager := xJwt.New(keys, xJwt.WithDenyList(xCache.DenyListOf(cacheManager, "jwt")))
+func DenyListOf(m *Manager, name string) *DenyList {
+	if m == nil {
+		return nil
+	}
+	switch {
+	case m.kind == CacheTypeRedis && m.rdb != nil, m.kind == CacheTypeMemory && m.mem != nil:
+		return &DenyList{m: m, prefix: "denylist:" + name + ":"}
+	default:
+		return nil
+	}
+}
+
+// Deny 将 id 加入吊销列表直至 until，added 为 false 表示 id 已被吊销。
+//
+// until 已过时不写入并视为新增（此时令牌本身已过期，无法通过校验）。
+func (d *DenyList) Deny(ctx context.Context, id string, until time.Time) (bool, error) {
+	if d == nil {
+		return false, errors.New("吊销列表的缓存后端未装配")
+	}
+	ttl := time.Until(until)
+	if ttl <= 0 {
+		return true, nil
+	}
+	if d.m.kind == CacheTypeMemory {
+		return d.m.mem.SetCond(d.prefix+id, until.Unix(), ttl, true, false, false), nil
+	}
+	return d.m.rdb.SetNX(ctx, d.prefix+id, strconv.FormatInt(until.Unix(), 10), ttl).Result()
+}
+
+// Denied 判断 id 是否已被吊销。
+func (d *DenyList) Denied(ctx context.Context, id string) (bool, error) {
+	if d == nil {
+		return false, errors.New("吊销列表的缓存后端未装配")
+	}
+	if d.m.kind == CacheTypeMemory {
+		return d.m.mem.Exists(d.prefix + id), nil
+	}
+	n, err := d.m.rdb.Exists(ctx, d.prefix+id).Result()
+	return n > 0, err
+}
@@ -0,0 +1,203 @@
+package xCache
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"time"
+
+	xCacheMemory "github.com/bamboo-services/bamboo-base-go/major/cache/memory"
+	"github.com/redis/go-redis/v9"
+)
+
+// ErrIdempotencyNotOwner 处理中标记已过期或已被其他请求取得，本次请求不再持有该幂等键。
+var ErrIdempotencyNotOwner = errors.New("幂等标记已不属于当前请求")
+
+// idempotencyCASScript 仅当 key 的当前值与期望的处理中标记一致时替换或删除。
+//
+// ARGV: 期望的标记、新值（为空时删除）、过期时间（毫秒，小于等于 0 表示不过期）。
+// 返回 1 表示已写入，0 表示标记已变化。
+var idempotencyCASScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) ~= ARGV[1] then
+	return 0
+end
+if ARGV[2] == '' then
+	redis.call('DEL', KEYS[1])
+elseif tonumber(ARGV[3]) > 0 then
+	redis.call('SET', KEYS[1], ARGV[2], 'PX', ARGV[3])
+else
+	redis.call('SET', KEYS[1], ARGV[2])
+end
+return 1
+`)
+
+// IdempotencyRecord 幂等键对应的请求记录。
+//
+// 首个请求写入仅含 Fingerprint 与 Token 的处理中标记，处理完成后整体替换为包含响应的记录。
+type IdempotencyRecord struct {
+	Fingerprint string      `json:"fingerprint"`      // 请求指纹（方法、路径与请求体摘要）
+	Token       string      `json:"token,omitempty"`  // 处理中标记的持有令牌，仅标记含有
+	Completed   bool        `json:"completed"`        // 是否已处理完成
+	Status      int         `json:"status,omitempty"` // 响应状态码
+	Header      http.Header `json:"header,omitempty"` // 响应头
+	Body        []byte      `json:"body,omitempty"`   // 响应体，通常为 xBase.BaseResponse 的 JSON
+}
+
+// IdempotencyStore 基于缓存的幂等记录存储，供 xMiddle.Idempotency 使用。
+//
+// 处理中标记以 SET NX 语义原子写入：Redis 后端在集群内互斥，Memory 后端仅单实例生效。
+// 完成与释放均先比对标记再写入（Redis 为 Lua 脚本，Memory 在分片锁内比对），
+// 标记过期后被其他请求重新取得时，原持有者无法覆盖或删除新标记。
+type IdempotencyStore struct {
+	m      *Manager
+	prefix string
+}
+
+// IdempotencyStoreOf 返回基于当前后端的幂等记录存储，底层 key 为 "idempotency:{name}:{key}"。
+//
+// 后端未装配时返回 nil，对 nil 调用方法返回错误。
+//
+// 使用示例：
+//
+//	r.POST("/orders", xMiddle.Idempotency(xCache.IdempotencyStoreOf(cacheManager, "orders")), handler.Create)
+func IdempotencyStoreOf(m *Manager, name string) *IdempotencyStore {
+	if m == nil {
+		return nil
+	}
+	switch {
+	case m.kind == CacheTypeRedis && m.rdb != nil, m.kind == CacheTypeMemory && m.mem != nil:
+		return &IdempotencyStore{m: m, prefix: "idempotency:" + name + ":"}
+	default:
+		return nil
+	}
+}
+
+// Acquire 尝试为 key 写入处理中标记，标记在 ttl 后过期以免处理方崩溃后永久占用。
+//
+// acquired 为 true 表示本次请求获得处理权，record 为写入的处理中标记，处理结束后应以 record.Token
+// 调用 [IdempotencyStore.Complete] 或 [IdempotencyStore.Release]；
+// 否则返回已存在的记录，由调用方根据 Completed 与 Fingerprint 决定重放或拒绝。
+func (s *IdempotencyStore) Acquire(ctx context.Context, key, fingerprint string, ttl time.Duration) (record *IdempotencyRecord, acquired bool, err error) {
+	if s == nil {
+		return nil, false, errors.New("幂等存储的缓存后端未装配")
+	}
+	var token [16]byte
+	if _, err = rand.Read(token[:]); err != nil {
+		return nil, false, err
+	}
+	marker := IdempotencyRecord{Fingerprint: fingerprint, Token: hex.EncodeToString(token[:])}
+	// 已有记录恰好在读取前过期时重试一次
+	for range 2 {
+		if acquired, err = s.setNX(ctx, s.prefix+key, marker, ttl); err != nil || acquired {
+			if acquired {
+				return &marker, true, nil
+			}
+			return nil, false, err
+		}
+		var found bool
+		if record, found, err = s.get(ctx, s.prefix+key); err != nil || found {
+			return record, false, err
+		}
+	}
+	return nil, false, errors.New("幂等记录在写入与读取之间反复过期")
+}
+
+// Complete 以包含响应的记录替换 token 对应的处理中标记，保留 ttl。
+//
+// 标记已过期或已被其他请求重新取得时不写入，返回 [ErrIdempotencyNotOwner]。
+func (s *IdempotencyStore) Complete(ctx context.Context, key, token string, record IdempotencyRecord, ttl time.Duration) error {
+	if s == nil {
+		return errors.New("幂等存储的缓存后端未装配")
+	}
+	record.Completed = true
+	record.Token = ""
+	return s.swap(ctx, key, IdempotencyRecord{Fingerprint: record.Fingerprint, Token: token}, &record, ttl)
+}
+
+// Release 删除 token 对应的处理中标记，使后续重试可重新处理。
+//
+// 标记已过期或已被其他请求重新取得时不删除，返回 [ErrIdempotencyNotOwner]。
+func (s *IdempotencyStore) Release(ctx context.Context, key, fingerprint, token string) error {
+	if s == nil {
+		return errors.New("幂等存储的缓存后端未装配")
+	}
+	return s.swap(ctx, key, IdempotencyRecord{Fingerprint: fingerprint, Token: token}, nil, 0)
+}
+
+// swap 仅当当前值仍为 marker 时以 record 替换，record 为 nil 时删除
+func (s *IdempotencyStore) swap(ctx context.Context, key string, marker IdempotencyRecord, record *IdempotencyRecord, ttl time.Duration) error {
+	if s.m.kind == CacheTypeMemory {
+		swapped := false
+		s.m.mem.Update(s.prefix+key, ttl, func(old any) any {
+			if current, ok := old.(IdempotencyRecord); !ok || current.Completed || current.Token != marker.Token ||
+				current.Fingerprint != marker.Fingerprint {
+				return xCacheMemory.UpdateNoChange
+			}
+			swapped = true
+			if record == nil {
+				return nil
+			}
+			return *record
+		})
+		if !swapped {
+			return ErrIdempotencyNotOwner
+		}
+		return nil
+	}
+	expected, err := s.m.codec.Marshal(marker)
+	if err != nil {
+		return err
+	}
+	var data []byte
+	if record != nil {
+		if data, err = s.m.codec.Marshal(*record); err != nil {
+			return err
+		}
+	}
+	swapped, err := idempotencyCASScript.Run(ctx, s.m.rdb, []string{s.prefix + key}, expected, data, ttl.Milliseconds()).Int()
+	if err != nil {
+		return err
+	}
+	if swapped == 0 {
+		return ErrIdempotencyNotOwner
+	}
+	return nil
+}
+
+// setNX 仅当 key 不存在时写入记录
+func (s *IdempotencyStore) setNX(ctx context.Context, key string, record IdempotencyRecord, ttl time.Duration) (bool, error) {
+	if s.m.kind == CacheTypeMemory {
+		return s.m.mem.SetCond(key, record, ttl, true, false, false), nil
+	}
+	data, err := s.m.codec.Marshal(record)
+	if err != nil {
+		return false, err
+	}
+	return s.m.rdb.SetNX(ctx, key, data, ttl).Result()
+}
+
+// get 读取记录
+func (s *IdempotencyStore) get(ctx context.Context, key string) (*IdempotencyRecord, bool, error) {
+	if s.m.kind == CacheTypeMemory {
+		value, ok := s.m.mem.Get(key)
+		record, isRecord := value.(IdempotencyRecord)
+		if !ok || !isRecord {
+			return nil, false, nil
+		}
+		return &record, true, nil
+	}
+	data, err := s.m.rdb.Get(ctx, key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	var record IdempotencyRecord
+	if err = s.m.codec.Unmarshal(data, &record); err != nil {
+		return nil, false, err
+	}
+	return &record, true, nil
+}
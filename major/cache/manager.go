@@ -1,10 +1,14 @@
 package xCache
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"sync"
 	"time"
 
 	xLog "github.com/bamboo-services/bamboo-base-go/common/log"
+	xRateLimit "github.com/bamboo-services/bamboo-base-go/common/ratelimit"
 	xCacheDriver "github.com/bamboo-services/bamboo-base-go/major/cache/driver"
 	xCacheMemory "github.com/bamboo-services/bamboo-base-go/major/cache/memory"
 	xCacheRedis "github.com/bamboo-services/bamboo-base-go/major/cache/redis"
@@ -106,6 +110,7 @@ type Manager struct {
 	ttl   time.Duration
 	log   *xLog.LogNamedLogger
 
+	onClose   []func()
 	closeOnce sync.Once
 }
 
@@ -150,6 +155,17 @@ func WithLogger(log *xLog.LogNamedLogger) ManagerOption {
 	return func(m *Manager) { m.log = log }
 }
 
+// WithOnClose 追加 [Manager.Close] 时执行的回调，按登记顺序执行。
+//
+// 用于随 Manager 一并释放外部登记的资源，如 Redis 连接池指标的抓取回调。
+func WithOnClose(fn func()) ManagerOption {
+	return func(m *Manager) {
+		if fn != nil {
+			m.onClose = append(m.onClose, fn)
+		}
+	}
+}
+
 // NewManager 构造缓存管理器。
 //
 // kind 为 [CacheTypeRedis] / [CacheTypeMemory]，需配合对应的 WithRedisClient /
@@ -306,14 +322,72 @@ func ListCacheOf[K any, V any](m *Manager) ListCache[K, V] {
 	}
 }
 
+// LimiterOf 返回基于当前后端的 [xRateLimit.Limiter] 实现。
+//
+// Memory 后端仅在单实例内生效；Redis 后端以 Lua 脚本保证集群内原子判定，多实例共享额度。
+// name 用于区分不同限流规则，底层 key 为 "ratelimit:{name}:{key}"，同名规则共享限流状态。
+//
+// 后端未装配时返回 nil。
+//
+// 使用示例：
+//
+//	limiter := xCache.LimiterOf(manager, "login", xRateLimit.PerMinute(5).Sliding())
+//	result, err := limiter.Allow(ctx, clientIP)
+func LimiterOf(m *Manager, name string, limit xRateLimit.Limit) xRateLimit.Limiter {
+	if m == nil {
+		return nil
+	}
+	prefix := "ratelimit:" + name + ":"
+	switch m.kind {
+	case CacheTypeRedis:
+		if m.rdb == nil {
+			return nil
+		}
+		return xCacheRedis.NewLimiter(m.rdb, limit, prefix)
+	case CacheTypeMemory:
+		if m.mem == nil {
+			return nil
+		}
+		return xCacheMemory.NewLimiter(m.mem, limit, prefix)
+	default:
+		return nil
+	}
+}
+
+// Check 检查缓存后端是否可用：Redis 后端执行 PING，Memory 后端执行写读探测。
+//
+// 实现 xHealth.Checker，Register 装配的缓存节点会自动登记为健康检查。
+func (m *Manager) Check(ctx context.Context) error {
+	switch m.kind {
+	case CacheTypeRedis:
+		if m.rdb == nil {
+			return errors.New("redis 客户端未装配")
+		}
+		if err := m.rdb.Ping(ctx).Err(); err != nil {
+			return fmt.Errorf("redis ping 失败: %w", err)
+		}
+		return nil
+	case CacheTypeMemory:
+		if m.mem == nil {
+			return errors.New("内存存储未装配")
+		}
+		return m.mem.Check(ctx)
+	default:
+		return fmt.Errorf("不支持的缓存类型: %s", m.kind)
+	}
+}
+
 // Close 释放底层资源。
 //
-// 当前仅对 Memory 后端有意义（停止 janitor goroutine）；Redis 后端关闭由
+// 停止 Memory 后端的 janitor goroutine，并执行 [WithOnClose] 登记的回调；Redis 客户端的关闭由
 // 调用方自行管理（通常跟随应用生命周期）。可安全多次调用。
 func (m *Manager) Close() {
 	m.closeOnce.Do(func() {
 		if m.mem != nil {
 			m.mem.Close()
 		}
+		for _, fn := range m.onClose {
+			fn()
+		}
 	})
 }
@@ -2,9 +2,11 @@ package xCache_test
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
+	xAuthz "github.com/bamboo-services/bamboo-base-go/common/authz"
 	xCache "github.com/bamboo-services/bamboo-base-go/major/cache"
 	xCacheMemory "github.com/bamboo-services/bamboo-base-go/major/cache/memory"
 )
@@ -289,3 +291,91 @@ func TestListWithNoSlide(t *testing.T) {
 }
 
 func strPtr(s string) *string { return &s }
+
+func TestDenyListOf(t *testing.T) {
+	store := xCacheMemory.NewStore(0, 0, 0)
+	defer store.Close()
+	m := xCache.NewManager(xCache.CacheTypeMemory, xCache.WithMemoryStore(store))
+	ctx := context.Background()
+
+	denyList := xCache.DenyListOf(m, "jwt")
+	if added, err := denyList.Deny(ctx, "jti-1", time.Now().Add(50*time.Millisecond)); !added || err != nil {
+		t.Fatalf("Deny = %v, %v", added, err)
+	}
+	if added, _ := denyList.Deny(ctx, "jti-1", time.Now().Add(50*time.Millisecond)); added {
+		t.Fatal("second Deny of jti-1 should report already denied")
+	}
+	if _, err := denyList.Deny(ctx, "jti-2", time.Now().Add(-time.Second)); err != nil {
+		t.Fatalf("Deny with past deadline failed: %v", err)
+	}
+	if denied, _ := denyList.Denied(ctx, "jti-1"); !denied {
+		t.Fatal("jti-1 should be denied")
+	}
+	if denied, _ := denyList.Denied(ctx, "jti-2"); denied {
+		t.Fatal("jti-2 should not be stored when already expired")
+	}
+	time.Sleep(80 * time.Millisecond)
+	if denied, _ := denyList.Denied(ctx, "jti-1"); denied {
+		t.Fatal("jti-1 should expire with its deadline")
+	}
+
+	var missing *xCache.DenyList
+	if _, err := missing.Denied(ctx, "jti-1"); err == nil {
+		t.Fatal("nil DenyList should return error")
+	}
+}
+
+func TestDecisionCacheOf(t *testing.T) {
+	store := xCacheMemory.NewStore(0, 0, 0)
+	defer store.Close()
+	m := xCache.NewManager(xCache.CacheTypeMemory, xCache.WithMemoryStore(store))
+	ctx := context.Background()
+
+	cache := xCache.DecisionCacheOf(m, "authz")
+	if _, ok, err := cache.Get(ctx, "k"); ok || err != nil {
+		t.Fatalf("empty cache Get = %v, %v", ok, err)
+	}
+	if err := cache.Set(ctx, "k", xAuthz.VerdictResourceDenied, time.Minute); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if verdict, ok, _ := cache.Get(ctx, "k"); !ok || verdict != xAuthz.VerdictResourceDenied {
+		t.Fatalf("Get = %v, %v", verdict, ok)
+	}
+}
+
+func TestIdempotencyStoreCompleteRequiresOwner(t *testing.T) {
+	store := xCacheMemory.NewStore(0, 0, 0)
+	defer store.Close()
+	m := xCache.NewManager(xCache.CacheTypeMemory, xCache.WithMemoryStore(store))
+	ctx := context.Background()
+	idem := xCache.IdempotencyStoreOf(m, "orders")
+
+	stale, acquired, err := idem.Acquire(ctx, "k", "fp", 30*time.Millisecond)
+	if !acquired || err != nil {
+		t.Fatalf("first Acquire = %v, %v", acquired, err)
+	}
+	time.Sleep(50 * time.Millisecond)
+	owner, acquired, err := idem.Acquire(ctx, "k", "fp", time.Minute)
+	if !acquired || err != nil || owner.Token == stale.Token {
+		t.Fatalf("Acquire after lock expiry = %v, %v", acquired, err)
+	}
+
+	result := xCache.IdempotencyRecord{Fingerprint: "fp", Status: 201, Body: []byte("stale")}
+	if err = idem.Complete(ctx, "k", stale.Token, result, time.Minute); !errors.Is(err, xCache.ErrIdempotencyNotOwner) {
+		t.Fatalf("Complete with expired marker = %v, want ErrIdempotencyNotOwner", err)
+	}
+	if err = idem.Release(ctx, "k", "fp", stale.Token); !errors.Is(err, xCache.ErrIdempotencyNotOwner) {
+		t.Fatalf("Release with expired marker = %v, want ErrIdempotencyNotOwner", err)
+	}
+	if record, acquired, _ := idem.Acquire(ctx, "k", "fp", time.Minute); acquired || record.Completed {
+		t.Fatal("stale owner must not overwrite or delete the new marker")
+	}
+
+	result.Body = []byte("fresh")
+	if err = idem.Complete(ctx, "k", owner.Token, result, time.Minute); err != nil {
+		t.Fatalf("Complete by owner failed: %v", err)
+	}
+	if record, _, _ := idem.Acquire(ctx, "k", "fp", time.Minute); !record.Completed || string(record.Body) != "fresh" {
+		t.Fatalf("stored record = %+v", record)
+	}
+}
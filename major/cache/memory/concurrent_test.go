@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	xRateLimit "github.com/bamboo-services/bamboo-base-go/common/ratelimit"
 	xCacheDriver "github.com/bamboo-services/bamboo-base-go/major/cache/driver"
 )
 
@@ -143,3 +144,42 @@ func TestConcurrentKeyCacheGetSet(t *testing.T) {
 	}
 	wg.Wait()
 }
+
+// TestConcurrentLimiterAllow 验证内存限流器在并发请求同一 key 时不超发。
+func TestConcurrentLimiterAllow(t *testing.T) {
+	store := NewStore(4, 0, 0)
+	defer store.Close()
+
+	for _, limit := range []xRateLimit.Limit{xRateLimit.PerHour(50), xRateLimit.PerHour(50).Sliding()} {
+		limiter := NewLimiter(store, limit, "ratelimit:"+string(limit.Algorithm)+":")
+		var (
+			wg      sync.WaitGroup
+			mu      sync.Mutex
+			allowed int
+		)
+		for i := 0; i < 200; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				result, err := limiter.Allow(context.Background(), "client")
+				if err != nil {
+					t.Errorf("Allow 返回错误: %v", err)
+					return
+				}
+				if result.Allowed {
+					mu.Lock()
+					allowed++
+					mu.Unlock()
+				}
+			}()
+		}
+		wg.Wait()
+		if allowed != 50 {
+			t.Errorf("%s: 放行 %d 次，期望 50 次", limit.Algorithm, allowed)
+		}
+	}
+
+	if _, err := NewLimiter(store, xRateLimit.Limit{}, "invalid:").Allow(context.Background(), "client"); err == nil {
+		t.Error("非法规则应返回校验错误")
+	}
+}
@@ -0,0 +1,54 @@
+package xCacheMemory
+
+import (
+	"context"
+	"time"
+
+	xRateLimit "github.com/bamboo-services/bamboo-base-go/common/ratelimit"
+)
+
+// Limiter [xRateLimit.Limiter] 的内存实现，适用于单实例部署。
+//
+// 限流状态以值类型存放在 [Store] 中，判定在 [Store.Update] 的分片锁内完成，
+// 同一 key 的并发请求不会超发；状态随 [xRateLimit.Limit.TTL] 过期后由 janitor 回收。
+type Limiter struct {
+	store  *Store
+	limit  xRateLimit.Limit
+	prefix string
+	err    error
+}
+
+// NewLimiter 构造一个基于内存的 [xRateLimit.Limiter] 实现。
+//
+// prefix 拼接在每个限流 key 之前，用于区分不同规则；limit 不合法时每次 Allow 均返回校验错误。
+func NewLimiter(store *Store, limit xRateLimit.Limit, prefix string) xRateLimit.Limiter {
+	return &Limiter{store: store, limit: limit, prefix: prefix, err: limit.Validate()}
+}
+
+// Allow 判定 key 对应的一次请求是否放行。
+func (l *Limiter) Allow(ctx context.Context, key string) (xRateLimit.Result, error) {
+	if l.err != nil {
+		return xRateLimit.Result{}, l.err
+	}
+	var result xRateLimit.Result
+	now := time.Now()
+	l.store.Update(l.prefix+key, l.limit.TTL(), func(old any) any {
+		if l.limit.Algorithm == xRateLimit.SlidingWindow {
+			var prev *xRateLimit.WindowState
+			if state, ok := old.(xRateLimit.WindowState); ok {
+				prev = &state
+			}
+			next, res := l.limit.AllowSlidingWindow(prev, now)
+			result = res
+			return next
+		}
+		var prev *xRateLimit.BucketState
+		if state, ok := old.(xRateLimit.BucketState); ok {
+			prev = &state
+		}
+		next, res := l.limit.AllowTokenBucket(prev, now)
+		result = res
+		return next
+	})
+	return result, nil
+}
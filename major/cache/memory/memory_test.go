@@ -157,3 +157,28 @@ func TestMemoryListCache(t *testing.T) {
 
 func strPtr(s string) *string { return &s }
 func intPtr(i int) *int       { return &i }
+
+// TestStoreMetrics 验证命中、未命中与 LRU 淘汰计入缓存指标。
+func TestStoreMetrics(t *testing.T) {
+	store := NewStore(1, 1, 0)
+	defer store.Close()
+
+	hits, misses, evictions := metricHits.Value(), metricMisses.Value(), metricEvictions.Value()
+	store.Set("a", 1, 0)
+	store.Get("a")
+	store.Get("missing")
+	store.Set("b", 2, 0)
+	if err := store.Check(context.Background()); err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+
+	if got := metricHits.Value() - hits; got != 1 {
+		t.Errorf("hits = %v, want 1", got)
+	}
+	if got := metricMisses.Value() - misses; got != 1 {
+		t.Errorf("misses = %v, want 1", got)
+	}
+	if got := metricEvictions.Value() - evictions; got < 1 {
+		t.Errorf("evictions = %v, want >= 1", got)
+	}
+}
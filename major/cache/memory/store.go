@@ -2,9 +2,21 @@ package xCacheMemory
 
 import (
 	"container/list"
+	"context"
+	"errors"
 	"sync"
 	"sync/atomic"
 	"time"
+
+	xMetrics "github.com/bamboo-services/bamboo-base-go/common/metrics"
+)
+
+// 缓存指标，所有 Store 实例共享 backend="memory" 序列
+var (
+	metricHits        = xMetrics.NewCounter("cache_hits_total", "缓存命中次数", "backend").With("memory")
+	metricMisses      = xMetrics.NewCounter("cache_misses_total", "缓存未命中次数", "backend").With("memory")
+	metricEvictions   = xMetrics.NewCounter("cache_evictions_total", "因容量上限被淘汰的缓存条目数", "backend").With("memory")
+	metricExpirations = xMetrics.NewCounter("cache_expirations_total", "因过期被清理的缓存条目数", "backend").With("memory")
 )
 
 // memoryEntry 通用缓存条目，承载任意数据结构的值。
@@ -111,6 +123,24 @@ func (s *Store) Close() {
 	}
 }
 
+// healthProbeKey 健康检查写读探测使用的键。
+const healthProbeKey = "__xlf_health_probe__"
+
+// Check 检查存储是否可用：janitor 仍在运行，且探测键可正常写入、读出与删除。
+//
+// 实现 xHealth.Checker，由缓存 Manager 的健康检查调用。
+func (s *Store) Check(_ context.Context) error {
+	if s.janitor == nil || !s.janitor.running.Load() {
+		return errors.New("内存存储已关闭")
+	}
+	s.Set(healthProbeKey, struct{}{}, time.Second)
+	defer s.Delete(healthProbeKey)
+	if _, ok := s.lookup(healthProbeKey); !ok {
+		return errors.New("内存存储写入后无法读出")
+	}
+	return nil
+}
+
 // getShard 根据 key 的 FNV-1a hash 选取分片。
 func (s *Store) getShard(key string) *memoryShard {
 	// FNV-1a 64bit
@@ -145,6 +175,7 @@ func (s *Store) cleanup() {
 			if e.expired(now) {
 				sh.order.Remove(e.elem)
 				delete(sh.data, k)
+				metricExpirations.Inc()
 			}
 		}
 		sh.mu.Unlock()
@@ -156,7 +187,20 @@ func (s *Store) cleanup() {
 // 返回的是 Value 字段在锁内读取的引用（any），调用方不再持有 *memoryEntry，
 // 因此与并发的 [Set]/[Update]（替换整个 Value 字段）不会产生 data race。
 // 若 Value 是切片/map 类型，调用方应只读不写；如需修改请走 [Update] 闭包。
+//
+// 每次调用计入 cache_hits_total / cache_misses_total{backend="memory"}。
 func (s *Store) Get(key string) (any, bool) {
+	value, ok := s.lookup(key)
+	if ok {
+		metricHits.Inc()
+	} else {
+		metricMisses.Inc()
+	}
+	return value, ok
+}
+
+// lookup 读取条目并刷新 LRU 顺序，不计入命中指标；顺带删除已过期的条目。
+func (s *Store) lookup(key string) (any, bool) {
 	sh := s.getShard(key)
 	sh.mu.Lock()
 	defer sh.mu.Unlock()
@@ -167,6 +211,7 @@ func (s *Store) Get(key string) (any, bool) {
 	if e.expired(time.Now()) {
 		sh.order.Remove(e.elem)
 		delete(sh.data, key)
+		metricExpirations.Inc()
 		return nil, false
 	}
 	sh.order.MoveToFront(e.elem)
@@ -214,6 +259,7 @@ func (s *Store) evict(sh *memoryShard) {
 	lru := back.Value.(*memoryEntryLRU)
 	sh.order.Remove(back)
 	delete(sh.data, lru.key)
+	metricEvictions.Inc()
 }
 
 // memoryEntryLRU 链表节点载荷，记录 key 用于淘汰时反查 map。
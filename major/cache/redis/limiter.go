@@ -0,0 +1,143 @@
+package xCacheRedis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	xRateLimit "github.com/bamboo-services/bamboo-base-go/common/ratelimit"
+	"github.com/redis/go-redis/v9"
+)
+
+// tokenBucketScript 令牌桶脚本，与 [xRateLimit.Limit.AllowTokenBucket] 算法一致。
+//
+// 时间取自 Redis 服务端 TIME（毫秒，含小数），避免多实例时钟偏差；状态以 hash 保存 tokens / ts。
+// ARGV: 桶容量、每个令牌的补充间隔（毫秒）、状态过期时间（毫秒）。
+// 返回 {是否放行, 剩余额度, 重试等待毫秒, 恢复满额毫秒}。
+var tokenBucketScript = redis.NewScript(`
+redis.replicate_commands()
+local capacity = tonumber(ARGV[1])
+local interval = tonumber(ARGV[2])
+local ttl = tonumber(ARGV[3])
+local clock = redis.call('TIME')
+local now = tonumber(clock[1]) * 1000 + tonumber(clock[2]) / 1000
+
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(state[1])
+local ts = tonumber(state[2])
+if tokens == nil or ts == nil then
+	tokens = capacity
+	ts = now
+elseif now > ts then
+	tokens = math.min(capacity, tokens + (now - ts) / interval)
+	ts = now
+end
+
+local allowed = 0
+local retry = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+else
+	retry = math.ceil((1 - tokens) * interval)
+end
+redis.call('HSET', KEYS[1], 'tokens', tokens, 'ts', ts)
+redis.call('PEXPIRE', KEYS[1], ttl)
+return {allowed, math.floor(tokens), retry, math.ceil((capacity - tokens) * interval)}
+`)
+
+// slidingWindowScript 滑动窗口脚本，与 [xRateLimit.Limit.AllowSlidingWindow] 算法一致。
+//
+// 状态以 hash 保存 start / prev / curr，窗口按服务端 Unix 毫秒对齐。
+// ARGV: 窗口内允许的请求数、窗口长度（毫秒）。
+// 返回 {是否放行, 剩余额度, 重试等待毫秒, 恢复满额毫秒}。
+var slidingWindowScript = redis.NewScript(`
+redis.replicate_commands()
+local rate = tonumber(ARGV[1])
+local period = tonumber(ARGV[2])
+local clock = redis.call('TIME')
+local now = tonumber(clock[1]) * 1000 + math.floor(tonumber(clock[2]) / 1000)
+local start = now - now % period
+
+local state = redis.call('HMGET', KEYS[1], 'start', 'prev', 'curr')
+local last = tonumber(state[1])
+local prev = 0
+local curr = 0
+if last == start then
+	prev = tonumber(state[2]) or 0
+	curr = tonumber(state[3]) or 0
+elseif last == start - period then
+	prev = tonumber(state[3]) or 0
+end
+
+local elapsed = now - start
+local estimate = prev * (1 - elapsed / period) + curr
+local allowed = 0
+local retry = 0
+if estimate + 1 <= rate then
+	curr = curr + 1
+	estimate = estimate + 1
+	allowed = 1
+	redis.call('HSET', KEYS[1], 'start', start, 'prev', prev, 'curr', curr)
+	redis.call('PEXPIRE', KEYS[1], 2 * period)
+elseif curr >= rate then
+	retry = math.max(1, period - elapsed + period * (1 - (rate - 1) / curr))
+else
+	retry = math.max(1, period * (1 - (rate - 1 - curr) / prev) - elapsed)
+end
+
+local reset = 0
+if curr > 0 then
+	reset = 2 * period - elapsed
+elseif prev > 0 then
+	reset = period - elapsed
+end
+return {allowed, math.max(0, math.floor(rate - estimate)), math.ceil(retry), math.ceil(reset)}
+`)
+
+// Limiter [xRateLimit.Limiter] 的 Redis 实现，适用于多实例共享限流额度。
+//
+// 每次判定在单个 Lua 脚本内完成「读状态 → 计算 → 写回」，集群内同一 key 的并发请求不会超发。
+type Limiter struct {
+	rdb    *redis.Client
+	limit  xRateLimit.Limit
+	prefix string
+	err    error
+}
+
+// NewLimiter 构造一个基于 Redis 的 [xRateLimit.Limiter] 实现。
+//
+// prefix 拼接在每个限流 key 之前，用于区分不同规则；limit 不合法时每次 Allow 均返回校验错误。
+// 脚本使用服务端 TIME 计时，需要 Redis 5.0 及以上版本。
+func NewLimiter(rdb *redis.Client, limit xRateLimit.Limit, prefix string) xRateLimit.Limiter {
+	return &Limiter{rdb: rdb, limit: limit, prefix: prefix, err: limit.Validate()}
+}
+
+// Allow 判定 key 对应的一次请求是否放行。
+func (l *Limiter) Allow(ctx context.Context, key string) (xRateLimit.Result, error) {
+	if l.err != nil {
+		return xRateLimit.Result{}, l.err
+	}
+	keys := []string{l.prefix + key}
+	var cmd *redis.Cmd
+	if l.limit.Algorithm == xRateLimit.SlidingWindow {
+		cmd = slidingWindowScript.Run(ctx, l.rdb, keys, l.limit.Rate, l.limit.Period.Milliseconds())
+	} else {
+		interval := float64(l.limit.Interval()) / float64(time.Millisecond)
+		cmd = tokenBucketScript.Run(ctx, l.rdb, keys, l.limit.Capacity(), interval, l.limit.TTL().Milliseconds()+1)
+	}
+	values, err := cmd.Int64Slice()
+	if err != nil {
+		return xRateLimit.Result{}, err
+	}
+	if len(values) != 4 {
+		return xRateLimit.Result{}, fmt.Errorf("限流脚本返回值数量异常: %d", len(values))
+	}
+	return xRateLimit.Result{
+		Allowed:    values[0] == 1,
+		Limit:      l.limit.Capacity(),
+		Remaining:  int(values[1]),
+		RetryAfter: time.Duration(values[2]) * time.Millisecond,
+		ResetAfter: time.Duration(values[3]) * time.Millisecond,
+	}, nil
+}
@@ -0,0 +1,361 @@
+package xConfig
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	xVaild "github.com/bamboo-services/bamboo-base-go/common/validator"
+	"github.com/go-playground/validator/v10"
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+var timeType = reflect.TypeOf(time.Time{})
+
+// Bind 将指定路径下的配置绑定到 target 结构体指针，并执行校验。
+//
+// 字段映射规则：
+//   - 优先使用 `config:"name"` 标签，`config:"-"` 表示忽略该字段
+//   - 未声明标签时使用字段名的 snake_case 形式，如 MaxSize -> max_size
+//   - 匿名嵌入结构体的字段平铺到当前层级
+//
+// 每个叶子字段都会先检查对应的环境变量（如 log.max_size -> LOG_MAX_SIZE），存在则覆盖文件值；
+// 两者都不存在时保留 target 中已有的值作为默认值。
+//
+// 绑定完成后使用 `validate` 标签校验，已注册 common/validator 中的全部自定义规则
+// （enum_string、strict_url 等）；校验失败时返回包含中文提示的错误。
+func (c *Config) Bind(key string, target any) error {
+	rv := reflect.ValueOf(target)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return errors.New("xConfig.Bind: target 必须是非 nil 指针")
+	}
+	key = normalizeKey(key)
+
+	c.mu.RLock()
+	raw := lookup(c.data, key)
+	c.mu.RUnlock()
+
+	if err := c.bindValue(key, raw, rv.Elem()); err != nil {
+		return err
+	}
+	if rv.Elem().Kind() == reflect.Struct {
+		if err := c.validator().Struct(target); err != nil {
+			var validationErrors validator.ValidationErrors
+			if errors.As(err, &validationErrors) {
+				return fmt.Errorf("配置校验失败[%s]: %s", key, strings.Join(xVaild.FormatValidationErrors(err), "; "))
+			}
+			return fmt.Errorf("配置校验失败[%s]: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// bindValue 按目标类型递归绑定，结构体字段逐个叠加环境变量。
+func (c *Config) bindValue(path string, raw any, rv reflect.Value) error {
+	if rv.Kind() == reflect.Struct && rv.Type() != timeType {
+		m, _ := raw.(map[string]any)
+		return c.bindStruct(path, m, rv)
+	}
+	if rv.Kind() == reflect.Ptr && rv.Type().Elem().Kind() == reflect.Struct && rv.Type().Elem() != timeType {
+		m, _ := raw.(map[string]any)
+		if m == nil && rv.IsNil() && !c.hasEnvUnder(path) {
+			return nil
+		}
+		if rv.IsNil() {
+			rv.Set(reflect.New(rv.Type().Elem()))
+		}
+		return c.bindStruct(path, m, rv.Elem())
+	}
+	if value, ok := c.lookupEnv(path); ok {
+		raw = value
+	}
+	if raw == nil {
+		return nil
+	}
+	if err := decodeValue(raw, rv); err != nil {
+		return fmt.Errorf("配置绑定失败[%s]: %w", path, err)
+	}
+	return nil
+}
+
+// bindStruct 绑定结构体的每个导出字段。
+func (c *Config) bindStruct(path string, raw map[string]any, rv reflect.Value) error {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			if err := c.bindStruct(path, raw, rv.Field(i)); err != nil {
+				return err
+			}
+			continue
+		}
+		name := fieldKey(field)
+		if name == "" {
+			continue
+		}
+		var sub any
+		if raw != nil {
+			sub = raw[name]
+		}
+		if err := c.bindValue(joinKey(path, name), sub, rv.Field(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// hasEnvUnder 判断是否存在以 path 为前缀的环境变量，用于决定是否分配 nil 结构体指针。
+func (c *Config) hasEnvUnder(path string) bool {
+	prefix := c.envName(path) + "_"
+	for _, env := range envList() {
+		if strings.HasPrefix(env, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// envList 返回当前进程的环境变量名列表。
+func envList() []string {
+	environ := os.Environ()
+	names := make([]string, 0, len(environ))
+	for _, item := range environ {
+		names = append(names, strings.SplitN(item, "=", 2)[0])
+	}
+	return names
+}
+
+// validator 懒加载校验器，并注册 common/validator 的自定义规则与中文字段名。
+func (c *Config) validator() *validator.Validate {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.validate == nil {
+		v := validator.New()
+		_ = xVaild.RegisterCustomValidators(v)
+		v.RegisterTagNameFunc(func(field reflect.StructField) string {
+			if label := field.Tag.Get("label"); label != "" {
+				return label
+			}
+			return fieldKey(field)
+		})
+		c.validate = v
+	}
+	return c.validate
+}
+
+// fieldKey 返回字段对应的配置键。
+func fieldKey(field reflect.StructField) string {
+	if tag, ok := field.Tag.Lookup("config"); ok {
+		name := strings.Split(tag, ",")[0]
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return strings.ToLower(name)
+		}
+	}
+	return toSnake(field.Name)
+}
+
+// toSnake 将驼峰命名转为 snake_case，连续大写视为缩写（如 DSN -> dsn，MaxTTL -> max_ttl）。
+func toSnake(name string) string {
+	runes := []rune(name)
+	var builder strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 && (unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1]))) {
+				builder.WriteByte('_')
+			}
+			builder.WriteRune(unicode.ToLower(r))
+			continue
+		}
+		builder.WriteRune(r)
+	}
+	return builder.String()
+}
+
+// joinKey 拼接配置路径。
+func joinKey(parent, child string) string {
+	if parent == "" {
+		return child
+	}
+	return parent + "." + child
+}
+
+// decodeInto 将原始值解码到指针 target。
+func decodeInto(raw any, target any) error {
+	rv := reflect.ValueOf(target)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return errors.New("target 必须是非 nil 指针")
+	}
+	return decodeValue(raw, rv.Elem())
+}
+
+// decodeValue 将配置文件或环境变量中的原始值转换为目标类型。
+func decodeValue(raw any, rv reflect.Value) error {
+	if raw == nil {
+		return nil
+	}
+	if rv.Type() == durationType {
+		d, err := toDuration(raw)
+		if err != nil {
+			return err
+		}
+		rv.SetInt(int64(d))
+		return nil
+	}
+	if rv.Type() == timeType {
+		t, err := time.Parse(time.RFC3339, fmt.Sprint(raw))
+		if t2, ok := raw.(time.Time); ok {
+			t, err = t2, nil
+		}
+		if err != nil {
+			return err
+		}
+		rv.Set(reflect.ValueOf(t))
+		return nil
+	}
+
+	switch rv.Kind() {
+	case reflect.Ptr:
+		if rv.IsNil() {
+			rv.Set(reflect.New(rv.Type().Elem()))
+		}
+		return decodeValue(raw, rv.Elem())
+	case reflect.Interface:
+		rv.Set(reflect.ValueOf(raw))
+	case reflect.String:
+		rv.SetString(fmt.Sprint(raw))
+	case reflect.Bool:
+		b, err := toBool(raw)
+		if err != nil {
+			return err
+		}
+		rv.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(strings.TrimSpace(numberString(raw)), 10, 64)
+		if err != nil {
+			return err
+		}
+		if rv.OverflowInt(n) {
+			return fmt.Errorf("数值溢出: %d", n)
+		}
+		rv.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(strings.TrimSpace(numberString(raw)), 10, 64)
+		if err != nil {
+			return err
+		}
+		if rv.OverflowUint(n) {
+			return fmt.Errorf("数值溢出: %d", n)
+		}
+		rv.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(strings.TrimSpace(fmt.Sprint(raw)), 64)
+		if err != nil {
+			return err
+		}
+		rv.SetFloat(f)
+	case reflect.Slice:
+		items, ok := raw.([]any)
+		if !ok {
+			items = make([]any, 0)
+			for _, part := range strings.Split(fmt.Sprint(raw), ",") {
+				if part = strings.TrimSpace(part); part != "" {
+					items = append(items, part)
+				}
+			}
+		}
+		slice := reflect.MakeSlice(rv.Type(), len(items), len(items))
+		for i, item := range items {
+			if err := decodeValue(item, slice.Index(i)); err != nil {
+				return err
+			}
+		}
+		rv.Set(slice)
+	case reflect.Map:
+		m, ok := raw.(map[string]any)
+		if !ok || rv.Type().Key().Kind() != reflect.String {
+			return fmt.Errorf("无法将 %T 转换为 %s", raw, rv.Type())
+		}
+		out := reflect.MakeMapWithSize(rv.Type(), len(m))
+		for key, item := range m {
+			elem := reflect.New(rv.Type().Elem()).Elem()
+			if err := decodeValue(item, elem); err != nil {
+				return err
+			}
+			out.SetMapIndex(reflect.ValueOf(key).Convert(rv.Type().Key()), elem)
+		}
+		rv.Set(out)
+	case reflect.Struct:
+		m, ok := raw.(map[string]any)
+		if !ok {
+			return fmt.Errorf("无法将 %T 转换为 %s", raw, rv.Type())
+		}
+		rt := rv.Type()
+		for i := 0; i < rt.NumField(); i++ {
+			field := rt.Field(i)
+			name := fieldKey(field)
+			if !field.IsExported() || name == "" {
+				continue
+			}
+			if err := decodeValue(m[name], rv.Field(i)); err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+		}
+	default:
+		return fmt.Errorf("不支持的字段类型: %s", rv.Type())
+	}
+	return nil
+}
+
+// numberString 将数值转换为整数字符串，兼容解析器产出的 float64（如 JSON 中的 10）。
+func numberString(raw any) string {
+	if f, ok := raw.(float64); ok && f == float64(int64(f)) {
+		return strconv.FormatInt(int64(f), 10)
+	}
+	return fmt.Sprint(raw)
+}
+
+// toBool 解析布尔值，兼容 1/0、yes/no、on/off。
+func toBool(raw any) (bool, error) {
+	if b, ok := raw.(bool); ok {
+		return b, nil
+	}
+	switch strings.ToLower(strings.TrimSpace(fmt.Sprint(raw))) {
+	case "1", "true", "yes", "on", "y":
+		return true, nil
+	case "0", "false", "no", "off", "n", "":
+		return false, nil
+	}
+	return false, fmt.Errorf("无法解析布尔值: %v", raw)
+}
+
+// toDuration 解析时长，支持 "30s" 等 Go Duration 字符串或纳秒整数。
+func toDuration(raw any) (time.Duration, error) {
+	switch typed := raw.(type) {
+	case time.Duration:
+		return typed, nil
+	case int:
+		return time.Duration(typed), nil
+	case int64:
+		return time.Duration(typed), nil
+	case uint64:
+		return time.Duration(typed), nil
+	case float64:
+		return time.Duration(typed), nil
+	}
+	text := strings.TrimSpace(fmt.Sprint(raw))
+	if n, err := strconv.ParseInt(text, 10, 64); err == nil {
+		return time.Duration(n), nil
+	}
+	return time.ParseDuration(text)
+}
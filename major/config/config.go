@@ -0,0 +1,214 @@
+package xConfig
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// Config 是分层配置中心，合并配置文件与环境变量，并支持文件热重载。
+//
+// 优先级（由高到低）：
+//  1. 环境变量（含 .env 加载结果），键名为路径大写并以下划线连接，如 log.level -> LOG_LEVEL
+//  2. 配置文件，按 [WithFile] / [WithOptionalFile] 声明顺序合并，后声明的覆盖先声明的
+//  3. [Config.Bind] 目标结构体中已有的字段值（视为默认值）
+//
+// 配置键统一转为小写，路径以 "." 分隔。支持 YAML（.yaml/.yml）、TOML（.toml）、JSON（.json）。
+type Config struct {
+	mu          sync.RWMutex
+	fileMu      sync.Mutex // 保护 files 中的文件状态，串行化读取
+	files       []fileSource
+	envPrefix   string
+	interval    time.Duration
+	data        map[string]any
+	subscribers []subscriber
+	validate    *validator.Validate
+	stopOnce    sync.Once
+	stopCh      chan struct{}
+	watchDone   chan struct{}
+}
+
+// Option 是 [Config] 的函数式选项。
+type Option func(*Config)
+
+// WithFile 追加一个必需的配置文件，文件不存在时 [Config.Load] 返回错误。
+func WithFile(path string) Option {
+	return func(c *Config) {
+		c.files = append(c.files, fileSource{path: path})
+	}
+}
+
+// WithOptionalFile 追加一个可选的配置文件，文件不存在时静默跳过。
+//
+// 适用于 config.local.yaml 等按环境覆盖的文件；文件被创建后热重载同样会生效。
+func WithOptionalFile(path string) Option {
+	return func(c *Config) {
+		c.files = append(c.files, fileSource{path: path, optional: true})
+	}
+}
+
+// WithEnvPrefix 设置环境变量前缀，如 "APP_" 时 log.level 对应 APP_LOG_LEVEL。
+//
+// 默认无前缀，与 xEnv 中的键名（LOG_LEVEL、XLF_PORT 等）保持一致。
+func WithEnvPrefix(prefix string) Option {
+	return func(c *Config) {
+		c.envPrefix = strings.ToUpper(prefix)
+	}
+}
+
+// WithWatchInterval 设置文件变更轮询间隔，默认 2s；小于等于 0 时禁用监听。
+func WithWatchInterval(interval time.Duration) Option {
+	return func(c *Config) {
+		c.interval = interval
+	}
+}
+
+// New 创建配置中心实例，需调用 [Config.Load] 后使用。
+func New(opts ...Option) *Config {
+	c := &Config{
+		interval: 2 * time.Second,
+		data:     make(map[string]any),
+		stopCh:   make(chan struct{}),
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(c)
+		}
+	}
+	return c
+}
+
+// Load 读取并合并全部配置文件。
+//
+// 任一必需文件不存在或解析失败时返回错误，已加载的数据保持不变。
+func (c *Config) Load() error {
+	data, err := c.readAll()
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	c.data = data
+	c.mu.Unlock()
+	return nil
+}
+
+// Get 返回指定路径的原始值，环境变量优先；不存在时返回 nil。
+func (c *Config) Get(key string) any {
+	key = normalizeKey(key)
+	if value, ok := c.lookupEnv(key); ok {
+		return value
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return lookup(c.data, key)
+}
+
+// GetString 返回字符串配置，不存在时返回 defaultValue。
+func (c *Config) GetString(key string, defaultValue string) string {
+	var value string
+	if c.decodeKey(key, &value) {
+		return value
+	}
+	return defaultValue
+}
+
+// GetInt 返回整数配置，不存在或格式错误时返回 defaultValue。
+func (c *Config) GetInt(key string, defaultValue int) int {
+	var value int
+	if c.decodeKey(key, &value) {
+		return value
+	}
+	return defaultValue
+}
+
+// GetBool 返回布尔配置，不存在或格式错误时返回 defaultValue。
+func (c *Config) GetBool(key string, defaultValue bool) bool {
+	var value bool
+	if c.decodeKey(key, &value) {
+		return value
+	}
+	return defaultValue
+}
+
+// GetDuration 返回时长配置（支持 "30s" 字符串或纳秒整数），不存在或格式错误时返回 defaultValue。
+func (c *Config) GetDuration(key string, defaultValue time.Duration) time.Duration {
+	var value time.Duration
+	if c.decodeKey(key, &value) {
+		return value
+	}
+	return defaultValue
+}
+
+// GetStringSlice 返回字符串列表配置（支持数组或逗号分隔字符串），不存在时返回 defaultValue。
+func (c *Config) GetStringSlice(key string, defaultValue []string) []string {
+	var value []string
+	if c.decodeKey(key, &value) {
+		return value
+	}
+	return defaultValue
+}
+
+// decodeKey 将指定路径的配置解码到 target，成功返回 true。
+func (c *Config) decodeKey(key string, target any) bool {
+	raw := c.Get(key)
+	if raw == nil {
+		return false
+	}
+	return decodeInto(raw, target) == nil
+}
+
+// lookupEnv 按路径读取环境变量。
+func (c *Config) lookupEnv(key string) (string, bool) {
+	if key == "" {
+		return "", false
+	}
+	return os.LookupEnv(c.envName(key))
+}
+
+// envName 将配置路径转换为环境变量名。
+func (c *Config) envName(key string) string {
+	return c.envPrefix + strings.ToUpper(strings.NewReplacer(".", "_", "-", "_").Replace(key))
+}
+
+// readAll 按声明顺序读取并合并全部配置文件。
+func (c *Config) readAll() (map[string]any, error) {
+	c.fileMu.Lock()
+	defer c.fileMu.Unlock()
+	merged := make(map[string]any)
+	for i := range c.files {
+		data, err := c.files[i].read()
+		if err != nil {
+			return nil, err
+		}
+		mergeMap(merged, data)
+	}
+	return merged, nil
+}
+
+// String 返回配置中心的简要描述，便于日志输出。
+func (c *Config) String() string {
+	paths := make([]string, 0, len(c.files))
+	for _, file := range c.files {
+		paths = append(paths, file.path)
+	}
+	return fmt.Sprintf("xConfig(files=%v, envPrefix=%q)", paths, c.envPrefix)
+}
+
+// Stop 停止文件监听，实现 xRegNode.Stopper，可重复调用。
+func (c *Config) Stop(_ context.Context) error {
+	c.stopOnce.Do(func() {
+		close(c.stopCh)
+	})
+	c.mu.Lock()
+	done := c.watchDone
+	c.mu.Unlock()
+	if done != nil {
+		<-done
+	}
+	return nil
+}
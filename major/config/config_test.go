@@ -0,0 +1,156 @@
+package xConfig
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testLogConfig struct {
+	Level    string `validate:"required,oneof=debug info warn error"`
+	MaxSize  int    `config:"max_size"`
+	MaxAge   time.Duration
+	Compress bool
+}
+
+type testAppConfig struct {
+	Name string `validate:"required"`
+	Log  testLogConfig
+	CORS struct {
+		AllowOrigins []string `config:"allow_origins"`
+	} `config:"cors"`
+}
+
+// writeFile 在临时目录写入配置文件并返回路径。
+func writeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("写入配置文件失败: %v", err)
+	}
+	return path
+}
+
+// TestConfigPrecedence 验证文件合并顺序与环境变量优先级。
+func TestConfigPrecedence(t *testing.T) {
+	dir := t.TempDir()
+	base := writeFile(t, dir, "config.yaml", `
+name: demo
+log:
+  level: info
+  max_size: 10
+  max_age: 24h
+cors:
+  allow_origins: [https://a.example.com]
+`)
+	override := writeFile(t, dir, "config.local.toml", `
+[log]
+level = "warn"
+`)
+	t.Setenv("LOG_MAX_SIZE", "20")
+	t.Setenv("LOG_COMPRESS", "true")
+
+	cfg := New(WithFile(base), WithFile(override), WithOptionalFile(filepath.Join(dir, "missing.json")))
+	if err := cfg.Load(); err != nil {
+		t.Fatalf("加载配置失败: %v", err)
+	}
+
+	var app testAppConfig
+	app.Log.MaxAge = time.Hour
+	if err := cfg.Bind("", &app); err != nil {
+		t.Fatalf("绑定配置失败: %v", err)
+	}
+	if app.Name != "demo" || app.Log.Level != "warn" {
+		t.Fatalf("文件合并结果不正确: %+v", app)
+	}
+	if app.Log.MaxSize != 20 || !app.Log.Compress {
+		t.Fatalf("环境变量未覆盖文件值: %+v", app.Log)
+	}
+	if app.Log.MaxAge != 24*time.Hour {
+		t.Fatalf("时长解析不正确: %v", app.Log.MaxAge)
+	}
+	if len(app.CORS.AllowOrigins) != 1 || app.CORS.AllowOrigins[0] != "https://a.example.com" {
+		t.Fatalf("列表解析不正确: %v", app.CORS.AllowOrigins)
+	}
+	if cfg.GetInt("log.max_size", 0) != 20 || cfg.GetString("log.level", "") != "warn" {
+		t.Fatal("Get 系列方法结果与 Bind 不一致")
+	}
+}
+
+// TestConfigBindValidation 验证绑定后执行 validate 校验。
+func TestConfigBindValidation(t *testing.T) {
+	dir := t.TempDir()
+	path := writeFile(t, dir, "config.json", `{"log": {"level": "verbose"}}`)
+	cfg := New(WithFile(path))
+	if err := cfg.Load(); err != nil {
+		t.Fatalf("加载配置失败: %v", err)
+	}
+
+	var logCfg testLogConfig
+	err := cfg.Bind("log", &logCfg)
+	if err == nil || !strings.Contains(err.Error(), "配置校验失败") {
+		t.Fatalf("期望校验失败, 实际: %v", err)
+	}
+
+	if err := New(WithFile(filepath.Join(dir, "absent.yaml"))).Load(); err == nil {
+		t.Fatal("必需文件缺失时应返回错误")
+	}
+}
+
+// TestConfigWatch 验证文件变更后通知对应路径的订阅者。
+func TestConfigWatch(t *testing.T) {
+	dir := t.TempDir()
+	path := writeFile(t, dir, "config.yaml", "log:\n  level: info\nname: demo\n")
+	cfg := New(WithFile(path), WithWatchInterval(10*time.Millisecond))
+	if err := cfg.Load(); err != nil {
+		t.Fatalf("加载配置失败: %v", err)
+	}
+
+	changed := make(chan string, 4)
+	cfg.Subscribe("log.level", func(ctx context.Context, c *Config) {
+		changed <- c.GetString("log.level", "")
+	})
+	cfg.Subscribe("name", func(ctx context.Context, c *Config) {
+		changed <- "name"
+	})
+	cfg.Watch(context.Background())
+	defer func() { _ = cfg.Stop(context.Background()) }()
+
+	writeFile(t, dir, "config.yaml", "log:\n  level: debug\nname: demo\n")
+	select {
+	case level := <-changed:
+		if level != "debug" {
+			t.Fatalf("订阅回调拿到的值不正确: %s", level)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("未收到配置变更通知")
+	}
+	select {
+	case other := <-changed:
+		t.Fatalf("未变化的路径不应收到通知: %s", other)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+// TestConfigStopDuringWatch 验证 Watch 与 Stop 并发调用时不产生数据竞争，且 Stop 等待监听协程退出。
+func TestConfigStopDuringWatch(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "config.yaml", "name: demo\n")
+	cfg := New(WithFile(path), WithWatchInterval(10*time.Millisecond))
+	if err := cfg.Load(); err != nil {
+		t.Fatalf("加载配置失败: %v", err)
+	}
+
+	started := make(chan struct{})
+	go func() {
+		cfg.Watch(context.Background())
+		close(started)
+	}()
+	_ = cfg.Stop(context.Background())
+	<-started
+	if err := cfg.Stop(context.Background()); err != nil {
+		t.Fatalf("重复 Stop 失败: %v", err)
+	}
+}
@@ -0,0 +1,145 @@
+package xConfig
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-yaml"
+	"github.com/pelletier/go-toml/v2"
+)
+
+// fileSource 描述一个配置文件来源及其最近一次读取时的状态。
+type fileSource struct {
+	path     string
+	optional bool
+	modTime  time.Time
+	size     int64
+	exists   bool
+}
+
+// read 读取并解析配置文件，返回键已小写化的嵌套 map。
+func (f *fileSource) read() (map[string]any, error) {
+	info, err := os.Stat(f.path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) && f.optional {
+			f.exists = false
+			return map[string]any{}, nil
+		}
+		return nil, fmt.Errorf("读取配置文件失败: %s: %w", f.path, err)
+	}
+	content, err := os.ReadFile(f.path)
+	if err != nil {
+		return nil, fmt.Errorf("读取配置文件失败: %s: %w", f.path, err)
+	}
+	data, err := parse(f.path, content)
+	if err != nil {
+		return nil, fmt.Errorf("解析配置文件失败: %s: %w", f.path, err)
+	}
+	f.exists, f.modTime, f.size = true, info.ModTime(), info.Size()
+	return data, nil
+}
+
+// changed 判断文件自上次读取后是否发生变化（修改时间、大小或存在性）。
+func (f *fileSource) changed() bool {
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return f.exists
+	}
+	return !f.exists || !info.ModTime().Equal(f.modTime) || info.Size() != f.size
+}
+
+// parse 按扩展名选择解析器。
+func parse(path string, content []byte) (map[string]any, error) {
+	raw := make(map[string]any)
+	var err error
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(content, &raw)
+	case ".toml":
+		err = toml.Unmarshal(content, &raw)
+	case ".json":
+		err = json.Unmarshal(content, &raw)
+	default:
+		return nil, fmt.Errorf("不支持的配置文件格式: %s", filepath.Ext(path))
+	}
+	if err != nil {
+		return nil, err
+	}
+	normalized, _ := normalizeValue(raw).(map[string]any)
+	if normalized == nil {
+		normalized = make(map[string]any)
+	}
+	return normalized, nil
+}
+
+// normalizeValue 递归将 map 键转为小写字符串，统一不同解析器的 map 类型。
+func normalizeValue(value any) any {
+	switch typed := value.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(typed))
+		for key, item := range typed {
+			out[strings.ToLower(key)] = normalizeValue(item)
+		}
+		return out
+	case map[any]any:
+		out := make(map[string]any, len(typed))
+		for key, item := range typed {
+			out[strings.ToLower(fmt.Sprint(key))] = normalizeValue(item)
+		}
+		return out
+	case []any:
+		out := make([]any, len(typed))
+		for i, item := range typed {
+			out[i] = normalizeValue(item)
+		}
+		return out
+	default:
+		return value
+	}
+}
+
+// mergeMap 将 src 深度合并到 dst，同名标量与数组由 src 覆盖。
+func mergeMap(dst, src map[string]any) {
+	for key, value := range src {
+		if srcMap, ok := value.(map[string]any); ok {
+			if dstMap, ok := dst[key].(map[string]any); ok {
+				mergeMap(dstMap, srcMap)
+				continue
+			}
+			copied := make(map[string]any, len(srcMap))
+			mergeMap(copied, srcMap)
+			dst[key] = copied
+			continue
+		}
+		dst[key] = value
+	}
+}
+
+// normalizeKey 规范化配置路径：去除首尾空白与 "."，并转为小写。
+func normalizeKey(key string) string {
+	return strings.ToLower(strings.Trim(strings.TrimSpace(key), "."))
+}
+
+// lookup 按 "." 分隔的路径在嵌套 map 中查找值，空路径返回整个 map。
+func lookup(data map[string]any, key string) any {
+	if key == "" {
+		return data
+	}
+	var current any = data
+	for _, part := range strings.Split(key, ".") {
+		m, ok := current.(map[string]any)
+		if !ok {
+			return nil
+		}
+		if current, ok = m[part]; !ok {
+			return nil
+		}
+	}
+	return current
+}
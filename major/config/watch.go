@@ -0,0 +1,113 @@
+package xConfig
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+	"time"
+
+	xLog "github.com/bamboo-services/bamboo-base-go/common/log"
+)
+
+// SubscribeFunc 是配置变更回调，c 为已完成重载的配置中心。
+type SubscribeFunc func(ctx context.Context, c *Config)
+
+// subscriber 记录一个订阅路径与回调。
+type subscriber struct {
+	key string
+	fn  SubscribeFunc
+}
+
+// Subscribe 订阅指定路径的配置变更，空路径表示订阅任意变更。
+//
+// 仅当重载后该路径下的值发生变化时才会回调；回调在监听协程中串行执行，
+// 应避免长时间阻塞。典型用法是在回调中重新 [Config.Bind] 并原子替换组件配置，
+// 如日志级别、CORS 白名单等。
+func (c *Config) Subscribe(key string, fn SubscribeFunc) {
+	if fn == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.subscribers = append(c.subscribers, subscriber{key: normalizeKey(key), fn: fn})
+}
+
+// Watch 启动配置文件监听协程，按 [WithWatchInterval] 轮询文件修改时间与大小。
+//
+// 检测到变化后重新读取全部文件；解析失败时保留旧配置并记录错误日志。
+// 监听在 ctx 结束或调用 [Config.Stop] 后退出。重复调用仅第一次生效。
+func (c *Config) Watch(ctx context.Context) {
+	c.mu.Lock()
+	if c.watchDone != nil || c.interval <= 0 || len(c.files) == 0 {
+		c.mu.Unlock()
+		return
+	}
+	done := make(chan struct{})
+	c.watchDone = done
+	c.mu.Unlock()
+
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(c.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-c.stopCh:
+				return
+			case <-ticker.C:
+				if c.filesChanged() {
+					_ = c.Reload(ctx)
+				}
+			}
+		}
+	}()
+}
+
+// Reload 立即重新读取全部配置文件，并通知值发生变化的订阅者。
+func (c *Config) Reload(ctx context.Context) error {
+	log := xLog.WithName(xLog.NamedCORE, "CONFIG")
+	data, err := c.readAll()
+	if err != nil {
+		log.Error(ctx, "配置重载失败，继续使用旧配置", slog.String("error", err.Error()))
+		return err
+	}
+
+	c.mu.Lock()
+	old := c.data
+	c.data = data
+	subscribers := append([]subscriber(nil), c.subscribers...)
+	c.mu.Unlock()
+	log.Info(ctx, "配置已重载", slog.String("source", c.String()))
+
+	for _, sub := range subscribers {
+		if reflect.DeepEqual(lookup(old, sub.key), lookup(data, sub.key)) {
+			continue
+		}
+		c.notify(ctx, log, sub)
+	}
+	return nil
+}
+
+// notify 执行单个订阅回调，回调 panic 不会中断监听协程。
+func (c *Config) notify(ctx context.Context, log *xLog.LogNamedLogger, sub subscriber) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.SugarError(ctx, "配置订阅回调 panic", "key", sub.key, "panic", r)
+		}
+	}()
+	sub.fn(ctx, c)
+}
+
+// filesChanged 判断任一配置文件是否发生变化。
+func (c *Config) filesChanged() bool {
+	c.fileMu.Lock()
+	defer c.fileMu.Unlock()
+	for i := range c.files {
+		if c.files[i].changed() {
+			return true
+		}
+	}
+	return false
+}
@@ -2,35 +2,84 @@ package xHelper
 
 import (
 	"context"
+	"log/slog"
+	"net/http"
 	"time"
 
+	xTrace "github.com/bamboo-services/bamboo-base-go/common/trace"
 	xConsts "github.com/bamboo-services/bamboo-base-go/defined/context"
 	"github.com/bamboo-services/bamboo-base-go/defined/http"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 )
 
-// RequestContext 是一个 Gin 中间件，用于为每个请求生成唯一 ID 和记录请求的开始时间。
+// RequestContext 是一个 Gin 中间件，用于为每个请求生成唯一 ID、创建链路 span 和记录请求的开始时间。
 //
 // - 请求唯一 ID 会通过 UUID 生成，并存储在响应头字段 `X-Request-UUID`，用于请求溯源。
+// - 链路遵循 W3C Trace Context：请求头携带有效 `traceparent` 时沿用其 trace id 作为上游 span 的子 span，
+// 否则创建新的根 span；当前 span 通过响应头 `traceparent`/`tracestate` 返回。
+// 该 span 记录请求方法、路由、状态码与耗时，5xx 或 c.Error 记录的错误会将其标记为失败，
+// 请求结束后交由 xTrace 的全局导出器导出。
 // - 请求的开始时间会被存储到上下文中，以实现请求生命周期的时间追踪。
 //
 // 上下文中设置的关键值：
 // - `context_request_key`: 表示请求的唯一标识符。
+// - `context_trace`: 表示当前请求的链路上下文（xTrace.SpanContext）。
+// - `context_span`: 表示当前请求的 span（*xTrace.Span），业务可通过 xTrace.SpanFromContext 追加属性。
 // - `context_user_start_time`: 表示请求开始处理的时间。
 func RequestContext() gin.HandlerFunc {
 	return func(c *gin.Context) {
+		startTime := time.Now()
+
 		// 生成请求唯一 ID 「用于溯源」
 		requestID := uuid.NewString()
 		c.Writer.Header().Set(xHttp.HeaderRequestUUID.String(), requestID)
 
-		c.Set(xConsts.RequestKey.String(), requestID)        // 上下文请求记录
-		c.Set(xConsts.UserStartTimeKey.String(), time.Now()) // 请求开始时间记录
+		// 创建请求 span，沿用上游链路「用于跨服务关联」
+		route := c.FullPath()
+		if route == "" {
+			route = c.Request.URL.Path
+		}
+		parent, _ := xTrace.ExtractParent(
+			c.GetHeader(xHttp.HeaderTraceParent.String()),
+			c.GetHeader(xHttp.HeaderTraceState.String()),
+		)
+		ctx, span := xTrace.Start(c.Request.Context(), c.Request.Method+" "+route,
+			xTrace.WithKind(xTrace.KindServer),
+			xTrace.WithParent(parent),
+			xTrace.WithStartTime(startTime),
+			xTrace.WithAttrs(
+				slog.String("http.request.method", c.Request.Method),
+				slog.String("http.route", c.FullPath()),
+				slog.String("url.path", c.Request.URL.Path),
+				slog.String("client.address", c.ClientIP()),
+			),
+		)
+		defer endRequestSpan(c, span)
+		xTrace.Inject(span.Context(), c.Writer.Header().Set)
+
+		c.Set(xConsts.RequestKey.String(), requestID)       // 上下文请求记录
+		c.Set(xConsts.TraceKey.String(), span.Context())    // 上下文链路记录
+		c.Set(xConsts.SpanKey.String(), span)               // 上下文 span 记录
+		c.Set(xConsts.UserStartTimeKey.String(), startTime) // 请求开始时间记录
 
-		// 将 RequestID 注入到标准 context 中（供 slog 使用）
-		ctx := context.WithValue(c.Request.Context(), xConsts.RequestKey, requestID)
+		// 将 RequestID 与链路上下文注入到标准 context 中（供 slog 使用）
+		ctx = context.WithValue(ctx, xConsts.RequestKey, requestID)
 		c.Request = c.Request.WithContext(ctx)
 
 		c.Next()
 	}
 }
+
+// endRequestSpan 记录响应状态并结束请求 span。
+func endRequestSpan(c *gin.Context, span *xTrace.Span) {
+	status := c.Writer.Status()
+	span.SetAttrs(slog.Int("http.response.status_code", status))
+	if err := c.Errors.Last(); err != nil {
+		span.SetError(err)
+	}
+	if status >= http.StatusInternalServerError {
+		span.SetStatus(xTrace.StatusError, http.StatusText(status))
+	}
+	span.End()
+}
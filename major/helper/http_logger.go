@@ -2,9 +2,9 @@ package xHelper
 
 import (
 	"bytes"
-	"encoding/json"
 	"io"
 	"log/slog"
+	"net/url"
 	"strings"
 	"time"
 
@@ -20,7 +20,7 @@ import (
 //
 // 请求开始日志记录：
 //   - 基础信息：method, path, client_ip
-//   - 调试模式额外信息：query, headers, body
+//   - 调试模式额外信息：query, headers, body（均按 xLog 的全局脱敏规则处理）
 //
 // 请求完成日志记录：
 //   - 响应状态码、耗时等结果信息
@@ -64,7 +64,7 @@ func HttpLogger() gin.HandlerFunc {
 		if xCtxUtil.IsDebugMode() {
 			// 添加查询参数
 			if c.Request.URL.RawQuery != "" {
-				args = append(args, slog.String("query", c.Request.URL.RawQuery))
+				args = append(args, slog.String("query", sanitizeQuery(c.Request.URL.RawQuery)))
 			}
 
 			// 添加请求头（脱敏）
@@ -117,38 +117,6 @@ func HttpLogger() gin.HandlerFunc {
 	}
 }
 
-// maskSensitive 对敏感值进行脱敏处理。
-//
-// 该函数对敏感值进行脱敏处理，保留左/右各最多3个字符，中间用...替换。
-// 短值（≤6字符）全部替换为 ******。
-//
-// 参数说明:
-//   - value: 原始敏感值
-//
-// 返回值:
-//   - 脱敏后的值
-//
-// 脱敏示例:
-//   - "Bearer eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..." → "Bea...J9..."
-//   - "mySecretToken123" → "myS...123"
-//   - "abc" → "******"（≤6字符全部隐藏）
-func maskSensitive(value string) string {
-	if len(value) <= 6 {
-		return "******"
-	}
-
-	leftLen := 3
-	rightLen := 3
-	if len(value) < leftLen+rightLen {
-		leftLen = len(value) / 2
-		rightLen = len(value) - leftLen
-	}
-
-	left := value[:leftLen]
-	right := value[len(value)-rightLen:]
-	return left + "..." + right
-}
-
 // shouldLogBody 判断是否应该记录请求体。
 //
 // 该函数根据请求方法和内容类型判断是否应该记录请求体。
@@ -265,8 +233,8 @@ func readRequestBody(c *gin.Context) string {
 
 // sanitizeBody 脱敏处理请求体。
 //
-// 该函数根据 Content-Type 选择脱敏方式对请求体进行脱敏处理。
-// 目前仅支持 JSON 格式的脱敏。
+// 该函数根据 Content-Type 选择脱敏方式，按 xLog 的全局脱敏规则（见 [xLog.SetRedactor]）处理请求体。
+// 支持 JSON 与 application/x-www-form-urlencoded 格式。
 //
 // 参数说明:
 //   - body: 原始请求体内容
@@ -287,9 +255,11 @@ func sanitizeBody(body string, header map[string][]string) string {
 		}
 	}
 
-	// JSON 格式脱敏
-	if strings.Contains(contentType, "application/json") {
+	switch {
+	case strings.Contains(contentType, "application/json"):
 		return sanitizeJSONBody(body)
+	case strings.Contains(contentType, "application/x-www-form-urlencoded"):
+		return sanitizeQuery(body)
 	}
 
 	// 其他格式暂不处理
@@ -298,7 +268,7 @@ func sanitizeBody(body string, header map[string][]string) string {
 
 // sanitizeJSONBody 脱敏 JSON 请求体中的敏感字段。
 //
-// 该函数解析 JSON 请求体，对敏感字段值进行脱敏处理，然后重新序列化。
+// 该函数解析 JSON 请求体，按字段名匹配脱敏规则（含嵌套对象与数组），然后重新序列化。
 //
 // 参数说明:
 //   - body: 原始 JSON 请求体内容
@@ -306,52 +276,48 @@ func sanitizeBody(body string, header map[string][]string) string {
 // 返回值:
 //   - 脱敏后的 JSON 请求体内容，如果解析失败则返回原始内容
 func sanitizeJSONBody(body string) string {
-	// 敏感字段列表（小写）
-	sensitiveFields := map[string]bool{
-		"password":         true,
-		"passwd":           true,
-		"token":            true,
-		"secret":           true,
-		"apikey":           true,
-		"api_key":          true,
-		"x-api-key":        true,
-		"access_token":     true,
-		"refresh_token":    true,
-		"old_password":     true,
-		"new_password":     true,
-		"confirm_password": true,
+	sanitized, ok := xLog.DefaultRedactor().JSON([]byte(body))
+	if !ok {
+		return body
 	}
+	return string(sanitized)
+}
 
-	var data map[string]interface{}
-	if err := json.Unmarshal([]byte(body), &data); err != nil {
-		return body
+// sanitizeQuery 脱敏 URL 查询参数或表单请求体中的敏感字段。
+//
+// 参数说明:
+//   - raw: 原始 key=value&... 文本
+//
+// 返回值:
+//   - 脱敏后的文本，如果解析失败则返回原始内容
+func sanitizeQuery(raw string) string {
+	values, err := url.ParseQuery(raw)
+	if err != nil {
+		return raw
 	}
 
-	for key, value := range data {
-		lowerKey := strings.ToLower(key)
-		if sensitiveFields[lowerKey] {
-			if strValue, ok := value.(string); ok {
-				data[key] = maskSensitive(strValue)
+	redactor := xLog.DefaultRedactor()
+	for key, items := range values {
+		for i, item := range items {
+			masked, keep := redactor.String(key, item)
+			if !keep {
+				delete(values, key)
+				break
 			}
+			items[i] = masked
 		}
 	}
-
-	sanitized, err := json.Marshal(data)
-	if err != nil {
-		return body
-	}
-
-	return string(sanitized)
+	return values.Encode()
 }
 
 // sanitizeHeaders 脱敏处理请求头信息。
 //
-// 该函数用于在调试模式下记录请求头时，对敏感字段进行脱敏处理，
+// 该函数用于在调试模式下记录请求头时，按 xLog 的全局脱敏规则处理敏感字段，
 // 防止密码、token 等敏感信息泄露到日志中。
 //
-// 脱敏规则：
+// 默认规则：
 //   - 完全移除: Set-Cookie
-//   - 中间隐藏脱敏: Authorization, Cookie, Proxy-Authorization, X-API-Key, Access-Token
+//   - 中间隐藏脱敏: Authorization, Cookie, Proxy-Authorization, X-API-Key, Access-Token 等
 //
 // 参数说明:
 //   - headers: 原始的 http.Header 对象
@@ -359,38 +325,16 @@ func sanitizeJSONBody(body string) string {
 // 返回值:
 //   - 脱敏后的请求头 map
 func sanitizeHeaders(headers map[string][]string) map[string]string {
-	// 完全移除的黑名单
-	blacklist := map[string]bool{
-		"set-cookie": true,
-	}
-
-	// 需要脱敏的字段（中间隐藏）
-	sensitiveMask := map[string]bool{
-		"authorization":       true,
-		"cookie":              true,
-		"proxy-authorization": true,
-		"x-api-key":           true,
-		"access-token":        true,
-	}
-
+	redactor := xLog.DefaultRedactor()
 	sanitized := make(map[string]string)
 
 	for key, values := range headers {
-		lowerKey := strings.ToLower(key)
-
-		// 检查是否在黑名单中（完全移除）
-		if blacklist[lowerKey] {
+		// 保留第一个值
+		if len(values) == 0 {
 			continue
 		}
-
-		// 检查是否需要脱敏
-		if sensitiveMask[lowerKey] && len(values) > 0 {
-			sanitized[key] = maskSensitive(values[0])
-		} else {
-			// 保留第一个值
-			if len(values) > 0 {
-				sanitized[key] = values[0]
-			}
+		if value, keep := redactor.String(key, values[0]); keep {
+			sanitized[key] = value
 		}
 	}
 
@@ -0,0 +1,68 @@
+package xHelper
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	xMetrics "github.com/bamboo-services/bamboo-base-go/common/metrics"
+	"github.com/gin-gonic/gin"
+)
+
+// unmatchedRoute 未匹配到任何路由时使用的 route 标签值，避免以原始路径作为标签导致序列数量失控
+const unmatchedRoute = "unmatched"
+
+// otherMethod 非标准 HTTP 方法使用的 method 标签值，避免客户端以任意方法名制造新的序列
+const otherMethod = "other"
+
+// metricMethods 按原值作为 method 标签的标准 HTTP 方法
+var metricMethods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodPost:    true,
+	http.MethodPut:     true,
+	http.MethodPatch:   true,
+	http.MethodDelete:  true,
+	http.MethodConnect: true,
+	http.MethodOptions: true,
+	http.MethodTrace:   true,
+}
+
+// HttpMetrics 提供 HTTP 请求指标采集中间件。
+//
+// 该中间件向 xMetrics 默认注册表写入以下指标：
+//   - http_requests_total{method,route,status}: 请求总数
+//   - http_request_duration_seconds{method,route}: 请求耗时直方图
+//   - http_requests_in_flight: 正在处理的请求数
+//
+// route 标签取 Gin 路由模板（如 /user/:id），未匹配到路由时为 "unmatched"；
+// method 标签仅保留标准 HTTP 方法，其余方法统一为 "other"。
+//
+// 返回值:
+//   - 返回一个 `gin.HandlerFunc` 类型的函数，用于注册到 Gin 中间件链中。
+//
+// 注意: 需将此中间件放置在 PanicRecovery 之前，panic 在内层被恢复为 500 后才能回到这里计数。
+func HttpMetrics() gin.HandlerFunc {
+	requests := xMetrics.NewCounter("http_requests_total", "HTTP 请求总数", "method", "route", "status")
+	duration := xMetrics.NewHistogram("http_request_duration_seconds", "HTTP 请求耗时（秒）", nil, "method", "route")
+	inFlight := xMetrics.NewGauge("http_requests_in_flight", "正在处理的 HTTP 请求数").With()
+
+	return func(c *gin.Context) {
+		startTime := time.Now()
+		inFlight.Inc()
+		defer inFlight.Dec()
+
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+		method := c.Request.Method
+		if !metricMethods[method] {
+			method = otherMethod
+		}
+		requests.Inc(method, route, strconv.Itoa(c.Writer.Status()))
+		duration.Observe(time.Since(startTime).Seconds(), method, route)
+	}
+}
@@ -0,0 +1,178 @@
+package xHook
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"time"
+
+	xMetrics "github.com/bamboo-services/bamboo-base-go/common/metrics"
+	"github.com/redis/go-redis/v9"
+)
+
+// redisReadCommands 计入缓存命中指标的读命令
+var redisReadCommands = map[string]struct{}{
+	"get": {}, "getex": {}, "getdel": {}, "hget": {}, "mget": {}, "hmget": {},
+}
+
+// RedisMetricsHook 采集 Redis 命令指标的钩子。
+//
+// 向 xMetrics 默认注册表写入以下指标：
+//   - redis_commands_total{command,status}: 命令执行次数，status 为 ok / error
+//   - redis_command_duration_seconds{command}: 命令耗时直方图，管道记为 "pipeline"
+//   - cache_hits_total / cache_misses_total{backend="redis"}: 读命令（get/getex/getdel/hget/mget/hmget）的命中情况，
+//     mget/hmget 按返回的每个元素分别计数
+//
+// 淘汰数与连接池状态由 [ObserveRedisClient] 在抓取时从服务端与客户端读取。
+//
+// 使用示例:
+//
+//	client.AddHook(xHook.NewRedisMetricsHook())
+type RedisMetricsHook struct {
+	commands *xMetrics.Counter
+	duration *xMetrics.Histogram
+	hits     *xMetrics.CounterValue
+	misses   *xMetrics.CounterValue
+}
+
+// NewRedisMetricsHook 创建 Redis 指标钩子
+func NewRedisMetricsHook() *RedisMetricsHook {
+	return &RedisMetricsHook{
+		commands: xMetrics.NewCounter("redis_commands_total", "Redis 命令执行次数", "command", "status"),
+		duration: xMetrics.NewHistogram("redis_command_duration_seconds", "Redis 命令耗时（秒）", nil, "command"),
+		hits:     xMetrics.NewCounter("cache_hits_total", "缓存命中次数", "backend").With("redis"),
+		misses:   xMetrics.NewCounter("cache_misses_total", "缓存未命中次数", "backend").With("redis"),
+	}
+}
+
+// DialHook 是一个 Redis DialHook 的转发钩子方法，无额外处理逻辑，仅直接调用下一钩子。
+//
+// 参数说明:
+//   - next: 下一个 `redis.DialHook` 处理函数。
+//
+// 返回值:
+//   - `redis.DialHook`: 直接返回传入的 `next` 钩子函数，无额外逻辑。
+func (h *RedisMetricsHook) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+// ProcessHook 记录单条 Redis 命令的耗时、结果与命中情况。
+//
+// 参数说明:
+//   - next: 下一个 `redis.ProcessHook` 处理函数。
+//
+// 返回值:
+//   - `redis.ProcessHook`: 带有指标采集逻辑的钩子函数。
+func (h *RedisMetricsHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		start := time.Now()
+		err := next(ctx, cmd)
+		h.duration.Observe(time.Since(start).Seconds(), cmd.Name())
+		h.record(cmd)
+		return err
+	}
+}
+
+// ProcessPipelineHook 记录管道整体耗时，并逐条记录命令结果与命中情况。
+//
+// 参数说明:
+//   - next: 下一个 `redis.ProcessPipelineHook` 处理函数。
+//
+// 返回值:
+//   - `redis.ProcessPipelineHook`: 带有指标采集逻辑的管道钩子函数。
+func (h *RedisMetricsHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		start := time.Now()
+		err := next(ctx, cmds)
+		h.duration.Observe(time.Since(start).Seconds(), "pipeline")
+		for _, cmd := range cmds {
+			h.record(cmd)
+		}
+		return err
+	}
+}
+
+// record 记录命令结果，redis.Nil 表示键不存在，计为成功与未命中
+func (h *RedisMetricsHook) record(cmd redis.Cmder) {
+	err := cmd.Err()
+	status := "ok"
+	if err != nil && !errors.Is(err, redis.Nil) {
+		status = "error"
+	}
+	h.commands.Inc(cmd.Name(), status)
+
+	if _, ok := redisReadCommands[cmd.Name()]; !ok || status == "error" {
+		return
+	}
+	if slice, ok := cmd.(*redis.SliceCmd); ok {
+		for _, value := range slice.Val() {
+			if value == nil {
+				h.misses.Inc()
+			} else {
+				h.hits.Inc()
+			}
+		}
+		return
+	}
+	if errors.Is(err, redis.Nil) {
+		h.misses.Inc()
+	} else {
+		h.hits.Inc()
+	}
+}
+
+// redisScrapeTimeout 抓取时读取服务端统计信息的超时时间
+const redisScrapeTimeout = time.Second
+
+// ObserveRedisClient 注册 Redis 客户端的抓取回调，每次导出指标前刷新：
+//   - cache_evictions_total{backend="redis"}: 服务端 INFO stats 中 evicted_keys 的增量（服务端重启后从新值继续累加）
+//   - redis_pool_*{name}: 客户端连接池状态
+//
+// 读取 INFO 失败或超时（1 秒）时跳过本次淘汰数刷新，不影响其余指标导出。
+//
+// 参数说明:
+//   - client: 已挂载 [RedisMetricsHook] 的 Redis 客户端。
+//   - name: 实例名称，写入连接池指标的 name 标签，通常使用节点 Key。
+//
+// 返回值:
+//   - func(): 注销抓取回调，关闭客户端前调用，可安全多次调用。
+func ObserveRedisClient(client *redis.Client, name string) (unregister func()) {
+	evictions := xMetrics.NewCounter("cache_evictions_total", "因容量上限被淘汰的缓存条目数", "backend").With("redis")
+	total := xMetrics.NewGauge("redis_pool_total_connections", "Redis 连接池当前连接数", "name").With(name)
+	idle := xMetrics.NewGauge("redis_pool_idle_connections", "Redis 连接池空闲连接数", "name").With(name)
+	hits := xMetrics.NewGauge("redis_pool_hits", "Redis 连接池复用连接的累计次数", "name").With(name)
+	misses := xMetrics.NewGauge("redis_pool_misses", "Redis 连接池新建连接的累计次数", "name").With(name)
+	timeouts := xMetrics.NewGauge("redis_pool_timeouts", "Redis 连接池等待连接超时的累计次数", "name").With(name)
+
+	var (
+		mu          sync.Mutex
+		lastEvicted float64
+	)
+	return xMetrics.OnScrape(func() {
+		stats := client.PoolStats()
+		total.Set(float64(stats.TotalConns))
+		idle.Set(float64(stats.IdleConns))
+		hits.Set(float64(stats.Hits))
+		misses.Set(float64(stats.Misses))
+		timeouts.Set(float64(stats.Timeouts))
+
+		ctx, cancel := context.WithTimeout(context.Background(), redisScrapeTimeout)
+		defer cancel()
+		info, err := client.InfoMap(ctx, "stats").Result()
+		if err != nil {
+			return
+		}
+		evicted, err := strconv.ParseFloat(info["Stats"]["evicted_keys"], 64)
+		if err != nil {
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if evicted < lastEvicted {
+			lastEvicted = 0
+		}
+		evictions.Add(evicted - lastEvicted)
+		lastEvicted = evicted
+	})
+}
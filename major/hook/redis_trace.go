@@ -0,0 +1,89 @@
+package xHook
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	xTrace "github.com/bamboo-services/bamboo-base-go/common/trace"
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisTraceHook 为 Redis 命令创建链路 span 的钩子。
+//
+// 每条命令对应一个 client span（名称为 "redis <命令>"），管道对应一个 "redis pipeline" span；
+// span 为调用上下文中当前 span 的子 span，记录命令名、管道命令数与耗时，执行出错时标记为失败（redis.Nil 除外）。
+// 出于安全考虑不记录命令参数。
+//
+// 使用示例:
+//
+//	client.AddHook(xHook.RedisTraceHook{})
+type RedisTraceHook struct{}
+
+// DialHook 是一个 Redis DialHook 的转发钩子方法，无额外处理逻辑，仅直接调用下一钩子。
+//
+// 参数说明:
+//   - next: 下一个 `redis.DialHook` 处理函数。
+//
+// 返回值:
+//   - `redis.DialHook`: 直接返回传入的 `next` 钩子函数，无额外逻辑。
+func (RedisTraceHook) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+// ProcessHook 为单条 Redis 命令创建 span。
+//
+// 参数说明:
+//   - next: 下一个 `redis.ProcessHook` 处理函数。
+//
+// 返回值:
+//   - `redis.ProcessHook`: 带有链路追踪逻辑的钩子函数。
+func (RedisTraceHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		ctx, span := xTrace.Start(ctx, "redis "+cmd.Name(),
+			xTrace.WithKind(xTrace.KindClient),
+			xTrace.WithAttrs(
+				slog.String("db.system", "redis"),
+				slog.String("db.operation", cmd.Name()),
+			),
+		)
+		err := next(ctx, cmd)
+		endRedisSpan(span, err)
+		return err
+	}
+}
+
+// ProcessPipelineHook 为 Redis 管道请求创建 span。
+//
+// 参数说明:
+//   - next: 下一个 `redis.ProcessPipelineHook` 处理函数。
+//
+// 返回值:
+//   - `redis.ProcessPipelineHook`: 带有链路追踪逻辑的管道钩子函数。
+func (RedisTraceHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		names := make([]string, 0, len(cmds))
+		for _, cmd := range cmds {
+			names = append(names, cmd.Name())
+		}
+		ctx, span := xTrace.Start(ctx, "redis pipeline",
+			xTrace.WithKind(xTrace.KindClient),
+			xTrace.WithAttrs(
+				slog.String("db.system", "redis"),
+				slog.String("db.operation", strings.Join(names, " ")),
+				slog.Int("db.redis.pipeline_length", len(cmds)),
+			),
+		)
+		err := next(ctx, cmds)
+		endRedisSpan(span, err)
+		return err
+	}
+}
+
+// endRedisSpan 记录执行结果并结束 span，redis.Nil 表示键不存在，不视为失败。
+func endRedisSpan(span *xTrace.Span, err error) {
+	if err != nil && err != redis.Nil {
+		span.SetError(err)
+	}
+	span.End()
+}
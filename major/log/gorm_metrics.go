@@ -0,0 +1,157 @@
+package log
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	xMetrics "github.com/bamboo-services/bamboo-base-go/common/metrics"
+	"gorm.io/gorm"
+)
+
+// gormStartKey 在 gorm.Statement 中保存执行开始时间的键
+const gormStartKey = "xmetrics:start"
+
+// gormMetricsPluginName 指标插件名称，即其在 gorm.Config.Plugins 中的键
+const gormMetricsPluginName = "xmetrics"
+
+// GormMetricsPlugin GORM 指标插件
+// 实现 gorm.Plugin 接口，向 xMetrics 默认注册表写入 SQL 耗时与连接池状态
+//
+// 采集的指标：
+//   - gorm_query_duration_seconds{system,operation,table}: SQL 执行耗时直方图
+//   - gorm_query_errors_total{system,operation,table}: SQL 执行失败次数（ErrRecordNotFound 除外）
+//   - gorm_pool_*{system,name}: 底层 *sql.DB 连接池状态，在每次抓取时刷新；name 区分同一方言的多个实例
+//
+// 关闭数据库前应调用 [GormMetricsPlugin.Close] 注销抓取回调，否则已关闭的连接池会一直被读取。
+type GormMetricsPlugin struct {
+	name     string
+	duration *xMetrics.Histogram
+	errors   *xMetrics.Counter
+
+	mu         sync.Mutex
+	unregister func()
+}
+
+// NewGormMetricsPlugin 创建 GORM 指标插件
+//
+// 参数说明:
+//   - name: 实例名称，写入连接池指标的 name 标签，通常使用节点 Key
+//
+// 使用示例:
+//
+//	if err := db.Use(xGormLog.NewGormMetricsPlugin("orders")); err != nil {
+//	    return err
+//	}
+func NewGormMetricsPlugin(name string) gorm.Plugin {
+	return &GormMetricsPlugin{
+		name:     name,
+		duration: xMetrics.NewHistogram("gorm_query_duration_seconds", "SQL 执行耗时（秒）", nil, "system", "operation", "table"),
+		errors:   xMetrics.NewCounter("gorm_query_errors_total", "SQL 执行失败次数", "system", "operation", "table"),
+	}
+}
+
+// Name 返回插件名称
+func (p *GormMetricsPlugin) Name() string {
+	return gormMetricsPluginName
+}
+
+// Initialize 为各类操作注册前后回调，并注册连接池状态的抓取回调
+//
+// 参数说明:
+//   - db: GORM 实例
+//
+// 返回值:
+//   - error: 注册回调失败时返回错误
+func (p *GormMetricsPlugin) Initialize(db *gorm.DB) error {
+	callback := db.Callback()
+	err := errors.Join(
+		callback.Create().Before("gorm:create").Register("xmetrics:before_create", beforeMetrics),
+		callback.Create().After("gorm:create").Register("xmetrics:after_create", p.afterMetrics("create")),
+		callback.Query().Before("gorm:query").Register("xmetrics:before_query", beforeMetrics),
+		callback.Query().After("gorm:query").Register("xmetrics:after_query", p.afterMetrics("query")),
+		callback.Update().Before("gorm:update").Register("xmetrics:before_update", beforeMetrics),
+		callback.Update().After("gorm:update").Register("xmetrics:after_update", p.afterMetrics("update")),
+		callback.Delete().Before("gorm:delete").Register("xmetrics:before_delete", beforeMetrics),
+		callback.Delete().After("gorm:delete").Register("xmetrics:after_delete", p.afterMetrics("delete")),
+		callback.Row().Before("gorm:row").Register("xmetrics:before_row", beforeMetrics),
+		callback.Row().After("gorm:row").Register("xmetrics:after_row", p.afterMetrics("row")),
+		callback.Raw().Before("gorm:raw").Register("xmetrics:before_raw", beforeMetrics),
+		callback.Raw().After("gorm:raw").Register("xmetrics:after_raw", p.afterMetrics("raw")),
+	)
+	if err != nil {
+		return err
+	}
+	unregister := p.registerPoolMetrics(db)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.unregister = unregister
+	return nil
+}
+
+// Close 注销连接池状态的抓取回调，可安全多次调用
+func (p *GormMetricsPlugin) Close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.unregister != nil {
+		p.unregister()
+		p.unregister = nil
+	}
+}
+
+// CloseGormMetrics 注销 db 上已注册的 [GormMetricsPlugin] 抓取回调，未注册该插件时不做任何操作
+func CloseGormMetrics(db *gorm.DB) {
+	if plugin, ok := db.Config.Plugins[gormMetricsPluginName].(*GormMetricsPlugin); ok {
+		plugin.Close()
+	}
+}
+
+// beforeMetrics 记录执行开始时间
+func beforeMetrics(db *gorm.DB) {
+	db.InstanceSet(gormStartKey, time.Now())
+}
+
+// afterMetrics 记录执行耗时与失败次数
+func (p *GormMetricsPlugin) afterMetrics(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		value, ok := db.InstanceGet(gormStartKey)
+		if !ok {
+			return
+		}
+		start, ok := value.(time.Time)
+		if !ok {
+			return
+		}
+		system := db.Dialector.Name()
+		table := db.Statement.Table
+		p.duration.Observe(time.Since(start).Seconds(), system, operation, table)
+		if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+			p.errors.Inc(system, operation, table)
+		}
+	}
+}
+
+// registerPoolMetrics 注册连接池状态仪表盘，在每次抓取时从 *sql.DB 读取最新值，返回注销函数
+func (p *GormMetricsPlugin) registerPoolMetrics(db *gorm.DB) func() {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil
+	}
+	system := db.Dialector.Name()
+	open := xMetrics.NewGauge("gorm_pool_open_connections", "连接池当前连接数", "system", "name").With(system, p.name)
+	inUse := xMetrics.NewGauge("gorm_pool_in_use_connections", "连接池使用中的连接数", "system", "name").With(system, p.name)
+	idle := xMetrics.NewGauge("gorm_pool_idle_connections", "连接池空闲连接数", "system", "name").With(system, p.name)
+	maxOpen := xMetrics.NewGauge("gorm_pool_max_open_connections", "连接池最大连接数，0 表示不限制", "system", "name").With(system, p.name)
+	waitCount := xMetrics.NewGauge("gorm_pool_wait_count", "等待获取连接的累计次数", "system", "name").With(system, p.name)
+	waitSeconds := xMetrics.NewGauge("gorm_pool_wait_seconds", "等待获取连接的累计耗时（秒）", "system", "name").With(system, p.name)
+
+	return xMetrics.OnScrape(func() {
+		stats := sqlDB.Stats()
+		open.Set(float64(stats.OpenConnections))
+		inUse.Set(float64(stats.InUse))
+		idle.Set(float64(stats.Idle))
+		maxOpen.Set(float64(stats.MaxOpenConnections))
+		waitCount.Set(float64(stats.WaitCount))
+		waitSeconds.Set(stats.WaitDuration.Seconds())
+	})
+}
@@ -0,0 +1,60 @@
+package log
+
+import (
+	"strings"
+	"testing"
+
+	xMetrics "github.com/bamboo-services/bamboo-base-go/common/metrics"
+	"github.com/libtnb/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// TestGormMetricsPlugin 验证 SQL 耗时、失败次数与连接池指标写入默认注册表
+func TestGormMetricsPlugin(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("打开数据库失败: %v", err)
+	}
+	if err := db.Use(NewGormMetricsPlugin("main")); err != nil {
+		t.Fatalf("注册插件失败: %v", err)
+	}
+
+	type metricUser struct {
+		ID   int64
+		Name string
+	}
+	if err := db.AutoMigrate(&metricUser{}); err != nil {
+		t.Fatalf("建表失败: %v", err)
+	}
+	if err := db.Create(&metricUser{ID: 1, Name: "bamboo"}).Error; err != nil {
+		t.Fatalf("插入失败: %v", err)
+	}
+	_ = db.Exec("SELECT * FROM missing").Error
+
+	var out strings.Builder
+	if err := xMetrics.Default().WriteText(&out); err != nil {
+		t.Fatalf("导出指标失败: %v", err)
+	}
+	text := out.String()
+	for _, want := range []string{
+		`gorm_query_duration_seconds_count{system="sqlite",operation="create",table="metric_users"} 1`,
+		`gorm_query_errors_total{system="sqlite",operation="raw",table=""} 1`,
+		`gorm_pool_open_connections{system="sqlite",name="main"}`,
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("缺少指标 %s:\n%s", want, text)
+		}
+	}
+
+	// 注销后抓取不再读取连接池，仪表盘保持注销前写入的值
+	CloseGormMetrics(db)
+	xMetrics.NewGauge("gorm_pool_open_connections", "", "system", "name").Set(-1, "sqlite", "main")
+	out.Reset()
+	if err := xMetrics.Default().WriteText(&out); err != nil {
+		t.Fatalf("导出指标失败: %v", err)
+	}
+	if want := `gorm_pool_open_connections{system="sqlite",name="main"} -1`; !strings.Contains(out.String(), want) {
+		t.Errorf("注销后抓取回调仍在刷新连接池指标:\n%s", out.String())
+	}
+}
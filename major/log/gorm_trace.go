@@ -0,0 +1,113 @@
+package log
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	xTrace "github.com/bamboo-services/bamboo-base-go/common/trace"
+	"gorm.io/gorm"
+)
+
+// gormSpanKey 在 gorm.Statement 中保存当前 span 的键
+const gormSpanKey = "xtrace:span"
+
+// gormSpan 当前 span 与执行前的 context，执行结束后恢复，避免链式复用的 Statement 嵌套 span
+type gormSpan struct {
+	span   *xTrace.Span
+	parent context.Context
+}
+
+// GormTracePlugin GORM 链路追踪插件
+// 实现 gorm.Plugin 接口，为每次 SQL 执行创建 client span
+//
+// span 名称为 "gorm.<操作>"（create/query/update/delete/row/raw），记录数据库类型、表名、
+// SQL 语句与受影响行数；执行出错时标记为失败（ErrRecordNotFound 除外）。
+// span 为 db.WithContext(ctx) 中当前 span 的子 span，SQL 日志因此与所属请求共享 trace id。
+type GormTracePlugin struct{}
+
+// NewGormTracePlugin 创建 GORM 链路追踪插件
+//
+// 使用示例:
+//
+//	if err := db.Use(xGormLog.NewGormTracePlugin()); err != nil {
+//	    return err
+//	}
+func NewGormTracePlugin() gorm.Plugin {
+	return &GormTracePlugin{}
+}
+
+// Name 返回插件名称
+func (p *GormTracePlugin) Name() string {
+	return "xtrace"
+}
+
+// Initialize 为各类操作注册前后回调
+//
+// 参数说明:
+//   - db: GORM 实例
+//
+// 返回值:
+//   - error: 注册回调失败时返回错误
+func (p *GormTracePlugin) Initialize(db *gorm.DB) error {
+	callback := db.Callback()
+	return errors.Join(
+		callback.Create().Before("gorm:create").Register("xtrace:before_create", beforeSpan("create")),
+		callback.Create().After("gorm:create").Register("xtrace:after_create", afterSpan),
+		callback.Query().Before("gorm:query").Register("xtrace:before_query", beforeSpan("query")),
+		callback.Query().After("gorm:query").Register("xtrace:after_query", afterSpan),
+		callback.Update().Before("gorm:update").Register("xtrace:before_update", beforeSpan("update")),
+		callback.Update().After("gorm:update").Register("xtrace:after_update", afterSpan),
+		callback.Delete().Before("gorm:delete").Register("xtrace:before_delete", beforeSpan("delete")),
+		callback.Delete().After("gorm:delete").Register("xtrace:after_delete", afterSpan),
+		callback.Row().Before("gorm:row").Register("xtrace:before_row", beforeSpan("row")),
+		callback.Row().After("gorm:row").Register("xtrace:after_row", afterSpan),
+		callback.Raw().Before("gorm:raw").Register("xtrace:before_raw", beforeSpan("raw")),
+		callback.Raw().After("gorm:raw").Register("xtrace:after_raw", afterSpan),
+	)
+}
+
+// beforeSpan 创建 span 并替换 Statement 的 context
+func beforeSpan(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		if db.Statement == nil || db.Statement.Context == nil {
+			return
+		}
+		ctx, span := xTrace.Start(db.Statement.Context, "gorm."+operation,
+			xTrace.WithKind(xTrace.KindClient),
+			xTrace.WithAttrs(
+				slog.String("db.system", db.Dialector.Name()),
+				slog.String("db.operation", operation),
+			),
+		)
+		db.InstanceSet(gormSpanKey, gormSpan{span: span, parent: db.Statement.Context})
+		db.Statement.Context = ctx
+	}
+}
+
+// afterSpan 记录 SQL 与执行结果并结束 span
+func afterSpan(db *gorm.DB) {
+	value, ok := db.InstanceGet(gormSpanKey)
+	if !ok {
+		return
+	}
+	current, ok := value.(gormSpan)
+	if !ok {
+		return
+	}
+	span := current.span
+	db.Statement.Context = current.parent
+
+	attrs := []slog.Attr{
+		slog.String("db.statement", db.Statement.SQL.String()),
+		slog.Int64("db.rows_affected", db.Statement.RowsAffected),
+	}
+	if db.Statement.Table != "" {
+		attrs = append(attrs, slog.String("db.sql.table", db.Statement.Table))
+	}
+	span.SetAttrs(attrs...)
+	if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+		span.SetError(db.Error)
+	}
+	span.End()
+}
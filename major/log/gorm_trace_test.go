@@ -0,0 +1,83 @@
+package log
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	xTrace "github.com/bamboo-services/bamboo-base-go/common/trace"
+	"github.com/libtnb/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// spanRecorder 记录导出的 span
+type spanRecorder struct {
+	mu    sync.Mutex
+	spans []xTrace.SpanData
+}
+
+func (r *spanRecorder) Export(_ context.Context, spans []xTrace.SpanData) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.spans = append(r.spans, spans...)
+	return nil
+}
+
+func (r *spanRecorder) Shutdown(_ context.Context) error { return nil }
+
+// TestGormTracePlugin 验证 SQL 执行创建请求 span 的子 span，记录语句与错误
+func TestGormTracePlugin(t *testing.T) {
+	recorder := &spanRecorder{}
+	xTrace.SetTracer(xTrace.NewTracer(xTrace.TracerConfig{Exporter: recorder}))
+	t.Cleanup(func() { _ = xTrace.Shutdown(context.Background()) })
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatalf("打开数据库失败: %v", err)
+	}
+	if err := db.Use(NewGormTracePlugin()); err != nil {
+		t.Fatalf("注册插件失败: %v", err)
+	}
+
+	type user struct {
+		ID   int64
+		Name string
+	}
+	ctx, parent := xTrace.Start(context.Background(), "request")
+	tx := db.WithContext(ctx)
+	if err := tx.Exec("CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT)").Error; err != nil {
+		t.Fatalf("建表失败: %v", err)
+	}
+	if err := tx.Create(&user{ID: 1, Name: "bamboo"}).Error; err != nil {
+		t.Fatalf("插入失败: %v", err)
+	}
+	var found user
+	if err := tx.First(&found, 2).Error; !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("应返回 ErrRecordNotFound: %v", err)
+	}
+	_ = tx.Exec("SELECT * FROM missing").Error
+	parent.End()
+
+	if err := xTrace.DefaultTracer().Flush(context.Background()); err != nil {
+		t.Fatalf("Flush 失败: %v", err)
+	}
+	if len(recorder.spans) != 5 {
+		t.Fatalf("应导出 5 个 span，实际 %d", len(recorder.spans))
+	}
+	for _, span := range recorder.spans[:4] {
+		if span.Kind != xTrace.KindClient || span.SpanContext.ParentID != parent.Context().SpanID {
+			t.Errorf("SQL span 应为请求 span 的子 span: %s %+v", span.Name, span.SpanContext)
+		}
+	}
+	if create := recorder.spans[1]; create.Name != "gorm.create" || create.Status != xTrace.StatusUnset {
+		t.Errorf("create span 不匹配: %+v", create)
+	}
+	if query := recorder.spans[2]; query.Name != "gorm.query" || query.Status == xTrace.StatusError {
+		t.Errorf("ErrRecordNotFound 不应标记为失败: %+v", query)
+	}
+	if raw := recorder.spans[3]; raw.Name != "gorm.raw" || raw.Status != xTrace.StatusError {
+		t.Errorf("执行失败的 SQL 应标记为失败: %+v", raw)
+	}
+}
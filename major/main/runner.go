@@ -14,8 +14,8 @@ import (
 // mainRunner 应用主运行器，聚合 Runner 启动期所需的所有状态与资源。
 //
 // 该结构体将原单函数 Runner 拆解为按生命周期阶段组织的方法集合：
-// initContext → initSignal → initSync → initGoroutine → initWeb。
-// 各阶段方法分布在同包的不同文件中（goroutine.go / web.go），
+// initContext → initSignal → initSync → startComponents → initGoroutine → initWeb → stopComponents。
+// 各阶段方法分布在同包的不同文件中（start.go / goroutine.go / web.go / stop.go），
 // 便于按职责维护。生命周期清理（ctxCancel / signal.Stop）由 [Runner] 函数
 // 在所有阶段完成后统一 defer 执行，避免子方法提前释放资源。
 type mainRunner struct {
@@ -29,7 +29,7 @@ type mainRunner struct {
 
 // syncGroup 协程同步原组，聚合 WaitGroup 与关闭通知通道。
 //
-// engineSync 统一计数 HTTP 服务协程与所有附加协程；shutdownNotify 在
+// engineSync 统一计数所有 HTTP 服务协程与附加协程；shutdownNotify 在
 // 收到退出信号时关闭，用于通知附加协程主动停止。
 type syncGroup struct {
 	engineSync     sync.WaitGroup // 协程退出同步
@@ -51,14 +51,19 @@ func newMainRunner(
 	}
 }
 
-// Runner 启动应用程序的主入口，协调 HTTP 服务与后台协程的运行、信号处理及优雅关闭。
+// Runner 启动应用程序的主入口，协调 HTTP 服务（主服务与 xOption.WithEngine 声明的附加服务）与后台协程的运行、信号处理及优雅关闭。
 //
 // 该函数首先验证 reg 参数及其核心组件的有效性，随后按生命周期阶段顺序执行：
-// initContext → initSignal → initSync → initGoroutine → initWeb。
-// 各阶段分布在同包的 goroutine.go / web.go 中，按职责拆分。
+// initContext → initSignal → initSync → startComponents → initGoroutine → initWeb。
+// 各阶段分布在同包的 start.go / goroutine.go / web.go / shutdown.go 中，按职责拆分。
 //
-// 在接收到退出信号时，initWeb 启动的关闭协程会取消上下文、通知所有后台协程停止，
-// 并在超时时间内强制关闭 HTTP 服务。函数最后阻塞等待所有相关资源清理完毕后才返回。
+// 启动协程与服务前，按初始化顺序调用各节点登记的启动回调（参见 [xRegNode.Starter]）；
+// 任一组件启动失败时释放已登记的组件并直接返回，不再启动服务。
+//
+// 在接收到退出信号时，initWeb 启动的关闭协程会先标记未就绪并等待摘流，随后按
+// HTTP → gRPC → Cron → 异步任务 的顺序分阶段关闭（各阶段有独立超时，参见 xLifecycle），
+// 最后取消上下文并通知其余后台协程停止。所有协程退出后，按初始化逆序调用各节点登记的关闭回调
+// （参见 [xRegNode.Stopper]），函数阻塞等待所有相关资源清理完毕后才返回。
 //
 // 参数:
 //   - reg 携带 Gin 引擎、上下文及依赖注入的核心注册信息，必须非空且包含有效组件。
@@ -67,7 +72,8 @@ func newMainRunner(
 //   - goroutineFunc 附加后台协程函数，每个函数接收运行期上下文。
 //     Runner 会在收到退出信号后取消上下文并等待所有协程退出。
 //
-// 环境变量 XLF_HOST 和 XLF_PORT 分别用于指定监听地址和端口，默认为 localhost:1118。
+// HTTP 服务的监听地址、超时、TLS 与 h2c 由 reg.ServerConfig() 决定，默认为 localhost:1118，
+// 可通过 XLF_* 环境变量或 xOption.WithServer 调整。
 func Runner(
 	reg *xReg.Reg,
 	log *xLog.LogNamedLogger,
@@ -87,9 +93,13 @@ func Runner(
 	defer signal.Stop(runner.sigChan)
 
 	runner.initSync()
+	if !runner.startComponents() {
+		return
+	}
 	runner.initGoroutine(goroutineFunc...)
 	runner.initWeb()
 	runner.sync.engineSync.Wait()
+	runner.stopComponents()
 
 	log.Info(runner.runCtx, "所有服务已安全退出")
 	return
@@ -115,10 +125,9 @@ func (runner *mainRunner) initSignal() {
 
 // initSync 初始化协程同步原组。
 //
-// engineSync 预置 1 个计数（对应 HTTP 服务协程），附加协程在 initGoroutine 中按需 Add。
+// engineSync 由 initGoroutine 按附加协程数、initWeb 按 HTTP 服务数（主服务 + 附加服务）分别 Add。
 // shutdownNotify 创建为开放通道，在收到退出信号时由关闭协程 close 广播。
 func (runner *mainRunner) initSync() {
 	runner.sync.engineSync = sync.WaitGroup{}
-	runner.sync.engineSync.Add(1)
 	runner.sync.shutdownNotify = make(chan struct{})
 }
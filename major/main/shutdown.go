@@ -0,0 +1,81 @@
+package xMain
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	xLifecycle "github.com/bamboo-services/bamboo-base-go/common/lifecycle"
+)
+
+// shutdown 执行分阶段的优雅关闭，由 initWeb 的关闭协程在收到退出信号或任一服务失败后调用。
+//
+// 关闭顺序：
+//  1. 标记未就绪（xLifecycle.SetReady(false)），/readyz 开始返回 503
+//  2. 摘流：等待 DrainPeriod，HTTP 服务在此期间照常处理请求；服务失败触发的关闭或再次收到信号时跳过
+//  3. PhaseHTTP：各 HTTP 服务在各自的 ShutdownTimeout 内并行 server.Shutdown
+//  4. PhaseGRPC → PhaseCron → PhaseAsync：通知对应阶段的成员停止，并在 PhaseTimeout 内等待其退出
+//  5. 取消运行期上下文并 close shutdownNotify，释放其余附加协程
+//
+// 每个阶段的开始与耗时均记录日志，超时的阶段记录错误后继续下一阶段。
+func (runner *mainRunner) shutdown(servers []httpServer, failed bool) {
+	config := runner.reg.ServerConfig()
+	xLifecycle.SetReady(false)
+	runner.log.Warn(runner.runCtx, "开始优雅关闭，已标记为未就绪", slog.Bool("server_failed", failed))
+
+	if period := config.DrainPeriod(); period > 0 && !failed {
+		runner.log.Info(runner.runCtx, "等待摘流", slog.Duration("drain_period", period))
+		timer := time.NewTimer(period)
+		select {
+		case <-timer.C:
+		case <-runner.sigChan:
+			timer.Stop()
+			runner.log.Warn(runner.runCtx, "再次收到退出信号，跳过摘流")
+		}
+	}
+
+	for _, phase := range xLifecycle.Phases() {
+		runner.stopPhase(phase, config.PhaseTimeout(phase), servers)
+	}
+
+	runner.ctxCancel()
+	close(runner.sync.shutdownNotify)
+}
+
+// stopPhase 关闭单个阶段并记录耗时；PhaseHTTP 关闭 HTTP 服务，其余阶段交由 xLifecycle.Stop 等待成员退出。
+func (runner *mainRunner) stopPhase(phase xLifecycle.Phase, timeout time.Duration, servers []httpServer) {
+	start := time.Now()
+	runner.log.Info(runner.runCtx, "正在关闭阶段", slog.String("phase", string(phase)), slog.Duration("timeout", timeout))
+
+	if phase == xLifecycle.PhaseHTTP {
+		runner.shutdownHTTP(servers)
+	} else {
+		stopCtx, stopCancel := context.WithTimeout(context.Background(), timeout)
+		defer stopCancel()
+		if err := xLifecycle.Stop(stopCtx, phase); err != nil {
+			runner.log.Error(runner.runCtx, err.Error(), slog.String("phase", string(phase)))
+		}
+	}
+
+	runner.log.Info(runner.runCtx, "阶段已关闭", slog.String("phase", string(phase)), slog.Duration("elapsed", time.Since(start)))
+}
+
+// shutdownHTTP 在各自的 ShutdownTimeout 内并行关闭全部 HTTP 服务，等待在途请求完成。
+func (runner *mainRunner) shutdownHTTP(servers []httpServer) {
+	var shutdownSync sync.WaitGroup
+	for _, srv := range servers {
+		shutdownSync.Add(1)
+		go func(srv httpServer) {
+			defer shutdownSync.Done()
+			runner.log.Warn(runner.runCtx, "正在关闭 HTTP 服务器...", slog.String("server", srv.name))
+			shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), srv.config.ShutdownTimeout())
+			defer shutdownCancel()
+
+			if err := srv.server.Shutdown(shutdownCtx); err != nil {
+				runner.log.Error(runner.runCtx, err.Error(), slog.String("server", srv.name))
+			}
+		}(srv)
+	}
+	shutdownSync.Wait()
+}
@@ -0,0 +1,14 @@
+package xMain
+
+// startComponents 在启动附加协程与 HTTP 服务前启动注册中心登记的组件。
+//
+// 调用 reg.Init.Start，按初始化顺序执行各节点的启动回调（参见 [xRegNode.Starter]），
+// 传入运行期上下文，应用退出时随之取消。任一组件启动失败时记录错误并释放全部已登记组件，返回 false。
+func (runner *mainRunner) startComponents() bool {
+	if err := runner.reg.Init.Start(runner.runCtx); err != nil {
+		runner.log.Error(runner.runCtx, "组件启动失败: "+err.Error())
+		runner.stopComponents()
+		return false
+	}
+	return true
+}
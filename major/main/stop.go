@@ -0,0 +1,34 @@
+package xMain
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	xLog "github.com/bamboo-services/bamboo-base-go/common/log"
+	xTrace "github.com/bamboo-services/bamboo-base-go/common/trace"
+)
+
+// componentStopTimeout 组件释放阶段的总超时时间。
+const componentStopTimeout = 15 * time.Second
+
+// stopComponents 在所有服务协程退出后释放注册中心登记的组件。
+//
+// 调用 reg.Init.Stop，按初始化逆序执行各节点的关闭回调（数据库连接池、缓存 Manager 等），
+// 随后导出尚未导出的 span，最后刷新日志输出（异步队列中尚未写出的日志），整个阶段受 [componentStopTimeout] 约束。
+// 运行期上下文此时已被取消，因此基于 context.Background() 派生独立的截止时间。
+func (runner *mainRunner) stopComponents() {
+	stopCtx, stopCancel := context.WithTimeout(context.Background(), componentStopTimeout)
+	defer stopCancel()
+
+	if err := runner.reg.Init.Stop(stopCtx); err != nil {
+		runner.log.Error(runner.runCtx, "组件释放未全部完成: "+err.Error())
+	}
+	if err := xTrace.Shutdown(stopCtx); err != nil {
+		runner.log.Warn(runner.runCtx, "链路导出未全部完成: "+err.Error())
+	}
+	if err := xLog.Flush(stopCtx); err != nil {
+		fmt.Fprintf(os.Stderr, "[LOG] 刷新日志失败: %v\n", err)
+	}
+}
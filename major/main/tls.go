@@ -0,0 +1,105 @@
+package xMain
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	xLog "github.com/bamboo-services/bamboo-base-go/common/log"
+)
+
+// certReloader 持有当前 TLS 证书，并在证书或私钥文件变化后重新加载。
+//
+// 通过 tls.Config.GetCertificate 在每次握手时提供最新证书，
+// 证书续期（如 cert-manager、certbot 覆盖文件）后无需重启服务。
+type certReloader struct {
+	certFile string
+	keyFile  string
+
+	mu      sync.RWMutex
+	cert    *tls.Certificate
+	modTime time.Time // 证书与私钥文件中较新的修改时间
+}
+
+// newCertReloader 加载证书与私钥，加载失败时返回错误。
+func newCertReloader(certFile, keyFile string) (*certReloader, error) {
+	r := &certReloader{certFile: certFile, keyFile: keyFile}
+	if err := r.load(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// GetCertificate 实现 tls.Config.GetCertificate。
+func (r *certReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.cert, nil
+}
+
+// watch 按 interval 轮询证书文件修改时间，变化后重新加载；ctx 结束时退出。
+//
+// 重新加载失败（如证书与私钥只更新了一半）时继续使用旧证书，下一轮重试。
+func (r *certReloader) watch(ctx context.Context, interval time.Duration, log *xLog.LogNamedLogger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			modTime, err := r.latestModTime()
+			if err != nil {
+				continue
+			}
+			r.mu.RLock()
+			changed := !modTime.Equal(r.modTime)
+			r.mu.RUnlock()
+			if !changed {
+				continue
+			}
+			if err := r.load(); err != nil {
+				log.Warn(ctx, "TLS 证书重新加载失败，继续使用旧证书", slog.String("error", err.Error()))
+				continue
+			}
+			log.Info(ctx, "TLS 证书已重新加载", slog.String("cert", r.certFile))
+		}
+	}
+}
+
+// load 读取证书与私钥并替换当前证书。
+func (r *certReloader) load() error {
+	modTime, err := r.latestModTime()
+	if err != nil {
+		return err
+	}
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("加载 TLS 证书失败: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cert = &cert
+	r.modTime = modTime
+	return nil
+}
+
+// latestModTime 返回证书与私钥文件中较新的修改时间。
+func (r *certReloader) latestModTime() (time.Time, error) {
+	var latest time.Time
+	for _, file := range []string{r.certFile, r.keyFile} {
+		info, err := os.Stat(file)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("读取 TLS 文件信息失败: %w", err)
+		}
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+	return latest, nil
+}
@@ -1,64 +1,149 @@
 package xMain
 
 import (
-	"context"
+	"crypto/tls"
 	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
-	"time"
+	"sync"
+	"sync/atomic"
 
-	xEnv "github.com/bamboo-services/bamboo-base-go/defined/env"
+	xLifecycle "github.com/bamboo-services/bamboo-base-go/common/lifecycle"
+	xOption "github.com/bamboo-services/bamboo-base-go/major/option"
 )
 
-// initWeb 启动 HTTP 服务并注册关闭协程。
+// httpServer 一个由 Runner 管理的 HTTP 服务。
+type httpServer struct {
+	name   string               // 服务名称，主服务为 [mainServerName]
+	config xOption.ServerConfig // 监听与超时配置
+	server *http.Server         // 标准库 HTTP 服务
+}
+
+// mainServerName 主 HTTP 服务（reg.Serve）的名称。
+const mainServerName = "main"
+
+// initWeb 启动主 HTTP 服务与全部附加 HTTP 服务，并注册关闭协程。
 //
-// 从环境变量 XLF_HOST / XLF_PORT 读取监听地址（默认 localhost:1118），
-// 创建 *http.Server 并启动两个协程：
+// 主服务配置来自 reg.ServerConfig()（默认 localhost:1118，可通过 XLF_* 环境变量或 xOption.WithServer 调整），
+// 附加服务来自 reg.NamedServers()（xOption.WithEngine 声明，如仅内网可达的管理端口）。
+// 每个服务各启动一个服务协程，另有一个共享的关闭协程：
 //
-//   - 服务协程：调用 ListenAndServe，退出时 Done WaitGroup 并 close serverFailed。
+//   - 服务协程：按配置监听（TCP / Unix 套接字 / 继承的文件描述符 / 已打开的监听器）后调用 Serve 或 ServeTLS，
+//     退出时 Done WaitGroup 并 close serverFailed（仅首个退出的服务）。
 //     非 ErrServerClosed 的错误记录到日志；ErrServerClosed（由关闭协程触发）属正常退出。
+//     全部服务监听成功后将就绪状态置为 true（xLifecycle.SetReady）。
 //
-//   - 关闭协程：select 同时监听 sigChan（SIGINT/SIGTERM）与 serverFailed（服务自己挂了），
-//     任一触发都执行关闭流程：取消运行期上下文 → close shutdownNotify 通知附加协程停止
-//     → 30s 超时内 server.Shutdown 优雅关闭 HTTP。
+//   - 关闭协程：select 同时监听 sigChan（SIGINT/SIGTERM）与 serverFailed（任一服务自己挂了），
+//     任一触发都执行分阶段关闭流程，参见 [mainRunner.shutdown]。
 //
 // serverFailed 的引入解决了端口占用等场景下服务协程提前退出、关闭协程却永久阻塞
-// 在 sigChan 的协程泄漏问题。
-//
-// shutdownNotify 的 close 在关闭协程中完成，先于 server.Shutdown，
-// 确保附加协程能及时收到通知并开始退出。
+// 在 sigChan 的协程泄漏问题；任一服务失败都会带动其余服务一起关闭。
 func (runner *mainRunner) initWeb() {
-	getHost := xEnv.GetEnvString(xEnv.Host, "localhost")
-	getPort := xEnv.GetEnvString(xEnv.Port, "1118")
-	server := &http.Server{
-		Addr:    getHost + ":" + getPort,
-		Handler: runner.reg.Serve,
-	}
+	servers := runner.httpServers()
 	serverFailed := make(chan struct{})
-
-	go func() {
-		defer runner.sync.engineSync.Done()
-		defer close(serverFailed)
-		runner.log.Info(runner.runCtx, "服务器已成功启动", slog.String("addr", "http(s)://"+server.Addr))
-		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
-			runner.log.Error(runner.runCtx, err.Error())
+	failOnce := sync.Once{}
+	var pending atomic.Int32
+	pending.Store(int32(len(servers)))
+	onListen := func() {
+		if pending.Add(-1) == 0 {
+			xLifecycle.SetReady(true)
 		}
-	}()
+	}
+
+	runner.sync.engineSync.Add(len(servers))
+	for _, srv := range servers {
+		go func(srv httpServer) {
+			defer runner.sync.engineSync.Done()
+			defer failOnce.Do(func() { close(serverFailed) })
+			if err := runner.serve(srv, onListen); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				runner.log.Error(runner.runCtx, err.Error(), slog.String("server", srv.name))
+			}
+		}(srv)
+	}
 
 	go func() {
+		failed := false
 		select {
 		case <-runner.sigChan:
 		case <-serverFailed:
+			failed = true
 		}
-		runner.ctxCancel()
-		close(runner.sync.shutdownNotify)
+		runner.shutdown(servers, failed)
+	}()
+}
+
+// httpServers 构建主服务与全部附加服务，主服务在前。
+func (runner *mainRunner) httpServers() []httpServer {
+	config := runner.reg.ServerConfig()
+	servers := []httpServer{{name: mainServerName, config: config, server: newHTTPServer(config, runner.reg.Serve)}}
+	for _, named := range runner.reg.NamedServers() {
+		servers = append(servers, httpServer{
+			name:   named.Name,
+			config: named.Config,
+			server: newHTTPServer(named.Config, named.Engine),
+		})
+	}
+	return servers
+}
 
-		runner.log.Warn(runner.runCtx, "正在关闭 HTTP 服务器...")
-		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 30*time.Second)
-		defer shutdownCancel()
+// newHTTPServer 按配置创建 *http.Server，设置超时、请求头大小与 h2c。
+func newHTTPServer(config xOption.ServerConfig, handler http.Handler) *http.Server {
+	server := &http.Server{
+		Addr:              config.Addr(),
+		Handler:           handler,
+		ReadTimeout:       config.ReadTimeout(),
+		ReadHeaderTimeout: config.ReadHeaderTimeout(),
+		WriteTimeout:      config.WriteTimeout(),
+		IdleTimeout:       config.IdleTimeout(),
+		MaxHeaderBytes:    config.MaxHeaderBytes(),
+	}
+	if config.H2C() {
+		protocols := new(http.Protocols)
+		protocols.SetHTTP1(true)
+		protocols.SetHTTP2(true)
+		protocols.SetUnencryptedHTTP2(true)
+		server.Protocols = protocols
+	}
+	return server
+}
 
-		if err := server.Shutdown(shutdownCtx); err != nil {
-			runner.log.Error(runner.runCtx, err.Error())
+// serve 创建监听器并阻塞运行 HTTP 服务，启用 TLS 时加载证书并启动证书变更监听。
+//
+// 监听器与证书就绪后调用 onListen，用于汇总全部服务的就绪状态。
+func (runner *mainRunner) serve(srv httpServer, onListen func()) error {
+	server, config := srv.server, srv.config
+	listener, err := config.Listen()
+	if err != nil {
+		return fmt.Errorf("HTTP 服务 %s 监听失败: %w", srv.name, err)
+	}
+
+	scheme := "http"
+	if config.TLSEnabled() {
+		reloader, err := newCertReloader(config.TLS())
+		if err != nil {
+			_ = listener.Close()
+			return err
 		}
-	}()
+		server.TLSConfig = &tls.Config{GetCertificate: reloader.GetCertificate}
+		go reloader.watch(runner.runCtx, config.TLSReloadInterval(), runner.log)
+		scheme = "https"
+	}
+
+	addr := scheme + "://" + listener.Addr().String()
+	if listener.Addr().Network() == "unix" {
+		addr = scheme + "+unix://" + listener.Addr().String()
+	}
+	runner.log.Info(runner.runCtx, "服务器已成功启动",
+		slog.String("server", srv.name),
+		slog.String("addr", addr),
+		slog.String("network", config.Network()),
+		slog.Bool("h2c", config.H2C()),
+	)
+	onListen()
+
+	if config.TLSEnabled() {
+		return server.ServeTLS(listener, "", "")
+	}
+	return server.Serve(listener)
 }
@@ -0,0 +1,114 @@
+package xMiddle
+
+import (
+	"errors"
+	"log/slog"
+
+	xAuth "github.com/bamboo-services/bamboo-base-go/common/auth"
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xJwt "github.com/bamboo-services/bamboo-base-go/common/jwt"
+	xLog "github.com/bamboo-services/bamboo-base-go/common/log"
+	xCtx "github.com/bamboo-services/bamboo-base-go/defined/context"
+	xHttp "github.com/bamboo-services/bamboo-base-go/defined/http"
+	xResult "github.com/bamboo-services/bamboo-base-go/major/result"
+	"github.com/gin-gonic/gin"
+)
+
+// authConfig 认证中间件配置
+type authConfig struct {
+	optional bool
+}
+
+// AuthOption 是 [Auth] 的函数式选项。
+type AuthOption func(*authConfig)
+
+// WithAuthOptional 未携带令牌时放行且不写入主体，适用于登录与匿名均可访问的接口；携带的令牌无效时仍会拒绝。
+func WithAuthOptional() AuthOption {
+	return func(c *authConfig) { c.optional = true }
+}
+
+// Auth 校验 Authorization 请求头中的 Bearer 访问令牌，并将已认证主体写入请求上下文。
+//
+// 校验失败时通过 xResult.AbortError 返回：
+//   - 未携带令牌：xError.TokenMissing
+//   - 令牌过期：xError.TokenExpired（客户端可携带 X-Refresh-Token 调用 [AuthRefresh] 换发）
+//   - 签名、算法、签发方、受众错误或已吊销：xError.TokenInvalid
+//   - 吊销列表不可用：xError.ServerInternalError
+//
+// 通过后处理器可使用 xAuth.FromContext(c) 读取主体，xAsync 任务与日志会自动继承。
+//
+// 参数说明:
+//   - manager: 令牌管理器，见 xJwt.New。
+//   - opts: 可选配置，见 [WithAuthOptional]。
+//
+// 返回值:
+//   - 返回一个 `gin.HandlerFunc` 类型的函数，用于注册到 Gin 中间件链中。
+func Auth(manager *xJwt.Manager, opts ...AuthOption) gin.HandlerFunc {
+	cfg := authConfig{}
+	for _, o := range opts {
+		if o != nil {
+			o(&cfg)
+		}
+	}
+
+	return func(ctx *gin.Context) {
+		token := xHttp.GetToken(ctx, xHttp.HeaderAuthorization)
+		if token == "" && cfg.optional {
+			ctx.Next()
+			return
+		}
+
+		claims, err := manager.Verify(ctx.Request.Context(), token)
+		if err != nil {
+			abortTokenError(ctx, err)
+			return
+		}
+		SetPrincipal(ctx, claims.Principal())
+		ctx.Next()
+	}
+}
+
+// AuthRefresh 使用 X-Refresh-Token 请求头中的刷新令牌换发新的令牌对，以 xJwt.TokenPair 作为响应数据返回。
+//
+// 旧刷新令牌随即失效（需为 manager 配置吊销列表）；错误映射与 [Auth] 一致。
+//
+// 使用示例:
+//
+//	r.POST("/auth/refresh", xMiddle.AuthRefresh(manager))
+func AuthRefresh(manager *xJwt.Manager) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		pair, err := manager.Refresh(ctx.Request.Context(), xHttp.GetToken(ctx, xHttp.HeaderRefreshToken))
+		if err != nil {
+			abortTokenError(ctx, err)
+			return
+		}
+		xResult.SuccessHasData(ctx, "令牌刷新成功", pair)
+	}
+}
+
+// SetPrincipal 将已认证主体同时写入 *gin.Context 与 c.Request.Context()。
+//
+// 自定义认证方式（如 API Key、会话）可调用本函数，使主体与 [Auth] 一样在处理器、异步任务与日志中可见。
+func SetPrincipal(ctx *gin.Context, principal *xAuth.Principal) {
+	ctx.Set(xCtx.PrincipalKey.String(), principal)
+	ctx.Request = ctx.Request.WithContext(xAuth.WithPrincipal(ctx.Request.Context(), principal))
+}
+
+// abortTokenError 将令牌校验错误映射为错误码并终止请求
+func abortTokenError(ctx *gin.Context, err error) {
+	var code *xError.ErrorCode
+	switch {
+	case errors.Is(err, xJwt.ErrTokenMissing):
+		code = xError.TokenMissing
+	case errors.Is(err, xJwt.ErrTokenExpired):
+		code = xError.TokenExpired
+	case errors.Is(err, xJwt.ErrTokenInvalid):
+		code = xError.TokenInvalid
+	default:
+		xLog.WithName(xLog.NamedMIDE).Error(ctx, "令牌校验失败", slog.Any("error", err))
+		xResult.AbortError(ctx, xError.ServerInternalError, "令牌校验失败", nil)
+		return
+	}
+	ctx.Header(xHttp.HeaderWWWAuthenticate.String(), "Bearer")
+	xResult.AbortError(ctx, code, xError.ErrMessage(err.Error()), nil)
+}
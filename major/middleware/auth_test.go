@@ -0,0 +1,140 @@
+package xMiddle
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	xAuth "github.com/bamboo-services/bamboo-base-go/common/auth"
+	xJwt "github.com/bamboo-services/bamboo-base-go/common/jwt"
+	xCache "github.com/bamboo-services/bamboo-base-go/major/cache"
+	xCacheMemory "github.com/bamboo-services/bamboo-base-go/major/cache/memory"
+	"github.com/gin-gonic/gin"
+)
+
+// newAuthEngine 构建挂载认证中间件与刷新接口的引擎，/me 返回当前主体标识
+func newAuthEngine(t *testing.T, manager *xJwt.Manager, opts ...AuthOption) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.POST("/refresh", AuthRefresh(manager))
+	engine.GET("/me", Auth(manager, opts...), func(c *gin.Context) {
+		fromGin := xAuth.Subject(c)
+		fromRequest := xAuth.Subject(c.Request.Context())
+		c.String(http.StatusOK, fromGin+"|"+fromRequest)
+	})
+	return engine
+}
+
+// serveAuth 携带请求头执行请求
+func serveAuth(engine *gin.Engine, method, path string, headers map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	recorder := httptest.NewRecorder()
+	engine.ServeHTTP(recorder, req)
+	return recorder
+}
+
+// newTestJwtManager 构造使用内存吊销列表的令牌管理器
+func newTestJwtManager(t *testing.T, opts ...xJwt.Option) *xJwt.Manager {
+	t.Helper()
+	store := xCacheMemory.NewStore(0, 0, 0)
+	t.Cleanup(store.Close)
+	cache := xCache.NewManager(xCache.CacheTypeMemory, xCache.WithMemoryStore(store))
+	keys, err := xJwt.NewKeySet(xJwt.HMACKey("test", []byte("0123456789abcdef0123456789abcdef")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return xJwt.New(keys, append([]xJwt.Option{xJwt.WithDenyList(xCache.DenyListOf(cache, "jwt"))}, opts...)...)
+}
+
+// TestAuth_Principal 验证令牌校验通过后主体同时写入 gin 与标准上下文，以及缺失 / 无效 / 过期的错误码。
+func TestAuth_Principal(t *testing.T) {
+	manager := newTestJwtManager(t)
+	engine := newAuthEngine(t, manager)
+	pair, err := manager.IssuePair(context.Background(), xJwt.Claims{Subject: "1001"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	recorder := serveAuth(engine, http.MethodGet, "/me", map[string]string{"Authorization": "Bearer " + pair.AccessToken})
+	if recorder.Code != http.StatusOK || recorder.Body.String() != "1001|1001" {
+		t.Fatalf("认证后响应 = %d %q", recorder.Code, recorder.Body.String())
+	}
+
+	cases := map[string]struct {
+		header string
+		output string
+	}{
+		"missing": {"", "TOKEN_MISSING"},
+		"invalid": {"Bearer not.a.token", "TOKEN_INVALID"},
+		"refresh": {"Bearer " + pair.RefreshToken, "TOKEN_INVALID"},
+	}
+	for name, c := range cases {
+		recorder = serveAuth(engine, http.MethodGet, "/me", map[string]string{"Authorization": c.header})
+		if recorder.Code != http.StatusUnauthorized || !hasOutput(t, recorder, c.output) {
+			t.Errorf("%s: 响应 = %d %s", name, recorder.Code, recorder.Body.String())
+		}
+	}
+
+	expired := newTestJwtManager(t, xJwt.WithAccessTTL(-time.Hour), xJwt.WithLeeway(0))
+	token, _ := expired.Issue(context.Background(), xJwt.Claims{Subject: "1001"})
+	recorder = serveAuth(newAuthEngine(t, expired), http.MethodGet, "/me", map[string]string{"Authorization": "Bearer " + token})
+	if recorder.Code != http.StatusUnauthorized || !hasOutput(t, recorder, "TOKEN_EXPIRED") {
+		t.Errorf("过期令牌响应 = %d %s", recorder.Code, recorder.Body.String())
+	}
+}
+
+// TestAuth_Optional 验证可选认证在无令牌时放行。
+func TestAuth_Optional(t *testing.T) {
+	engine := newAuthEngine(t, newTestJwtManager(t), WithAuthOptional())
+	if recorder := serveAuth(engine, http.MethodGet, "/me", nil); recorder.Code != http.StatusOK || recorder.Body.String() != "|" {
+		t.Errorf("可选认证响应 = %d %q", recorder.Code, recorder.Body.String())
+	}
+	if recorder := serveAuth(engine, http.MethodGet, "/me", map[string]string{"Authorization": "Bearer bad"}); recorder.Code != http.StatusUnauthorized {
+		t.Errorf("可选认证下无效令牌仍应拒绝，状态码 = %d", recorder.Code)
+	}
+}
+
+// TestAuthRefresh 验证刷新接口换发令牌且旧刷新令牌失效。
+func TestAuthRefresh(t *testing.T) {
+	manager := newTestJwtManager(t)
+	engine := newAuthEngine(t, manager)
+	pair, _ := manager.IssuePair(context.Background(), xJwt.Claims{Subject: "1001"})
+
+	recorder := serveAuth(engine, http.MethodPost, "/refresh", map[string]string{"X-Refresh-Token": pair.RefreshToken})
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("刷新响应 = %d %s", recorder.Code, recorder.Body.String())
+	}
+	var body struct {
+		Data xJwt.TokenPair `json:"data"`
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &body); err != nil || body.Data.AccessToken == "" {
+		t.Fatalf("刷新响应缺少令牌: %v %s", err, recorder.Body.String())
+	}
+	if recorder = serveAuth(engine, http.MethodGet, "/me", map[string]string{"Authorization": "Bearer " + body.Data.AccessToken}); recorder.Code != http.StatusOK {
+		t.Errorf("新访问令牌不可用，状态码 = %d", recorder.Code)
+	}
+
+	recorder = serveAuth(engine, http.MethodPost, "/refresh", map[string]string{"X-Refresh-Token": pair.RefreshToken})
+	if recorder.Code != http.StatusUnauthorized || !hasOutput(t, recorder, "TOKEN_INVALID") {
+		t.Errorf("重放刷新令牌响应 = %d %s", recorder.Code, recorder.Body.String())
+	}
+}
+
+// hasOutput 判断响应体的 output 字段是否为期望值
+func hasOutput(t *testing.T, recorder *httptest.ResponseRecorder, output string) bool {
+	t.Helper()
+	var body struct {
+		Output string `json:"output"`
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &body); err != nil {
+		t.Fatalf("响应不是合法 JSON: %v", err)
+	}
+	return body.Output == output
+}
@@ -0,0 +1,45 @@
+package xMiddle
+
+import (
+	xAuth "github.com/bamboo-services/bamboo-base-go/common/auth"
+	xAuthz "github.com/bamboo-services/bamboo-base-go/common/authz"
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xResult "github.com/bamboo-services/bamboo-base-go/major/result"
+	"github.com/gin-gonic/gin"
+)
+
+// Authorize 按路由声明的访问要求校验当前主体，需挂载在 [Auth] 或其他调用 [SetPrincipal] 的中间件之后。
+//
+// 路由参数作为请求属性，既用于替换 Requirement.Resource 中的 {name} 占位符，也传递给策略中的具名条件。
+// 判定失败时通过 xResult.AbortError 返回：
+//   - 未认证：xError.Unauthorized
+//   - 角色不满足：xError.RoleDenied
+//   - 无规则授予该动作：xError.PermissionDenied
+//   - 命中拒绝规则或条件不满足：xError.ResourceDenied
+//
+// 使用示例:
+//
+//	orders := r.Group("/orders", xMiddle.Auth(jwtManager))
+//	orders.GET("/:id", xMiddle.Authorize(enforcer, xAuthz.Require("order:{id}", "read")), handler.Get)
+//	orders.DELETE("/:id", xMiddle.Authorize(enforcer, xAuthz.RequireRole("admin")), handler.Delete)
+//
+// 参数说明:
+//   - enforcer: 授权判定器，见 xAuthz.New。
+//   - need: 访问要求，见 xAuthz.Require、xAuthz.RequireRole。
+//
+// 返回值:
+//   - 返回一个 `gin.HandlerFunc` 类型的函数，用于注册到路由或路由组。
+func Authorize(enforcer *xAuthz.Enforcer, need xAuthz.Requirement) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		attrs := make(map[string]string, len(ctx.Params))
+		for _, param := range ctx.Params {
+			attrs[param.Key] = param.Value
+		}
+		principal, _ := xAuth.FromContext(ctx)
+		if err := enforcer.Authorize(ctx.Request.Context(), principal, need, attrs); err != nil {
+			xResult.AbortError(ctx, xAuthz.ErrorCode(err), xError.ErrMessage(err.Error()), nil)
+			return
+		}
+		ctx.Next()
+	}
+}
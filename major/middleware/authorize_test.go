@@ -0,0 +1,58 @@
+package xMiddle
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	xAuth "github.com/bamboo-services/bamboo-base-go/common/auth"
+	xAuthz "github.com/bamboo-services/bamboo-base-go/common/authz"
+	"github.com/gin-gonic/gin"
+)
+
+// TestAuthorize 验证路由参数替换资源占位符并传递给条件，以及各拒绝原因的错误码。
+func TestAuthorize(t *testing.T) {
+	policy := xAuthz.Policy{Roles: []xAuthz.Role{
+		{Name: "user", Rules: []xAuthz.Rule{{Resource: "order:*", Actions: []string{"read"}, Condition: "owner"}}},
+		{Name: "admin", Rules: []xAuthz.Rule{{Resource: "**", Actions: []string{"*"}}}},
+	}}
+	enforcer, err := xAuthz.New(context.Background(), xAuthz.Static(policy),
+		xAuthz.WithCondition("owner", func(_ context.Context, req xAuthz.Request) bool {
+			return req.Attributes["id"] == req.Principal.Subject
+		}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.Use(func(c *gin.Context) {
+		if subject := c.GetHeader("X-Subject"); subject != "" {
+			SetPrincipal(c, &xAuth.Principal{Subject: subject, Roles: []string{c.GetHeader("X-Role")}})
+		}
+	})
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	engine.GET("/orders/:id", Authorize(enforcer, xAuthz.Require("order:{id}", "read")), ok)
+	engine.DELETE("/orders/:id", Authorize(enforcer, xAuthz.Require("order:{id}", "delete")), ok)
+	engine.GET("/admin", Authorize(enforcer, xAuthz.RequireRole("admin")), ok)
+
+	cases := []struct {
+		method, path, subject, role string
+		status                      int
+		output                      string
+	}{
+		{http.MethodGet, "/orders/7", "7", "user", http.StatusOK, ""},
+		{http.MethodGet, "/orders/8", "7", "user", http.StatusForbidden, "RESOURCE_DENIED"},
+		{http.MethodDelete, "/orders/7", "7", "user", http.StatusForbidden, "PERMISSION_DENIED"},
+		{http.MethodGet, "/admin", "7", "user", http.StatusForbidden, "ROLE_DENIED"},
+		{http.MethodDelete, "/orders/8", "1", "admin", http.StatusOK, ""},
+		{http.MethodGet, "/orders/7", "", "", http.StatusUnauthorized, "UNAUTHORIZED"},
+	}
+	for _, c := range cases {
+		recorder := serveAuth(engine, c.method, c.path, map[string]string{"X-Subject": c.subject, "X-Role": c.role})
+		if recorder.Code != c.status || (c.output != "" && !hasOutput(t, recorder, c.output)) {
+			t.Errorf("%s %s as %s/%s: 响应 = %d %s", c.method, c.path, c.subject, c.role, recorder.Code, recorder.Body.String())
+		}
+	}
+}
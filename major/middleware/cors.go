@@ -1,11 +1,213 @@
 package xMiddle
 
-import "github.com/gin-gonic/gin"
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	xLog "github.com/bamboo-services/bamboo-base-go/common/log"
+	xHttp "github.com/bamboo-services/bamboo-base-go/defined/http"
+	xOptCors "github.com/bamboo-services/bamboo-base-go/major/option/cors"
+	"github.com/gin-gonic/gin"
+)
 
 // ReleaseAllCors 设置跨域请求的头部信息，允许所有来源的请求，并支持常用的 HTTP 方法和头部。
+//
+// Deprecated: 固定下发 "*"，携带 Cookie 的请求会被浏览器拒绝，且不处理预检与 Vary。
+// 请改用 xOption.WithCors 或 [Cors]，后续版本将移除本函数。
 func ReleaseAllCors(ctx *gin.Context) {
 	ctx.Writer.Header().Set("Access-Control-Allow-Origin", "*")
 	ctx.Writer.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
 	ctx.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
 	ctx.Next()
 }
+
+// Cors 按 [xOptCors.CorsConfig] 处理跨域请求，配置在构造时固定；需运行期替换配置时使用 [NewCorsHandler]。
+//
+// 处理规则：
+//   - 未携带 Origin 的请求直接放行，不写入任何跨域头
+//   - 来源不在允许列表中：预检请求返回 403，普通请求照常处理但不写入跨域头（由浏览器拦截响应）
+//   - 预检请求（OPTIONS 且携带 Access-Control-Request-Method）：校验方法后下发
+//     Allow-Methods / Allow-Headers / Max-Age 并以 204 结束，不进入后续处理器
+//   - 普通请求：下发 Allow-Origin、Expose-Headers，启用凭据时下发 Allow-Credentials
+//
+// 除「允许全部来源」时下发 "*" 外，Allow-Origin 均回显请求来源，并追加 Vary: Origin，
+// 避免共享缓存将某一来源的响应返回给其他来源。允许全部来源时不下发 Allow-Credentials，
+// 该组合本应被 [xOptCors.CorsConfig.Validate] 拒绝。
+//
+// 参数说明:
+//   - cfg: 跨域配置，通常由 xOption.WithCors 构造。
+//
+// 返回值:
+//   - 返回一个 `gin.HandlerFunc` 类型的函数，用于注册到 Gin 中间件链中。
+func Cors(cfg xOptCors.CorsConfig) gin.HandlerFunc {
+	return NewCorsHandler(cfg).Handle
+}
+
+// CorsHandler 支持运行期替换配置的跨域中间件，处理规则同 [Cors]。
+//
+// 预处理后的配置保存在 atomic.Pointer 中，[CorsHandler.Update] 与请求处理可并发进行，
+// 每个请求使用处理开始时的配置。Register 启用 WithCors 时构造该中间件挂载到全部引擎，
+// 并订阅配置中心的 [xOptCors.ConfigKey] 段，配置变更后自动替换。
+type CorsHandler struct {
+	log   *xLog.LogNamedLogger
+	state atomic.Pointer[corsState]
+}
+
+// corsState 预处理后的跨域配置
+type corsState struct {
+	matcher        originMatcher
+	credentials    bool
+	methods        []string
+	allowMethods   string
+	allowHeaders   []string
+	reflectHeaders bool
+	exposeHeaders  string
+	maxAge         string
+}
+
+// NewCorsHandler 以初始配置构造可替换配置的跨域中间件。
+func NewCorsHandler(cfg xOptCors.CorsConfig) *CorsHandler {
+	h := &CorsHandler{log: xLog.WithName(xLog.NamedMIDE)}
+	h.Update(cfg)
+	return h
+}
+
+// Update 原子替换跨域配置，之后开始处理的请求使用新配置；调用方应先通过 [xOptCors.CorsConfig.Validate] 校验。
+func (h *CorsHandler) Update(cfg xOptCors.CorsConfig) {
+	methods := cfg.AllowMethods()
+	allowHeaders := cfg.AllowHeaders()
+	matcher := newOriginMatcher(cfg.AllowOrigins())
+	// 未经 Validate 的 "*" 与凭据组合同样不下发凭据，避免任意来源以用户身份读取响应
+	state := &corsState{
+		matcher:        matcher,
+		credentials:    cfg.AllowCredentials() && !matcher.any,
+		methods:        methods,
+		allowMethods:   strings.Join(methods, ", "),
+		allowHeaders:   allowHeaders,
+		reflectHeaders: slices.Contains(allowHeaders, "*"),
+		exposeHeaders:  strings.Join(cfg.ExposeHeaders(), ", "),
+	}
+	if seconds := int(cfg.MaxAge().Seconds()); seconds > 0 {
+		state.maxAge = strconv.Itoa(seconds)
+	}
+	h.state.Store(state)
+}
+
+// Handle 处理跨域请求，可直接作为 `gin.HandlerFunc` 注册到 Gin 中间件链中。
+func (h *CorsHandler) Handle(ctx *gin.Context) {
+	origin := ctx.GetHeader(xHttp.HeaderOrigin.String())
+	if origin == "" {
+		ctx.Next()
+		return
+	}
+
+	s := h.state.Load()
+	header := ctx.Writer.Header()
+	preflight := ctx.Request.Method == http.MethodOptions &&
+		ctx.GetHeader(xHttp.HeaderAccessControlRequestMethod.String()) != ""
+	allowOrigin := origin
+	if s.matcher.any && !s.credentials {
+		allowOrigin = "*"
+	} else {
+		header.Add(xHttp.HeaderVary.String(), xHttp.HeaderOrigin.String())
+	}
+
+	if !s.matcher.match(origin) {
+		if preflight {
+			h.log.Debug(ctx, "拒绝未允许来源的跨域预检请求")
+			ctx.AbortWithStatus(http.StatusForbidden)
+			return
+		}
+		ctx.Next()
+		return
+	}
+
+	header.Set(xHttp.HeaderAccessControlAllowOrigin.String(), allowOrigin)
+	if s.credentials {
+		header.Set(xHttp.HeaderAccessControlAllowCredentials.String(), "true")
+	}
+
+	if !preflight {
+		if s.exposeHeaders != "" {
+			header.Set(xHttp.HeaderAccessControlExposeHeaders.String(), s.exposeHeaders)
+		}
+		ctx.Next()
+		return
+	}
+
+	header.Add(xHttp.HeaderVary.String(), xHttp.HeaderAccessControlRequestMethod.String())
+	header.Add(xHttp.HeaderVary.String(), xHttp.HeaderAccessControlRequestHeaders.String())
+	requestMethod := strings.ToUpper(ctx.GetHeader(xHttp.HeaderAccessControlRequestMethod.String()))
+	if !slices.Contains(s.methods, requestMethod) {
+		h.log.Debug(ctx, "拒绝未允许方法的跨域预检请求")
+		ctx.AbortWithStatus(http.StatusForbidden)
+		return
+	}
+	header.Set(xHttp.HeaderAccessControlAllowMethods.String(), s.allowMethods)
+	if s.reflectHeaders {
+		if requested := ctx.GetHeader(xHttp.HeaderAccessControlRequestHeaders.String()); requested != "" {
+			header.Set(xHttp.HeaderAccessControlAllowHeaders.String(), requested)
+		}
+	} else if len(s.allowHeaders) > 0 {
+		header.Set(xHttp.HeaderAccessControlAllowHeaders.String(), strings.Join(s.allowHeaders, ", "))
+	}
+	if s.maxAge != "" {
+		header.Set(xHttp.HeaderAccessControlMaxAge.String(), s.maxAge)
+	}
+	ctx.AbortWithStatus(http.StatusNoContent)
+}
+
+// originMatcher 来源匹配器，预先拆分精确来源与子域名通配模式
+type originMatcher struct {
+	any       bool
+	exact     map[string]struct{}
+	wildcards []originWildcard
+}
+
+// originWildcard 子域名通配模式，如 https://*.example.com 拆分为 "https://" 与 ".example.com"
+type originWildcard struct {
+	prefix string
+	suffix string
+}
+
+// newOriginMatcher 根据允许的来源构建匹配器，来源统一转为小写
+func newOriginMatcher(origins []string) originMatcher {
+	m := originMatcher{exact: make(map[string]struct{})}
+	for _, origin := range origins {
+		origin = strings.ToLower(origin)
+		switch {
+		case origin == "*":
+			m.any = true
+		case strings.Contains(origin, "://*."):
+			prefix, suffix, _ := strings.Cut(origin, "*")
+			m.wildcards = append(m.wildcards, originWildcard{prefix: prefix, suffix: suffix})
+		default:
+			m.exact[origin] = struct{}{}
+		}
+	}
+	return m
+}
+
+// match 判断来源是否被允许
+func (m originMatcher) match(origin string) bool {
+	if m.any {
+		return true
+	}
+	origin = strings.ToLower(origin)
+	if _, ok := m.exact[origin]; ok {
+		return true
+	}
+	for _, w := range m.wildcards {
+		if len(origin) <= len(w.prefix)+len(w.suffix) ||
+			!strings.HasPrefix(origin, w.prefix) || !strings.HasSuffix(origin, w.suffix) {
+			continue
+		}
+		if sub := origin[len(w.prefix) : len(origin)-len(w.suffix)]; !strings.ContainsAny(sub, ":/") {
+			return true
+		}
+	}
+	return false
+}
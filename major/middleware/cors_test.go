@@ -0,0 +1,125 @@
+package xMiddle
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	xOptCors "github.com/bamboo-services/bamboo-base-go/major/option/cors"
+	"github.com/gin-gonic/gin"
+)
+
+// serveCors 以给定配置构建引擎并执行请求。
+func serveCors(cfg xOptCors.CorsConfig, method, origin string, headers map[string]string) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.Use(Cors(cfg))
+	engine.Any("/api", func(c *gin.Context) { c.String(http.StatusOK, "ok") })
+
+	req := httptest.NewRequest(method, "/api", nil)
+	if origin != "" {
+		req.Header.Set("Origin", origin)
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	recorder := httptest.NewRecorder()
+	engine.ServeHTTP(recorder, req)
+	return recorder
+}
+
+// TestCors_AllowList 验证精确来源、子域名通配与凭据下的回显与 Vary。
+func TestCors_AllowList(t *testing.T) {
+	cfg := xOptCors.New(
+		xOptCors.WithAllowOrigins("https://app.example.com", "https://*.example.org"),
+		xOptCors.WithCredentials(),
+	)
+
+	for _, origin := range []string{"https://app.example.com", "https://a.b.example.org"} {
+		recorder := serveCors(cfg, http.MethodGet, origin, nil)
+		if got := recorder.Header().Get("Access-Control-Allow-Origin"); got != origin {
+			t.Errorf("%s: Allow-Origin = %q", origin, got)
+		}
+		if recorder.Header().Get("Access-Control-Allow-Credentials") != "true" {
+			t.Errorf("%s: 应下发 Allow-Credentials", origin)
+		}
+		if recorder.Header().Get("Access-Control-Expose-Headers") == "" {
+			t.Errorf("%s: 应下发 Expose-Headers", origin)
+		}
+		if recorder.Header().Get("Vary") != "Origin" {
+			t.Errorf("%s: Vary = %q", origin, recorder.Header().Get("Vary"))
+		}
+	}
+
+	for _, origin := range []string{"https://example.org", "https://evil.com", "http://app.example.com"} {
+		recorder := serveCors(cfg, http.MethodGet, origin, nil)
+		if recorder.Code != http.StatusOK || recorder.Header().Get("Access-Control-Allow-Origin") != "" {
+			t.Errorf("%s: 未允许的来源不应下发跨域头: code=%d", origin, recorder.Code)
+		}
+	}
+}
+
+// TestCors_Preflight 验证预检请求的放行、拒绝与缓存时间。
+func TestCors_Preflight(t *testing.T) {
+	cfg := xOptCors.New(xOptCors.WithAllowOrigins("*"), xOptCors.WithAllowHeaders("*"))
+	recorder := serveCors(cfg, http.MethodOptions, "https://any.example.com", map[string]string{
+		"Access-Control-Request-Method":  "PUT",
+		"Access-Control-Request-Headers": "X-Custom",
+	})
+	if recorder.Code != http.StatusNoContent {
+		t.Fatalf("预检应返回 204: code=%d", recorder.Code)
+	}
+	if recorder.Header().Get("Access-Control-Allow-Origin") != "*" {
+		t.Errorf("允许全部来源且无凭据时应下发 *: %q", recorder.Header().Get("Access-Control-Allow-Origin"))
+	}
+	if recorder.Header().Get("Access-Control-Allow-Headers") != "X-Custom" {
+		t.Errorf("应回显预检声明的请求头: %q", recorder.Header().Get("Access-Control-Allow-Headers"))
+	}
+	if recorder.Header().Get("Access-Control-Max-Age") != "600" {
+		t.Errorf("Max-Age = %q", recorder.Header().Get("Access-Control-Max-Age"))
+	}
+
+	recorder = serveCors(cfg, http.MethodOptions, "https://any.example.com", map[string]string{
+		"Access-Control-Request-Method": "TRACE",
+	})
+	if recorder.Code != http.StatusForbidden {
+		t.Errorf("未允许的方法应返回 403: code=%d", recorder.Code)
+	}
+
+	if recorder = serveCors(cfg, http.MethodGet, "", nil); recorder.Header().Get("Access-Control-Allow-Origin") != "" {
+		t.Error("无 Origin 的请求不应下发跨域头")
+	}
+
+	recorder = serveCors(xOptCors.New(xOptCors.WithAllowOrigins("*"), xOptCors.WithCredentials()),
+		http.MethodGet, "https://evil.example.com", nil)
+	if recorder.Header().Get("Access-Control-Allow-Origin") != "*" || recorder.Header().Get("Access-Control-Allow-Credentials") != "" {
+		t.Errorf("允许全部来源时不应回显来源并下发凭据: %v", recorder.Header())
+	}
+}
+
+// TestCorsHandler_Update 验证替换配置后新请求按新的来源白名单处理。
+func TestCorsHandler_Update(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	handler := NewCorsHandler(xOptCors.New(xOptCors.WithAllowOrigins("https://a.example.com")))
+	engine := gin.New()
+	engine.Use(handler.Handle)
+	engine.GET("/api", func(c *gin.Context) { c.String(http.StatusOK, "ok") })
+	allowOrigin := func(origin string) string {
+		req := httptest.NewRequest(http.MethodGet, "/api", nil)
+		req.Header.Set("Origin", origin)
+		recorder := httptest.NewRecorder()
+		engine.ServeHTTP(recorder, req)
+		return recorder.Header().Get("Access-Control-Allow-Origin")
+	}
+
+	if got := allowOrigin("https://b.example.com"); got != "" {
+		t.Fatalf("替换前不应允许 b.example.com: %q", got)
+	}
+	handler.Update(xOptCors.New(xOptCors.WithAllowOrigins("https://b.example.com")))
+	if got := allowOrigin("https://b.example.com"); got != "https://b.example.com" {
+		t.Errorf("替换后应允许 b.example.com: %q", got)
+	}
+	if got := allowOrigin("https://a.example.com"); got != "" {
+		t.Errorf("替换后不应再允许 a.example.com: %q", got)
+	}
+}
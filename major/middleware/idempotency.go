@@ -0,0 +1,260 @@
+package xMiddle
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	xAuth "github.com/bamboo-services/bamboo-base-go/common/auth"
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xLog "github.com/bamboo-services/bamboo-base-go/common/log"
+	xHttp "github.com/bamboo-services/bamboo-base-go/defined/http"
+	xCache "github.com/bamboo-services/bamboo-base-go/major/cache"
+	xResult "github.com/bamboo-services/bamboo-base-go/major/result"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// DefaultIdempotencyTTL 默认已完成响应的保留时间
+	DefaultIdempotencyTTL = 24 * time.Hour
+
+	// DefaultIdempotencyLockTTL 默认处理中标记的过期时间，处理方崩溃后超过该时间可重新处理
+	DefaultIdempotencyLockTTL = time.Minute
+
+	// DefaultIdempotencyMaxBodySize 默认参与指纹计算的请求体上限（1 MiB）
+	DefaultIdempotencyMaxBodySize int64 = 1 << 20
+
+	// maxIdempotencyKeyLength 幂等键的最大长度
+	maxIdempotencyKeyLength = 255
+)
+
+// idempotencyMethods 需要幂等保护的非安全方法
+var idempotencyMethods = map[string]bool{
+	http.MethodPost:   true,
+	http.MethodPut:    true,
+	http.MethodPatch:  true,
+	http.MethodDelete: true,
+}
+
+// idempotencySkipHeaders 不随存储响应重放的响应头：每次请求各自生成的标识与限流状态，
+// 以及 Set-Cookie、X-Refresh-Token 等凭据（凭据不应落入缓存，也不应交给持有同一幂等键的重试请求）
+var idempotencySkipHeaders = []xHttp.Header{
+	xHttp.HeaderRequestUUID,
+	xHttp.HeaderTraceParent,
+	xHttp.HeaderTraceState,
+	xHttp.HeaderRateLimitLimit,
+	xHttp.HeaderRateLimitRemaining,
+	xHttp.HeaderRateLimitReset,
+	xHttp.HeaderRetryAfter,
+	xHttp.HeaderSetCookie,
+	xHttp.HeaderRefreshToken,
+}
+
+// idempotencyConfig 幂等中间件配置
+type idempotencyConfig struct {
+	ttl         time.Duration
+	lockTTL     time.Duration
+	maxBodySize int64
+	required    bool
+}
+
+// IdempotencyOption 是 [Idempotency] 的函数式选项。
+type IdempotencyOption func(*idempotencyConfig)
+
+// WithIdempotencyTTL 设置已完成响应的保留时间，默认 [DefaultIdempotencyTTL]；小于等于 0 时保持原值。
+func WithIdempotencyTTL(ttl time.Duration) IdempotencyOption {
+	return func(c *idempotencyConfig) {
+		if ttl > 0 {
+			c.ttl = ttl
+		}
+	}
+}
+
+// WithIdempotencyLockTTL 设置处理中标记的过期时间，默认 [DefaultIdempotencyLockTTL]；应大于接口的最长处理时间。
+//
+// 处理超时后标记可被重试请求重新取得，原请求结束时不再存储其响应，也不会删除新标记。
+func WithIdempotencyLockTTL(ttl time.Duration) IdempotencyOption {
+	return func(c *idempotencyConfig) {
+		if ttl > 0 {
+			c.lockTTL = ttl
+		}
+	}
+}
+
+// WithIdempotencyMaxBodySize 设置携带幂等键时请求体的最大字节数，默认 [DefaultIdempotencyMaxBodySize]；小于等于 0 时保持原值。
+//
+// 请求体需完整读入内存计算指纹，超过上限时返回 xError.PayloadTooLarge（HTTP 413）。
+func WithIdempotencyMaxBodySize(size int64) IdempotencyOption {
+	return func(c *idempotencyConfig) {
+		if size > 0 {
+			c.maxBodySize = size
+		}
+	}
+}
+
+// WithIdempotencyRequired 要求非安全方法必须携带 Idempotency-Key，缺失时返回 xError.HeaderError；默认缺失时直接放行。
+func WithIdempotencyRequired() IdempotencyOption {
+	return func(c *idempotencyConfig) { c.required = true }
+}
+
+// Idempotency 为 POST / PUT / PATCH / DELETE 请求提供基于 Idempotency-Key 请求头的幂等保护。
+//
+// 幂等键按已认证主体（见 xAuth.Subject）隔离，请求指纹由方法、请求 URI 与请求体摘要组成：
+//   - 首次请求：写入处理中标记后执行后续处理器，响应状态码小于 500 时存储状态码、响应头与响应体，
+//     5xx 或 panic 时删除标记以便客户端重试
+//   - 已完成的重试：直接重放存储的响应，并附加 Idempotent-Replayed: true
+//   - 处理中的并发重复请求：返回 xError.RepeatOperation
+//   - 同一幂等键的指纹不一致：返回 xError.UnprocessableEntity
+//   - 请求体超过 [WithIdempotencyMaxBodySize] 上限：返回 xError.PayloadTooLarge
+//
+// 缓存后端不可用时记录告警并按无幂等键处理。需挂载在 [Auth] 之后，才能按主体隔离幂等键。
+//
+// 使用示例:
+//
+//	r.POST("/orders", xMiddle.Auth(jwtManager), xMiddle.Idempotency(xCache.IdempotencyStoreOf(cacheManager, "orders")), handler.Create)
+//
+// 参数说明:
+//   - store: 幂等记录存储，见 xCache.IdempotencyStoreOf。
+//   - opts: 可选配置，见 [WithIdempotencyTTL]、[WithIdempotencyLockTTL]、[WithIdempotencyMaxBodySize]、[WithIdempotencyRequired]。
+//
+// 返回值:
+//   - 返回一个 `gin.HandlerFunc` 类型的函数，用于注册到 Gin 中间件链中。
+func Idempotency(store *xCache.IdempotencyStore, opts ...IdempotencyOption) gin.HandlerFunc {
+	cfg := idempotencyConfig{
+		ttl:         DefaultIdempotencyTTL,
+		lockTTL:     DefaultIdempotencyLockTTL,
+		maxBodySize: DefaultIdempotencyMaxBodySize,
+	}
+	for _, o := range opts {
+		if o != nil {
+			o(&cfg)
+		}
+	}
+	log := xLog.WithName(xLog.NamedMIDE)
+
+	return func(ctx *gin.Context) {
+		if !idempotencyMethods[ctx.Request.Method] {
+			ctx.Next()
+			return
+		}
+		key := strings.TrimSpace(ctx.GetHeader(xHttp.HeaderIdempotencyKey.String()))
+		if key == "" {
+			if cfg.required {
+				xResult.AbortError(ctx, xError.HeaderError, "缺少 Idempotency-Key 请求头", nil)
+				return
+			}
+			ctx.Next()
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			xResult.AbortError(ctx, xError.HeaderError, "Idempotency-Key 长度不能超过 255", nil)
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(ctx.Writer, ctx.Request.Body, cfg.maxBodySize))
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				xResult.AbortError(ctx, xError.PayloadTooLarge, "请求体超过幂等处理的大小上限", nil)
+				return
+			}
+			xResult.AbortError(ctx, xError.BodyError, "读取请求体失败", nil)
+			return
+		}
+		ctx.Request.Body = io.NopCloser(bytes.NewReader(body))
+		sum := sha256.New()
+		sum.Write([]byte(ctx.Request.Method + " " + ctx.Request.URL.RequestURI() + "\n"))
+		sum.Write(body)
+		fingerprint := hex.EncodeToString(sum.Sum(nil))
+		key = xAuth.Subject(ctx) + ":" + key
+
+		reqCtx := ctx.Request.Context()
+		record, acquired, err := store.Acquire(reqCtx, key, fingerprint, cfg.lockTTL)
+		if err != nil {
+			log.Warn(reqCtx, "写入幂等标记失败，已按无幂等键处理", slog.Any("error", err))
+			ctx.Next()
+			return
+		}
+		if !acquired {
+			switch {
+			case record.Fingerprint != fingerprint:
+				xResult.AbortError(ctx, xError.UnprocessableEntity, "Idempotency-Key 已用于不同的请求", nil)
+			case !record.Completed:
+				xResult.AbortError(ctx, xError.RepeatOperation, "相同 Idempotency-Key 的请求正在处理中", nil)
+			default:
+				replayIdempotent(ctx, record)
+			}
+			return
+		}
+
+		token := record.Token
+		writer := &idempotencyWriter{ResponseWriter: ctx.Writer}
+		ctx.Writer = writer
+		// 请求结束后客户端可能已断开，存储操作不随请求上下文取消
+		storeCtx := context.WithoutCancel(reqCtx)
+		completed := false
+		defer func() {
+			if !completed {
+				if releaseErr := store.Release(storeCtx, key, fingerprint, token); releaseErr != nil {
+					log.Warn(reqCtx, "删除幂等标记失败", slog.Any("error", releaseErr))
+				}
+			}
+		}()
+
+		ctx.Next()
+
+		status := writer.Status()
+		if status >= http.StatusInternalServerError {
+			return
+		}
+		header := writer.Header().Clone()
+		for _, h := range idempotencySkipHeaders {
+			header.Del(h.String())
+		}
+		result := xCache.IdempotencyRecord{Fingerprint: fingerprint, Status: status, Header: header, Body: writer.body.Bytes()}
+		err = store.Complete(storeCtx, key, token, result, cfg.ttl)
+		// 标记已过期并被重试请求取得时，不存储本次响应，也无需释放
+		completed = err == nil || errors.Is(err, xCache.ErrIdempotencyNotOwner)
+		if err != nil {
+			log.Warn(reqCtx, "存储幂等响应失败", slog.Any("error", err))
+		}
+	}
+}
+
+// replayIdempotent 重放已存储的响应并终止请求
+func replayIdempotent(ctx *gin.Context, record *xCache.IdempotencyRecord) {
+	for name, values := range record.Header {
+		for _, value := range values {
+			ctx.Writer.Header().Add(name, value)
+		}
+	}
+	ctx.Header(xHttp.HeaderIdempotentReplayed.String(), "true")
+	ctx.Status(record.Status)
+	_, _ = ctx.Writer.Write(record.Body)
+	ctx.Abort()
+}
+
+// idempotencyWriter 在写出响应的同时保留响应体副本
+type idempotencyWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+// Write 写出并记录响应体
+func (w *idempotencyWriter) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+// WriteString 写出并记录响应体
+func (w *idempotencyWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
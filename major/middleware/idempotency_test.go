@@ -0,0 +1,128 @@
+package xMiddle
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xCache "github.com/bamboo-services/bamboo-base-go/major/cache"
+	xCacheMemory "github.com/bamboo-services/bamboo-base-go/major/cache/memory"
+	xResult "github.com/bamboo-services/bamboo-base-go/major/result"
+	"github.com/gin-gonic/gin"
+)
+
+// newIdempotencyEngine 构建挂载幂等中间件的引擎，/orders 每次处理递增计数，/fail 返回 500；
+// entered 非 nil 时 /orders 进入处理器后通知 entered 并等待其被关闭
+func newIdempotencyEngine(t *testing.T, calls *atomic.Int32, entered chan struct{}, opts ...IdempotencyOption) *gin.Engine {
+	t.Helper()
+	store := xCacheMemory.NewStore(0, 0, 0)
+	t.Cleanup(store.Close)
+	cache := xCache.NewManager(xCache.CacheTypeMemory, xCache.WithMemoryStore(store))
+
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.Use(Idempotency(xCache.IdempotencyStoreOf(cache, "test"), opts...))
+	engine.POST("/orders", func(c *gin.Context) {
+		if entered != nil {
+			entered <- struct{}{}
+			<-entered
+		}
+		c.Header("X-Order-Id", "1")
+		c.Header("X-Refresh-Token", "refresh")
+		c.Header("Set-Cookie", "session=secret")
+		xResult.SuccessHasData(c, "创建成功", gin.H{"call": calls.Add(1)})
+	})
+	engine.POST("/fail", func(c *gin.Context) {
+		calls.Add(1)
+		xResult.AbortError(c, xError.ServerInternalError, "处理失败", nil)
+	})
+	return engine
+}
+
+// serveIdempotent 携带幂等键与请求体执行 POST 请求
+func serveIdempotent(engine *gin.Engine, path, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.Header.Set("Idempotency-Key", key)
+	recorder := httptest.NewRecorder()
+	engine.ServeHTTP(recorder, req)
+	return recorder
+}
+
+// TestIdempotency_Replay 验证重试重放存储的响应且不再执行处理器、不重放凭据响应头，请求体不一致时拒绝。
+func TestIdempotency_Replay(t *testing.T) {
+	var calls atomic.Int32
+	engine := newIdempotencyEngine(t, &calls, nil)
+
+	first := serveIdempotent(engine, "/orders", "k1", `{"sku":1}`)
+	retry := serveIdempotent(engine, "/orders", "k1", `{"sku":1}`)
+	if calls.Load() != 1 {
+		t.Fatalf("重试不应再次执行处理器，执行次数 = %d", calls.Load())
+	}
+	if retry.Code != first.Code || retry.Body.String() != first.Body.String() {
+		t.Errorf("重放响应不一致:\n%s\n%s", first.Body.String(), retry.Body.String())
+	}
+	if retry.Header().Get("X-Order-Id") != "1" || retry.Header().Get("Idempotent-Replayed") != "true" {
+		t.Errorf("重放响应头不完整: %v", retry.Header())
+	}
+	if retry.Header().Get("X-Refresh-Token") != "" || retry.Header().Get("Set-Cookie") != "" {
+		t.Errorf("重放响应不应携带凭据响应头: %v", retry.Header())
+	}
+
+	mismatch := serveIdempotent(engine, "/orders", "k1", `{"sku":2}`)
+	if mismatch.Code != http.StatusUnprocessableEntity || !hasOutput(t, mismatch, "UNPROCESSABLE_ENTITY") {
+		t.Errorf("请求体不一致响应 = %d %s", mismatch.Code, mismatch.Body.String())
+	}
+
+	if serveIdempotent(engine, "/orders", "k2", `{"sku":1}`); calls.Load() != 2 {
+		t.Errorf("不同幂等键应各自处理，执行次数 = %d", calls.Load())
+	}
+}
+
+// TestIdempotency_Concurrent 验证处理中的重复请求返回 RepeatOperation。
+func TestIdempotency_Concurrent(t *testing.T) {
+	var calls atomic.Int32
+	entered := make(chan struct{})
+	engine := newIdempotencyEngine(t, &calls, entered)
+
+	done := make(chan *httptest.ResponseRecorder)
+	go func() { done <- serveIdempotent(engine, "/orders", "k1", "{}") }()
+	<-entered
+	duplicate := serveIdempotent(engine, "/orders", "k1", "{}")
+	close(entered)
+	if first := <-done; first.Code != http.StatusOK {
+		t.Fatalf("首个请求响应 = %d", first.Code)
+	}
+	if duplicate.Code != http.StatusBadRequest || !hasOutput(t, duplicate, "REPEAT_OPERATION") {
+		t.Errorf("并发重复请求响应 = %d %s", duplicate.Code, duplicate.Body.String())
+	}
+}
+
+// TestIdempotency_ServerError 验证 5xx 响应不被存储，重试会重新处理。
+func TestIdempotency_ServerError(t *testing.T) {
+	var calls atomic.Int32
+	engine := newIdempotencyEngine(t, &calls, nil)
+	serveIdempotent(engine, "/fail", "k1", "{}")
+	serveIdempotent(engine, "/fail", "k1", "{}")
+	if calls.Load() != 2 {
+		t.Errorf("5xx 后重试应重新处理，执行次数 = %d", calls.Load())
+	}
+}
+
+// TestIdempotency_BodyTooLarge 验证请求体超过上限时返回 413 且不执行处理器。
+func TestIdempotency_BodyTooLarge(t *testing.T) {
+	var calls atomic.Int32
+	engine := newIdempotencyEngine(t, &calls, nil, WithIdempotencyMaxBodySize(8))
+
+	if recorder := serveIdempotent(engine, "/orders", "k1", `{"sku":12345}`); recorder.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("超限请求体应返回 413: code=%d", recorder.Code)
+	}
+	if calls.Load() != 0 {
+		t.Fatalf("超限请求不应执行处理器，执行次数 = %d", calls.Load())
+	}
+	if recorder := serveIdempotent(engine, "/orders", "k2", `{"a":1}`); recorder.Code != http.StatusOK {
+		t.Errorf("上限内的请求体应正常处理: code=%d", recorder.Code)
+	}
+}
@@ -9,7 +9,7 @@ import (
 // 若检测到请求方法为 OPTIONS，则记录调试日志并终止请求返回 200 状态码。
 //
 // Deprecated: AllowOption 命名不符合规范，且与 AllowOptionRequest 重复。
-// 请改用 AllowOptionRequest，后续版本将移除本函数。
+// 跨域预检请改用 xOption.WithCors，其他场景请改用 AllowOptionRequest，后续版本将移除本函数。
 func AllowOption(ctx *gin.Context) {
 	if ctx.Request.Method == "OPTIONS" {
 		xLog.WithName(xLog.NamedMIDE).Debug(ctx, "检测到 OPTIONS 请求，继续处理")
@@ -19,6 +19,8 @@ func AllowOption(ctx *gin.Context) {
 
 // AllowOptionRequest 允许 HTTP OPTIONS 预检请求通过。
 // 若检测到请求方法为 OPTIONS，则记录调试日志并终止请求返回 200 状态码。
+//
+// 该中间件不校验来源，启用 xOption.WithCors 后预检请求由 [Cors] 处理，无需再挂载本中间件。
 func AllowOptionRequest(ctx *gin.Context) {
 	if ctx.Request.Method == "OPTIONS" {
 		xLog.WithName(xLog.NamedMIDE).Debug(ctx, "检测到 OPTIONS 请求，继续处理")
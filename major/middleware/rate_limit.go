@@ -0,0 +1,150 @@
+package xMiddle
+
+import (
+	"log/slog"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xLog "github.com/bamboo-services/bamboo-base-go/common/log"
+	xRateLimit "github.com/bamboo-services/bamboo-base-go/common/ratelimit"
+	xHttp "github.com/bamboo-services/bamboo-base-go/defined/http"
+	xResult "github.com/bamboo-services/bamboo-base-go/major/result"
+	"github.com/gin-gonic/gin"
+)
+
+// RateLimitKeyFunc 从请求中提取限流维度，返回空字符串表示本次请求不参与限流。
+type RateLimitKeyFunc func(ctx *gin.Context) string
+
+// RateLimitByIP 按客户端 IP 限流，IP 的解析受 gin 的 TrustedProxies 配置影响。
+func RateLimitByIP() RateLimitKeyFunc {
+	return func(ctx *gin.Context) string {
+		return "ip:" + ctx.ClientIP()
+	}
+}
+
+// RateLimitByRoute 按路由模板限流（如 "POST /users/:id"），未匹配路由的请求统一计入 "unmatched"。
+func RateLimitByRoute() RateLimitKeyFunc {
+	return func(ctx *gin.Context) string {
+		route := ctx.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		return "route:" + ctx.Request.Method + " " + route
+	}
+}
+
+// RateLimitByUser 按用户限流，identify 返回当前请求的用户标识，返回空字符串（如未登录）时不参与限流。
+func RateLimitByUser(identify func(ctx *gin.Context) string) RateLimitKeyFunc {
+	return func(ctx *gin.Context) string {
+		user := identify(ctx)
+		if user == "" {
+			return ""
+		}
+		return "user:" + user
+	}
+}
+
+// RateLimitKeys 组合多个维度，如「每个用户在每个路由上」；任一维度为空时本次请求不参与限流。
+func RateLimitKeys(keys ...RateLimitKeyFunc) RateLimitKeyFunc {
+	return func(ctx *gin.Context) string {
+		parts := make([]string, 0, len(keys))
+		for _, key := range keys {
+			part := key(ctx)
+			if part == "" {
+				return ""
+			}
+			parts = append(parts, part)
+		}
+		return strings.Join(parts, "|")
+	}
+}
+
+// rateLimitConfig 限流中间件配置
+type rateLimitConfig struct {
+	errorCode  *xError.ErrorCode
+	failClosed bool
+}
+
+// RateLimitOption 是 [RateLimit] 的函数式选项。
+type RateLimitOption func(*rateLimitConfig)
+
+// WithRateLimitErrorCode 设置超出限流时返回的错误码，默认 xError.LimitExceeded。
+//
+// 登录、验证码等需要明确告知「访问受限」的场景可改用 xError.AccessLimited。
+func WithRateLimitErrorCode(code *xError.ErrorCode) RateLimitOption {
+	return func(c *rateLimitConfig) { c.errorCode = code }
+}
+
+// WithRateLimitFailClosed 限流后端（如 Redis）不可用时拒绝请求并返回 xError.AccessLimited，
+// 默认记录告警后放行。
+func WithRateLimitFailClosed() RateLimitOption {
+	return func(c *rateLimitConfig) { c.failClosed = true }
+}
+
+// RateLimit 按限流器对请求限流。
+//
+// 参与限流的请求都会下发 X-RateLimit-Limit、X-RateLimit-Remaining 与 X-RateLimit-Reset（额度完全恢复的秒数）；
+// 超出限流时追加 Retry-After，并通过 xResult.AbortError 返回错误码（默认 xError.LimitExceeded）。
+//
+// 参数说明:
+//   - limiter: 限流器，通常由 xCache.LimiterOf 按缓存后端构造，Memory 后端仅单实例生效，Redis 后端集群共享额度。
+//   - key: 限流维度，可使用 [RateLimitByIP]、[RateLimitByRoute]、[RateLimitByUser] 或其组合 [RateLimitKeys]。
+//   - opts: 可选配置，见 [WithRateLimitErrorCode]、[WithRateLimitFailClosed]。
+//
+// 返回值:
+//   - 返回一个 `gin.HandlerFunc` 类型的函数，用于注册到 Gin 中间件链中。
+//
+// 使用示例:
+//
+//	limiter := xCache.LimiterOf(manager, "login", xRateLimit.PerMinute(5).Sliding())
+//	r.POST("/login", xMiddle.RateLimit(limiter, xMiddle.RateLimitByIP()), loginHandler)
+func RateLimit(limiter xRateLimit.Limiter, key RateLimitKeyFunc, opts ...RateLimitOption) gin.HandlerFunc {
+	if limiter == nil || key == nil {
+		panic("xMiddle: RateLimit 的 limiter 与 key 不能为 nil")
+	}
+	cfg := rateLimitConfig{errorCode: xError.LimitExceeded}
+	for _, o := range opts {
+		if o != nil {
+			o(&cfg)
+		}
+	}
+	log := xLog.WithName(xLog.NamedMIDE)
+
+	return func(ctx *gin.Context) {
+		k := key(ctx)
+		if k == "" {
+			ctx.Next()
+			return
+		}
+
+		result, err := limiter.Allow(ctx.Request.Context(), k)
+		if err != nil {
+			if cfg.failClosed {
+				xResult.AbortError(ctx, xError.AccessLimited, "限流服务不可用", nil)
+				return
+			}
+			log.Warn(ctx, "限流判定失败，已放行请求", slog.String("key", k), slog.Any("error", err))
+			ctx.Next()
+			return
+		}
+
+		header := ctx.Writer.Header()
+		header.Set(xHttp.HeaderRateLimitLimit.String(), strconv.Itoa(result.Limit))
+		header.Set(xHttp.HeaderRateLimitRemaining.String(), strconv.Itoa(result.Remaining))
+		header.Set(xHttp.HeaderRateLimitReset.String(), strconv.Itoa(ceilSeconds(result.ResetAfter)))
+		if !result.Allowed {
+			header.Set(xHttp.HeaderRetryAfter.String(), strconv.Itoa(max(1, ceilSeconds(result.RetryAfter))))
+			xResult.AbortError(ctx, cfg.errorCode, "请求过于频繁，请稍后再试", nil)
+			return
+		}
+		ctx.Next()
+	}
+}
+
+// ceilSeconds 将时长向上取整为秒
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,92 @@
+package xMiddle
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xRateLimit "github.com/bamboo-services/bamboo-base-go/common/ratelimit"
+	xCacheMemory "github.com/bamboo-services/bamboo-base-go/major/cache/memory"
+	"github.com/gin-gonic/gin"
+)
+
+// failingLimiter 始终返回错误的限流器
+type failingLimiter struct{}
+
+func (failingLimiter) Allow(context.Context, string) (xRateLimit.Result, error) {
+	return xRateLimit.Result{}, errors.New("backend down")
+}
+
+// serveRateLimit 以给定中间件构建引擎并按客户端 IP 执行请求。
+func serveRateLimit(handler gin.HandlerFunc, ip string) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.Use(handler)
+	engine.GET("/api", func(c *gin.Context) { c.String(http.StatusOK, "ok") })
+
+	req := httptest.NewRequest(http.MethodGet, "/api", nil)
+	req.RemoteAddr = ip + ":1234"
+	recorder := httptest.NewRecorder()
+	engine.ServeHTTP(recorder, req)
+	return recorder
+}
+
+// TestRateLimit_ByIP 验证按 IP 限流、响应头与超限错误。
+func TestRateLimit_ByIP(t *testing.T) {
+	store := xCacheMemory.NewStore(0, 0, 0)
+	defer store.Close()
+	limiter := xCacheMemory.NewLimiter(store, xRateLimit.PerMinute(2), "ratelimit:test:")
+	handler := RateLimit(limiter, RateLimitByIP())
+
+	for i := 0; i < 2; i++ {
+		recorder := serveRateLimit(handler, "10.0.0.1")
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("第 %d 次请求状态码 = %d", i+1, recorder.Code)
+		}
+		if got := recorder.Header().Get("X-RateLimit-Limit"); got != "2" {
+			t.Errorf("X-RateLimit-Limit = %q", got)
+		}
+	}
+
+	recorder := serveRateLimit(handler, "10.0.0.1")
+	if recorder.Code != http.StatusBadRequest {
+		t.Fatalf("超限状态码 = %d，期望 400（LimitExceeded）", recorder.Code)
+	}
+	if recorder.Header().Get("X-RateLimit-Remaining") != "0" || recorder.Header().Get("Retry-After") != "30" {
+		t.Errorf("超限响应头 Remaining/Retry-After = %q/%q", recorder.Header().Get("X-RateLimit-Remaining"), recorder.Header().Get("Retry-After"))
+	}
+
+	if recorder = serveRateLimit(handler, "10.0.0.2"); recorder.Code != http.StatusOK {
+		t.Errorf("其他 IP 不应受影响，状态码 = %d", recorder.Code)
+	}
+}
+
+// TestRateLimit_Options 验证自定义错误码、空维度跳过与后端故障的放行 / 拒绝策略。
+func TestRateLimit_Options(t *testing.T) {
+	store := xCacheMemory.NewStore(0, 0, 0)
+	defer store.Close()
+	limiter := xCacheMemory.NewLimiter(store, xRateLimit.PerMinute(1).Sliding(), "ratelimit:options:")
+
+	handler := RateLimit(limiter, RateLimitKeys(RateLimitByIP(), RateLimitByRoute()), WithRateLimitErrorCode(xError.AccessLimited))
+	serveRateLimit(handler, "10.0.0.1")
+	if recorder := serveRateLimit(handler, "10.0.0.1"); recorder.Code != http.StatusForbidden {
+		t.Errorf("AccessLimited 状态码 = %d，期望 403", recorder.Code)
+	}
+
+	anonymous := RateLimit(limiter, RateLimitByUser(func(*gin.Context) string { return "" }))
+	for i := 0; i < 3; i++ {
+		if recorder := serveRateLimit(anonymous, "10.0.0.1"); recorder.Code != http.StatusOK || recorder.Header().Get("X-RateLimit-Limit") != "" {
+			t.Fatal("无用户标识时不应参与限流")
+		}
+	}
+
+	if recorder := serveRateLimit(RateLimit(failingLimiter{}, RateLimitByIP()), "10.0.0.1"); recorder.Code != http.StatusOK {
+		t.Errorf("默认应在后端故障时放行，状态码 = %d", recorder.Code)
+	}
+	if recorder := serveRateLimit(RateLimit(failingLimiter{}, RateLimitByIP(), WithRateLimitFailClosed()), "10.0.0.1"); recorder.Code != http.StatusForbidden {
+		t.Errorf("FailClosed 应在后端故障时拒绝，状态码 = %d", recorder.Code)
+	}
+}
@@ -0,0 +1,27 @@
+package option
+
+import (
+	xConfig "github.com/bamboo-services/bamboo-base-go/major/config"
+)
+
+// WithConfig 声明配置中心，由 Register 在日志器初始化前加载。
+//
+// Register 会：
+//   - 在 loggerInit 之前调用 [xConfig.Config.Load]，加载失败时中断启动
+//   - 将配置中心注册到 xCtx.ConfigKey，业务节点可通过 xCtxUtil.ConfigTypedKey 取用
+//   - 启动文件监听，并订阅 log.level 实现日志级别热更新
+//   - 在 Runner 退出时停止文件监听
+//
+// 传入 nil 表示不启用配置中心，仍沿用 .env + xEnv 的读取方式。
+//
+// 使用示例：
+//
+//	xOption.WithConfig(xConfig.New(
+//	    xConfig.WithFile("config.yaml"),
+//	    xConfig.WithOptionalFile("config.local.yaml"),
+//	))
+func WithConfig(cfg *xConfig.Config) Option {
+	return func(c *Config) {
+		c.config = cfg
+	}
+}
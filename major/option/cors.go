@@ -0,0 +1,32 @@
+package option
+
+import (
+	xOptCors "github.com/bamboo-services/bamboo-base-go/major/option/cors"
+)
+
+// CorsConfig 跨域配置，详见 [xOptCors.CorsConfig]。
+type CorsConfig = xOptCors.CorsConfig
+
+// WithCors 启用跨域中间件，并将 [xOptCors.CorsOption] 包裹为顶层 [Option]。
+//
+// 启用后 Register 在主引擎与全部附加引擎挂载 xMiddle.CorsHandler，配置优先级为：
+// 默认值 < CORS_* 环境变量 < 配置文件 cors 段（声明 [WithConfig] 时） < WithCors 显式选项。
+// 配置文件 cors 段变更后按同一优先级重新合并并即时替换，校验失败时保留原配置。
+// 可多次调用叠加，nil 选项会被跳过。
+//
+// 使用示例：
+//
+//	xOption.WithCors(
+//	    xOptCors.WithAllowOrigins("https://app.example.com", "https://*.example.com"),
+//	    xOptCors.WithCredentials(),
+//	)
+func WithCors(opts ...xOptCors.CorsOption) Option {
+	return func(c *Config) {
+		c.corsEnabled = true
+		for _, o := range opts {
+			if o != nil {
+				c.cors = append(c.cors, o)
+			}
+		}
+	}
+}
@@ -0,0 +1,260 @@
+// Package xOptCors 跨域配置子包，定义 [CorsConfig] 与 [CorsOption]。
+//
+// 与 logger / tracing / server 子包对称：
+//   - 外层 [CorsConfig] 为数据载体，字段小写只读，仅通过 getter 暴露
+//   - [CorsOption] 为修改函数，直接作用于 *CorsConfig
+//   - 各 WithXxx 均返回 [CorsOption]，由父包 [option.WithCors] 包裹为顶层 Option
+//
+// 该子包不 import option 父包，避免循环依赖。
+package xOptCors
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	xEnv "github.com/bamboo-services/bamboo-base-go/defined/env"
+	xHttp "github.com/bamboo-services/bamboo-base-go/defined/http"
+	xConfig "github.com/bamboo-services/bamboo-base-go/major/config"
+)
+
+// ConfigKey 配置文件中跨域配置所在的段。
+const ConfigKey = "cors"
+
+// DefaultMaxAge 默认预检结果缓存时间。
+const DefaultMaxAge = 10 * time.Minute
+
+// CorsConfig 跨域配置，描述允许的来源、方法、请求头、暴露的响应头、凭据与预检缓存时间。
+//
+// 字段均为小写，仅通过 getter 暴露只读视图。零值不可直接使用，请通过 [New] 构造（已填充默认值）。
+type CorsConfig struct {
+	allowOrigins     []string
+	allowMethods     []string
+	allowHeaders     []string
+	exposeHeaders    []string
+	allowCredentials bool
+	maxAge           time.Duration
+	err              error
+}
+
+// AllowOrigins 返回允许的来源，"*" 表示全部，"https://*.example.com" 匹配任意层级子域名。
+func (c CorsConfig) AllowOrigins() []string { return append([]string(nil), c.allowOrigins...) }
+
+// AllowMethods 返回预检允许的请求方法。
+func (c CorsConfig) AllowMethods() []string { return append([]string(nil), c.allowMethods...) }
+
+// AllowHeaders 返回预检允许的请求头，包含 "*" 时回显预检声明的请求头。
+func (c CorsConfig) AllowHeaders() []string { return append([]string(nil), c.allowHeaders...) }
+
+// ExposeHeaders 返回允许浏览器脚本读取的响应头。
+func (c CorsConfig) ExposeHeaders() []string { return append([]string(nil), c.exposeHeaders...) }
+
+// AllowCredentials 返回是否允许携带 Cookie 等凭据。
+func (c CorsConfig) AllowCredentials() bool { return c.allowCredentials }
+
+// MaxAge 返回预检结果缓存时间，0 表示不下发 Access-Control-Max-Age。
+func (c CorsConfig) MaxAge() time.Duration { return c.maxAge }
+
+// Validate 校验配置：来源模式是否合法、"*" 是否与凭据同时启用，以及 [FromConfig] 读取配置文件时是否出错。
+//
+// 允许全部来源且携带凭据等同于任意网站都能以用户身份读取响应，浏览器因此拒绝 "*" 与凭据并用，这里同样拒绝。
+//
+// Register 在装配前调用，校验失败时中断启动。
+func (c CorsConfig) Validate() error {
+	errs := []error{c.err}
+	for _, origin := range c.allowOrigins {
+		if origin == "*" {
+			if c.allowCredentials {
+				errs = append(errs, errors.New("允许全部来源 \"*\" 时不能启用凭据，请改为声明具体来源"))
+			}
+			continue
+		}
+		scheme, host, ok := strings.Cut(origin, "://")
+		if !ok || scheme == "" || host == "" {
+			errs = append(errs, fmt.Errorf("跨域来源 %q 缺少协议或主机", origin))
+			continue
+		}
+		if strings.Count(origin, "*") > 1 || (strings.Contains(host, "*") && !strings.HasPrefix(host, "*.")) {
+			errs = append(errs, fmt.Errorf("跨域来源 %q 的通配符仅支持 <协议>://*.<域名> 形式", origin))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// CorsOption 是 [CorsConfig] 的二级选项。
+type CorsOption func(*CorsConfig)
+
+// New 构造跨域配置，先填充默认值再依次应用 opts。
+//
+// 默认值：
+//   - 不允许任何来源（需通过 [WithAllowOrigins]、CORS_ALLOW_ORIGINS 或配置文件声明）
+//   - 方法 GET、POST、PUT、PATCH、DELETE、HEAD、OPTIONS
+//   - 请求头 Content-Type、Authorization、X-Requested-With、X-Request-UUID、X-Refresh-Token、Traceparent、Tracestate、Idempotency-Key
+//   - 暴露响应头 X-Request-UUID、Traceparent、Idempotent-Replayed 与限流响应头 X-RateLimit-*、Retry-After
+//   - 不允许凭据，预检缓存 10 分钟
+//
+// nil 选项会被跳过。
+func New(opts ...CorsOption) CorsConfig {
+	cfg := CorsConfig{
+		allowMethods: []string{
+			http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch,
+			http.MethodDelete, http.MethodHead, http.MethodOptions,
+		},
+		allowHeaders: []string{
+			xHttp.HeaderContentType.String(),
+			xHttp.HeaderAuthorization.String(),
+			xHttp.HeaderXRequestedWith.String(),
+			xHttp.HeaderRequestUUID.String(),
+			xHttp.HeaderRefreshToken.String(),
+			xHttp.HeaderTraceParent.String(),
+			xHttp.HeaderTraceState.String(),
+			xHttp.HeaderIdempotencyKey.String(),
+		},
+		exposeHeaders: []string{
+			xHttp.HeaderRequestUUID.String(),
+			xHttp.HeaderTraceParent.String(),
+			xHttp.HeaderIdempotentReplayed.String(),
+			xHttp.HeaderRateLimitLimit.String(),
+			xHttp.HeaderRateLimitRemaining.String(),
+			xHttp.HeaderRateLimitReset.String(),
+			xHttp.HeaderRetryAfter.String(),
+		},
+		maxAge: DefaultMaxAge,
+	}
+	for _, o := range opts {
+		if o != nil {
+			o(&cfg)
+		}
+	}
+	return cfg
+}
+
+// WithAllowOrigins 设置允许的来源，替换原有列表。
+//
+// 支持三种形式：
+//   - "*"：允许全部来源，下发 "*"；不能与 [WithCredentials] 同时使用，见 [CorsConfig.Validate]
+//   - "https://app.example.com"：精确匹配（协议、主机、端口均需一致，大小写不敏感）
+//   - "https://*.example.com"：匹配 example.com 的任意层级子域名，不匹配 example.com 本身
+func WithAllowOrigins(origins ...string) CorsOption {
+	return func(c *CorsConfig) { c.allowOrigins = normalize(origins) }
+}
+
+// WithAllowMethods 设置预检允许的请求方法，替换原有列表；为空时保持原值。
+func WithAllowMethods(methods ...string) CorsOption {
+	return func(c *CorsConfig) {
+		if methods = normalize(methods); len(methods) > 0 {
+			for i, method := range methods {
+				methods[i] = strings.ToUpper(method)
+			}
+			c.allowMethods = methods
+		}
+	}
+}
+
+// WithAllowHeaders 设置预检允许的请求头，替换原有列表；传入 "*" 时回显预检声明的请求头。
+func WithAllowHeaders(headers ...string) CorsOption {
+	return func(c *CorsConfig) { c.allowHeaders = normalize(headers) }
+}
+
+// WithExposeHeaders 设置允许浏览器脚本读取的响应头，替换原有列表。
+func WithExposeHeaders(headers ...string) CorsOption {
+	return func(c *CorsConfig) { c.exposeHeaders = normalize(headers) }
+}
+
+// WithCredentials 允许跨域请求携带 Cookie、HTTP 认证等凭据。
+//
+// 启用后响应回显具体来源并下发 Access-Control-Allow-Credentials: true。
+func WithCredentials() CorsOption {
+	return func(c *CorsConfig) { c.allowCredentials = true }
+}
+
+// WithMaxAge 设置预检结果缓存时间，0 表示不下发 Access-Control-Max-Age，负数时保持原值。
+func WithMaxAge(maxAge time.Duration) CorsOption {
+	return func(c *CorsConfig) {
+		if maxAge >= 0 {
+			c.maxAge = maxAge
+		}
+	}
+}
+
+// FromEnv 从 CORS_* 环境变量读取配置，未设置的项保持原值。
+func FromEnv() CorsOption {
+	return func(c *CorsConfig) {
+		if value, ok := xEnv.GetEnv(xEnv.CorsAllowOrigins); ok {
+			WithAllowOrigins(strings.Split(value, ",")...)(c)
+		}
+		if value, ok := xEnv.GetEnv(xEnv.CorsAllowMethods); ok {
+			WithAllowMethods(strings.Split(value, ",")...)(c)
+		}
+		if value, ok := xEnv.GetEnv(xEnv.CorsAllowHeaders); ok {
+			WithAllowHeaders(strings.Split(value, ",")...)(c)
+		}
+		if value, ok := xEnv.GetEnv(xEnv.CorsExposeHeaders); ok {
+			WithExposeHeaders(strings.Split(value, ",")...)(c)
+		}
+		c.allowCredentials = xEnv.GetEnvBool(xEnv.CorsAllowCredentials, c.allowCredentials)
+		if value, err := time.ParseDuration(xEnv.GetEnvString(xEnv.CorsMaxAge, "")); err == nil {
+			WithMaxAge(value)(c)
+		}
+	}
+}
+
+// Settings 配置文件中跨域段的结构，字段名与 CORS_* 环境变量一一对应。
+//
+// 配置文件示例（YAML）：
+//
+//	cors:
+//	  allow_origins: [https://app.example.com, https://*.example.com]
+//	  allow_credentials: true
+//	  max_age: 10m
+type Settings struct {
+	AllowOrigins     []string      `config:"allow_origins"`
+	AllowMethods     []string      `config:"allow_methods"`
+	AllowHeaders     []string      `config:"allow_headers"`
+	ExposeHeaders    []string      `config:"expose_headers"`
+	AllowCredentials bool          `config:"allow_credentials"`
+	MaxAge           time.Duration `config:"max_age"`
+}
+
+// FromConfig 从配置中心的 key 段（通常为 [ConfigKey]）读取配置，未声明的项保持原值。
+//
+// 配置中心需已 Load；读取或校验失败时错误由 [CorsConfig.Validate] 返回。cfg 为 nil 时不做修改。
+// 选项仅在 [New] 时读取一次；经 xOption.WithCors 启用时，Register 订阅 [ConfigKey] 段并在变更后重新构造配置。
+func FromConfig(cfg *xConfig.Config, key string) CorsOption {
+	return func(c *CorsConfig) {
+		if cfg == nil {
+			return
+		}
+		settings := Settings{
+			AllowOrigins:     c.allowOrigins,
+			AllowMethods:     c.allowMethods,
+			AllowHeaders:     c.allowHeaders,
+			ExposeHeaders:    c.exposeHeaders,
+			AllowCredentials: c.allowCredentials,
+			MaxAge:           c.maxAge,
+		}
+		if err := cfg.Bind(key, &settings); err != nil {
+			c.err = fmt.Errorf("读取跨域配置失败: %w", err)
+			return
+		}
+		WithAllowOrigins(settings.AllowOrigins...)(c)
+		WithAllowMethods(settings.AllowMethods...)(c)
+		WithAllowHeaders(settings.AllowHeaders...)(c)
+		WithExposeHeaders(settings.ExposeHeaders...)(c)
+		c.allowCredentials = settings.AllowCredentials
+		WithMaxAge(settings.MaxAge)(c)
+	}
+}
+
+// normalize 去除空白与空项，返回新的切片
+func normalize(values []string) []string {
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			result = append(result, value)
+		}
+	}
+	return result
+}
@@ -0,0 +1,71 @@
+package xOptCors_test
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	xConfig "github.com/bamboo-services/bamboo-base-go/major/config"
+	xOptCors "github.com/bamboo-services/bamboo-base-go/major/option/cors"
+)
+
+// TestFromEnvAndConfig 验证 CORS_* 环境变量、配置文件 cors 段与显式选项的合并顺序。
+func TestFromEnvAndConfig(t *testing.T) {
+	t.Setenv("CORS_ALLOW_ORIGINS", "https://env.example.com, https://*.env.example.com")
+	t.Setenv("CORS_MAX_AGE", "1m")
+
+	cfg := xOptCors.New(xOptCors.FromEnv())
+	if got := cfg.AllowOrigins(); !slices.Equal(got, []string{"https://env.example.com", "https://*.env.example.com"}) {
+		t.Errorf("环境变量来源不匹配: %v", got)
+	}
+	if cfg.MaxAge() != time.Minute || cfg.AllowCredentials() {
+		t.Errorf("环境变量 max_age / credentials 不匹配: %v %v", cfg.MaxAge(), cfg.AllowCredentials())
+	}
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	content := "cors:\n  allow_origins: [https://file.example.com]\n  allow_credentials: true\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("写入配置文件失败: %v", err)
+	}
+	// 配置中心同样以 CORS_ALLOW_ORIGINS 覆盖文件值，此处移除以验证文件生效（t.Setenv 会在结束时恢复）
+	_ = os.Unsetenv("CORS_ALLOW_ORIGINS")
+	cc := xConfig.New(xConfig.WithFile(path))
+	if err := cc.Load(); err != nil {
+		t.Fatalf("加载配置失败: %v", err)
+	}
+
+	cfg = xOptCors.New(xOptCors.FromEnv(), xOptCors.FromConfig(cc, xOptCors.ConfigKey), xOptCors.WithMaxAge(0))
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("配置应合法: %v", err)
+	}
+	if got := cfg.AllowOrigins(); !slices.Equal(got, []string{"https://file.example.com"}) {
+		t.Errorf("配置文件来源不匹配: %v", got)
+	}
+	if !cfg.AllowCredentials() || cfg.MaxAge() != 0 {
+		t.Errorf("配置文件 credentials / 显式 max_age 不匹配: %v %v", cfg.AllowCredentials(), cfg.MaxAge())
+	}
+	if len(cfg.AllowMethods()) == 0 {
+		t.Error("配置文件未声明的方法应保持默认值")
+	}
+	if slices.Contains(cfg.ExposeHeaders(), "X-Refresh-Token") {
+		t.Error("默认暴露响应头不应包含刷新令牌 X-Refresh-Token")
+	}
+}
+
+// TestValidate 验证非法来源模式以及 "*" 与凭据的组合被拒绝。
+func TestValidate(t *testing.T) {
+	valid := xOptCors.New(xOptCors.WithAllowOrigins("*", "https://app.example.com", "http://*.example.com:8080"))
+	if err := valid.Validate(); err != nil {
+		t.Errorf("合法来源被拒绝: %v", err)
+	}
+	for _, origin := range []string{"app.example.com", "https://app.*.example.com", "https://*example.com"} {
+		if err := xOptCors.New(xOptCors.WithAllowOrigins(origin)).Validate(); err == nil {
+			t.Errorf("非法来源 %q 应被拒绝", origin)
+		}
+	}
+	if err := xOptCors.New(xOptCors.WithAllowOrigins("*"), xOptCors.WithCredentials()).Validate(); err == nil {
+		t.Error("允许全部来源时启用凭据应被拒绝")
+	}
+}
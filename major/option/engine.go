@@ -0,0 +1,121 @@
+package option
+
+import (
+	"context"
+
+	xOptServer "github.com/bamboo-services/bamboo-base-go/major/option/server"
+	"github.com/gin-gonic/gin"
+)
+
+// EngineConfig 附加 HTTP 服务配置，描述一个命名 Gin 引擎的监听参数与路由注册器。
+//
+// 附加引擎与主引擎（reg.Serve）共享 reg.Init.Ctx 与同一套全局中间件，
+// 由 Runner 与主 HTTP 服务一起启动、一起优雅关闭，典型用法是将健康检查、指标、
+// pprof 与管理路由暴露在仅内网可达的独立端口上。
+type EngineConfig struct {
+	name     string
+	declared bool
+	server   []xOptServer.ServerOption
+	routes   []RouteRegistrar
+}
+
+// Name 返回引擎名称。
+func (e EngineConfig) Name() string { return e.name }
+
+// Declared 判断是否通过 [WithEngine] 声明过监听参数。
+func (e EngineConfig) Declared() bool { return e.declared }
+
+// Server 返回该引擎的 HTTP 服务配置。
+//
+// 与主服务不同，附加引擎不读取 XLF_* 环境变量，监听地址需通过 [WithEngine] 显式指定；
+// 未指定或与主服务、其他附加引擎的监听目标相同时，Register 返回错误。
+func (e EngineConfig) Server() xOptServer.ServerConfig { return xOptServer.New(e.server...) }
+
+// Routes 返回挂载到该引擎的路由注册器，按调用顺序排列。
+func (e EngineConfig) Routes() []RouteRegistrar { return e.routes }
+
+// WithEngine 声明一个命名的附加 Gin 引擎及其 HTTP 服务配置。
+//
+// 同名多次调用时选项按调用顺序叠加。name 为空时跳过。
+// opts 须包含 xOptServer.WithAddr / WithUnixSocket / WithListenFD / WithListener 之一，
+// 否则附加引擎会落到主服务的默认端口，Register 返回错误。使用示例：
+//
+//	xOption.WithEngine("admin", xOptServer.WithAddr("127.0.0.1", "9090")),
+//	xOption.WithEngineRoute("admin", func(ctx context.Context, serve *gin.Engine) {
+//	    xRoute.LogLevel(serve.Group("/admin/log"))
+//	}),
+func WithEngine(name string, opts ...xOptServer.ServerOption) Option {
+	return func(c *Config) {
+		engine := c.engine(name)
+		if engine == nil {
+			return
+		}
+		engine.declared = true
+		for _, o := range opts {
+			if o != nil {
+				engine.server = append(engine.server, o)
+			}
+		}
+	}
+}
+
+// WithEngineRoute 向命名附加引擎注册一个或多个路由注册器，语义同 [WithRoute]。
+//
+// 引擎须通过 [WithEngine] 声明，否则 Register 返回错误。nil 注册器会被跳过。
+func WithEngineRoute(name string, rs ...RouteRegistrar) Option {
+	return func(c *Config) {
+		engine := c.engine(name)
+		if engine == nil {
+			return
+		}
+		for _, r := range rs {
+			if r != nil {
+				engine.routes = append(engine.routes, r)
+			}
+		}
+	}
+}
+
+// WithEngineRouteGroup 向命名附加引擎注册一个带前缀的路由组，语义同 [WithRouteGroup]。
+func WithEngineRouteGroup(name, prefix string, r func(rg *gin.RouterGroup)) Option {
+	if r == nil {
+		return nil
+	}
+	return WithEngineRoute(name, func(ctx context.Context, serve *gin.Engine) {
+		r(serve.Group(prefix))
+	})
+}
+
+// Engines 返回附加引擎配置，按首次出现的顺序排列。
+func (c *Config) Engines() []EngineConfig {
+	engines := make([]EngineConfig, 0, len(c.engines))
+	for _, engine := range c.engines {
+		engines = append(engines, *engine)
+	}
+	return engines
+}
+
+// HasEngine 判断是否通过 [WithEngine] 声明了指定名称的附加引擎。
+func (c *Config) HasEngine(name string) bool {
+	for _, engine := range c.engines {
+		if engine.name == name {
+			return engine.declared
+		}
+	}
+	return false
+}
+
+// engine 按名称查找附加引擎配置，不存在时创建；name 为空返回 nil
+func (c *Config) engine(name string) *EngineConfig {
+	if name == "" {
+		return nil
+	}
+	for _, engine := range c.engines {
+		if engine.name == name {
+			return engine
+		}
+	}
+	engine := &EngineConfig{name: name}
+	c.engines = append(c.engines, engine)
+	return engine
+}
@@ -0,0 +1,35 @@
+package option
+
+import (
+	xOptHealth "github.com/bamboo-services/bamboo-base-go/major/option/health"
+)
+
+// HealthConfig 健康检查配置，详见 [xOptHealth.HealthConfig]。
+type HealthConfig = xOptHealth.HealthConfig
+
+// WithHealth 启用健康检查路由，并将 [xOptHealth.HealthOption] 包裹为顶层 [Option]。
+//
+// 启用后 Register 注册三个路由（默认挂载到主引擎）：
+//   - GET /healthz  存活检查，进程能处理请求即返回 200，不执行依赖检查
+//   - GET /readyz   就绪检查，进程就绪且必需检查全部通过时返回 200，否则 503
+//   - GET /health   详细健康报告（JSON），包含每项检查的状态、耗时与失败原因
+//
+// 检查项来自注册节点（数据库、缓存 Manager、实现 xHealth.Checker 的组件，以及声明了
+// xRegNode.OnCheck 的节点）与 [xOptHealth.WithCheck]。可多次调用叠加，nil 选项会被跳过。
+//
+// 使用示例：
+//
+//	xOption.WithHealth(
+//	    xOptHealth.WithEngine("admin"),
+//	    xOptHealth.WithTimeout(time.Second),
+//	)
+func WithHealth(opts ...xOptHealth.HealthOption) Option {
+	return func(c *Config) {
+		c.healthEnabled = true
+		for _, o := range opts {
+			if o != nil {
+				c.health = append(c.health, o)
+			}
+		}
+	}
+}
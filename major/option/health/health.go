@@ -0,0 +1,141 @@
+// Package xOptHealth 健康检查配置子包，定义 [HealthConfig] 与 [HealthOption]。
+//
+// 与 logger / tracing / server 子包对称：
+//   - 外层 [HealthConfig] 为数据载体，字段小写只读，仅通过 getter 暴露
+//   - [HealthOption] 为修改函数，直接作用于 *HealthConfig
+//   - 各 WithXxx 均返回 [HealthOption]，由父包 [option.WithHealth] 包裹为顶层 Option
+//
+// 该子包不 import option 父包，避免循环依赖。
+package xOptHealth
+
+import (
+	"time"
+
+	xHealth "github.com/bamboo-services/bamboo-base-go/common/health"
+)
+
+const (
+	// DefaultLivenessPath 默认存活检查路径。
+	DefaultLivenessPath = "/healthz"
+
+	// DefaultReadinessPath 默认就绪检查路径。
+	DefaultReadinessPath = "/readyz"
+
+	// DefaultReportPath 默认详细健康报告路径。
+	DefaultReportPath = "/health"
+)
+
+// Check 通过 [WithCheck] 声明的自定义检查项。
+type Check struct {
+	Name    string
+	Checker xHealth.Checker
+	Options []xHealth.CheckOption
+}
+
+// HealthConfig 健康检查配置，描述路由路径、挂载的引擎、检查超时、结果缓存与自定义检查项。
+//
+// 字段均为小写，仅通过 getter 暴露只读视图。零值不可直接使用，请通过 [New] 构造（已填充默认值）。
+type HealthConfig struct {
+	livenessPath  string
+	readinessPath string
+	reportPath    string
+	engine        string
+	timeout       time.Duration
+	cacheTTL      time.Duration
+	checks        []Check
+}
+
+// Paths 返回存活检查、就绪检查与详细报告的路由路径，空串表示不注册该路由。
+func (c HealthConfig) Paths() (liveness, readiness, report string) {
+	return c.livenessPath, c.readinessPath, c.reportPath
+}
+
+// Engine 返回挂载健康检查路由的附加引擎名称，空串表示主引擎。
+func (c HealthConfig) Engine() string { return c.engine }
+
+// Timeout 返回单项检查的默认超时时间。
+func (c HealthConfig) Timeout() time.Duration { return c.timeout }
+
+// CacheTTL 返回检查结果缓存时间。
+func (c HealthConfig) CacheTTL() time.Duration { return c.cacheTTL }
+
+// Checks 返回自定义检查项，按声明顺序排列。
+func (c HealthConfig) Checks() []Check { return append([]Check(nil), c.checks...) }
+
+// HealthOption 是 [HealthConfig] 的二级选项。
+type HealthOption func(*HealthConfig)
+
+// New 构造健康检查配置，先填充默认值再依次应用 opts。
+//
+// 默认值：
+//   - 路由 /healthz、/readyz、/health，挂载到主引擎
+//   - 单项检查超时 3s，结果缓存 2s
+//
+// nil 选项会被跳过。
+func New(opts ...HealthOption) HealthConfig {
+	cfg := HealthConfig{
+		livenessPath:  DefaultLivenessPath,
+		readinessPath: DefaultReadinessPath,
+		reportPath:    DefaultReportPath,
+		timeout:       xHealth.DefaultTimeout,
+		cacheTTL:      xHealth.DefaultCacheTTL,
+	}
+	for _, o := range opts {
+		if o != nil {
+			o(&cfg)
+		}
+	}
+	return cfg
+}
+
+// WithPaths 设置存活检查、就绪检查与详细报告的路由路径，传入 "-" 表示不注册该路由，空串保持原值。
+func WithPaths(liveness, readiness, report string) HealthOption {
+	return func(c *HealthConfig) {
+		setPath(&c.livenessPath, liveness)
+		setPath(&c.readinessPath, readiness)
+		setPath(&c.reportPath, report)
+	}
+}
+
+// WithEngine 将健康检查路由挂载到 xOption.WithEngine 声明的附加引擎（如仅内网可达的管理端口）。
+func WithEngine(name string) HealthOption {
+	return func(c *HealthConfig) { c.engine = name }
+}
+
+// WithTimeout 设置单项检查的默认超时时间，<= 0 时保持原值。
+func WithTimeout(timeout time.Duration) HealthOption {
+	return func(c *HealthConfig) {
+		if timeout > 0 {
+			c.timeout = timeout
+		}
+	}
+}
+
+// WithCacheTTL 设置检查结果缓存时间，0 表示每次请求都重新检查。
+func WithCacheTTL(ttl time.Duration) HealthOption {
+	return func(c *HealthConfig) { c.cacheTTL = max(ttl, 0) }
+}
+
+// WithCheck 追加不对应注册节点的自定义检查项，如下游 HTTP 服务、磁盘空间等。
+//
+// 注册节点的检查通过 xRegNode.OnCheck 或组件实现 xHealth.Checker 声明，无需在此重复。
+// checker 为 nil 时跳过。
+func WithCheck(name string, checker xHealth.Checker, opts ...xHealth.CheckOption) HealthOption {
+	return func(c *HealthConfig) {
+		if checker == nil {
+			return
+		}
+		c.checks = append(c.checks, Check{Name: name, Checker: checker, Options: opts})
+	}
+}
+
+// setPath 按 WithPaths 约定更新路径："-" 禁用，空串保持原值
+func setPath(target *string, path string) {
+	switch path {
+	case "":
+	case "-":
+		*target = ""
+	default:
+		*target = path
+	}
+}
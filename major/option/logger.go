@@ -0,0 +1,31 @@
+package option
+
+import (
+	xOptLogger "github.com/bamboo-services/bamboo-base-go/major/option/logger"
+)
+
+// LoggerConfig 日志配置，详见 [xOptLogger.LoggerConfig]。
+type LoggerConfig = xOptLogger.LoggerConfig
+
+// WithLogger 将 [xOptLogger.LoggerOption] 包裹为顶层 [Option]，供 Register 使用。
+//
+// 可多次调用叠加，选项按调用顺序追加。Register 装配日志时的优先级为：
+// 默认值 < LOG_* 环境变量（[xOptLogger.FromEnv]） < WithLogger 显式选项。
+// nil LoggerOption 会被跳过。
+//
+// 使用示例：
+//
+//	xOption.WithLogger(
+//	    xOptLogger.WithPath("/var/log/app"),
+//	    xOptLogger.WithMaxAge(7),
+//	    xOptLogger.WithMaxBackups(30),
+//	)
+func WithLogger(opts ...xOptLogger.LoggerOption) Option {
+	return func(c *Config) {
+		for _, o := range opts {
+			if o != nil {
+				c.logger = append(c.logger, o)
+			}
+		}
+	}
+}
@@ -0,0 +1,449 @@
+// Package xOptLogger 日志配置子包，定义 [LoggerConfig] 与 [LoggerOption]。
+//
+// 与 cache / database 子包对称：
+//   - 外层 [LoggerConfig] 为数据载体，字段小写只读，仅通过 getter 暴露
+//   - [LoggerOption] 为修改函数，直接作用于 *LoggerConfig
+//   - [FromEnv] 与各 WithXxx 均返回 [LoggerOption]，由父包 [option.WithLogger] 包裹为顶层 Option
+//
+// 该子包不 import option 父包，避免循环依赖。
+package xOptLogger
+
+import (
+	"log/slog"
+	"strings"
+	"time"
+
+	xLog "github.com/bamboo-services/bamboo-base-go/common/log"
+	xCtxUtil "github.com/bamboo-services/bamboo-base-go/common/utility/context"
+	xEnv "github.com/bamboo-services/bamboo-base-go/defined/env"
+)
+
+const (
+	// DefaultPath 默认日志目录。
+	DefaultPath = ".logs"
+
+	// DefaultMaxSize 默认单个日志文件最大大小（MB）。
+	DefaultMaxSize = 10
+)
+
+// LoggerConfig 日志配置，描述日志级别、输出目录、切割阈值与保留策略。
+//
+// 字段均为小写，仅通过 getter 暴露只读视图，避免下游直接修改内部状态。
+// 零值不可直接使用，请通过 [New] 构造（已填充默认值）。
+type LoggerConfig struct {
+	level      slog.Level
+	levels     map[string]slog.Level
+	path       string
+	maxSize    int
+	maxAge     int
+	maxBackups int
+	compress   bool
+
+	rotation    xLog.RotationPolicy
+	maxArchives int
+	compression xLog.Compression
+	onArchive   xLog.ArchiveHook
+
+	consoleFormat xLog.Format
+	fileFormat    xLog.Format
+
+	sinks         []xLog.Sink
+	async         *xLog.AsyncConfig
+	syslogNetwork string
+	syslogAddr    string
+	sampling      *xLog.SamplingPolicy
+	redact        xLog.RedactPolicy
+}
+
+// Level 返回初始日志级别。
+func (c LoggerConfig) Level() slog.Level { return c.level }
+
+// LoggerLevels 返回按 logger 名称单独设置的初始级别。
+func (c LoggerConfig) LoggerLevels() map[string]slog.Level { return c.levels }
+
+// Path 返回日志目录。
+func (c LoggerConfig) Path() string { return c.path }
+
+// MaxSize 返回单个日志文件最大大小（MB）。
+func (c LoggerConfig) MaxSize() int { return c.maxSize }
+
+// MaxAge 返回切割文件与归档的最大保留天数，0 表示不按时间清理。
+func (c LoggerConfig) MaxAge() int { return c.maxAge }
+
+// MaxBackups 返回切割文件的最大保留数量，0 表示不限制。
+func (c LoggerConfig) MaxBackups() int { return c.maxBackups }
+
+// MaxArchives 返回归档的最大保留数量，0 表示沿用 MaxBackups。
+func (c LoggerConfig) MaxArchives() int { return c.maxArchives }
+
+// Compress 返回是否将前一天的日志打包归档。
+func (c LoggerConfig) Compress() bool { return c.compress }
+
+// Rotation 返回切割策略，nil 表示使用默认的跨天 + 大小切割。
+func (c LoggerConfig) Rotation() xLog.RotationPolicy { return c.rotation }
+
+// Compression 返回归档压缩算法，默认 gzip。
+func (c LoggerConfig) Compression() xLog.Compression { return c.compression }
+
+// OnArchive 返回归档完成后的回调，nil 表示未设置。
+func (c LoggerConfig) OnArchive() xLog.ArchiveHook { return c.onArchive }
+
+// ConsoleFormat 返回控制台输出格式。
+func (c LoggerConfig) ConsoleFormat() xLog.Format { return c.consoleFormat }
+
+// FileFormat 返回文件输出格式。
+func (c LoggerConfig) FileFormat() xLog.Format { return c.fileFormat }
+
+// Sinks 返回额外的日志输出目标。
+func (c LoggerConfig) Sinks() []xLog.Sink { return c.sinks }
+
+// Async 返回异步写入配置，nil 表示同步写入。
+func (c LoggerConfig) Async() *xLog.AsyncConfig { return c.async }
+
+// Syslog 返回 syslog 输出的协议与地址，addr 为空表示未启用。
+func (c LoggerConfig) Syslog() (network, addr string) { return c.syslogNetwork, c.syslogAddr }
+
+// Sampling 返回采样与重复抑制策略，nil 表示不启用。
+func (c LoggerConfig) Sampling() *xLog.SamplingPolicy { return c.sampling }
+
+// Redact 返回敏感数据脱敏策略，默认使用 [xLog.DefaultRedactRules]。
+func (c LoggerConfig) Redact() xLog.RedactPolicy { return c.redact }
+
+// LoggerOption 是 [LoggerConfig] 的二级选项。
+type LoggerOption func(*LoggerConfig)
+
+// New 构造日志配置，先填充默认值再依次应用 opts。
+//
+// 默认值与历史硬编码行为保持一致：
+//   - 级别: 调试模式为 debug，否则为 info
+//   - 目录: .logs
+//   - 单文件大小: 10MB
+//   - 跨天或超过单文件大小时切割，不按时间/数量清理，启用 tar.gz 归档
+//   - 控制台彩色文本，文件 JSON
+//
+// nil 选项会被跳过。
+func New(opts ...LoggerOption) LoggerConfig {
+	cfg := LoggerConfig{
+		level:    slog.LevelInfo,
+		path:     DefaultPath,
+		maxSize:  DefaultMaxSize,
+		compress: true,
+
+		compression: xLog.CompressionGzip,
+
+		consoleFormat: xLog.FormatText,
+		fileFormat:    xLog.FormatJSON,
+	}
+	if xCtxUtil.IsDebugMode() {
+		cfg.level = slog.LevelDebug
+	}
+	for _, o := range opts {
+		if o != nil {
+			o(&cfg)
+		}
+	}
+	return cfg
+}
+
+// WithLevel 设置初始日志级别。
+//
+// 运行期可通过配置中心的 log.level 动态调整。
+func WithLevel(level slog.Level) LoggerOption {
+	return func(c *LoggerConfig) { c.level = level }
+}
+
+// WithLoggerLevel 为指定 logger（WithName 的 name，如 xLog.NamedREPO）单独设置初始级别。
+//
+// 运行期可通过 xLog.Levels() 或 xRoute.LogLevel 管理路由继续调整。
+func WithLoggerLevel(name string, level slog.Level) LoggerOption {
+	return func(c *LoggerConfig) {
+		if c.levels == nil {
+			c.levels = make(map[string]slog.Level)
+		}
+		c.levels[name] = level
+	}
+}
+
+// WithPath 设置日志目录，空串保持原值。
+func WithPath(path string) LoggerOption {
+	return func(c *LoggerConfig) {
+		if path != "" {
+			c.path = path
+		}
+	}
+}
+
+// WithMaxSize 设置单个日志文件最大大小（MB），<= 0 时保持原值。
+func WithMaxSize(mb int) LoggerOption {
+	return func(c *LoggerConfig) {
+		if mb > 0 {
+			c.maxSize = mb
+		}
+	}
+}
+
+// WithMaxAge 设置切割文件与归档的最大保留天数，0 表示不按时间清理。
+func WithMaxAge(days int) LoggerOption {
+	return func(c *LoggerConfig) { c.maxAge = max(days, 0) }
+}
+
+// WithMaxBackups 设置切割文件的最大保留数量，0 表示不限制；未设置 [WithMaxArchives] 时同样约束归档数量。
+func WithMaxBackups(n int) LoggerOption {
+	return func(c *LoggerConfig) { c.maxBackups = max(n, 0) }
+}
+
+// WithMaxArchives 设置归档的最大保留数量，0 表示沿用 [WithMaxBackups]。
+func WithMaxArchives(n int) LoggerOption {
+	return func(c *LoggerConfig) { c.maxArchives = max(n, 0) }
+}
+
+// WithCompress 设置是否将前一天的日志打包归档。
+//
+// 关闭后旧日志以原文件形式保留，仍受 [WithMaxAge] / [WithMaxBackups] 约束。
+func WithCompress(compress bool) LoggerOption {
+	return func(c *LoggerConfig) { c.compress = compress }
+}
+
+// WithRotation 设置切割策略，多个策略任意一个触发即切割，不传或全为 nil 时恢复默认策略。
+//
+// 按大小切割需显式包含 [xLog.SizePolicy]，例如每小时或超过 100MB 时切割：
+//
+//	xOptLogger.WithRotation(xLog.HourlyPolicy(), xLog.SizePolicy(100<<20))
+func WithRotation(policies ...xLog.RotationPolicy) LoggerOption {
+	return func(c *LoggerConfig) {
+		c.rotation = nil
+		for _, policy := range policies {
+			if policy != nil {
+				c.rotation = xLog.AnyPolicy(policies...)
+				return
+			}
+		}
+	}
+}
+
+// WithCompression 设置归档压缩算法，支持 [xLog.CompressionGzip] 与 [xLog.CompressionZstd]。
+func WithCompression(compression xLog.Compression) LoggerOption {
+	return func(c *LoggerConfig) {
+		if compression != "" {
+			c.compression = compression
+		}
+	}
+}
+
+// WithArchiveHook 设置归档完成后的回调，可用于将归档上传至对象存储后再交由保留策略清理。
+func WithArchiveHook(hook xLog.ArchiveHook) LoggerOption {
+	return func(c *LoggerConfig) { c.onArchive = hook }
+}
+
+// WithConsoleFormat 设置控制台输出格式。
+//
+// 容器环境中可设为 [xLog.FormatJSON]，让标准输出直接被日志采集器解析。
+func WithConsoleFormat(format xLog.Format) LoggerOption {
+	return func(c *LoggerConfig) { c.consoleFormat = format }
+}
+
+// WithFileFormat 设置文件输出格式，支持 [xLog.FormatJSON] 与 [xLog.FormatLogfmt]。
+func WithFileFormat(format xLog.Format) LoggerOption {
+	return func(c *LoggerConfig) { c.fileFormat = format }
+}
+
+// WithSinks 追加额外的日志输出目标（如 [xLog.NewRingSink]），可多次调用叠加。
+//
+// nil 会被跳过。输出目标与控制台、文件一并接收每条日志。
+func WithSinks(sinks ...xLog.Sink) LoggerOption {
+	return func(c *LoggerConfig) {
+		for _, sink := range sinks {
+			if sink != nil {
+				c.sinks = append(c.sinks, sink)
+			}
+		}
+	}
+}
+
+// WithAsync 启用异步写入，日志先进入容量为 queueSize 的有界队列，由后台协程写出。
+//
+// policy 决定队列满时阻塞调用方还是丢弃日志；queueSize <= 0 表示关闭异步写入。
+// 未写出的日志在 xMain.Runner 退出前刷新。
+func WithAsync(queueSize int, policy xLog.OverflowPolicy) LoggerOption {
+	return func(c *LoggerConfig) {
+		if queueSize <= 0 {
+			c.async = nil
+			return
+		}
+		c.async = &xLog.AsyncConfig{QueueSize: queueSize, Policy: policy}
+	}
+}
+
+// WithSyslog 启用 syslog 输出，network 为 udp 或 tcp，消息体格式与文件输出格式一致。
+func WithSyslog(network, addr string) LoggerOption {
+	return func(c *LoggerConfig) {
+		c.syslogNetwork = network
+		c.syslogAddr = addr
+	}
+}
+
+// WithSampling 设置所有 logger 默认的采样与重复抑制配置。
+//
+// 已通过 [WithLoggerSampling] 单独设置的 logger 不受影响。
+func WithSampling(config xLog.SamplingConfig) LoggerOption {
+	return func(c *LoggerConfig) {
+		c.samplingPolicy().Default = &config
+	}
+}
+
+// WithLoggerSampling 为指定 logger 单独设置采样配置，config 为 nil 表示该 logger 永不采样。
+//
+// 例如只对 NamedRESU 的 WARN 限流，而 NamedINIT 始终完整输出：
+//
+//	xOptLogger.WithLoggerSampling(xLog.NamedRESU, &xLog.SamplingConfig{
+//	    First: 10, Thereafter: 100, Dedup: true, Levels: []slog.Level{slog.LevelWarn},
+//	})
+//	xOptLogger.WithLoggerSampling(xLog.NamedINIT, nil)
+func WithLoggerSampling(name string, config *xLog.SamplingConfig) LoggerOption {
+	return func(c *LoggerConfig) {
+		policy := c.samplingPolicy()
+		if policy.Loggers == nil {
+			policy.Loggers = make(map[string]*xLog.SamplingConfig)
+		}
+		policy.Loggers[name] = config
+	}
+}
+
+// samplingPolicy 返回采样策略，未初始化时创建
+func (c *LoggerConfig) samplingPolicy() *xLog.SamplingPolicy {
+	if c.sampling == nil {
+		c.sampling = &xLog.SamplingPolicy{}
+	}
+	return c.sampling
+}
+
+// WithRedactRules 追加按字段名匹配的脱敏规则，优先于默认规则，可多次调用叠加。
+//
+// 规则同时作用于日志属性、xResult/xError 的 data 以及 HTTP 请求日志的请求头、查询参数与请求体：
+//
+//	xOptLogger.WithRedactRules(
+//	    xLog.RedactRule{Pattern: "*address*"},
+//	    xLog.RedactRule{Pattern: "real_name", Masker: xLog.MaskerOmit},
+//	)
+func WithRedactRules(rules ...xLog.RedactRule) LoggerOption {
+	return func(c *LoggerConfig) {
+		c.redact.Rules = append(c.redact.Rules, rules...)
+	}
+}
+
+// WithMasker 注册自定义脱敏器，可在 [xLog.RedactRule].Masker 与结构体标签 `log:"<name>"` 中引用。
+//
+// 与内置脱敏器（mask/phone/email/idcard/bankcard）同名时覆盖内置实现，nil 会被跳过。
+func WithMasker(name string, masker xLog.Masker) LoggerOption {
+	return func(c *LoggerConfig) {
+		if name == "" || masker == nil {
+			return
+		}
+		if c.redact.Maskers == nil {
+			c.redact.Maskers = make(map[string]xLog.Masker)
+		}
+		c.redact.Maskers[name] = masker
+	}
+}
+
+// WithoutDefaultRedactRules 不使用 [xLog.DefaultRedactRules]，仅按 [WithRedactRules] 与结构体标签脱敏。
+func WithoutDefaultRedactRules() LoggerOption {
+	return func(c *LoggerConfig) { c.redact.DisableDefaults = true }
+}
+
+// FromEnv 从环境变量构造日志配置的 [LoggerOption]。
+//
+// 读取的环境变量（仅覆盖已设置且合法的项，否则保持当前值）:
+//   - LOG_LEVEL        debug/info/notice/warn/error，无法识别时忽略
+//   - LOG_LEVELS       按 logger 名称的级别，如 REPO=debug,HTTP=warn，无法识别的项忽略
+//   - LOG_PATH         日志目录
+//   - LOG_MAX_SIZE     单个日志文件最大大小（MB）
+//   - LOG_MAX_AGE      最大保留天数
+//   - LOG_MAX_BACKUPS  切割文件最大保留数量
+//   - LOG_MAX_ARCHIVES 归档最大保留数量
+//   - LOG_COMPRESS     是否打包归档
+//   - LOG_COMPRESSION  归档压缩算法 gzip/zstd，无法识别时忽略
+//   - LOG_ROTATE       切割策略，逗号分隔的 size/hourly/daily 组合，size 使用当前单文件大小，无法识别时忽略
+//   - LOG_CONSOLE_FORMAT  控制台输出格式 text/json/logfmt，无法识别时忽略
+//   - LOG_FILE_FORMAT     文件输出格式 json/logfmt，无法识别时忽略
+//   - LOG_ASYNC_QUEUE_SIZE  异步写入队列容量，> 0 时启用异步写入
+//   - LOG_ASYNC_POLICY      异步队列满时的策略 block/drop，默认 block
+//   - LOG_SYSLOG_ADDR       syslog 地址，形如 udp://127.0.0.1:514 或 tcp://host:601
+//   - LOG_SAMPLE_FIRST / LOG_SAMPLE_THEREAFTER / LOG_SAMPLE_INTERVAL / LOG_SAMPLE_DEDUP
+//     默认采样配置，FIRST > 0 或 DEDUP=true 时启用
+//   - LOG_SAMPLE_EXCLUDE    永不采样的 logger 名称，逗号分隔
+//   - LOG_REDACT_KEYS       额外的脱敏字段规则，逗号分隔的 pattern[=masker]，如 *address*,id_number=idcard
+//
+// 该函数依赖 .env 已在 Register 阶段通过 godotenv 加载完成。
+func FromEnv() LoggerOption {
+	return func(c *LoggerConfig) {
+		if level, ok := xLog.ParseLevel(xEnv.GetEnvString(xEnv.LogLevel, "")); ok {
+			c.level = level
+		}
+		for _, item := range strings.Split(xEnv.GetEnvString(xEnv.LogLevels, ""), ",") {
+			name, text, ok := strings.Cut(item, "=")
+			if level, valid := xLog.ParseLevel(text); ok && valid && strings.TrimSpace(name) != "" {
+				WithLoggerLevel(strings.TrimSpace(name), level)(c)
+			}
+		}
+		if path := xEnv.GetEnvString(xEnv.LogPath, ""); path != "" {
+			c.path = path
+		}
+		if size := xEnv.GetEnvInt(xEnv.LogMaxSize, 0); size > 0 {
+			c.maxSize = size
+		}
+		c.maxAge = max(xEnv.GetEnvInt(xEnv.LogMaxAge, c.maxAge), 0)
+		c.maxBackups = max(xEnv.GetEnvInt(xEnv.LogMaxBackups, c.maxBackups), 0)
+		c.maxArchives = max(xEnv.GetEnvInt(xEnv.LogMaxArchives, c.maxArchives), 0)
+		c.compress = xEnv.GetEnvBool(xEnv.LogCompress, c.compress)
+		if compression, ok := xLog.ParseCompression(xEnv.GetEnvString(xEnv.LogCompression, "")); ok {
+			c.compression = compression
+		}
+		if policy, ok := xLog.ParseRotationPolicy(xEnv.GetEnvString(xEnv.LogRotate, ""), int64(c.maxSize)*1024*1024); ok {
+			c.rotation = policy
+		}
+		if format, ok := xLog.ParseFormat(xEnv.GetEnvString(xEnv.LogConsoleFormat, "")); ok {
+			c.consoleFormat = format
+		}
+		if format, ok := xLog.ParseFormat(xEnv.GetEnvString(xEnv.LogFileFormat, "")); ok {
+			c.fileFormat = format
+		}
+		if size := xEnv.GetEnvInt(xEnv.LogAsyncQueueSize, 0); size > 0 {
+			policy, _ := xLog.ParseOverflowPolicy(xEnv.GetEnvString(xEnv.LogAsyncPolicy, ""))
+			c.async = &xLog.AsyncConfig{QueueSize: size, Policy: policy}
+		}
+		sampleFromEnv(c)
+		for _, item := range strings.Split(xEnv.GetEnvString(xEnv.LogRedactKeys, ""), ",") {
+			pattern, masker, _ := strings.Cut(item, "=")
+			if pattern = strings.TrimSpace(pattern); pattern != "" {
+				WithRedactRules(xLog.RedactRule{Pattern: pattern, Masker: strings.TrimSpace(masker)})(c)
+			}
+		}
+		if addr := xEnv.GetEnvString(xEnv.LogSyslogAddr, ""); addr != "" {
+			c.syslogNetwork, c.syslogAddr = "udp", addr
+			if network, rest, ok := strings.Cut(addr, "://"); ok {
+				c.syslogNetwork, c.syslogAddr = network, rest
+			}
+		}
+	}
+}
+
+// sampleFromEnv 读取 LOG_SAMPLE_* 环境变量
+func sampleFromEnv(c *LoggerConfig) {
+	config := xLog.SamplingConfig{
+		First:      xEnv.GetEnvInt(xEnv.LogSampleFirst, 0),
+		Thereafter: xEnv.GetEnvInt(xEnv.LogSampleThereafter, 0),
+		Dedup:      xEnv.GetEnvBool(xEnv.LogSampleDedup, false),
+	}
+	if interval, err := time.ParseDuration(xEnv.GetEnvString(xEnv.LogSampleInterval, "")); err == nil {
+		config.Interval = interval
+	}
+	if config.First > 0 || config.Dedup {
+		WithSampling(config)(c)
+	}
+	for _, name := range strings.Split(xEnv.GetEnvString(xEnv.LogSampleExclude, ""), ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			WithLoggerSampling(name, nil)(c)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package xOptLogger_test
+
+import (
+	"log/slog"
+	"testing"
+	"time"
+
+	xLog "github.com/bamboo-services/bamboo-base-go/common/log"
+	xOptLogger "github.com/bamboo-services/bamboo-base-go/major/option/logger"
+)
+
+// TestFromEnv_OverridesDefaults 验证 LOG_* 环境变量覆盖默认值。
+func TestFromEnv_OverridesDefaults(t *testing.T) {
+	t.Setenv("LOG_LEVEL", "warn")
+	t.Setenv("LOG_PATH", "/tmp/app-logs")
+	t.Setenv("LOG_MAX_SIZE", "50")
+	t.Setenv("LOG_MAX_AGE", "7")
+	t.Setenv("LOG_MAX_BACKUPS", "3")
+	t.Setenv("LOG_COMPRESS", "false")
+
+	cfg := xOptLogger.New(xOptLogger.FromEnv())
+	if cfg.Level() != slog.LevelWarn {
+		t.Errorf("Level 不匹配: got=%v want=%v", cfg.Level(), slog.LevelWarn)
+	}
+	if cfg.Path() != "/tmp/app-logs" {
+		t.Errorf("Path 不匹配: got=%q", cfg.Path())
+	}
+	if cfg.MaxSize() != 50 || cfg.MaxAge() != 7 || cfg.MaxBackups() != 3 {
+		t.Errorf("切割/保留参数不匹配: size=%d age=%d backups=%d", cfg.MaxSize(), cfg.MaxAge(), cfg.MaxBackups())
+	}
+	if cfg.Compress() {
+		t.Error("LOG_COMPRESS=false 时 Compress 应为 false")
+	}
+}
+
+// TestFromEnv_InvalidKeepsDefaults 验证非法或缺失的环境变量保持默认值，显式选项优先于环境变量。
+func TestFromEnv_InvalidKeepsDefaults(t *testing.T) {
+	t.Setenv("XLF_DEBUG", "false")
+	t.Setenv("LOG_LEVEL", "verbose")
+	t.Setenv("LOG_MAX_SIZE", "abc")
+	t.Setenv("LOG_MAX_AGE", "-1")
+
+	cfg := xOptLogger.New(xOptLogger.FromEnv(), xOptLogger.WithMaxBackups(5))
+	if cfg.Level() != slog.LevelInfo {
+		t.Errorf("非法 LOG_LEVEL 应保持默认 info: got=%v", cfg.Level())
+	}
+	if cfg.Path() != xOptLogger.DefaultPath || cfg.MaxSize() != xOptLogger.DefaultMaxSize {
+		t.Errorf("默认值不匹配: path=%q size=%d", cfg.Path(), cfg.MaxSize())
+	}
+	if cfg.MaxAge() != 0 {
+		t.Errorf("负数 LOG_MAX_AGE 应归零: got=%d", cfg.MaxAge())
+	}
+	if cfg.MaxBackups() != 5 {
+		t.Errorf("显式选项应生效: got=%d", cfg.MaxBackups())
+	}
+	if !cfg.Compress() {
+		t.Error("默认应启用归档")
+	}
+}
+
+// TestFromEnv_Formats 验证 LOG_CONSOLE_FORMAT / LOG_FILE_FORMAT 解析，非法值保持默认。
+func TestFromEnv_Formats(t *testing.T) {
+	t.Setenv("LOG_CONSOLE_FORMAT", "json")
+	t.Setenv("LOG_FILE_FORMAT", "yaml")
+
+	cfg := xOptLogger.New(xOptLogger.FromEnv())
+	if cfg.ConsoleFormat() != xLog.FormatJSON {
+		t.Errorf("ConsoleFormat 不匹配: got=%q", cfg.ConsoleFormat())
+	}
+	if cfg.FileFormat() != xLog.FormatJSON {
+		t.Errorf("非法 LOG_FILE_FORMAT 应保持默认 json: got=%q", cfg.FileFormat())
+	}
+}
+
+// TestFromEnv_AsyncAndSyslog 验证异步队列与 syslog 地址解析。
+func TestFromEnv_AsyncAndSyslog(t *testing.T) {
+	t.Setenv("LOG_ASYNC_QUEUE_SIZE", "256")
+	t.Setenv("LOG_ASYNC_POLICY", "drop")
+	t.Setenv("LOG_SYSLOG_ADDR", "tcp://syslog.local:601")
+
+	cfg := xOptLogger.New(xOptLogger.FromEnv())
+	if async := cfg.Async(); async == nil || async.QueueSize != 256 || async.Policy != xLog.OverflowDrop {
+		t.Errorf("Async 不匹配: %+v", async)
+	}
+	if network, addr := cfg.Syslog(); network != "tcp" || addr != "syslog.local:601" {
+		t.Errorf("Syslog 不匹配: network=%q addr=%q", network, addr)
+	}
+
+	cfg = xOptLogger.New(xOptLogger.FromEnv(), xOptLogger.WithAsync(0, ""))
+	if cfg.Async() != nil {
+		t.Error("WithAsync(0) 应关闭异步写入")
+	}
+}
+
+// TestFromEnv_LoggerLevels 验证 LOG_LEVELS 解析，非法项被忽略。
+func TestFromEnv_LoggerLevels(t *testing.T) {
+	t.Setenv("LOG_LEVELS", "REPO=debug, HTTP=warn,bad,CRON=loud")
+
+	levels := xOptLogger.New(xOptLogger.FromEnv()).LoggerLevels()
+	if len(levels) != 2 || levels["REPO"] != slog.LevelDebug || levels["HTTP"] != slog.LevelWarn {
+		t.Errorf("LoggerLevels 不匹配: %v", levels)
+	}
+}
+
+// TestFromEnv_Sampling 验证 LOG_SAMPLE_* 解析与排除列表。
+func TestFromEnv_Sampling(t *testing.T) {
+	t.Setenv("LOG_SAMPLE_FIRST", "10")
+	t.Setenv("LOG_SAMPLE_THEREAFTER", "50")
+	t.Setenv("LOG_SAMPLE_INTERVAL", "2s")
+	t.Setenv("LOG_SAMPLE_EXCLUDE", "INIT, MAIN")
+
+	policy := xOptLogger.New(xOptLogger.FromEnv()).Sampling()
+	if policy == nil || policy.Default == nil {
+		t.Fatal("应启用默认采样")
+	}
+	if policy.Default.First != 10 || policy.Default.Thereafter != 50 || policy.Default.Interval != 2*time.Second {
+		t.Errorf("默认采样配置不匹配: %+v", policy.Default)
+	}
+	for _, name := range []string{"INIT", "MAIN"} {
+		if c, ok := policy.Loggers[name]; !ok || c != nil {
+			t.Errorf("%s 应永不采样: %v, %v", name, c, ok)
+		}
+	}
+}
+
+// TestFromEnv_Redact 验证 LOG_REDACT_KEYS 解析，显式规则追加在环境变量规则之后。
+func TestFromEnv_Redact(t *testing.T) {
+	t.Setenv("LOG_REDACT_KEYS", "*address*, id_number=idcard,")
+
+	policy := xOptLogger.New(xOptLogger.FromEnv(), xOptLogger.WithRedactRules(xLog.RedactRule{Pattern: "remark"})).Redact()
+	want := []xLog.RedactRule{{Pattern: "*address*"}, {Pattern: "id_number", Masker: xLog.MaskerIDCard}, {Pattern: "remark"}}
+	if len(policy.Rules) != len(want) {
+		t.Fatalf("规则数量不匹配: %+v", policy.Rules)
+	}
+	for i, rule := range want {
+		if policy.Rules[i] != rule {
+			t.Errorf("规则 %d 不匹配: got=%+v want=%+v", i, policy.Rules[i], rule)
+		}
+	}
+	if policy.DisableDefaults {
+		t.Error("默认应启用内置规则")
+	}
+}
+
+// TestFromEnv_Rotation 验证 LOG_ROTATE / LOG_COMPRESSION / LOG_MAX_ARCHIVES 解析。
+func TestFromEnv_Rotation(t *testing.T) {
+	t.Setenv("LOG_ROTATE", "hourly,size")
+	t.Setenv("LOG_MAX_SIZE", "1")
+	t.Setenv("LOG_COMPRESSION", "ZSTD")
+	t.Setenv("LOG_MAX_ARCHIVES", "14")
+
+	cfg := xOptLogger.New(xOptLogger.FromEnv())
+	if cfg.Compression() != xLog.CompressionZstd || cfg.MaxArchives() != 14 {
+		t.Errorf("归档配置不匹配: compression=%q archives=%d", cfg.Compression(), cfg.MaxArchives())
+	}
+	policy := cfg.Rotation()
+	if policy == nil {
+		t.Fatal("应设置切割策略")
+	}
+	now := time.Now()
+	if !policy.ShouldRotate(xLog.RotationState{Size: 1 << 20, Incoming: 1, OpenedAt: now, Now: now}) {
+		t.Error("size 阈值应取 LOG_MAX_SIZE")
+	}
+	if !policy.ShouldRotate(xLog.RotationState{Size: 1, OpenedAt: now.Add(-2 * time.Hour), Now: now}) {
+		t.Error("hourly 应生效")
+	}
+
+	if xOptLogger.New(xOptLogger.FromEnv(), xOptLogger.WithRotation()).Rotation() != nil {
+		t.Error("WithRotation 不传策略时应恢复默认")
+	}
+}
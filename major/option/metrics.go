@@ -0,0 +1,32 @@
+package option
+
+import (
+	xOptMetrics "github.com/bamboo-services/bamboo-base-go/major/option/metrics"
+)
+
+// MetricsConfig 指标导出配置，详见 [xOptMetrics.MetricsConfig]。
+type MetricsConfig = xOptMetrics.MetricsConfig
+
+// WithMetrics 启用 Prometheus 指标导出路由，并将 [xOptMetrics.MetricsOption] 包裹为顶层 [Option]。
+//
+// 启用后 Register 注册 GET /metrics（默认挂载到主引擎），以 Prometheus 文本格式导出
+// xMetrics 默认注册表：HTTP 请求、gRPC 调用、GORM 查询与连接池、缓存命中/未命中/淘汰、
+// Cron 任务与异步任务等内置指标，以及业务通过 xMetrics.NewCounter 等声明的自定义指标。
+// 未启用时内置埋点照常采集，可自行通过 xMetrics.Handler() 暴露。可多次调用叠加，nil 选项会被跳过。
+//
+// 使用示例：
+//
+//	xOption.WithMetrics(
+//	    xOptMetrics.WithEngine("admin"),
+//	    xOptMetrics.WithPath("/internal/metrics"),
+//	)
+func WithMetrics(opts ...xOptMetrics.MetricsOption) Option {
+	return func(c *Config) {
+		c.metricsEnabled = true
+		for _, o := range opts {
+			if o != nil {
+				c.metrics = append(c.metrics, o)
+			}
+		}
+	}
+}
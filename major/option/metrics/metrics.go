@@ -0,0 +1,80 @@
+// Package xOptMetrics 指标导出配置子包，定义 [MetricsConfig] 与 [MetricsOption]。
+//
+// 与 logger / tracing / health 子包对称：
+//   - 外层 [MetricsConfig] 为数据载体，字段小写只读，仅通过 getter 暴露
+//   - [MetricsOption] 为修改函数，直接作用于 *MetricsConfig
+//   - 各 WithXxx 均返回 [MetricsOption]，由父包 [option.WithMetrics] 包裹为顶层 Option
+//
+// 该子包不 import option 父包，避免循环依赖。
+package xOptMetrics
+
+import (
+	xMetrics "github.com/bamboo-services/bamboo-base-go/common/metrics"
+)
+
+// DefaultPath 默认指标导出路径。
+const DefaultPath = "/metrics"
+
+// MetricsConfig 指标导出配置，描述路由路径、挂载的引擎与导出的注册表。
+//
+// 字段均为小写，仅通过 getter 暴露只读视图。零值不可直接使用，请通过 [New] 构造（已填充默认值）。
+type MetricsConfig struct {
+	path     string
+	engine   string
+	registry *xMetrics.Registry
+}
+
+// Path 返回指标导出路由路径。
+func (c MetricsConfig) Path() string { return c.path }
+
+// Engine 返回挂载指标路由的附加引擎名称，空串表示主引擎。
+func (c MetricsConfig) Engine() string { return c.engine }
+
+// Registry 返回导出的指标注册表。
+func (c MetricsConfig) Registry() *xMetrics.Registry { return c.registry }
+
+// MetricsOption 是 [MetricsConfig] 的二级选项。
+type MetricsOption func(*MetricsConfig)
+
+// New 构造指标导出配置，先填充默认值再依次应用 opts。
+//
+// 默认值：路由 /metrics，挂载到主引擎，导出 xMetrics.Default() 注册表。
+//
+// nil 选项会被跳过。
+func New(opts ...MetricsOption) MetricsConfig {
+	cfg := MetricsConfig{
+		path:     DefaultPath,
+		registry: xMetrics.Default(),
+	}
+	for _, o := range opts {
+		if o != nil {
+			o(&cfg)
+		}
+	}
+	return cfg
+}
+
+// WithPath 设置指标导出路由路径，空串时保持原值。
+func WithPath(path string) MetricsOption {
+	return func(c *MetricsConfig) {
+		if path != "" {
+			c.path = path
+		}
+	}
+}
+
+// WithEngine 将指标路由挂载到 xOption.WithEngine 声明的附加引擎（如仅内网可达的管理端口）。
+func WithEngine(name string) MetricsOption {
+	return func(c *MetricsConfig) { c.engine = name }
+}
+
+// WithRegistry 导出自定义的指标注册表，nil 时保持原值。
+//
+// 框架内置埋点始终写入 xMetrics.Default()，替换后内置指标不再导出，通常仅用于测试或隔离场景。
+func WithRegistry(registry *xMetrics.Registry) MetricsOption {
+	return func(c *MetricsConfig) {
+		if registry != nil {
+			c.registry = registry
+		}
+	}
+}
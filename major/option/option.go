@@ -1,7 +1,14 @@
 package option
 
 import (
+	xConfig "github.com/bamboo-services/bamboo-base-go/major/config"
+	xOptCors "github.com/bamboo-services/bamboo-base-go/major/option/cors"
 	xOptDatabase "github.com/bamboo-services/bamboo-base-go/major/option/database"
+	xOptHealth "github.com/bamboo-services/bamboo-base-go/major/option/health"
+	xOptLogger "github.com/bamboo-services/bamboo-base-go/major/option/logger"
+	xOptMetrics "github.com/bamboo-services/bamboo-base-go/major/option/metrics"
+	xOptServer "github.com/bamboo-services/bamboo-base-go/major/option/server"
+	xOptTracing "github.com/bamboo-services/bamboo-base-go/major/option/tracing"
 )
 
 // Option 定义应用级配置选项，采用函数式选项模式（functional options）。
@@ -30,6 +37,20 @@ type Config struct {
 	cache    CacheConfig
 	database xOptDatabase.DatabaseConfig
 	routes   []RouteRegistrar
+	config   *xConfig.Config
+	logger   []xOptLogger.LoggerOption
+	tracing  []xOptTracing.TracingOption
+	server   []xOptServer.ServerOption
+	engines  []*EngineConfig
+
+	health        []xOptHealth.HealthOption
+	healthEnabled bool
+
+	metrics        []xOptMetrics.MetricsOption
+	metricsEnabled bool
+
+	cors        []xOptCors.CorsOption
+	corsEnabled bool
 }
 
 // Apply 将传入的选项逐个应用到 [Config]，返回装配完成的配置实例。
@@ -52,6 +73,58 @@ func (c *Config) Cache() CacheConfig { return c.cache }
 // Database 返回数据库配置的只读视图。
 func (c *Config) Database() xOptDatabase.DatabaseConfig { return c.database }
 
+// Config 返回配置中心实例，未通过 [WithConfig] 声明时返回 nil。
+func (c *Config) Config() *xConfig.Config { return c.config }
+
+// Logger 返回日志配置，按 默认值 < LOG_* 环境变量 < [WithLogger] 显式选项 的顺序合并。
+//
+// 环境变量在调用时读取，Register 在加载 .env 之后调用。
+func (c *Config) Logger() xOptLogger.LoggerConfig {
+	opts := append([]xOptLogger.LoggerOption{xOptLogger.FromEnv()}, c.logger...)
+	return xOptLogger.New(opts...)
+}
+
+// Tracing 返回链路追踪配置，按 默认值 < TRACE_* 环境变量 < [WithTracing] 显式选项 的顺序合并。
+//
+// 环境变量在调用时读取，Register 在加载 .env 之后调用。
+func (c *Config) Tracing() xOptTracing.TracingConfig {
+	opts := append([]xOptTracing.TracingOption{xOptTracing.FromEnv()}, c.tracing...)
+	return xOptTracing.New(opts...)
+}
+
+// Server 返回 HTTP 服务配置，按 默认值 < XLF_* 环境变量 < [WithServer] 显式选项 的顺序合并。
+//
+// 环境变量在调用时读取，Register 在加载 .env 之后调用。
+func (c *Config) Server() xOptServer.ServerConfig {
+	opts := append([]xOptServer.ServerOption{xOptServer.FromEnv()}, c.server...)
+	return xOptServer.New(opts...)
+}
+
+// Health 返回健康检查配置，以及是否通过 [WithHealth] 启用了健康检查路由。
+//
+// 未启用时仍返回默认配置，Register 据此构建 reg.Health() 供 gRPC 健康服务等使用。
+func (c *Config) Health() (xOptHealth.HealthConfig, bool) {
+	return xOptHealth.New(c.health...), c.healthEnabled
+}
+
+// Metrics 返回指标导出配置，以及是否通过 [WithMetrics] 启用了指标路由。
+func (c *Config) Metrics() (xOptMetrics.MetricsConfig, bool) {
+	return xOptMetrics.New(c.metrics...), c.metricsEnabled
+}
+
+// Cors 返回跨域配置，以及是否通过 [WithCors] 启用了跨域中间件。
+//
+// 按 默认值 < CORS_* 环境变量 < 配置文件 cors 段 < [WithCors] 显式选项 的顺序合并；
+// 配置文件在调用时读取，Register 在配置中心 Load 之后调用，并在 cors 段变更时重新调用以替换跨域中间件配置。
+func (c *Config) Cors() (xOptCors.CorsConfig, bool) {
+	opts := []xOptCors.CorsOption{xOptCors.FromEnv()}
+	if c.config != nil {
+		opts = append(opts, xOptCors.FromConfig(c.config, xOptCors.ConfigKey))
+	}
+	opts = append(opts, c.cors...)
+	return xOptCors.New(opts...), c.corsEnabled
+}
+
 // Routes 返回路由注册器列表，按 WithRoute / WithRouteGroup 的调用顺序排列。
 //
 // Register 会在 Exec + engineInit 后按此顺序逐个执行，每个 [RouteRegistrar] 接收
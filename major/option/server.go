@@ -0,0 +1,31 @@
+package option
+
+import (
+	xOptServer "github.com/bamboo-services/bamboo-base-go/major/option/server"
+)
+
+// ServerConfig HTTP 服务配置，详见 [xOptServer.ServerConfig]。
+type ServerConfig = xOptServer.ServerConfig
+
+// WithServer 将 [xOptServer.ServerOption] 包裹为顶层 [Option]，供 Register 使用。
+//
+// 可多次调用叠加，选项按调用顺序追加。Runner 启动 HTTP 服务时的优先级为：
+// 默认值 < XLF_* 环境变量（[xOptServer.FromEnv]） < WithServer 显式选项。
+// nil ServerOption 会被跳过。
+//
+// 使用示例：
+//
+//	xOption.WithServer(
+//	    xOptServer.WithReadHeaderTimeout(5*time.Second),
+//	    xOptServer.WithIdleTimeout(2*time.Minute),
+//	    xOptServer.WithTLS("/etc/app/tls.crt", "/etc/app/tls.key"),
+//	)
+func WithServer(opts ...xOptServer.ServerOption) Option {
+	return func(c *Config) {
+		for _, o := range opts {
+			if o != nil {
+				c.server = append(c.server, o)
+			}
+		}
+	}
+}
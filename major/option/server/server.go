@@ -0,0 +1,398 @@
+// Package xOptServer HTTP 服务配置子包，定义 [ServerConfig] 与 [ServerOption]。
+//
+// 与 logger / tracing 子包对称：
+//   - 外层 [ServerConfig] 为数据载体，字段小写只读，仅通过 getter 暴露
+//   - [ServerOption] 为修改函数，直接作用于 *ServerConfig
+//   - [FromEnv] 与各 WithXxx 均返回 [ServerOption]，由父包 [option.WithServer] 包裹为顶层 Option
+//
+// 该子包不 import option 父包，避免循环依赖。
+package xOptServer
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"strconv"
+	"time"
+
+	xLifecycle "github.com/bamboo-services/bamboo-base-go/common/lifecycle"
+	xEnv "github.com/bamboo-services/bamboo-base-go/defined/env"
+)
+
+const (
+	// DefaultHost 默认监听地址。
+	DefaultHost = "localhost"
+
+	// DefaultPort 默认监听端口。
+	DefaultPort = "1118"
+
+	// DefaultShutdownTimeout 默认优雅关闭超时时间。
+	DefaultShutdownTimeout = 30 * time.Second
+
+	// DefaultPhaseTimeout 默认 gRPC / Cron / 异步任务各关闭阶段的超时时间。
+	DefaultPhaseTimeout = 30 * time.Second
+
+	// DefaultTLSReloadInterval 默认证书文件变更检测间隔。
+	DefaultTLSReloadInterval = 10 * time.Second
+)
+
+// ServerConfig HTTP 服务配置，描述监听方式、超时、请求头大小、TLS 与 h2c。
+//
+// 字段均为小写，仅通过 getter 暴露只读视图，避免下游直接修改内部状态。
+// 零值不可直接使用，请通过 [New] 构造（已填充默认值）。
+type ServerConfig struct {
+	host       string
+	port       string
+	addrSet    bool
+	unixSocket string
+	listenFD   int
+	listener   net.Listener
+
+	readTimeout       time.Duration
+	readHeaderTimeout time.Duration
+	writeTimeout      time.Duration
+	idleTimeout       time.Duration
+	shutdownTimeout   time.Duration
+	maxHeaderBytes    int
+
+	drainPeriod   time.Duration
+	phaseTimeout  time.Duration
+	phaseTimeouts map[xLifecycle.Phase]time.Duration
+
+	certFile          string
+	keyFile           string
+	tlsReloadInterval time.Duration
+	h2c               bool
+}
+
+// Addr 返回 TCP 监听地址 host:port。
+func (c ServerConfig) Addr() string { return net.JoinHostPort(c.host, c.port) }
+
+// UnixSocket 返回 Unix 套接字路径，空串表示不使用。
+func (c ServerConfig) UnixSocket() string { return c.unixSocket }
+
+// ListenFD 返回继承的监听文件描述符，0 表示不使用。
+func (c ServerConfig) ListenFD() int { return c.listenFD }
+
+// Listener 返回通过 [WithListener] 传入的已打开监听器，nil 表示未设置。
+func (c ServerConfig) Listener() net.Listener { return c.listener }
+
+// ReadTimeout 返回读取整个请求（含请求体）的超时时间，0 表示不限制。
+func (c ServerConfig) ReadTimeout() time.Duration { return c.readTimeout }
+
+// ReadHeaderTimeout 返回读取请求头的超时时间，0 表示沿用 ReadTimeout。
+func (c ServerConfig) ReadHeaderTimeout() time.Duration { return c.readHeaderTimeout }
+
+// WriteTimeout 返回写出响应的超时时间，0 表示不限制。
+func (c ServerConfig) WriteTimeout() time.Duration { return c.writeTimeout }
+
+// IdleTimeout 返回 keep-alive 连接的空闲超时时间，0 表示沿用 ReadTimeout。
+func (c ServerConfig) IdleTimeout() time.Duration { return c.idleTimeout }
+
+// ShutdownTimeout 返回优雅关闭的超时时间。
+func (c ServerConfig) ShutdownTimeout() time.Duration { return c.shutdownTimeout }
+
+// DrainPeriod 返回退出时标记未就绪后、关闭服务前的摘流等待时长，0 表示不等待。
+func (c ServerConfig) DrainPeriod() time.Duration { return c.drainPeriod }
+
+// PhaseTimeout 返回指定关闭阶段的超时时间。
+//
+// [xLifecycle.PhaseHTTP] 阶段即 ShutdownTimeout；其余阶段优先使用 [WithPhaseTimeout] 的单独设置，
+// 否则使用统一的阶段超时（默认 30s，可由 XLF_PHASE_TIMEOUT 覆盖）。
+func (c ServerConfig) PhaseTimeout(phase xLifecycle.Phase) time.Duration {
+	if phase == xLifecycle.PhaseHTTP {
+		return c.shutdownTimeout
+	}
+	if timeout, ok := c.phaseTimeouts[phase]; ok {
+		return timeout
+	}
+	return c.phaseTimeout
+}
+
+// MaxHeaderBytes 返回请求头最大字节数，0 表示使用 net/http 默认值（1MB）。
+func (c ServerConfig) MaxHeaderBytes() int { return c.maxHeaderBytes }
+
+// TLS 返回证书与私钥文件路径，任一为空表示未启用 TLS。
+func (c ServerConfig) TLS() (certFile, keyFile string) { return c.certFile, c.keyFile }
+
+// TLSEnabled 判断是否启用 TLS。
+func (c ServerConfig) TLSEnabled() bool { return c.certFile != "" && c.keyFile != "" }
+
+// TLSReloadInterval 返回证书文件变更检测间隔。
+func (c ServerConfig) TLSReloadInterval() time.Duration { return c.tlsReloadInterval }
+
+// H2C 判断是否在明文连接上启用 HTTP/2（h2c）。
+func (c ServerConfig) H2C() bool { return c.h2c }
+
+// Network 返回监听方式的描述：listener / fd / unix / tcp。
+func (c ServerConfig) Network() string {
+	switch {
+	case c.listener != nil:
+		return "listener"
+	case c.listenFD > 0:
+		return "fd"
+	case c.unixSocket != "":
+		return "unix"
+	default:
+		return "tcp"
+	}
+}
+
+// HasListenTarget 判断是否显式指定了监听目标（TCP 地址、Unix 套接字、文件描述符或监听器），
+// 未指定时 [ServerConfig.Listen] 使用默认地址 localhost:1118。
+func (c ServerConfig) HasListenTarget() bool {
+	return c.addrSet || c.unixSocket != "" || c.listenFD > 0 || c.listener != nil
+}
+
+// SameListenTarget 判断两份配置是否会监听同一目标：相同的文件描述符、Unix 套接字，
+// 或相同端口且主机相同或任一方为通配地址的 TCP 地址。使用已打开监听器的配置不参与比较。
+func (c ServerConfig) SameListenTarget(other ServerConfig) bool {
+	if c.Network() != other.Network() {
+		return false
+	}
+	switch c.Network() {
+	case "fd":
+		return c.listenFD == other.listenFD
+	case "unix":
+		return c.unixSocket == other.unixSocket
+	case "tcp":
+		return c.port == other.port && (c.host == other.host || isWildcardHost(c.host) || isWildcardHost(other.host))
+	default:
+		return false
+	}
+}
+
+// isWildcardHost 判断主机是否为监听全部网卡的通配地址
+func isWildcardHost(host string) bool {
+	return host == "" || host == "0.0.0.0" || host == "::"
+}
+
+// Listen 按优先级 已打开监听器 > 继承的文件描述符 > Unix 套接字 > TCP 地址 返回监听器。
+//
+// 使用 Unix 套接字时会先删除残留的同名套接字文件。
+func (c ServerConfig) Listen() (net.Listener, error) {
+	switch c.Network() {
+	case "listener":
+		return c.listener, nil
+	case "fd":
+		file := os.NewFile(uintptr(c.listenFD), "listen-fd-"+strconv.Itoa(c.listenFD))
+		if file == nil {
+			return nil, fmt.Errorf("无效的监听文件描述符: %d", c.listenFD)
+		}
+		defer file.Close()
+		listener, err := net.FileListener(file)
+		if err != nil {
+			return nil, fmt.Errorf("从文件描述符 %d 创建监听器失败: %w", c.listenFD, err)
+		}
+		return listener, nil
+	case "unix":
+		if info, err := os.Lstat(c.unixSocket); err == nil && info.Mode()&fs.ModeSocket != 0 {
+			if err := os.Remove(c.unixSocket); err != nil {
+				return nil, fmt.Errorf("删除残留套接字文件失败: %w", err)
+			}
+		} else if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("检查套接字文件失败: %w", err)
+		}
+		return net.Listen("unix", c.unixSocket)
+	default:
+		return net.Listen("tcp", c.Addr())
+	}
+}
+
+// ServerOption 是 [ServerConfig] 的二级选项。
+type ServerOption func(*ServerConfig)
+
+// New 构造 HTTP 服务配置，先填充默认值再依次应用 opts。
+//
+// 默认值与历史硬编码行为保持一致：
+//   - 监听 localhost:1118
+//   - 不设置读写与空闲超时，请求头大小使用 net/http 默认值
+//   - 优雅关闭超时 30s，不等待摘流，其余关闭阶段超时 30s
+//   - 不启用 TLS 与 h2c
+//
+// nil 选项会被跳过。
+func New(opts ...ServerOption) ServerConfig {
+	cfg := ServerConfig{
+		host:              DefaultHost,
+		port:              DefaultPort,
+		shutdownTimeout:   DefaultShutdownTimeout,
+		phaseTimeout:      DefaultPhaseTimeout,
+		tlsReloadInterval: DefaultTLSReloadInterval,
+	}
+	for _, o := range opts {
+		if o != nil {
+			o(&cfg)
+		}
+	}
+	return cfg
+}
+
+// WithAddr 设置 TCP 监听地址，host 或 port 为空时保持原值。
+func WithAddr(host, port string) ServerOption {
+	return func(c *ServerConfig) {
+		if host != "" {
+			c.host = host
+			c.addrSet = true
+		}
+		if port != "" {
+			c.port = port
+			c.addrSet = true
+		}
+	}
+}
+
+// WithUnixSocket 改为监听 Unix 套接字，空串表示恢复 TCP 监听。
+func WithUnixSocket(path string) ServerOption {
+	return func(c *ServerConfig) { c.unixSocket = path }
+}
+
+// WithListenFD 改为使用继承的文件描述符监听，适用于 systemd 等套接字激活场景（首个描述符为 3）。
+func WithListenFD(fd int) ServerOption {
+	return func(c *ServerConfig) { c.listenFD = max(fd, 0) }
+}
+
+// WithListener 使用已打开的监听器，优先级最高；监听器的关闭由 HTTP 服务负责。
+func WithListener(listener net.Listener) ServerOption {
+	return func(c *ServerConfig) { c.listener = listener }
+}
+
+// WithReadTimeout 设置读取整个请求（含请求体）的超时时间，0 表示不限制。
+func WithReadTimeout(timeout time.Duration) ServerOption {
+	return func(c *ServerConfig) { c.readTimeout = max(timeout, 0) }
+}
+
+// WithReadHeaderTimeout 设置读取请求头的超时时间，0 表示沿用 ReadTimeout。
+func WithReadHeaderTimeout(timeout time.Duration) ServerOption {
+	return func(c *ServerConfig) { c.readHeaderTimeout = max(timeout, 0) }
+}
+
+// WithWriteTimeout 设置写出响应的超时时间，0 表示不限制。
+//
+// 存在 SSE、大文件下载等长响应时应保持为 0 或足够大。
+func WithWriteTimeout(timeout time.Duration) ServerOption {
+	return func(c *ServerConfig) { c.writeTimeout = max(timeout, 0) }
+}
+
+// WithIdleTimeout 设置 keep-alive 连接的空闲超时时间，0 表示沿用 ReadTimeout。
+func WithIdleTimeout(timeout time.Duration) ServerOption {
+	return func(c *ServerConfig) { c.idleTimeout = max(timeout, 0) }
+}
+
+// WithShutdownTimeout 设置优雅关闭超时时间，<= 0 时保持原值。
+func WithShutdownTimeout(timeout time.Duration) ServerOption {
+	return func(c *ServerConfig) {
+		if timeout > 0 {
+			c.shutdownTimeout = timeout
+		}
+	}
+}
+
+// WithDrainPeriod 设置退出时的摘流等待时长，0 表示不等待。
+//
+// 收到退出信号后先将就绪状态置为 false（/readyz 返回 503），等待该时长让负载均衡摘除实例、
+// 在途请求完成，再按 HTTP → gRPC → Cron → 异步任务 的顺序关闭。
+// 通常设置为略大于负载均衡健康检查间隔 × 失败阈值。
+func WithDrainPeriod(period time.Duration) ServerOption {
+	return func(c *ServerConfig) { c.drainPeriod = max(period, 0) }
+}
+
+// WithPhaseTimeout 设置指定关闭阶段的超时时间，<= 0 时保持原值。
+//
+// [xLifecycle.PhaseHTTP] 等价于 [WithShutdownTimeout]。
+func WithPhaseTimeout(phase xLifecycle.Phase, timeout time.Duration) ServerOption {
+	return func(c *ServerConfig) {
+		if timeout <= 0 {
+			return
+		}
+		if phase == xLifecycle.PhaseHTTP {
+			c.shutdownTimeout = timeout
+			return
+		}
+		phaseTimeouts := make(map[xLifecycle.Phase]time.Duration, len(c.phaseTimeouts)+1)
+		for p, t := range c.phaseTimeouts {
+			phaseTimeouts[p] = t
+		}
+		phaseTimeouts[phase] = timeout
+		c.phaseTimeouts = phaseTimeouts
+	}
+}
+
+// WithMaxHeaderBytes 设置请求头最大字节数，0 表示使用 net/http 默认值。
+func WithMaxHeaderBytes(n int) ServerOption {
+	return func(c *ServerConfig) { c.maxHeaderBytes = max(n, 0) }
+}
+
+// WithTLS 启用 TLS，证书与私钥文件发生变化时自动重新加载，无需重启服务。
+func WithTLS(certFile, keyFile string) ServerOption {
+	return func(c *ServerConfig) {
+		c.certFile = certFile
+		c.keyFile = keyFile
+	}
+}
+
+// WithTLSReloadInterval 设置证书文件变更检测间隔，<= 0 时保持原值。
+func WithTLSReloadInterval(interval time.Duration) ServerOption {
+	return func(c *ServerConfig) {
+		if interval > 0 {
+			c.tlsReloadInterval = interval
+		}
+	}
+}
+
+// WithH2C 设置是否在明文连接上启用 HTTP/2（h2c），通常用于服务网格或内网 gRPC-Web 网关之后。
+func WithH2C(enabled bool) ServerOption {
+	return func(c *ServerConfig) { c.h2c = enabled }
+}
+
+// FromEnv 从环境变量构造 HTTP 服务配置的 [ServerOption]。
+//
+// 读取的环境变量（仅覆盖已设置且合法的项，否则保持当前值）:
+//   - XLF_HOST / XLF_PORT     TCP 监听地址与端口
+//   - XLF_UNIX_SOCKET         Unix 套接字路径
+//   - XLF_LISTEN_FD           继承的监听文件描述符
+//   - XLF_READ_TIMEOUT / XLF_READ_HEADER_TIMEOUT / XLF_WRITE_TIMEOUT / XLF_IDLE_TIMEOUT
+//     各类超时（Go Duration，如 15s）
+//   - XLF_SHUTDOWN_TIMEOUT    优雅关闭超时（Go Duration）
+//   - XLF_DRAIN_PERIOD        退出时的摘流等待时长（Go Duration）
+//   - XLF_PHASE_TIMEOUT       gRPC / Cron / 异步任务各关闭阶段的超时（Go Duration）
+//   - XLF_MAX_HEADER_BYTES    请求头最大字节数
+//   - XLF_TLS_CERT / XLF_TLS_KEY  证书与私钥文件路径
+//   - XLF_H2C                 是否启用 h2c
+//
+// 该函数依赖 .env 已在 Register 阶段通过 godotenv 加载完成。
+func FromEnv() ServerOption {
+	return func(c *ServerConfig) {
+		WithAddr(xEnv.GetEnvString(xEnv.Host, ""), xEnv.GetEnvString(xEnv.Port, ""))(c)
+		if path := xEnv.GetEnvString(xEnv.UnixSocket, ""); path != "" {
+			c.unixSocket = path
+		}
+		if fd := xEnv.GetEnvInt(xEnv.ListenFD, 0); fd > 0 {
+			c.listenFD = fd
+		}
+		durationFromEnv(xEnv.ReadTimeout, &c.readTimeout)
+		durationFromEnv(xEnv.ReadHeaderTimeout, &c.readHeaderTimeout)
+		durationFromEnv(xEnv.WriteTimeout, &c.writeTimeout)
+		durationFromEnv(xEnv.IdleTimeout, &c.idleTimeout)
+		if timeout, err := time.ParseDuration(xEnv.GetEnvString(xEnv.ShutdownTimeout, "")); err == nil && timeout > 0 {
+			c.shutdownTimeout = timeout
+		}
+		durationFromEnv(xEnv.DrainPeriod, &c.drainPeriod)
+		if timeout, err := time.ParseDuration(xEnv.GetEnvString(xEnv.PhaseTimeout, "")); err == nil && timeout > 0 {
+			c.phaseTimeout = timeout
+		}
+		c.maxHeaderBytes = max(xEnv.GetEnvInt(xEnv.MaxHeaderBytes, c.maxHeaderBytes), 0)
+		if cert, key := xEnv.GetEnvString(xEnv.TLSCert, ""), xEnv.GetEnvString(xEnv.TLSKey, ""); cert != "" && key != "" {
+			c.certFile, c.keyFile = cert, key
+		}
+		c.h2c = xEnv.GetEnvBool(xEnv.H2C, c.h2c)
+	}
+}
+
+// durationFromEnv 读取 Go Duration 形式的环境变量，非法或负数时保持原值
+func durationFromEnv(key xEnv.EnvKey, target *time.Duration) {
+	if value, err := time.ParseDuration(xEnv.GetEnvString(key, "")); err == nil && value >= 0 {
+		*target = value
+	}
+}
@@ -0,0 +1,151 @@
+package xOptServer_test
+
+import (
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	xLifecycle "github.com/bamboo-services/bamboo-base-go/common/lifecycle"
+	xOptServer "github.com/bamboo-services/bamboo-base-go/major/option/server"
+)
+
+// TestFromEnv_OverridesDefaults 验证 XLF_* 环境变量覆盖默认值，显式选项优先于环境变量。
+func TestFromEnv_OverridesDefaults(t *testing.T) {
+	t.Setenv("XLF_HOST", "0.0.0.0")
+	t.Setenv("XLF_PORT", "8080")
+	t.Setenv("XLF_READ_HEADER_TIMEOUT", "5s")
+	t.Setenv("XLF_IDLE_TIMEOUT", "2m")
+	t.Setenv("XLF_SHUTDOWN_TIMEOUT", "10s")
+	t.Setenv("XLF_MAX_HEADER_BYTES", "65536")
+	t.Setenv("XLF_TLS_CERT", "/etc/tls.crt")
+	t.Setenv("XLF_TLS_KEY", "/etc/tls.key")
+	t.Setenv("XLF_H2C", "true")
+
+	cfg := xOptServer.New(xOptServer.FromEnv(), xOptServer.WithWriteTimeout(30*time.Second))
+	if cfg.Addr() != "0.0.0.0:8080" {
+		t.Errorf("Addr 不匹配: got=%q", cfg.Addr())
+	}
+	if cfg.ReadHeaderTimeout() != 5*time.Second || cfg.IdleTimeout() != 2*time.Minute || cfg.WriteTimeout() != 30*time.Second {
+		t.Errorf("超时不匹配: header=%v idle=%v write=%v", cfg.ReadHeaderTimeout(), cfg.IdleTimeout(), cfg.WriteTimeout())
+	}
+	if cfg.ShutdownTimeout() != 10*time.Second || cfg.MaxHeaderBytes() != 65536 {
+		t.Errorf("关闭超时/请求头大小不匹配: %v %d", cfg.ShutdownTimeout(), cfg.MaxHeaderBytes())
+	}
+	if cert, key := cfg.TLS(); !cfg.TLSEnabled() || cert != "/etc/tls.crt" || key != "/etc/tls.key" {
+		t.Errorf("TLS 不匹配: cert=%q key=%q", cert, key)
+	}
+	if !cfg.H2C() {
+		t.Error("XLF_H2C=true 时应启用 h2c")
+	}
+}
+
+// TestFromEnv_InvalidKeepsDefaults 验证非法或缺失的环境变量保持默认值。
+func TestFromEnv_InvalidKeepsDefaults(t *testing.T) {
+	t.Setenv("XLF_READ_TIMEOUT", "fast")
+	t.Setenv("XLF_SHUTDOWN_TIMEOUT", "-1s")
+	t.Setenv("XLF_TLS_CERT", "/etc/tls.crt")
+
+	cfg := xOptServer.New(xOptServer.FromEnv())
+	if cfg.Addr() != net.JoinHostPort(xOptServer.DefaultHost, xOptServer.DefaultPort) {
+		t.Errorf("默认地址不匹配: got=%q", cfg.Addr())
+	}
+	if cfg.ReadTimeout() != 0 || cfg.ShutdownTimeout() != xOptServer.DefaultShutdownTimeout {
+		t.Errorf("非法超时应保持默认: read=%v shutdown=%v", cfg.ReadTimeout(), cfg.ShutdownTimeout())
+	}
+	if cfg.TLSEnabled() {
+		t.Error("仅设置证书未设置私钥时不应启用 TLS")
+	}
+	if cfg.Network() != "tcp" {
+		t.Errorf("默认应监听 TCP: got=%q", cfg.Network())
+	}
+}
+
+// TestPhaseTimeout 验证摘流时长与各关闭阶段超时的默认值、环境变量与单独设置。
+func TestPhaseTimeout(t *testing.T) {
+	t.Setenv("XLF_DRAIN_PERIOD", "5s")
+	t.Setenv("XLF_PHASE_TIMEOUT", "20s")
+
+	cfg := xOptServer.New(
+		xOptServer.FromEnv(),
+		xOptServer.WithShutdownTimeout(15*time.Second),
+		xOptServer.WithPhaseTimeout(xLifecycle.PhaseAsync, time.Minute),
+	)
+	if cfg.DrainPeriod() != 5*time.Second {
+		t.Errorf("DrainPeriod 不匹配: %v", cfg.DrainPeriod())
+	}
+	if got := cfg.PhaseTimeout(xLifecycle.PhaseHTTP); got != 15*time.Second {
+		t.Errorf("HTTP 阶段应使用 ShutdownTimeout: %v", got)
+	}
+	if got := cfg.PhaseTimeout(xLifecycle.PhaseGRPC); got != 20*time.Second {
+		t.Errorf("gRPC 阶段应使用 XLF_PHASE_TIMEOUT: %v", got)
+	}
+	if got := cfg.PhaseTimeout(xLifecycle.PhaseAsync); got != time.Minute {
+		t.Errorf("异步阶段应使用单独设置: %v", got)
+	}
+	if got := xOptServer.New().PhaseTimeout(xLifecycle.PhaseCron); got != xOptServer.DefaultPhaseTimeout {
+		t.Errorf("默认阶段超时不匹配: %v", got)
+	}
+}
+
+// TestSameListenTarget 验证监听目标的显式声明判断与冲突比较。
+func TestSameListenTarget(t *testing.T) {
+	if xOptServer.New().HasListenTarget() || xOptServer.New(xOptServer.WithAddr("", "")).HasListenTarget() {
+		t.Error("未指定地址时不应视为声明了监听目标")
+	}
+	if !xOptServer.New(xOptServer.WithUnixSocket("/tmp/app.sock")).HasListenTarget() {
+		t.Error("Unix 套接字应视为声明了监听目标")
+	}
+
+	main := xOptServer.New()
+	cases := []struct {
+		opt  xOptServer.ServerOption
+		same bool
+	}{
+		{xOptServer.WithAddr("localhost", "1118"), true},
+		{xOptServer.WithAddr("0.0.0.0", "1118"), true},
+		{xOptServer.WithAddr("localhost", "9090"), false},
+		{xOptServer.WithUnixSocket("/tmp/app.sock"), false},
+	}
+	for _, c := range cases {
+		if got := xOptServer.New(c.opt).SameListenTarget(main); got != c.same {
+			t.Errorf("SameListenTarget 不匹配: got=%v want=%v", got, c.same)
+		}
+	}
+}
+
+// TestListen_UnixSocket 验证 Unix 套接字监听会清理残留的套接字文件。
+func TestListen_UnixSocket(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.sock")
+	stale, err := net.Listen("unix", path)
+	if err != nil {
+		t.Skipf("当前平台不支持 Unix 套接字: %v", err)
+	}
+	stale.(*net.UnixListener).SetUnlinkOnClose(false)
+	_ = stale.Close()
+
+	cfg := xOptServer.New(xOptServer.WithUnixSocket(path))
+	listener, err := cfg.Listen()
+	if err != nil {
+		t.Fatalf("监听 Unix 套接字失败: %v", err)
+	}
+	defer listener.Close()
+	if listener.Addr().Network() != "unix" || cfg.Network() != "unix" {
+		t.Errorf("监听方式不匹配: %s", listener.Addr().Network())
+	}
+}
+
+// TestListen_Listener 验证已打开的监听器优先于其他监听方式。
+func TestListen_Listener(t *testing.T) {
+	opened, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("监听失败: %v", err)
+	}
+	defer opened.Close()
+
+	cfg := xOptServer.New(xOptServer.WithUnixSocket("/tmp/unused.sock"), xOptServer.WithListener(opened))
+	listener, err := cfg.Listen()
+	if err != nil || listener != opened {
+		t.Errorf("应直接返回已打开的监听器: %v, %v", listener, err)
+	}
+}
@@ -0,0 +1,30 @@
+package option
+
+import (
+	xOptTracing "github.com/bamboo-services/bamboo-base-go/major/option/tracing"
+)
+
+// TracingConfig 链路追踪配置，详见 [xOptTracing.TracingConfig]。
+type TracingConfig = xOptTracing.TracingConfig
+
+// WithTracing 将 [xOptTracing.TracingOption] 包裹为顶层 [Option]，供 Register 使用。
+//
+// 可多次调用叠加，选项按调用顺序追加。Register 装配链路追踪时的优先级为：
+// 默认值 < TRACE_* 环境变量（[xOptTracing.FromEnv]） < WithTracing 显式选项。
+// nil TracingOption 会被跳过。
+//
+// 使用示例：
+//
+//	xOption.WithTracing(
+//	    xOptTracing.WithOTLP("http://127.0.0.1:4318", nil),
+//	    xOptTracing.WithServiceName("order-service"),
+//	)
+func WithTracing(opts ...xOptTracing.TracingOption) Option {
+	return func(c *Config) {
+		for _, o := range opts {
+			if o != nil {
+				c.tracing = append(c.tracing, o)
+			}
+		}
+	}
+}
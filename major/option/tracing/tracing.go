@@ -0,0 +1,205 @@
+// Package xOptTracing 链路追踪配置子包，定义 [TracingConfig] 与 [TracingOption]。
+//
+// 与 logger 子包对称：
+//   - 外层 [TracingConfig] 为数据载体，字段小写只读，仅通过 getter 暴露
+//   - [TracingOption] 为修改函数，直接作用于 *TracingConfig
+//   - [FromEnv] 与各 WithXxx 均返回 [TracingOption]，由父包 [option.WithTracing] 包裹为顶层 Option
+//
+// 该子包不 import option 父包，避免循环依赖。
+package xOptTracing
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	xTrace "github.com/bamboo-services/bamboo-base-go/common/trace"
+	xEnv "github.com/bamboo-services/bamboo-base-go/defined/env"
+)
+
+// ExporterType span 导出器类型。
+type ExporterType string
+
+const (
+	ExporterNone   ExporterType = "none"   // 不导出（默认），span 仍用于日志关联
+	ExporterStdout ExporterType = "stdout" // 每行一个 JSON 对象写入标准输出
+	ExporterFile   ExporterType = "file"   // 每行一个 JSON 对象追加写入文件
+	ExporterOTLP   ExporterType = "otlp"   // OTLP/HTTP 发送到收集器
+	ExporterCustom ExporterType = "custom" // 通过 [WithExporter] 指定的自定义导出器
+)
+
+// DefaultFile file 导出器的默认输出文件。
+const DefaultFile = ".logs/trace.log"
+
+// TracingConfig 链路追踪配置，描述 span 导出目标与批量导出参数。
+//
+// 字段均为小写，仅通过 getter 暴露只读视图，避免下游直接修改内部状态。
+// 零值不可直接使用，请通过 [New] 构造（已填充默认值）。
+type TracingConfig struct {
+	exporterType ExporterType
+	exporter     xTrace.Exporter
+	file         string
+	otlp         xTrace.OTLPConfig
+	queueSize    int
+	batchSize    int
+	interval     time.Duration
+}
+
+// ExporterType 返回导出器类型。
+func (c TracingConfig) ExporterType() ExporterType { return c.exporterType }
+
+// Enabled 判断是否导出 span。
+func (c TracingConfig) Enabled() bool { return c.exporterType != ExporterNone }
+
+// File 返回 file 导出器的输出文件路径。
+func (c TracingConfig) File() string { return c.file }
+
+// OTLP 返回 OTLP 导出器配置。
+func (c TracingConfig) OTLP() xTrace.OTLPConfig { return c.otlp }
+
+// NewExporter 按导出器类型构造 [xTrace.Exporter]，类型为 none 时返回 nil。
+func (c TracingConfig) NewExporter() (xTrace.Exporter, error) {
+	switch c.exporterType {
+	case ExporterNone:
+		return nil, nil
+	case ExporterStdout:
+		return xTrace.NewWriterExporter(nil), nil
+	case ExporterFile:
+		return xTrace.NewFileExporter(c.file)
+	case ExporterOTLP:
+		return xTrace.NewOTLPExporter(c.otlp)
+	case ExporterCustom:
+		return c.exporter, nil
+	default:
+		return nil, fmt.Errorf("不支持的链路导出器: %s", c.exporterType)
+	}
+}
+
+// TracerConfig 返回批量导出参数，Exporter 由调用方填充。
+func (c TracingConfig) TracerConfig() xTrace.TracerConfig {
+	return xTrace.TracerConfig{
+		QueueSize: c.queueSize,
+		BatchSize: c.batchSize,
+		Interval:  c.interval,
+	}
+}
+
+// TracingOption 是 [TracingConfig] 的二级选项。
+type TracingOption func(*TracingConfig)
+
+// New 构造链路追踪配置，先填充默认值再依次应用 opts。
+//
+// 默认不导出 span（HTTP/gRPC/数据库/Redis 等 span 仍会创建，用于日志的 trace_id/span_id 关联），
+// 服务名默认取 APP_NAME。nil 选项会被跳过。
+func New(opts ...TracingOption) TracingConfig {
+	cfg := TracingConfig{
+		exporterType: ExporterNone,
+		file:         DefaultFile,
+		otlp: xTrace.OTLPConfig{
+			ServiceName: xEnv.GetEnvString(xEnv.AppName, ""),
+		},
+	}
+	for _, o := range opts {
+		if o != nil {
+			o(&cfg)
+		}
+	}
+	return cfg
+}
+
+// WithStdout 将 span 以 JSON 行写入标准输出。
+func WithStdout() TracingOption {
+	return func(c *TracingConfig) { c.exporterType = ExporterStdout }
+}
+
+// WithFile 将 span 以 JSON 行追加写入 path，空串使用 [DefaultFile]。
+func WithFile(path string) TracingOption {
+	return func(c *TracingConfig) {
+		c.exporterType = ExporterFile
+		if path != "" {
+			c.file = path
+		}
+	}
+}
+
+// WithOTLP 将 span 以 OTLP/HTTP 发送到 endpoint（如本地 OpenTelemetry Collector 的 http://127.0.0.1:4318）。
+//
+// headers 为额外请求头，可为 nil。
+func WithOTLP(endpoint string, headers map[string]string) TracingOption {
+	return func(c *TracingConfig) {
+		c.exporterType = ExporterOTLP
+		c.otlp.Endpoint = endpoint
+		c.otlp.Headers = headers
+	}
+}
+
+// WithServiceName 设置上报的服务名（OTLP resource 的 service.name）。
+func WithServiceName(name string) TracingOption {
+	return func(c *TracingConfig) {
+		if name != "" {
+			c.otlp.ServiceName = name
+		}
+	}
+}
+
+// WithExporter 使用自定义导出器，nil 时保持原值。
+func WithExporter(exporter xTrace.Exporter) TracingOption {
+	return func(c *TracingConfig) {
+		if exporter != nil {
+			c.exporterType = ExporterCustom
+			c.exporter = exporter
+		}
+	}
+}
+
+// WithBatch 设置批量导出参数：队列容量、单批最大数量与定时导出间隔，<= 0 的项使用默认值。
+func WithBatch(queueSize, batchSize int, interval time.Duration) TracingOption {
+	return func(c *TracingConfig) {
+		c.queueSize = queueSize
+		c.batchSize = batchSize
+		c.interval = interval
+	}
+}
+
+// FromEnv 从环境变量构造链路追踪配置的 [TracingOption]。
+//
+// 读取的环境变量（仅覆盖已设置且合法的项，否则保持当前值）:
+//   - TRACE_EXPORTER       none/stdout/file/otlp，无法识别时忽略
+//   - TRACE_FILE           file 导出器的输出文件路径
+//   - TRACE_OTLP_ENDPOINT  OTLP/HTTP 收集器地址
+//   - TRACE_OTLP_HEADERS   OTLP 额外请求头，如 Authorization=Bearer xxx,X-Tenant=demo
+//   - TRACE_SERVICE_NAME   上报的服务名
+//
+// 该函数依赖 .env 已在 Register 阶段通过 godotenv 加载完成。
+func FromEnv() TracingOption {
+	return func(c *TracingConfig) {
+		switch ExporterType(strings.ToLower(strings.TrimSpace(xEnv.GetEnvString(xEnv.TraceExporter, "")))) {
+		case ExporterNone:
+			c.exporterType = ExporterNone
+		case ExporterStdout:
+			c.exporterType = ExporterStdout
+		case ExporterFile:
+			c.exporterType = ExporterFile
+		case ExporterOTLP:
+			c.exporterType = ExporterOTLP
+		}
+		if path := xEnv.GetEnvString(xEnv.TraceFile, ""); path != "" {
+			c.file = path
+		}
+		if endpoint := xEnv.GetEnvString(xEnv.TraceOTLPEndpoint, ""); endpoint != "" {
+			c.otlp.Endpoint = endpoint
+		}
+		for _, item := range strings.Split(xEnv.GetEnvString(xEnv.TraceOTLPHeaders, ""), ",") {
+			key, value, ok := strings.Cut(item, "=")
+			if key = strings.TrimSpace(key); ok && key != "" {
+				if c.otlp.Headers == nil {
+					c.otlp.Headers = make(map[string]string)
+				}
+				c.otlp.Headers[key] = strings.TrimSpace(value)
+			}
+		}
+		if name := xEnv.GetEnvString(xEnv.TraceServiceName, ""); name != "" {
+			c.otlp.ServiceName = name
+		}
+	}
+}
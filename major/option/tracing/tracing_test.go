@@ -0,0 +1,51 @@
+package xOptTracing_test
+
+import (
+	"testing"
+
+	xOptTracing "github.com/bamboo-services/bamboo-base-go/major/option/tracing"
+)
+
+// TestFromEnv_OverridesDefaults 验证 TRACE_* 环境变量覆盖默认值。
+func TestFromEnv_OverridesDefaults(t *testing.T) {
+	t.Setenv("APP_NAME", "demo")
+	t.Setenv("TRACE_EXPORTER", "OTLP")
+	t.Setenv("TRACE_OTLP_ENDPOINT", "127.0.0.1:4318")
+	t.Setenv("TRACE_OTLP_HEADERS", "Authorization=Bearer xxx, X-Tenant=demo,invalid")
+	t.Setenv("TRACE_SERVICE_NAME", "order-service")
+
+	cfg := xOptTracing.New(xOptTracing.FromEnv())
+	if cfg.ExporterType() != xOptTracing.ExporterOTLP || !cfg.Enabled() {
+		t.Errorf("ExporterType 不匹配: got=%q", cfg.ExporterType())
+	}
+	otlp := cfg.OTLP()
+	if otlp.Endpoint != "127.0.0.1:4318" || otlp.ServiceName != "order-service" {
+		t.Errorf("OTLP 配置不匹配: %+v", otlp)
+	}
+	if len(otlp.Headers) != 2 || otlp.Headers["Authorization"] != "Bearer xxx" || otlp.Headers["X-Tenant"] != "demo" {
+		t.Errorf("OTLP 请求头不匹配: %v", otlp.Headers)
+	}
+	if exporter, err := cfg.NewExporter(); err != nil || exporter == nil {
+		t.Errorf("应创建 OTLP 导出器: exporter=%v err=%v", exporter, err)
+	}
+}
+
+// TestFromEnv_InvalidKeepsDefaults 验证非法或缺失的环境变量保持默认值，显式选项优先于环境变量。
+func TestFromEnv_InvalidKeepsDefaults(t *testing.T) {
+	t.Setenv("APP_NAME", "demo")
+	t.Setenv("TRACE_EXPORTER", "jaeger")
+
+	cfg := xOptTracing.New(xOptTracing.FromEnv())
+	if cfg.Enabled() || cfg.File() != xOptTracing.DefaultFile || cfg.OTLP().ServiceName != "demo" {
+		t.Errorf("默认值不匹配: type=%q file=%q service=%q", cfg.ExporterType(), cfg.File(), cfg.OTLP().ServiceName)
+	}
+	if exporter, err := cfg.NewExporter(); err != nil || exporter != nil {
+		t.Errorf("none 不应创建导出器: exporter=%v err=%v", exporter, err)
+	}
+
+	t.Setenv("TRACE_EXPORTER", "stdout")
+	cfg = xOptTracing.New(xOptTracing.FromEnv(), xOptTracing.WithFile(""))
+	if cfg.ExporterType() != xOptTracing.ExporterFile || cfg.File() != xOptTracing.DefaultFile {
+		t.Errorf("显式选项应生效: type=%q file=%q", cfg.ExporterType(), cfg.File())
+	}
+}
@@ -9,6 +9,7 @@ import (
 	xCtx "github.com/bamboo-services/bamboo-base-go/defined/context"
 	xCache "github.com/bamboo-services/bamboo-base-go/major/cache"
 	xCacheMemory "github.com/bamboo-services/bamboo-base-go/major/cache/memory"
+	xHook "github.com/bamboo-services/bamboo-base-go/major/hook"
 	xOption "github.com/bamboo-services/bamboo-base-go/major/option"
 	xRegNode "github.com/bamboo-services/bamboo-base-go/major/register/node"
 	"github.com/redis/go-redis/v9"
@@ -17,7 +18,7 @@ import (
 // CacheInit 根据传入的 [xOption.CacheConfig] 构造缓存初始化节点。
 //
 // 返回的 Node 会根据 [CacheConfig.Type] 选择对应后端：
-//   - CacheTypeRedis：使用 go-redis 构造 *redis.Client 并 Ping 验证，封装进 [*xCache.Manager]
+//   - CacheTypeRedis：使用 go-redis 构造 *redis.Client（挂载 [xHook.RedisTraceHook] 与 [xHook.RedisMetricsHook]）并 Ping 验证，封装进 [*xCache.Manager]
 //   - CacheTypeMemory：构造 [*xCacheMemory.Store]（含分片 + TTL + janitor），封装进 [*xCache.Manager]
 //
 // 返回值统一为 [*xCache.Manager]，由调用方注册到 [xCtx.CacheManagerKey]。
@@ -63,13 +64,17 @@ func initRedisCache(ctx context.Context, rOpts xOption.RedisOptions, log *xLog.L
 		ReadTimeout:  rOpts.ReadTimeout,
 		WriteTimeout: rOpts.WriteTimeout,
 	})
+	client.AddHook(xHook.RedisTraceHook{})
+	client.AddHook(xHook.NewRedisMetricsHook())
 	if err := client.Ping(ctx).Err(); err != nil {
 		return nil, fmt.Errorf("redis 连接失败: %w", err)
 	}
+	unregister := xHook.ObserveRedisClient(client, xCtx.CacheManagerKey.String())
 	log.Info(ctx, "缓存连接成功", slog.String("type", string(xCache.CacheTypeRedis)))
 	return xCache.NewManager(xCache.CacheTypeRedis,
 		xCache.WithRedisClient(client),
 		xCache.WithLogger(log),
+		xCache.WithOnClose(unregister),
 	), nil
 }
 
@@ -102,3 +107,33 @@ func RedisClientFromManager() xRegNode.Node {
 		return manager.Redis(), nil
 	}
 }
+
+// CacheStop 是缓存节点的关闭回调，关闭 [*xCache.Manager] 及其持有的 *redis.Client。
+//
+// Manager 关闭时一并注销 Redis 连接池指标的抓取回调，避免抓取已关闭的客户端。
+//
+// *redis.Client 由 [CacheInit] 创建并归 Manager 所有，因此在此一并关闭；
+// [xCtx.RedisClientKey] 节点仅是兼容视图，不单独登记关闭回调。
+func CacheStop(_ context.Context, value any) error {
+	manager, ok := value.(*xCache.Manager)
+	if !ok || manager == nil {
+		return nil
+	}
+	manager.Close()
+	if client := manager.Redis(); client != nil {
+		return client.Close()
+	}
+	return nil
+}
+
+// RedisCheck 是 *redis.Client 节点的健康检查回调，执行 PING。
+//
+// 框架注册的 [xCtx.RedisClientKey] 只是缓存 Manager 的兼容视图，由 Manager 自身的检查覆盖，不重复登记；
+// 业务侧自行注册的 *redis.Client 节点可通过 [xRegNode.OnCheck](xInit.RedisCheck) 接入健康检查。
+func RedisCheck(ctx context.Context, value any) error {
+	client, ok := value.(*redis.Client)
+	if !ok || client == nil {
+		return fmt.Errorf("节点值不是 *redis.Client: %T", value)
+	}
+	return client.Ping(ctx).Err()
+}
@@ -6,6 +6,7 @@ import (
 	"log/slog"
 
 	xLog "github.com/bamboo-services/bamboo-base-go/common/log"
+	xCtx "github.com/bamboo-services/bamboo-base-go/defined/context"
 	xGormLog "github.com/bamboo-services/bamboo-base-go/major/log"
 	xOptDatabase "github.com/bamboo-services/bamboo-base-go/major/option/database"
 	xRegNode "github.com/bamboo-services/bamboo-base-go/major/register/node"
@@ -22,12 +23,13 @@ import (
 //
 // 返回的 [xRegNode.Node] 会根据 Config.Driver 选择对应的 GORM 驱动
 // （mysql / postgres / sqlite / oracle / sqlserver），用项目自带的 [xGormLog.SlogLogger] 作为 GORM
-// 日志适配器，并按 Common() 中的连接池参数配置底层 *sql.DB。
+// 日志适配器，注册 [xGormLog.GormTracePlugin] 为每次 SQL 执行创建 span、
+// [xGormLog.GormMetricsPlugin] 采集 SQL 耗时与连接池指标，并按 Common() 中的连接池参数配置底层 *sql.DB。
 //
 // 调用方：Register 在 Use 阶段按 option 决定是否装配此节点。
 	// 若 Driver 为 DriverNone，调用方应跳过此工厂。
 func DatabaseInit(cfg xOptDatabase.DatabaseConfig) xRegNode.Node {
-	return func(ctx context.Context) (any, error) {
+	return func(ctx context.Context) (_ any, err error) {
 		log := xLog.WithName(xLog.NamedINIT)
 		log.Debug(ctx, "正在连接数据库", slog.String("driver", string(cfg.Driver())))
 
@@ -48,6 +50,19 @@ func DatabaseInit(cfg xOptDatabase.DatabaseConfig) xRegNode.Node {
 		if err != nil {
 			return nil, fmt.Errorf("连接数据库失败: %w", err)
 		}
+		// 建连后任一步骤失败都不会登记关闭回调，需在此释放连接池与已注册的指标回调
+		defer func() {
+			if err != nil {
+				_ = DatabaseStop(ctx, db)
+			}
+		}()
+
+		if err = db.Use(xGormLog.NewGormTracePlugin()); err != nil {
+			return nil, fmt.Errorf("注册数据库链路追踪插件失败: %w", err)
+		}
+		if err = db.Use(xGormLog.NewGormMetricsPlugin(xCtx.DatabaseKey.String())); err != nil {
+			return nil, fmt.Errorf("注册数据库指标插件失败: %w", err)
+		}
 
 		if err = applyPool(db, cfg.Common()); err != nil {
 			return nil, fmt.Errorf("配置数据库连接池失败: %w", err)
@@ -55,14 +70,14 @@ func DatabaseInit(cfg xOptDatabase.DatabaseConfig) xRegNode.Node {
 
 		// AutoMigrate：声明的表自动建表
 		if tables := cfg.AutoMigrateTables(); len(tables) > 0 {
-			if err := db.AutoMigrate(tables...); err != nil {
+			if err = db.AutoMigrate(tables...); err != nil {
 				return nil, fmt.Errorf("数据库表自动迁移失败: %w", err)
 			}
 			log.Debug(ctx, "数据库表自动迁移完成", slog.Int("tables", len(tables)))
 		}
 		// Prepare：建表后数据初始化，按注册顺序执行
 		for i, fn := range cfg.Prepares() {
-			if err := fn(ctx, db); err != nil {
+			if err = fn(ctx, db); err != nil {
 				return nil, fmt.Errorf("数据初始化失败(第%d个): %w", i+1, err)
 			}
 		}
@@ -113,3 +128,34 @@ func applyPool(db *gorm.DB, c xOptDatabase.CommonOptions) error {
 	}
 	return nil
 }
+
+// DatabaseStop 是数据库节点的关闭回调，注销连接池指标的抓取回调并关闭 *gorm.DB 底层的 *sql.DB 连接池。
+//
+// 由 Register 通过 [xRegNode.OnStop] 登记，在 Runner 退出时调用。
+func DatabaseStop(_ context.Context, value any) error {
+	db, ok := value.(*gorm.DB)
+	if !ok || db == nil {
+		return nil
+	}
+	xGormLog.CloseGormMetrics(db)
+	sqlDB, err := db.DB()
+	if err != nil {
+		return fmt.Errorf("获取数据库连接池失败: %w", err)
+	}
+	return sqlDB.Close()
+}
+
+// DatabaseCheck 是数据库节点的健康检查回调，对 *gorm.DB 底层连接池执行 PingContext。
+//
+// 由 Register 通过 [xRegNode.OnCheck] 登记；业务侧自行注册的 *gorm.DB 节点也可直接复用。
+func DatabaseCheck(ctx context.Context, value any) error {
+	db, ok := value.(*gorm.DB)
+	if !ok || db == nil {
+		return fmt.Errorf("节点值不是 *gorm.DB: %T", value)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return fmt.Errorf("获取数据库连接池失败: %w", err)
+	}
+	return sqlDB.PingContext(ctx)
+}
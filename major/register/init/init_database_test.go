@@ -2,6 +2,7 @@ package xInit_test
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"gorm.io/gorm"
@@ -95,3 +96,31 @@ func TestDatabaseInit_MultiPrepare(t *testing.T) {
 
 	t.Log("✅ WithPrepare 可变参数多回调均生效")
 }
+
+func TestDatabaseInit_ClosesOnFailure(t *testing.T) {
+	// Prepare 失败时节点不会登记关闭回调，DatabaseInit 需自行关闭已建立的连接池。
+	var captured *gorm.DB
+	cfg := xOption.Apply(
+		xOption.WithDatabase(
+			xOptDatabase.SQLite(":memory:"),
+			xOptDatabase.WithPrepare(xOptDatabase.PrepareFunc(func(ctx context.Context, db *gorm.DB) error {
+				captured = db
+				return errors.New("prepare failed")
+			})),
+		),
+	).Database()
+
+	if _, err := xInit.DatabaseInit(cfg)(context.Background()); err == nil {
+		t.Fatal("Prepare 失败时 DatabaseInit 应返回错误")
+	}
+	if captured == nil {
+		t.Fatal("Prepare 回调未执行")
+	}
+	sqlDB, err := captured.DB()
+	if err != nil {
+		t.Fatalf("获取连接池失败: %v", err)
+	}
+	if err := sqlDB.Ping(); err == nil {
+		t.Error("初始化失败后连接池应已关闭")
+	}
+}
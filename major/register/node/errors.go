@@ -0,0 +1,43 @@
+package xRegNode
+
+import (
+	"errors"
+	"fmt"
+
+	xCtx "github.com/bamboo-services/bamboo-base-go/defined/context"
+)
+
+var (
+	// ErrMissingDependency 表示节点依赖的 key 既未注册，也不存在于初始上下文中。
+	ErrMissingDependency = errors.New("节点依赖缺失")
+
+	// ErrDependencyCycle 表示节点之间存在循环依赖。
+	ErrDependencyCycle = errors.New("检测到循环依赖")
+
+	// ErrInvalidDependency 表示节点声明了非法依赖（如 xCtx.Exec）。
+	ErrInvalidDependency = errors.New("节点依赖非法")
+)
+
+// NodeError 是 [RegNode.ExecE] 返回的结构化错误，标识出错节点及原因。
+//
+// Index 为节点在注册队列中的下标，Key 为注册时的 ContextKey，Err 为根因。
+// 可通过 errors.As 取出 NodeError，或通过 errors.Is 判断 [ErrMissingDependency] /
+// [ErrDependencyCycle] 等依赖类错误。
+type NodeError struct {
+	Index int             // 节点注册下标
+	Key   xCtx.ContextKey // 节点 ContextKey
+	Err   error           // 根因
+}
+
+// Error 返回与历史 panic 信息一致的错误描述。
+func (e *NodeError) Error() string {
+	if e.Key.IsExec() {
+		return fmt.Sprintf("执行逻辑节点失败: index=%d Key=%v err=%v", e.Index, e.Key, e.Err)
+	}
+	return fmt.Sprintf("执行注册节点失败: index=%d Key=%v err=%v", e.Index, e.Key, e.Err)
+}
+
+// Unwrap 返回根因，支持 errors.Is / errors.As 链式判断。
+func (e *NodeError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,138 @@
+package xRegNode
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	xCtx "github.com/bamboo-services/bamboo-base-go/defined/context"
+)
+
+// resolveLayers 根据节点依赖关系对 list 做拓扑分层。
+//
+// 返回的每一层是可并行执行的节点下标（层内按注册顺序排列），层与层之间严格串行。
+// 未声明依赖（Deps 为 nil）的节点隐式依赖所有先于它注册的节点，保持原有的顺序语义。
+// hasValue 用于判断依赖是否已由外部上下文提供。解析失败时返回 [*NodeError]。
+func resolveLayers(list []RegNodeList, hasValue func(key xCtx.ContextKey) bool) ([][]int, error) {
+	indexOf := make(map[xCtx.ContextKey]int, len(list))
+	for i, node := range list {
+		if !node.Key.IsExec() {
+			indexOf[node.Key] = i
+		}
+	}
+
+	edges := make([][]int, len(list)) // edges[i] 为节点 i 依赖的节点下标
+	for i, node := range list {
+		if node.Deps == nil {
+			for j := 0; j < i; j++ {
+				edges[i] = append(edges[i], j)
+			}
+			continue
+		}
+		for _, dep := range node.Deps {
+			if dep.IsExec() {
+				return nil, &NodeError{Index: i, Key: node.Key, Err: fmt.Errorf("%w: 不能依赖 %v", ErrInvalidDependency, dep)}
+			}
+			j, ok := indexOf[dep]
+			if !ok {
+				if hasValue != nil && hasValue(dep) {
+					continue
+				}
+				return nil, &NodeError{Index: i, Key: node.Key, Err: fmt.Errorf("%w: 依赖 %v 未注册", ErrMissingDependency, dep)}
+			}
+			if j == i {
+				return nil, &NodeError{Index: i, Key: node.Key, Err: fmt.Errorf("%w: %v(index=%d) -> %v(index=%d)", ErrDependencyCycle, node.Key, i, node.Key, i)}
+			}
+			edges[i] = append(edges[i], j)
+		}
+	}
+
+	pending := make([]int, len(list))
+	dependents := make([][]int, len(list))
+	for i, deps := range edges {
+		pending[i] = len(deps)
+		for _, j := range deps {
+			dependents[j] = append(dependents[j], i)
+		}
+	}
+
+	layers := make([][]int, 0)
+	done := 0
+	current := make([]int, 0)
+	for i := range list {
+		if pending[i] == 0 {
+			current = append(current, i)
+		}
+	}
+	for len(current) > 0 {
+		layers = append(layers, current)
+		done += len(current)
+		next := make([]int, 0)
+		for _, j := range current {
+			for _, i := range dependents[j] {
+				pending[i]--
+				if pending[i] == 0 {
+					next = append(next, i)
+				}
+			}
+		}
+		sort.Ints(next)
+		current = next
+	}
+
+	if done != len(list) {
+		first, path := describeCycle(list, edges, pending)
+		return nil, &NodeError{Index: first, Key: list[first].Key, Err: fmt.Errorf("%w: %s", ErrDependencyCycle, path)}
+	}
+	return layers, nil
+}
+
+// describeCycle 在未完成拓扑排序的节点中找出一条环路，格式化为 "a -> b -> a"，
+// 同时返回环路起点的节点下标。
+func describeCycle(list []RegNodeList, edges [][]int, pending []int) (int, string) {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make([]int, len(list))
+	stack := make([]int, 0)
+
+	var cycle []int
+	var visit func(i int) bool
+	visit = func(i int) bool {
+		state[i] = visiting
+		stack = append(stack, i)
+		for _, j := range edges[i] {
+			if pending[j] == 0 {
+				continue
+			}
+			if state[j] == visiting {
+				for k := len(stack) - 1; k >= 0; k-- {
+					if stack[k] == j {
+						cycle = append(append(cycle, stack[k:]...), j)
+						return true
+					}
+				}
+			}
+			if state[j] == unvisited && visit(j) {
+				return true
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[i] = visited
+		return false
+	}
+
+	for i := range list {
+		if pending[i] > 0 && state[i] == unvisited && visit(i) {
+			break
+		}
+	}
+
+	names := make([]string, 0, len(cycle))
+	for _, i := range cycle {
+		names = append(names, fmt.Sprintf("%v(index=%d)", list[i].Key, i))
+	}
+	return cycle[0], strings.Join(names, " -> ")
+}
@@ -0,0 +1,61 @@
+package xRegNode
+
+import (
+	"context"
+
+	xHealth "github.com/bamboo-services/bamboo-base-go/common/health"
+	xCtx "github.com/bamboo-services/bamboo-base-go/defined/context"
+)
+
+// CheckFunc 是节点的健康检查回调，value 为节点初始化时返回的组件实例。
+//
+// 用于为未实现 [xHealth.Checker] 的第三方类型（如 *gorm.DB、*redis.Client）补充检查逻辑，参见 [OnCheck]。
+type CheckFunc func(ctx context.Context, value any) error
+
+// OnCheck 为节点声明健康检查回调。
+//
+// 声明后优先于返回值自身实现的 [xHealth.Checker] 使用；节点返回 nil 时不会登记。
+func OnCheck(fn CheckFunc) NodeOption {
+	return func(node *RegNodeList) {
+		node.Check = fn
+	}
+}
+
+// HealthCheck 一个已登记的节点健康检查。
+type HealthCheck struct {
+	Key      xCtx.ContextKey // 节点 ContextKey，作为检查名称
+	Optional bool            // 节点是否为可选节点，失败时不影响就绪状态
+	Checker  xHealth.Checker // 检查逻辑
+}
+
+// registerCheck 根据节点声明与返回值登记健康检查，按初始化完成顺序追加。
+func (rn *RegNode) registerCheck(node RegNodeList, value any) {
+	if value == nil || node.Key.IsExec() {
+		return
+	}
+	var checker xHealth.Checker
+	switch {
+	case node.Check != nil:
+		fn := node.Check
+		checker = xHealth.CheckFunc(func(ctx context.Context) error {
+			return fn(ctx, value)
+		})
+	default:
+		if c, ok := value.(xHealth.Checker); ok {
+			checker = c
+		}
+	}
+	if checker == nil {
+		return
+	}
+	rn.checkMu.Lock()
+	defer rn.checkMu.Unlock()
+	rn.checks = append(rn.checks, HealthCheck{Key: node.Key, Optional: node.Optional, Checker: checker})
+}
+
+// HealthChecks 返回已登记的节点健康检查，按初始化完成顺序排列。
+func (rn *RegNode) HealthChecks() []HealthCheck {
+	rn.checkMu.Lock()
+	defer rn.checkMu.Unlock()
+	return append([]HealthCheck(nil), rn.checks...)
+}
@@ -0,0 +1,41 @@
+package xRegNode
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// checkComponent 是实现 xHealth.Checker 的测试组件。
+type checkComponent struct{ err error }
+
+func (c *checkComponent) Check(context.Context) error { return c.err }
+
+// TestHealthChecks 验证 OnCheck 优先于组件自身实现的 Checker，未实现检查的节点不登记。
+func TestHealthChecks(t *testing.T) {
+	rn := NewRegNode(context.Background())
+	rn.Use("cache", func(ctx context.Context) (any, error) {
+		return &checkComponent{}, nil
+	}, DependsOn())
+	rn.Use("db", func(ctx context.Context) (any, error) {
+		return &checkComponent{}, nil
+	}, DependsOn(), OnCheck(func(ctx context.Context, value any) error {
+		return errors.New("db down")
+	}))
+	rn.Use("mail", func(ctx context.Context) (any, error) {
+		return nil, errors.New("smtp unavailable")
+	}, DependsOn(), Optional())
+	rn.Use("plain", func(ctx context.Context) (any, error) { return "no-check", nil }, DependsOn())
+	rn.Exec()
+
+	checks := rn.HealthChecks()
+	if len(checks) != 2 || checks[0].Key != "cache" || checks[1].Key != "db" {
+		t.Fatalf("登记的健康检查不匹配: %+v", checks)
+	}
+	if err := checks[0].Checker.Check(context.Background()); err != nil {
+		t.Errorf("cache 应使用组件自身的 Check: %v", err)
+	}
+	if err := checks[1].Checker.Check(context.Background()); err == nil || err.Error() != "db down" {
+		t.Errorf("db 应使用 OnCheck 回调: %v", err)
+	}
+}
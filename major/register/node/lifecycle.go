@@ -0,0 +1,214 @@
+package xRegNode
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	xLog "github.com/bamboo-services/bamboo-base-go/common/log"
+	xCtx "github.com/bamboo-services/bamboo-base-go/defined/context"
+)
+
+// Starter 是节点返回值可选实现的生命周期接口。
+//
+// 节点函数返回的组件实例若实现了 Starter，Exec 会自动登记其 Start 方法，
+// 并在 [RegNode.Start] 时按初始化顺序调用，用于在全部组件就绪后再开始工作（如消费者开始拉取消息）。
+// ctx 为运行期上下文，应用退出时被取消；实现方应启动后台协程后立即返回，不应阻塞。
+type Starter interface {
+	Start(ctx context.Context) error
+}
+
+// StartFunc 是节点的启动回调，value 为节点初始化时返回的组件实例。
+//
+// 用于为未实现 [Starter] 的第三方类型补充启动逻辑，参见 [OnStart]。
+type StartFunc func(ctx context.Context, value any) error
+
+// OnStart 为节点声明启动回调。
+//
+// 声明后优先于返回值自身实现的 [Starter] 使用；节点返回 nil 时不会登记。
+func OnStart(fn StartFunc) NodeOption {
+	return func(node *RegNodeList) {
+		node.Start = fn
+	}
+}
+
+// startHook 记录一个已登记的启动回调。
+type startHook struct {
+	key   xCtx.ContextKey
+	start func(ctx context.Context) error
+}
+
+// registerStart 根据节点声明与返回值登记启动回调，按初始化完成顺序追加。
+func (rn *RegNode) registerStart(node RegNodeList, value any) {
+	if value == nil {
+		return
+	}
+	rn.startMu.Lock()
+	defer rn.startMu.Unlock()
+	switch {
+	case node.Start != nil:
+		fn := node.Start
+		rn.starts = append(rn.starts, startHook{key: node.Key, start: func(ctx context.Context) error {
+			return fn(ctx, value)
+		}})
+	default:
+		if starter, ok := value.(Starter); ok {
+			rn.starts = append(rn.starts, startHook{key: node.Key, start: starter.Start})
+		}
+	}
+}
+
+// Start 按初始化顺序调用所有已登记的启动回调。
+//
+// 被依赖方总是先于依赖方启动；任一回调失败（或 panic）即停止并返回错误，
+// 已启动与未启动的组件都仍登记在关闭回调中，调用方应随后调用 [RegNode.Stop] 释放。
+//
+// 回调执行后即被清空，重复调用只会执行此后经 [RegNode.UseAfterExec] 新登记的回调。
+func (rn *RegNode) Start(ctx context.Context) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	rn.startMu.Lock()
+	hooks := rn.starts
+	rn.starts = nil
+	rn.startMu.Unlock()
+	if len(hooks) == 0 {
+		return nil
+	}
+
+	log := xLog.WithName(xLog.NamedCORE)
+	log.Info(ctx, "正在启动已注册组件", slog.Int("count", len(hooks)))
+	for _, hook := range hooks {
+		if err := runStartHook(ctx, hook); err != nil {
+			log.Error(ctx, "组件启动失败", slog.String("key", hook.key.String()), slog.String("error", err.Error()))
+			return err
+		}
+		log.Debug(ctx, "组件已启动", slog.String("key", hook.key.String()))
+	}
+	return nil
+}
+
+// runStartHook 执行单个启动回调，回调内部 panic 会被转换为错误。
+func runStartHook(ctx context.Context, hook startHook) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("启动组件 panic: Key=%v panic=%v", hook.key, r)
+		}
+	}()
+	if err := hook.start(ctx); err != nil {
+		return fmt.Errorf("启动组件失败: Key=%v err=%w", hook.key, err)
+	}
+	return nil
+}
+
+// Stopper 是节点返回值可选实现的生命周期接口。
+//
+// 节点函数返回的组件实例若实现了 Stopper，Exec 会自动登记其 Stop 方法，
+// 并在 [RegNode.Stop] 时按初始化的逆序调用，用于释放连接池、消费者等资源。
+// ctx 携带关闭截止时间，实现方应在 ctx 结束前返回。
+type Stopper interface {
+	Stop(ctx context.Context) error
+}
+
+// StopFunc 是节点的关闭回调，value 为节点初始化时返回的组件实例。
+//
+// 用于为未实现 [Stopper] 的第三方类型（如 *gorm.DB）补充关闭逻辑，参见 [OnStop]。
+type StopFunc func(ctx context.Context, value any) error
+
+// OnStop 为节点声明关闭回调。
+//
+// 声明后优先于返回值自身实现的 [Stopper] 使用；节点返回 nil 时不会登记。
+func OnStop(fn StopFunc) NodeOption {
+	return func(node *RegNodeList) {
+		node.Stop = fn
+	}
+}
+
+// stopHook 记录一个已登记的关闭回调。
+type stopHook struct {
+	key  xCtx.ContextKey
+	stop func(ctx context.Context) error
+}
+
+// registerStop 根据节点声明与返回值登记关闭回调，按初始化完成顺序追加。
+func (rn *RegNode) registerStop(node RegNodeList, value any) {
+	if value == nil {
+		return
+	}
+	rn.stopMu.Lock()
+	defer rn.stopMu.Unlock()
+	switch {
+	case node.Stop != nil:
+		fn := node.Stop
+		rn.stops = append(rn.stops, stopHook{key: node.Key, stop: func(ctx context.Context) error {
+			return fn(ctx, value)
+		}})
+	default:
+		if stopper, ok := value.(Stopper); ok {
+			rn.stops = append(rn.stops, stopHook{key: node.Key, stop: stopper.Stop})
+		}
+	}
+}
+
+// Stop 按初始化的逆序调用所有已登记的关闭回调。
+//
+// 依赖方总是先于被依赖方关闭（例如业务消费者先于数据库连接池），
+// 每个回调都在 ctx 的截止时间内执行；ctx 结束后剩余回调不再等待，记录为超时跳过。
+// 单个回调失败不会中断后续回调，所有错误通过 errors.Join 汇总返回。
+//
+// 该方法是幂等的：回调执行后即被清空，重复调用直接返回 nil。
+func (rn *RegNode) Stop(ctx context.Context) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	rn.stopMu.Lock()
+	hooks := rn.stops
+	rn.stops = nil
+	rn.stopMu.Unlock()
+	if len(hooks) == 0 {
+		return nil
+	}
+
+	log := xLog.WithName(xLog.NamedCORE)
+	log.Info(ctx, "正在释放已注册组件", slog.Int("count", len(hooks)))
+
+	var errs []error
+	for i := len(hooks) - 1; i >= 0; i-- {
+		hook := hooks[i]
+		if err := runStopHook(ctx, hook); err != nil {
+			log.Error(ctx, "组件释放失败", slog.String("key", hook.key.String()), slog.String("error", err.Error()))
+			errs = append(errs, err)
+			continue
+		}
+		log.Debug(ctx, "组件已释放", slog.String("key", hook.key.String()))
+	}
+	return errors.Join(errs...)
+}
+
+// runStopHook 在 ctx 的截止时间内执行单个关闭回调，回调内部 panic 会被转换为错误。
+func runStopHook(ctx context.Context, hook stopHook) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("释放组件超时跳过: Key=%v err=%w", hook.key, err)
+	}
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- fmt.Errorf("释放组件 panic: Key=%v panic=%v", hook.key, r)
+			}
+		}()
+		if err := hook.stop(ctx); err != nil {
+			done <- fmt.Errorf("释放组件失败: Key=%v err=%w", hook.key, err)
+			return
+		}
+		done <- nil
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return fmt.Errorf("释放组件超时: Key=%v err=%w", hook.key, ctx.Err())
+	}
+}
@@ -0,0 +1,136 @@
+package xRegNode
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// stopRecorder 是实现 Stopper 的测试组件。
+type stopRecorder struct {
+	name  string
+	order *[]string
+}
+
+func (s *stopRecorder) Stop(context.Context) error {
+	*s.order = append(*s.order, s.name)
+	return nil
+}
+
+// TestStopReverseOrder 验证关闭回调按初始化逆序执行，且 OnStop 优先于 Stopper。
+func TestStopReverseOrder(t *testing.T) {
+	rn := NewRegNode(context.Background())
+	order := make([]string, 0)
+	rn.Use("service", func(ctx context.Context) (any, error) {
+		return &stopRecorder{name: "service", order: &order}, nil
+	}, DependsOn("db"))
+	rn.Use("db", func(ctx context.Context) (any, error) {
+		return &stopRecorder{name: "ignored", order: &order}, nil
+	}, DependsOn(), OnStop(func(ctx context.Context, value any) error {
+		order = append(order, "db")
+		return nil
+	}))
+	rn.Use("plain", func(ctx context.Context) (any, error) { return "no-stop", nil })
+	rn.Exec()
+
+	if err := rn.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop 返回错误: %v", err)
+	}
+	if got := strings.Join(order, ","); got != "service,db" {
+		t.Fatalf("关闭顺序不正确: %s", got)
+	}
+	if err := rn.Stop(context.Background()); err != nil || len(order) != 2 {
+		t.Fatalf("重复 Stop 不应再次执行回调: err=%v order=%v", err, order)
+	}
+}
+
+// TestStopAfterLayerFailure 验证同层节点失败时，已成功的同层节点仍登记关闭回调并可被 Stop 释放。
+func TestStopAfterLayerFailure(t *testing.T) {
+	rn := NewRegNode(context.Background())
+	order := make([]string, 0)
+	rn.Use("base", func(ctx context.Context) (any, error) { return &stopRecorder{name: "base", order: &order}, nil }, DependsOn())
+	rn.Use("db", func(ctx context.Context) (any, error) { return nil, errors.New("dial timeout") }, DependsOn("base"))
+	rn.Use("cache", func(ctx context.Context) (any, error) {
+		return &stopRecorder{name: "cache", order: &order}, nil
+	}, DependsOn("base"))
+
+	if _, err := rn.ExecE(); err == nil {
+		t.Fatal("db 失败时 ExecE 应返回错误")
+	}
+	if err := rn.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop 失败: %v", err)
+	}
+	if strings.Join(order, ",") != "cache,base" {
+		t.Fatalf("同层已成功的节点未被释放: %v", order)
+	}
+}
+
+// TestStopCollectsErrorsAndDeadline 验证回调错误会被汇总，超时回调不会阻塞 Stop。
+func TestStopCollectsErrorsAndDeadline(t *testing.T) {
+	rn := NewRegNode(context.Background())
+	rn.Use("broken", func(ctx context.Context) (any, error) { return 1, nil },
+		OnStop(func(ctx context.Context, value any) error { return errors.New("close failed") }))
+	rn.Use("slow", func(ctx context.Context) (any, error) { return 2, nil },
+		OnStop(func(ctx context.Context, value any) error {
+			time.Sleep(time.Second)
+			return nil
+		}))
+	rn.Exec()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err := rn.Stop(ctx)
+	if time.Since(start) > 500*time.Millisecond {
+		t.Fatal("Stop 未遵守截止时间")
+	}
+	if err == nil || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("期望包含超时错误, 实际: %v", err)
+	}
+	if !strings.Contains(err.Error(), "Key=broken") {
+		t.Fatalf("期望包含 broken 节点错误, 实际: %v", err)
+	}
+}
+
+// startRecorder 同时实现 Starter 与 Stopper 的测试组件。
+type startRecorder struct {
+	stopRecorder
+	err error
+}
+
+func (s *startRecorder) Start(context.Context) error {
+	*s.order = append(*s.order, "start:"+s.name)
+	return s.err
+}
+
+// TestStartOrder 验证启动回调按初始化顺序执行，OnStart 优先于 Starter，失败即停止且组件仍可被 Stop 释放。
+func TestStartOrder(t *testing.T) {
+	rn := NewRegNode(context.Background())
+	order := make([]string, 0)
+	rn.Use("consumer", func(ctx context.Context) (any, error) {
+		return &startRecorder{stopRecorder: stopRecorder{name: "consumer", order: &order}, err: errors.New("broker unavailable")}, nil
+	}, DependsOn("db"))
+	rn.Use("db", func(ctx context.Context) (any, error) {
+		return &startRecorder{stopRecorder: stopRecorder{name: "ignored", order: &order}}, nil
+	}, DependsOn(), OnStart(func(ctx context.Context, value any) error {
+		order = append(order, "start:db")
+		return nil
+	}))
+	rn.Use("worker", func(ctx context.Context) (any, error) {
+		return &startRecorder{stopRecorder: stopRecorder{name: "worker", order: &order}}, nil
+	}, DependsOn("consumer"))
+	rn.Exec()
+
+	err := rn.Start(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "broker unavailable") {
+		t.Fatalf("consumer 启动失败时 Start 应返回错误: %v", err)
+	}
+	if err := rn.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop 返回错误: %v", err)
+	}
+	if got := strings.Join(order, ","); got != "start:db,start:consumer,worker,consumer,ignored" {
+		t.Fatalf("启动与关闭顺序不正确: %s", got)
+	}
+}
@@ -2,7 +2,11 @@ package xRegNode
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log/slog"
+	"sync"
+	"time"
 
 	xLog "github.com/bamboo-services/bamboo-base-go/common/log"
 	xCtx "github.com/bamboo-services/bamboo-base-go/defined/context"
@@ -23,16 +27,34 @@ import (
 type Node func(ctx context.Context) (any, error)
 
 // RegNodeList 存储组件的上下文键及其初始化函数。
+//
+// Deps 为 nil 时节点隐式依赖所有先于它注册的节点（按注册顺序执行）；
+// 非 nil（包括空切片）时仅依赖其中声明的 key，参见 [DependsOn]。
+//
+// Start 为可选的启动回调，参见 [OnStart]；Stop 为可选的关闭回调，参见 [OnStop]；Check 为可选的健康检查，参见 [OnCheck]；
+// Optional 标记节点失败时降级为 nil，参见 [Optional]。
 type RegNodeList struct {
-	Key  xCtx.ContextKey
-	Node Node
+	Key      xCtx.ContextKey
+	Node     Node
+	Deps     []xCtx.ContextKey
+	Start    StartFunc
+	Stop     StopFunc
+	Check    CheckFunc
+	Optional bool
 }
 
 // RegNode 是应用程序组件注册和初始化的管理器。
 type RegNode struct {
-	list  []RegNodeList
-	value xCtx.ContextNodeList
-	Ctx   context.Context
+	list    []RegNodeList
+	value   xCtx.ContextNodeList
+	starts  []startHook
+	startMu sync.Mutex
+	stops   []stopHook
+	stopMu  sync.Mutex
+	checks  []HealthCheck
+	checkMu sync.Mutex
+	report  *StartupReport
+	Ctx     context.Context
 }
 
 // NewRegNode 创建并初始化 RegNode 实例。
@@ -63,10 +85,12 @@ func NewRegNode(ctx context.Context) *RegNode {
 // 参数:
 //   - ctxKey: 上下文键，用于在上下文中唯一标识该组件，不能为空或重复
 //   - registerFunc: 组件初始化函数，接收当前上下文并返回组件实例或错误
+//   - opts: 节点可选配置，如 [DependsOn] 声明依赖
 //
 // 注册顺序:
-//   - 应按照组件的依赖关系顺序注册（被依赖的组件先注册）
-//   - 例如：配置 -> 日志器 -> 数据库 -> 业务服务
+//   - 未声明依赖的节点按注册顺序执行（被依赖的组件需先注册）
+//   - 通过 [DependsOn] 声明依赖的节点由 Exec 拓扑排序，注册顺序不再重要，
+//     且与无依赖关系的节点并行初始化
 //
 // Panic 条件:
 //   - 在 Exec() 执行后再次调用（list 已被清空）
@@ -81,8 +105,29 @@ func NewRegNode(ctx context.Context) *RegNode {
 //	rn.Use(xCtx.DatabaseKey, func(ctx context.Context) (any, error) {
 //	    cfg := ctx.Value(xCtx.ConfigKey).(Config)
 //	    return connectDB(cfg.DSN), nil
-//	})
-func (rn *RegNode) Use(ctxKey xCtx.ContextKey, registerFunc Node) {
+//	}, xRegNode.DependsOn(xCtx.ConfigKey))
+func (rn *RegNode) Use(ctxKey xCtx.ContextKey, registerFunc Node, opts ...NodeOption) {
+	node := RegNodeList{Key: ctxKey, Node: registerFunc}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&node)
+		}
+	}
+	rn.UseList(node)
+}
+
+// UseList 注册一组预先声明的节点，校验规则与 [Use] 一致。
+//
+// 节点上声明的 Deps / Stop / Check 会原样保留，适用于 [xReg.Register] 透传业务侧的 nodeList。
+func (rn *RegNode) UseList(nodes ...RegNodeList) {
+	for _, node := range nodes {
+		rn.add(node)
+	}
+}
+
+// add 校验并追加单个节点到执行队列。
+func (rn *RegNode) add(node RegNodeList) {
+	ctxKey, registerFunc := node.Key, node.Node
 	if rn.list == nil {
 		panic("初始化外部禁止二次初始化")
 	}
@@ -99,61 +144,184 @@ func (rn *RegNode) Use(ctxKey xCtx.ContextKey, registerFunc Node) {
 			}
 		}
 	}
-	rn.list = append(rn.list, RegNodeList{Key: ctxKey, Node: registerFunc})
+	rn.list = append(rn.list, node)
 }
 
-// Exec 按注册顺序执行所有初始化节点，并将结果存入上下文。
+// Exec 按依赖关系执行所有初始化节点，并将结果存入上下文。
 //
-// 该方法遍历通过 Use() 注册的所有初始化函数，按注册顺序依次执行。
-// 每个函数执行成功后，其返回值会通过 context.WithValue 存储到 Ctx 中，
+// 该方法先根据节点声明的依赖对 list 做拓扑分层，再逐层执行：同一层内的节点互不依赖，
+// 会并行调用；每层全部完成后按注册顺序将返回值通过 context.WithValue 存储到 Ctx 中，
 // 键为注册时指定的 ContextKey，值为函数返回的组件实例。
 //
 // 执行流程:
-//  1. 遍历 list 中的所有节点
-//  2. 调用节点的 Node 函数，传入当前 Ctx（包含已初始化的组件）
-//  3. 将返回值存入 Ctx，更新上下文
-//  4. 继续执行下一个节点
+//  1. 解析依赖图，检测缺失依赖与循环依赖
+//  2. 按层调用节点的 Node 函数，传入当前 Ctx（包含此前各层已初始化的组件）
+//  3. 层内全部完成后将返回值存入 Ctx，更新上下文
+//  4. 继续执行下一层
 //  5. 所有节点执行完成后，清空 list 释放内存
 //
 // Panic 条件:
-//   - 任何节点函数返回非 nil 错误时，会 panic 并输出节点索引、键名和错误信息
+//   - 节点依赖了未注册且初始 Ctx 中不存在的 key
+//   - 节点之间存在循环依赖（错误信息中会列出环路）
+//   - 任何非可选节点函数返回非 nil 错误时，会 panic 并输出节点索引、键名和错误信息
+//
+// 如需以返回值而非 panic 的方式处理失败，请使用 [RegNode.ExecE]。
 //
 // 注意事项:
 //   - 该方法只能调用一次，执行后 list 会被设置为 nil
 //   - 执行后不能再调用 Use() 方法注册新节点
-//   - 节点函数中可以通过 ctx.Value() 访问之前已初始化的组件
+//   - 节点函数中可以通过 ctx.Value() 访问其依赖（或之前已初始化）的组件
+//   - 未声明依赖的节点保持原有的串行语义，与历史行为一致
 //
 // 使用示例:
 //
 //	rn := NewRegNode()
 //	rn.Use(xCtx.ConfigKey, loadConfigFunc)
 //	rn.Use(xCtx.LoggerKey, initLoggerFunc)
-//	rn.Exec() // 按顺序执行所有初始化函数
+//	rn.Exec() // 按依赖顺序执行所有初始化函数
 //	// 此时 rn.Ctx 包含所有已初始化的组件
 func (rn *RegNode) Exec() xCtx.ContextNodeList {
+	value, err := rn.ExecE()
+	if err != nil {
+		panic(err.Error())
+	}
+	return value
+}
+
+// ExecE 与 [RegNode.Exec] 行为一致，但节点失败时返回错误而不是 panic。
+//
+// 返回的错误为 [*NodeError]，包含失败节点的注册下标、ContextKey 与根因；
+// 依赖缺失、循环依赖同样以 NodeError 返回，可通过 errors.Is 判断
+// [ErrMissingDependency] / [ErrDependencyCycle]。
+//
+// 通过 [Optional] 标记的节点失败时不会中断执行：记录 WARN 日志，并以 nil 值写入上下文。
+//
+// 失败后 RegNode 不可再次执行；此前已初始化的组件（含失败节点同层内已成功的节点）仍保留在登记的关闭回调中，
+// 调用方可通过 [RegNode.Stop] 释放后重新构造 RegNode 重试。
+func (rn *RegNode) ExecE() (xCtx.ContextNodeList, error) {
+	if rn.list == nil {
+		return nil, errors.New("初始化外部禁止二次初始化")
+	}
 	log := xLog.WithName(xLog.NamedINIT)
 	log.Info(rn.Ctx, "========== 初始化开始 ==========")
-	for i, node := range rn.list {
-		val, err := node.Node(rn.Ctx)
-		if !node.Key.IsExec() {
-			if err != nil {
-				panic(fmt.Sprintf("执行注册节点失败: index=%d Key=%v err=%v", i, node.Key, err))
+	rn.report = newStartupReport()
+
+	layers, err := resolveLayers(rn.list, func(key xCtx.ContextKey) bool {
+		return rn.Ctx.Value(key) != nil
+	})
+	if err != nil {
+		rn.list = nil
+		rn.finishReport(log, err)
+		return nil, err
+	}
+
+	for layerIndex, layer := range layers {
+		results := rn.execLayer(layer)
+		for _, result := range results {
+			node := rn.list[result.index]
+			rn.report.record(layerIndex, node, result)
+		}
+		if nodeErr := rn.layerError(results); nodeErr != nil {
+			// 同层其他节点可能已初始化成功，登记其关闭回调，调用方才能通过 Stop 释放
+			for _, result := range results {
+				if result.err == nil {
+					rn.registerStop(rn.list[result.index], result.value)
+				}
 			}
-			rn.value.Append(node.Key, val)
-			rn.Ctx = context.WithValue(rn.Ctx, node.Key, val)
-		} else {
-			if err != nil {
-				panic(fmt.Sprintf("执行逻辑节点失败: index=%d Key=%v err=%v", i, node.Key, err))
+			rn.list = nil
+			rn.finishReport(log, nodeErr)
+			return nil, nodeErr
+		}
+		for _, result := range results {
+			node := rn.list[result.index]
+			if result.err != nil {
+				log.Warn(rn.Ctx, "可选节点初始化失败，已降级为 nil",
+					slog.Int("index", result.index),
+					slog.String("key", node.Key.String()),
+					slog.String("error", result.err.Error()),
+				)
+				result.value = nil
+			}
+			if !node.Key.IsExec() {
+				rn.value.Append(node.Key, result.value)
+				rn.Ctx = context.WithValue(rn.Ctx, node.Key, result.value)
 			}
+			rn.registerStart(node, result.value)
+			rn.registerStop(node, result.value)
+			rn.registerCheck(node, result.value)
 		}
 	}
 	log.Info(rn.Ctx, "========== 初始化完成 ==========")
+	rn.finishReport(log, nil)
 
 	rn.Ctx = context.WithValue(rn.Ctx, xCtx.RegNodeKey, rn.value)
+	rn.Ctx = context.WithValue(rn.Ctx, xCtx.StartupReportKey, rn.report)
 	rn.list = nil
 	log.Debug(rn.Ctx, "初始化剩余项处理完毕")
 
-	return rn.value
+	return rn.value, nil
+}
+
+// layerError 返回层内按注册顺序第一个非可选节点的失败，全部成功或仅可选节点失败时返回 nil。
+func (rn *RegNode) layerError(results []nodeResult) *NodeError {
+	for _, result := range results {
+		if node := rn.list[result.index]; result.err != nil && !node.Optional {
+			return &NodeError{Index: result.index, Key: node.Key, Err: result.err}
+		}
+	}
+	return nil
+}
+
+// nodeResult 记录单个节点的执行结果。
+type nodeResult struct {
+	index    int
+	value    any
+	err      error
+	duration time.Duration
+}
+
+// runNode 执行单个节点并记录耗时。
+func (rn *RegNode) runNode(ctx context.Context, index int) nodeResult {
+	start := time.Now()
+	value, err := rn.list[index].Node(ctx)
+	return nodeResult{index: index, value: value, err: err, duration: time.Since(start)}
+}
+
+// execLayer 执行同一拓扑层内的节点，返回按注册顺序排列的结果。
+//
+// 层内只有一个节点时直接在当前协程执行；否则每个节点独立协程并行执行，
+// 节点内部的 panic 会被捕获并在当前协程重新抛出（取注册顺序最靠前的一个），
+// 保证调用方的 recover 行为与串行执行一致。
+func (rn *RegNode) execLayer(layer []int) []nodeResult {
+	results := make([]nodeResult, len(layer))
+	if len(layer) == 1 {
+		results[0] = rn.runNode(rn.Ctx, layer[0])
+		return results
+	}
+
+	ctx := rn.Ctx
+	panics := make([]any, len(layer))
+	var wg sync.WaitGroup
+	for i, index := range layer {
+		wg.Add(1)
+		go func(i, index int) {
+			defer wg.Done()
+			defer func() {
+				if r := recover(); r != nil {
+					panics[i] = r
+				}
+			}()
+			results[i] = rn.runNode(ctx, index)
+		}(i, index)
+	}
+	wg.Wait()
+
+	for _, r := range panics {
+		if r != nil {
+			panic(r)
+		}
+	}
+	return results
 }
 
 // GetRegNodeList 获取已注册并初始化的组件上下文列表。
@@ -211,6 +379,9 @@ func (rn *RegNode) UseAfterExec(ctxKey xCtx.ContextKey, registerFunc Node) {
 		panic(fmt.Sprintf("UseAfterExec 执行失败: Key=%v err=%v", ctxKey, err))
 	}
 	rn.value.Append(ctxKey, val)
+	rn.registerStart(RegNodeList{Key: ctxKey, Node: registerFunc}, val)
+	rn.registerStop(RegNodeList{Key: ctxKey, Node: registerFunc}, val)
+	rn.registerCheck(RegNodeList{Key: ctxKey, Node: registerFunc}, val)
 	rn.Ctx = context.WithValue(rn.Ctx, ctxKey, val)
 	rn.Ctx = context.WithValue(rn.Ctx, xCtx.RegNodeKey, rn.value)
 }
@@ -0,0 +1,189 @@
+package xRegNode
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	xCtx "github.com/bamboo-services/bamboo-base-go/defined/context"
+)
+
+// recoverMessage 执行 fn 并返回其 panic 信息（未 panic 时返回空串）。
+func recoverMessage(fn func()) (msg string) {
+	defer func() {
+		if r := recover(); r != nil {
+			msg = fmt.Sprint(r)
+		}
+	}()
+	fn()
+	return ""
+}
+
+// TestExecSequentialWithoutDeps 验证未声明依赖的节点保持注册顺序执行。
+func TestExecSequentialWithoutDeps(t *testing.T) {
+	rn := NewRegNode(context.Background())
+	order := make([]string, 0)
+	for _, name := range []string{"a", "b", "c"} {
+		rn.Use(xCtx.ContextKey(name), func(ctx context.Context) (any, error) {
+			order = append(order, name)
+			return name, nil
+		})
+	}
+	rn.Exec()
+
+	if got := strings.Join(order, ","); got != "a,b,c" {
+		t.Fatalf("执行顺序不符合注册顺序: %s", got)
+	}
+	if rn.Ctx.Value(xCtx.ContextKey("c")) != "c" {
+		t.Fatal("Ctx 未写入节点返回值")
+	}
+}
+
+// TestExecTopologicalOrder 验证声明依赖的节点可以先于其依赖注册。
+func TestExecTopologicalOrder(t *testing.T) {
+	rn := NewRegNode(context.Background())
+	var gotDB any
+	rn.Use("service", func(ctx context.Context) (any, error) {
+		gotDB = ctx.Value(xCtx.ContextKey("db"))
+		return "service", nil
+	}, DependsOn("db"))
+	rn.Use("db", func(ctx context.Context) (any, error) {
+		return "db", nil
+	}, DependsOn())
+	rn.Exec()
+
+	if gotDB != "db" {
+		t.Fatalf("service 节点未拿到依赖 db，实际: %v", gotDB)
+	}
+	list := rn.value.GetList()
+	if len(list) != 2 || list[0].Key != "db" || list[1].Key != "service" {
+		t.Fatalf("ContextNodeList 顺序不正确: %+v", list)
+	}
+}
+
+// TestExecParallelIndependentNodes 验证互不依赖的节点并行执行。
+func TestExecParallelIndependentNodes(t *testing.T) {
+	rn := NewRegNode(context.Background())
+	var running, peak int32
+	slow := func(ctx context.Context) (any, error) {
+		current := atomic.AddInt32(&running, 1)
+		for {
+			old := atomic.LoadInt32(&peak)
+			if current <= old || atomic.CompareAndSwapInt32(&peak, old, current) {
+				break
+			}
+		}
+		time.Sleep(50 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		return true, nil
+	}
+	rn.Use("x", slow, DependsOn())
+	rn.Use("y", slow, DependsOn())
+	rn.Use("z", slow, DependsOn())
+	rn.Exec()
+
+	if atomic.LoadInt32(&peak) < 2 {
+		t.Fatalf("独立节点未并行执行, peak=%d", peak)
+	}
+}
+
+// TestExecMissingDependency 验证缺失依赖时给出明确错误。
+func TestExecMissingDependency(t *testing.T) {
+	rn := NewRegNode(context.Background())
+	rn.Use("service", func(ctx context.Context) (any, error) { return nil, nil }, DependsOn("db"))
+
+	msg := recoverMessage(func() { rn.Exec() })
+	if !strings.Contains(msg, "依赖缺失") || !strings.Contains(msg, "db") {
+		t.Fatalf("缺失依赖错误信息不明确: %q", msg)
+	}
+}
+
+// TestExecDependencyFromBaseContext 验证依赖可由初始上下文提供。
+func TestExecDependencyFromBaseContext(t *testing.T) {
+	base := context.WithValue(context.Background(), xCtx.ContextKey("config"), "cfg")
+	rn := NewRegNode(base)
+	var got any
+	rn.Use("service", func(ctx context.Context) (any, error) {
+		got = ctx.Value(xCtx.ContextKey("config"))
+		return nil, nil
+	}, DependsOn("config"))
+	rn.Exec()
+
+	if got != "cfg" {
+		t.Fatalf("未从初始上下文拿到依赖，实际: %v", got)
+	}
+}
+
+// TestExecCycleDetection 验证循环依赖会列出环路。
+func TestExecCycleDetection(t *testing.T) {
+	rn := NewRegNode(context.Background())
+	noop := func(ctx context.Context) (any, error) { return nil, nil }
+	rn.Use("a", noop, DependsOn("b"))
+	rn.Use("b", noop, DependsOn("c"))
+	rn.Use("c", noop, DependsOn("a"))
+
+	msg := recoverMessage(func() { rn.Exec() })
+	if !strings.Contains(msg, "循环依赖") || !strings.Contains(msg, "a(index=0)") {
+		t.Fatalf("循环依赖错误信息不明确: %q", msg)
+	}
+}
+
+// TestExecParallelNodeError 验证并行层中的节点错误仍会中断初始化。
+func TestExecParallelNodeError(t *testing.T) {
+	rn := NewRegNode(context.Background())
+	rn.Use("ok", func(ctx context.Context) (any, error) { return 1, nil }, DependsOn())
+	rn.Use("bad", func(ctx context.Context) (any, error) { return nil, errors.New("boom") }, DependsOn())
+
+	msg := recoverMessage(func() { rn.Exec() })
+	if !strings.Contains(msg, "Key=bad") || !strings.Contains(msg, "boom") {
+		t.Fatalf("节点失败信息不正确: %q", msg)
+	}
+}
+
+// TestExecEReturnsNodeError 验证 ExecE 以结构化错误返回失败节点信息。
+func TestExecEReturnsNodeError(t *testing.T) {
+	rn := NewRegNode(context.Background())
+	cause := errors.New("dial timeout")
+	rn.Use("db", func(ctx context.Context) (any, error) { return nil, cause })
+
+	_, err := rn.ExecE()
+	var nodeErr *NodeError
+	if !errors.As(err, &nodeErr) {
+		t.Fatalf("期望 *NodeError, 实际: %T %v", err, err)
+	}
+	if nodeErr.Index != 0 || nodeErr.Key != "db" || !errors.Is(err, cause) {
+		t.Fatalf("NodeError 字段不正确: %+v", nodeErr)
+	}
+
+	rn = NewRegNode(context.Background())
+	rn.Use("service", func(ctx context.Context) (any, error) { return nil, nil }, DependsOn("db"))
+	if _, err = rn.ExecE(); !errors.Is(err, ErrMissingDependency) {
+		t.Fatalf("期望 ErrMissingDependency, 实际: %v", err)
+	}
+}
+
+// TestExecEOptionalNode 验证可选节点失败时降级为 nil 且不中断后续节点。
+func TestExecEOptionalNode(t *testing.T) {
+	rn := NewRegNode(context.Background())
+	var after bool
+	rn.Use("mq", func(ctx context.Context) (any, error) { return "conn", errors.New("unreachable") }, Optional())
+	rn.Use("service", func(ctx context.Context) (any, error) {
+		after = true
+		return ctx.Value(xCtx.ContextKey("mq")), nil
+	})
+
+	list, err := rn.ExecE()
+	if err != nil {
+		t.Fatalf("可选节点失败不应返回错误: %v", err)
+	}
+	if !after {
+		t.Fatal("可选节点失败后后续节点未执行")
+	}
+	if list.Get("mq") != nil || list.Get("service") != nil {
+		t.Fatalf("可选节点应以 nil 写入上下文: %+v", list.GetList())
+	}
+}
@@ -0,0 +1,39 @@
+package xRegNode
+
+import (
+	xCtx "github.com/bamboo-services/bamboo-base-go/defined/context"
+)
+
+// NodeOption 是注册节点的可选配置项，作用于 [RegNodeList]。
+type NodeOption func(node *RegNodeList)
+
+// DependsOn 声明节点所依赖的 ContextKey 列表。
+//
+// 声明后节点进入「显式依赖」模式：Exec 仅保证 keys 对应的节点先于当前节点完成，
+// 不再隐式等待所有先注册的节点，从而允许与无关节点并行初始化。
+// 不传任何 key 表示该节点没有依赖，可在第一批次立即执行。
+//
+// 依赖的 key 既可以是本次注册的其他节点，也可以是 RegNode 初始 ctx 中已存在的值；
+// 两者都不满足时 Exec 会报告依赖缺失。xCtx.Exec 不能作为依赖项。
+func DependsOn(keys ...xCtx.ContextKey) NodeOption {
+	return func(node *RegNodeList) {
+		deps := make([]xCtx.ContextKey, 0, len(keys))
+		for _, key := range keys {
+			if key.IsNil() {
+				continue
+			}
+			deps = append(deps, key)
+		}
+		node.Deps = deps
+	}
+}
+
+// Optional 将节点标记为可选。
+//
+// 可选节点初始化失败时不会中断启动：Exec 记录 WARN 日志，并以 nil 值写入上下文，
+// 依赖它的节点仍会执行，需自行处理 nil。适用于非关键的外部依赖（如可降级的第三方服务）。
+func Optional() NodeOption {
+	return func(node *RegNodeList) {
+		node.Optional = true
+	}
+}
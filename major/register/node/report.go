@@ -0,0 +1,185 @@
+package xRegNode
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	xLog "github.com/bamboo-services/bamboo-base-go/common/log"
+	xCtx "github.com/bamboo-services/bamboo-base-go/defined/context"
+)
+
+// NodeStatus 描述节点在启动阶段的执行结果。
+type NodeStatus string
+
+const (
+	NodeStatusSuccess  NodeStatus = "success"  // 初始化成功
+	NodeStatusFailed   NodeStatus = "failed"   // 初始化失败，启动中断
+	NodeStatusDegraded NodeStatus = "degraded" // 可选节点失败，已降级为 nil
+)
+
+// NodeReport 记录单个节点的启动明细。
+type NodeReport struct {
+	Index    int             `json:"index"`       // 节点注册下标
+	Key      xCtx.ContextKey `json:"key"`         // 节点 ContextKey
+	Layer    int             `json:"layer"`       // 拓扑层级，同层节点并行执行
+	Type     string          `json:"type"`        // 返回值类型，nil 时为 "<nil>"
+	Status   NodeStatus      `json:"status"`      // 执行结果
+	Duration time.Duration   `json:"duration_ns"` // 节点耗时
+	Error    string          `json:"error,omitempty"`
+}
+
+// StartupReport 是一次 Exec 的启动报告，包含各节点耗时、返回值类型与执行结果。
+//
+// 通过 [RegNode.Report] 或 [GetStartupReport] 获取，可直接序列化为 JSON 供诊断接口输出。
+type StartupReport struct {
+	mu        sync.RWMutex
+	startedAt time.Time
+	total     time.Duration
+	err       error
+	nodes     []NodeReport
+}
+
+// StartupReportView 是 [StartupReport] 的只读快照，便于 JSON 序列化。
+type StartupReportView struct {
+	StartedAt time.Time     `json:"started_at"`
+	Total     time.Duration `json:"total_ns"`
+	Success   bool          `json:"success"`
+	Error     string        `json:"error,omitempty"`
+	Nodes     []NodeReport  `json:"nodes"`
+}
+
+// newStartupReport 创建以当前时间为起点的启动报告。
+func newStartupReport() *StartupReport {
+	return &StartupReport{
+		startedAt: time.Now(),
+		nodes:     make([]NodeReport, 0),
+	}
+}
+
+// record 记录一个节点的执行结果。
+func (r *StartupReport) record(layer int, node RegNodeList, result nodeResult) {
+	item := NodeReport{
+		Index:    result.index,
+		Key:      node.Key,
+		Layer:    layer,
+		Type:     fmt.Sprintf("%T", result.value),
+		Status:   NodeStatusSuccess,
+		Duration: result.duration,
+	}
+	if result.err != nil {
+		item.Status = NodeStatusFailed
+		if node.Optional {
+			item.Status = NodeStatusDegraded
+			item.Type = fmt.Sprintf("%T", nil)
+		}
+		item.Error = result.err.Error()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.nodes = append(r.nodes, item)
+}
+
+// finish 记录启动总耗时与最终错误。
+func (r *StartupReport) finish(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.total = time.Since(r.startedAt)
+	r.err = err
+}
+
+// Snapshot 返回启动报告的只读快照，节点按执行顺序排列。
+func (r *StartupReport) Snapshot() StartupReportView {
+	if r == nil {
+		return StartupReportView{Nodes: make([]NodeReport, 0)}
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	view := StartupReportView{
+		StartedAt: r.startedAt,
+		Total:     r.total,
+		Success:   r.err == nil,
+		Nodes:     append([]NodeReport(nil), r.nodes...),
+	}
+	if r.err != nil {
+		view.Error = r.err.Error()
+	}
+	return view
+}
+
+// Slowest 返回耗时最长的 n 个节点，n <= 0 时返回全部节点（按耗时降序）。
+func (r *StartupReport) Slowest(n int) []NodeReport {
+	nodes := r.Snapshot().Nodes
+	sort.SliceStable(nodes, func(i, j int) bool {
+		return nodes[i].Duration > nodes[j].Duration
+	})
+	if n > 0 && n < len(nodes) {
+		nodes = nodes[:n]
+	}
+	return nodes
+}
+
+// Table 将启动报告格式化为对齐的文本表格。
+func (r *StartupReport) Table() string {
+	view := r.Snapshot()
+	var builder strings.Builder
+	writer := tabwriter.NewWriter(&builder, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(writer, "INDEX\tLAYER\tKEY\tSTATUS\tDURATION\tTYPE")
+	for _, node := range view.Nodes {
+		_, _ = fmt.Fprintf(writer, "%d\t%d\t%s\t%s\t%s\t%s\n",
+			node.Index, node.Layer, node.Key, node.Status, node.Duration.Round(time.Microsecond), node.Type)
+	}
+	_, _ = fmt.Fprintf(writer, "TOTAL\t\t%d nodes\t%s\t%s\t\n", len(view.Nodes), statusOf(view), view.Total.Round(time.Microsecond))
+	_ = writer.Flush()
+	return builder.String()
+}
+
+// statusOf 返回报告整体状态文本。
+func statusOf(view StartupReportView) NodeStatus {
+	if !view.Success {
+		return NodeStatusFailed
+	}
+	for _, node := range view.Nodes {
+		if node.Status == NodeStatusDegraded {
+			return NodeStatusDegraded
+		}
+	}
+	return NodeStatusSuccess
+}
+
+// finishReport 结束启动报告并输出汇总表格。
+func (rn *RegNode) finishReport(log *xLog.LogNamedLogger, err error) {
+	rn.report.finish(err)
+	if err != nil {
+		log.Error(rn.Ctx, "启动报告:\n"+rn.report.Table())
+		return
+	}
+	log.Info(rn.Ctx, "启动报告:\n"+rn.report.Table())
+}
+
+// Report 返回最近一次 Exec / ExecE 的启动报告；尚未执行时返回 nil。
+func (rn *RegNode) Report() *StartupReport {
+	return rn.report
+}
+
+// GetStartupReport 从上下文中获取启动报告。
+//
+// Exec 成功后报告会写入 [xCtx.StartupReportKey]，路由注册器可据此挂载诊断接口：
+//
+//	serve.GET("/debug/startup", func(c *gin.Context) {
+//	    c.JSON(200, xRegNode.GetStartupReport(ctx).Snapshot())
+//	})
+func GetStartupReport(ctx context.Context) *StartupReport {
+	if ctx == nil {
+		return nil
+	}
+	if report, ok := ctx.Value(xCtx.StartupReportKey).(*StartupReport); ok {
+		return report
+	}
+	return nil
+}
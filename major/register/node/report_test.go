@@ -0,0 +1,61 @@
+package xRegNode
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestStartupReport 验证启动报告记录耗时、类型与状态，并可通过上下文获取。
+func TestStartupReport(t *testing.T) {
+	rn := NewRegNode(context.Background())
+	rn.Use("slow", func(ctx context.Context) (any, error) {
+		time.Sleep(20 * time.Millisecond)
+		return 42, nil
+	})
+	rn.Use("optional", func(ctx context.Context) (any, error) { return nil, errors.New("down") }, Optional())
+	rn.Exec()
+
+	report := GetStartupReport(rn.Ctx)
+	if report == nil || report != rn.Report() {
+		t.Fatal("上下文中未写入启动报告")
+	}
+	view := report.Snapshot()
+	if !view.Success || len(view.Nodes) != 2 {
+		t.Fatalf("启动报告内容不正确: %+v", view)
+	}
+	if view.Nodes[0].Type != "int" || view.Nodes[0].Duration < 20*time.Millisecond {
+		t.Fatalf("slow 节点记录不正确: %+v", view.Nodes[0])
+	}
+	if view.Nodes[1].Status != NodeStatusDegraded || view.Nodes[1].Error != "down" {
+		t.Fatalf("optional 节点记录不正确: %+v", view.Nodes[1])
+	}
+	if slowest := report.Slowest(1); len(slowest) != 1 || slowest[0].Key != "slow" {
+		t.Fatalf("Slowest 结果不正确: %+v", slowest)
+	}
+
+	table := report.Table()
+	if !strings.Contains(table, "slow") || !strings.Contains(table, "degraded") {
+		t.Fatalf("表格缺少节点信息:\n%s", table)
+	}
+	if _, err := json.Marshal(view); err != nil {
+		t.Fatalf("启动报告无法序列化: %v", err)
+	}
+}
+
+// TestStartupReportOnFailure 验证启动失败时报告记录失败节点。
+func TestStartupReportOnFailure(t *testing.T) {
+	rn := NewRegNode(context.Background())
+	rn.Use("bad", func(ctx context.Context) (any, error) { return nil, errors.New("boom") })
+	if _, err := rn.ExecE(); err == nil {
+		t.Fatal("期望返回错误")
+	}
+
+	view := rn.Report().Snapshot()
+	if view.Success || len(view.Nodes) != 1 || view.Nodes[0].Status != NodeStatusFailed {
+		t.Fatalf("失败报告内容不正确: %+v", view)
+	}
+}
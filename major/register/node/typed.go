@@ -0,0 +1,27 @@
+package xRegNode
+
+import (
+	"context"
+
+	xCtx "github.com/bamboo-services/bamboo-base-go/defined/context"
+)
+
+// TypedNode 是返回类型为 T 的组件初始化函数。
+type TypedNode[T any] func(ctx context.Context) (T, error)
+
+// UseTyped 以 [xCtx.TypedKey] 注册组件初始化函数。
+//
+// 与 [RegNode.Use] 等价，但 registerFunc 的返回类型必须与 key 绑定的类型 T 一致，
+// 生产者与消费者（[xCtx.TypedKey.Value] / xCtxUtil.GetTyped）的类型不匹配会在编译期报错。
+// 值仍以 key.Key() 存入 ContextNodeList，原有按 ContextKey 读取的方式不受影响。
+//
+// Go 方法不支持类型参数，因此以包级函数的形式提供。
+func UseTyped[T any](rn *RegNode, key xCtx.TypedKey[T], registerFunc TypedNode[T], opts ...NodeOption) {
+	if registerFunc == nil {
+		rn.Use(key.Key(), nil, opts...)
+		return
+	}
+	rn.Use(key.Key(), func(ctx context.Context) (any, error) {
+		return registerFunc(ctx)
+	}, opts...)
+}
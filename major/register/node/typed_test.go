@@ -0,0 +1,43 @@
+package xRegNode
+
+import (
+	"context"
+	"testing"
+
+	xCtx "github.com/bamboo-services/bamboo-base-go/defined/context"
+)
+
+type testConfig struct {
+	DSN string
+}
+
+// TestUseTyped 验证 TypedKey 注册的值可按类型读取，同时兼容 ContextKey 读取。
+func TestUseTyped(t *testing.T) {
+	configKey := xCtx.NewTypedKey[*testConfig]("test_config")
+
+	rn := NewRegNode(context.Background())
+	UseTyped(rn, configKey, func(ctx context.Context) (*testConfig, error) {
+		return &testConfig{DSN: "sqlite::memory:"}, nil
+	})
+	var got *testConfig
+	rn.Use("service", func(ctx context.Context) (any, error) {
+		got, _ = configKey.Value(ctx)
+		return nil, nil
+	}, DependsOn(configKey.Key()))
+	list := rn.Exec()
+
+	if got == nil || got.DSN != "sqlite::memory:" {
+		t.Fatalf("依赖节点未按类型拿到配置: %+v", got)
+	}
+	if cfg, ok := configKey.From(list); !ok || cfg != got {
+		t.Fatal("ContextNodeList 中未按类型读取到配置")
+	}
+	if _, ok := list.Get(configKey.Key()).(*testConfig); !ok {
+		t.Fatal("TypedKey 注册的值无法按 ContextKey 读取")
+	}
+
+	otherKey := xCtx.NewTypedKey[string]("test_config")
+	if _, ok := otherKey.Value(rn.Ctx); ok {
+		t.Fatal("类型不匹配时不应返回值")
+	}
+}
@@ -2,18 +2,81 @@ package xReg
 
 import (
 	"context"
+	"fmt"
+	"log/slog"
+	"time"
 
+	xHealth "github.com/bamboo-services/bamboo-base-go/common/health"
+	xTrace "github.com/bamboo-services/bamboo-base-go/common/trace"
 	xCtx "github.com/bamboo-services/bamboo-base-go/defined/context"
+	xMiddle "github.com/bamboo-services/bamboo-base-go/major/middleware"
 	xOption "github.com/bamboo-services/bamboo-base-go/major/option"
+	xOptCors "github.com/bamboo-services/bamboo-base-go/major/option/cors"
 	xInit "github.com/bamboo-services/bamboo-base-go/major/register/init"
 	xRegNode "github.com/bamboo-services/bamboo-base-go/major/register/node"
 	"github.com/gin-gonic/gin"
 )
 
+// registerStopTimeout 是 RegisterE 失败时释放已初始化组件的超时时间。
+const registerStopTimeout = 15 * time.Second
+
 // Reg 表示应用程序的核心注册结构，包含所有初始化后的组件实例。
 type Reg struct {
-	Serve *gin.Engine       // Gin 引擎实例
-	Init  *xRegNode.RegNode // 初始化节点
+	Serve    *gin.Engine       // Gin 引擎实例
+	Init     *xRegNode.RegNode // 初始化节点
+	logLevel *slog.LevelVar    // 全局日志级别，支持运行期调整
+	server   *xOption.ServerConfig
+	engines  []NamedServer
+	health   *xHealth.Registry
+	cors     *xMiddle.CorsHandler            // 启用 WithCors 时的跨域中间件，由 newEngine 挂载，配置中心变更时替换
+	logClose func(ctx context.Context) error // 释放 loggerInit 创建的日志器，仅在 RegisterE 失败时调用
+}
+
+// NamedServer 附加的命名 HTTP 服务，由 [xOption.WithEngine] 声明。
+type NamedServer struct {
+	Name   string               // 引擎名称
+	Engine *gin.Engine          // 与主引擎共享中间件栈与 reg.Init.Ctx 的 Gin 引擎
+	Config xOption.ServerConfig // 监听与超时配置
+}
+
+// Engine 返回指定名称的附加 Gin 引擎，未声明时返回 nil。
+func (r *Reg) Engine(name string) *gin.Engine {
+	for _, server := range r.engines {
+		if server.Name == name {
+			return server.Engine
+		}
+	}
+	return nil
+}
+
+// NamedServers 返回全部附加 HTTP 服务，按声明顺序排列，由 Runner 与主服务一起启动。
+func (r *Reg) NamedServers() []NamedServer {
+	return append([]NamedServer(nil), r.engines...)
+}
+
+// ServerConfig 返回 Runner 启动 HTTP 服务所用的配置。
+//
+// 由 [Register] 根据 [xOption.WithServer] 与 XLF_* 环境变量合并得到；
+// 未经 Register 构造的 Reg 返回仅由环境变量决定的配置。
+func (r *Reg) ServerConfig() xOption.ServerConfig {
+	if r.server == nil {
+		return xOption.Apply().Server()
+	}
+	return *r.server
+}
+
+// Health 返回健康检查注册表，汇总各注册节点与 xOptHealth.WithCheck 声明的检查项。
+//
+// 无论是否启用 xOption.WithHealth 路由都可用，例如交给 gRPC 健康服务：
+//
+//	xGrpcRunner.WithHealth(reg.Health(), 5*time.Second)
+//
+// 未经 Register 构造的 Reg 返回不含检查项的空注册表。
+func (r *Reg) Health() *xHealth.Registry {
+	if r.health == nil {
+		r.health = xHealth.New()
+	}
+	return r.health
 }
 
 // New 创建并返回一个未初始化的 `Reg` 实例。
@@ -35,15 +98,21 @@ func newReg(ctx context.Context) *Reg {
 // Exec 执行、Gin 引擎构建与路由挂载，调用方拿到 *Reg 后即可直接交给 Runner 启动。
 //
 // 装配顺序（严格固定）：
-//  1. 配置器与日志器（configInit / loggerInit）
+//  1. 配置器、日志器与链路追踪（configInit / opts 中的配置中心 Load / loggerInit / tracingInit）；
+//     若声明了配置中心，注册到 ConfigKey 并启动文件监听
 //  2. 雪花算法节点（SnowflakeNodeKey，框架强制注册）
 //  3. opts 中的数据库节点（DatabaseKey，仅当 DatabaseConfig.Enabled()）
 //  4. opts 中的缓存节点（CacheManagerKey，仅当 CacheConfig.Enabled()）；
 //     若为 Redis 后端，额外补注册 *redis.Client 到 RedisClientKey
-//  5. nodeList 中的业务节点（按传入顺序）
-//  6. 一次 Exec() 完成全部装配
-//  7. Gin 引擎构建（engineInit）
-//  8. opts 中的路由注册器逐个挂载到 Gin 引擎
+//  5. nodeList 中的业务节点（按传入顺序；声明了 Deps 的节点按依赖关系调度）
+//  6. 一次 Exec() 完成全部装配（数据库与缓存节点并行初始化）；
+//     数据库与缓存会登记关闭回调，由 Runner 退出时通过 reg.Init.Stop 逆序释放；
+//     业务节点声明的启动回调（xRegNode.OnStart / Starter）由 Runner 在启动服务前通过 reg.Init.Start 执行
+//  7. Gin 引擎构建（engineInit），以及 opts 中 WithEngine 声明的附加引擎；启用 WithCors 时挂载跨域中间件，
+//     并在声明了配置中心时订阅 cors 段，变更后即时替换跨域配置
+//  8. 健康检查注册表（reg.Health()），启用 WithHealth 时挂载 /healthz、/readyz、/health；
+//     启用 WithMetrics 时挂载 /metrics
+//  9. opts 中的路由注册器逐个挂载到主引擎，WithEngineRoute 注册器挂载到对应附加引擎
 //
 // 参数:
 //   - ctx: 根上下文，会随组件装配逐步 WithValue 演进
@@ -58,34 +127,114 @@ func newReg(ctx context.Context) *Reg {
 // CacheManagerKey / RedisClientKey，否则 Use 会因重复注册 panic。框架会在
 // nodeList 之前注册这些保留键。
 func Register(ctx context.Context, nodeList []xRegNode.RegNodeList, opts ...xOption.Option) *Reg {
+	reg, err := RegisterE(ctx, nodeList, opts...)
+	if err != nil {
+		panic(err.Error())
+	}
+	return reg
+}
+
+// RegisterE 与 [Register] 装配流程一致，但选项校验、日志器、链路追踪或节点初始化失败时返回错误而不是 panic。
+//
+// 节点失败时返回的错误为 [*xRegNode.NodeError]，可通过 errors.As 取出失败节点的下标、ContextKey 与根因，
+// 便于调用方重试、降级或输出启动报告。失败时已初始化的组件会在 [registerStopTimeout]
+// 内按逆序释放，调用方可直接再次调用 RegisterE 重试。
+//
+// 通过 [xRegNode.Optional] 标记的业务节点失败时不会返回错误，而是记录 WARN 日志并以 nil 写入上下文。
+func RegisterE(ctx context.Context, nodeList []xRegNode.RegNodeList, opts ...xOption.Option) (*Reg, error) {
 	reg := newReg(ctx)
 	reg.configInit()
-	reg.loggerInit()
 
 	cfg := xOption.Apply(opts...)
+	server := cfg.Server()
+	if err := validateEngines(server, cfg.Engines()); err != nil {
+		return nil, err
+	}
+	hc, healthEnabled := cfg.Health()
+	if name := hc.Engine(); healthEnabled && name != "" && !cfg.HasEngine(name) {
+		return nil, fmt.Errorf("健康检查挂载的附加引擎 %q 未通过 xOption.WithEngine 声明", name)
+	}
+	mc, metricsEnabled := cfg.Metrics()
+	if name := mc.Engine(); metricsEnabled && name != "" && !cfg.HasEngine(name) {
+		return nil, fmt.Errorf("指标路由挂载的附加引擎 %q 未通过 xOption.WithEngine 声明", name)
+	}
+	if cc := cfg.Config(); cc != nil {
+		if err := cc.Load(); err != nil {
+			return nil, fmt.Errorf("加载配置失败: %w", err)
+		}
+	}
+	if corsCfg, corsEnabled := cfg.Cors(); corsEnabled {
+		if err := corsCfg.Validate(); err != nil {
+			return nil, fmt.Errorf("跨域配置无效: %w", err)
+		}
+		reg.cors = xMiddle.NewCorsHandler(corsCfg)
+		if cc := cfg.Config(); cc != nil {
+			cc.Subscribe(xOptCors.ConfigKey, reg.corsReloader(cfg))
+		}
+	}
+	if err := reg.loggerInit(cfg.Logger()); err != nil {
+		return nil, err
+	}
+	reg.server = &server
+	if err := reg.tracingInit(cfg.Tracing()); err != nil {
+		stopCtx, stopCancel := context.WithTimeout(context.Background(), registerStopTimeout)
+		defer stopCancel()
+		reg.closeLogger(stopCtx)
+		return nil, err
+	}
+
+	// 配置中心（来自 opts），无依赖，最先就绪
+	if cc := cfg.Config(); cc != nil {
+		reg.Init.Use(xCtx.ConfigKey, reg.configNode(cc), xRegNode.DependsOn())
+	}
 
 	// 基础设施：雪花
-	reg.Init.Use(xCtx.SnowflakeNodeKey, xInit.SnowflakeInit)
-	// 基础设施：数据库（来自 opts）
+	reg.Init.Use(xCtx.SnowflakeNodeKey, xInit.SnowflakeInit, xRegNode.DependsOn())
+	// 基础设施：数据库（来自 opts），与缓存互不依赖，可并行建连
 	if dc := cfg.Database(); dc.Enabled() {
-		reg.Init.Use(xCtx.DatabaseKey, xInit.DatabaseInit(dc))
+		reg.Init.Use(xCtx.DatabaseKey, xInit.DatabaseInit(dc),
+			xRegNode.DependsOn(xCtx.SnowflakeNodeKey),
+			xRegNode.OnStop(xInit.DatabaseStop),
+			xRegNode.OnCheck(xInit.DatabaseCheck),
+		)
 	}
 	// 基础设施：缓存（来自 opts）
 	if cc := cfg.Cache(); cc.Enabled() {
-		reg.Init.Use(xCtx.CacheManagerKey, xInit.CacheInit(cc))
+		reg.Init.Use(xCtx.CacheManagerKey, xInit.CacheInit(cc),
+			xRegNode.DependsOn(xCtx.SnowflakeNodeKey),
+			xRegNode.OnStop(xInit.CacheStop),
+		)
 		if cc.Type() == xOption.CacheTypeRedis {
-			reg.Init.Use(xCtx.RedisClientKey, xInit.RedisClientFromManager())
+			reg.Init.Use(xCtx.RedisClientKey, xInit.RedisClientFromManager(), xRegNode.DependsOn(xCtx.CacheManagerKey))
 		}
 	}
 	// 业务节点（来自 nodeList）
-	for _, node := range nodeList {
-		reg.Init.Use(node.Key, node.Node)
-	}
+	reg.Init.UseList(nodeList...)
 	// 一次 Exec 完成全部装配
-	reg.Init.Exec()
+	if _, err := reg.Init.ExecE(); err != nil {
+		stopCtx, stopCancel := context.WithTimeout(context.Background(), registerStopTimeout)
+		defer stopCancel()
+		_ = reg.Init.Stop(stopCtx)
+		_ = xTrace.Shutdown(stopCtx)
+		reg.closeLogger(stopCtx)
+		return nil, err
+	}
 
-	// Gin 引擎
+	// Gin 引擎（主引擎与附加引擎）
 	reg.engineInit()
+	for _, engine := range cfg.Engines() {
+		reg.engines = append(reg.engines, NamedServer{Name: engine.Name(), Engine: reg.newEngine(), Config: engine.Server()})
+	}
+
+	// 健康检查（Exec 之后，节点检查已登记）
+	reg.healthInit(hc)
+	if healthEnabled {
+		reg.healthRoute(hc)
+	}
+	// 指标导出
+	if metricsEnabled {
+		reg.metricsRoute(mc)
+	}
 
 	// 路由注册（engineInit 之后，ctx 已含全部组件）
 	// 注意：此处捕获的 reg.Init.Ctx 来自 Register 阶段，尚未被 Runner 的 WithCancel 包裹。
@@ -94,6 +243,12 @@ func Register(ctx context.Context, nodeList []xRegNode.RegNodeList, opts ...xOpt
 	for _, registrar := range cfg.Routes() {
 		registrar(reg.Init.Ctx, reg.Serve)
 	}
+	for _, engine := range cfg.Engines() {
+		serve := reg.Engine(engine.Name())
+		for _, registrar := range engine.Routes() {
+			registrar(reg.Init.Ctx, serve)
+		}
+	}
 
-	return reg
+	return reg, nil
 }
@@ -1,6 +1,13 @@
 package xReg
 
 import (
+	"context"
+	"log/slog"
+
+	xLog "github.com/bamboo-services/bamboo-base-go/common/log"
+	xConfig "github.com/bamboo-services/bamboo-base-go/major/config"
+	xOption "github.com/bamboo-services/bamboo-base-go/major/option"
+	xRegNode "github.com/bamboo-services/bamboo-base-go/major/register/node"
 	"github.com/joho/godotenv"
 )
 
@@ -12,3 +19,49 @@ func (_ *Reg) configInit() {
 	// 加载 .env 文件到环境变量（忽略不存在的错误）
 	_ = godotenv.Load()
 }
+
+// configNode 返回配置中心的注册节点。
+//
+// 节点启动文件监听，并订阅 log.level：配置变更后即时调整全局日志级别；
+// 启用 WithCors 时 RegisterE 另行订阅 cors 段，见 corsReloader。
+// 返回的 *xConfig.Config 实现了 Stopper，Runner 退出时会停止监听。
+func (r *Reg) configNode(cc *xConfig.Config) xRegNode.Node {
+	return func(ctx context.Context) (any, error) {
+		r.applyLogLevel(ctx, cc)
+		cc.Subscribe("log.level", func(ctx context.Context, c *xConfig.Config) {
+			r.applyLogLevel(ctx, c)
+		})
+		cc.Watch(ctx)
+		return cc, nil
+	}
+}
+
+// corsReloader 返回 cors 段的变更回调：按 cfg.Cors() 重新合并配置并替换跨域中间件，校验失败时保留旧配置。
+func (r *Reg) corsReloader(cfg *xOption.Config) xConfig.SubscribeFunc {
+	return func(ctx context.Context, _ *xConfig.Config) {
+		corsCfg, _ := cfg.Cors()
+		if err := corsCfg.Validate(); err != nil {
+			xLog.WithName(xLog.NamedINIT).Warn(ctx, "跨域配置无效，保留原配置", slog.Any("error", err))
+			return
+		}
+		r.cors.Update(corsCfg)
+		xLog.WithName(xLog.NamedINIT).Info(ctx, "跨域配置已更新")
+	}
+}
+
+// applyLogLevel 读取 log.level 并更新全局日志级别，未配置或无法识别时保持不变。
+func (r *Reg) applyLogLevel(ctx context.Context, cc *xConfig.Config) {
+	text := cc.GetString("log.level", "")
+	if text == "" || r.logLevel == nil {
+		return
+	}
+	level, ok := xLog.ParseLevel(text)
+	if !ok {
+		xLog.WithName(xLog.NamedINIT).Warn(ctx, "无法识别的日志级别", slog.String("level", text))
+		return
+	}
+	if r.logLevel.Level() != level {
+		r.logLevel.Set(level)
+		xLog.WithName(xLog.NamedINIT).Info(ctx, "日志级别已更新", slog.String("level", level.String()))
+	}
+}
@@ -1,10 +1,13 @@
 package xReg
 
 import (
+	"fmt"
+
 	xLog "github.com/bamboo-services/bamboo-base-go/common/log"
 	xCtxUtil "github.com/bamboo-services/bamboo-base-go/common/utility/context"
 	xVaild "github.com/bamboo-services/bamboo-base-go/common/validator"
 	xHelper "github.com/bamboo-services/bamboo-base-go/major/helper"
+	xOption "github.com/bamboo-services/bamboo-base-go/major/option"
 	xMajorCtxUtil "github.com/bamboo-services/bamboo-base-go/major/utility/context"
 	xMajorValidator "github.com/bamboo-services/bamboo-base-go/major/validator"
 	"github.com/gin-gonic/gin"
@@ -50,10 +53,42 @@ func (r *Reg) engineInit() {
 		}
 	}
 
-	r.Serve = gin.New(func(engine *gin.Engine) {
+	r.Serve = r.newEngine()
+}
+
+// newEngine 创建挂载全局中间件的 Gin 引擎，主引擎与附加引擎共用同一套中间件栈。
+func (r *Reg) newEngine() *gin.Engine {
+	return gin.New(func(engine *gin.Engine) {
 		engine.Use(xHelper.RequestContext())
+		engine.Use(xHelper.HttpMetrics())
 		engine.Use(xHelper.PanicRecovery())
 		engine.Use(xHelper.HttpLogger())
+		if r.cors != nil {
+			engine.Use(r.cors.Handle)
+		}
 		engine.Use(r.Init.InjectContext())
 	})
 }
+
+// validateEngines 校验附加引擎的监听参数：须通过 WithEngine 声明并显式指定监听目标，
+// 且不得与主服务或其他附加引擎监听同一目标，避免启动后才因端口占用失败。
+func validateEngines(primary xOption.ServerConfig, engines []xOption.EngineConfig) error {
+	for i, engine := range engines {
+		if !engine.Declared() {
+			return fmt.Errorf("附加引擎 %q 未通过 xOption.WithEngine 声明监听参数", engine.Name())
+		}
+		server := engine.Server()
+		if !server.HasListenTarget() {
+			return fmt.Errorf("附加引擎 %q 未指定监听地址，请通过 xOptServer.WithAddr 或 WithUnixSocket 等选项设置", engine.Name())
+		}
+		if server.SameListenTarget(primary) {
+			return fmt.Errorf("附加引擎 %q 的监听地址与主服务相同", engine.Name())
+		}
+		for _, other := range engines[:i] {
+			if server.SameListenTarget(other.Server()) {
+				return fmt.Errorf("附加引擎 %q 的监听地址与附加引擎 %q 相同", engine.Name(), other.Name())
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package xReg
+
+import (
+	"log/slog"
+
+	xHealth "github.com/bamboo-services/bamboo-base-go/common/health"
+	xLog "github.com/bamboo-services/bamboo-base-go/common/log"
+	xOption "github.com/bamboo-services/bamboo-base-go/major/option"
+	xRoute "github.com/bamboo-services/bamboo-base-go/major/route"
+)
+
+// healthInit 汇总注册节点登记的健康检查与 xOptHealth.WithCheck 声明的检查项，构建健康检查注册表。
+//
+// 在 Exec 之后调用，此时各节点的组件实例已就绪。可选节点的检查标记为可选，失败时不影响就绪状态。
+func (r *Reg) healthInit(hc xOption.HealthConfig) {
+	r.health = xHealth.New(xHealth.WithTimeout(hc.Timeout()), xHealth.WithCacheTTL(hc.CacheTTL()))
+	for _, check := range r.Init.HealthChecks() {
+		var opts []xHealth.CheckOption
+		if check.Optional {
+			opts = append(opts, xHealth.Optional())
+		}
+		r.health.Register(check.Key.String(), check.Checker, opts...)
+	}
+	for _, check := range hc.Checks() {
+		r.health.Register(check.Name, check.Checker, check.Options...)
+	}
+	xLog.WithName(xLog.NamedINIT).Debug(r.Init.Ctx, "健康检查已装配", slog.Any("checks", r.health.Names()))
+}
+
+// healthRoute 将健康检查路由挂载到主引擎或 xOptHealth.WithEngine 指定的附加引擎。
+//
+// 附加引擎是否已声明由 RegisterE 在 Exec 之前校验。
+func (r *Reg) healthRoute(hc xOption.HealthConfig) {
+	serve := r.Serve
+	if name := hc.Engine(); name != "" {
+		serve = r.Engine(name)
+	}
+	liveness, readiness, report := hc.Paths()
+	xRoute.Health(serve, r.health, liveness, readiness, report)
+}
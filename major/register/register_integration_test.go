@@ -2,14 +2,31 @@ package xReg
 
 import (
 	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
+	xHealth "github.com/bamboo-services/bamboo-base-go/common/health"
+	xLifecycle "github.com/bamboo-services/bamboo-base-go/common/lifecycle"
 	xCtx "github.com/bamboo-services/bamboo-base-go/defined/context"
 	xCache "github.com/bamboo-services/bamboo-base-go/major/cache"
+	xConfig "github.com/bamboo-services/bamboo-base-go/major/config"
 	xOption "github.com/bamboo-services/bamboo-base-go/major/option"
 	xOptCache "github.com/bamboo-services/bamboo-base-go/major/option/cache"
+	xOptCors "github.com/bamboo-services/bamboo-base-go/major/option/cors"
 	xOptDatabase "github.com/bamboo-services/bamboo-base-go/major/option/database"
+	xOptHealth "github.com/bamboo-services/bamboo-base-go/major/option/health"
+	xOptLogger "github.com/bamboo-services/bamboo-base-go/major/option/logger"
+	xOptMetrics "github.com/bamboo-services/bamboo-base-go/major/option/metrics"
+	xOptServer "github.com/bamboo-services/bamboo-base-go/major/option/server"
 	xRegNode "github.com/bamboo-services/bamboo-base-go/major/register/node"
+	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 )
 
@@ -59,4 +76,276 @@ func TestRegisterOptionUptoExec(t *testing.T) {
 	if reg.Init.Ctx.Value(xCtx.CacheManagerKey) == nil {
 		t.Fatal("reg.Init.Ctx 未包含 CacheManagerKey")
 	}
-}
\ No newline at end of file
+}
+
+// TestRegisterStopReleasesDatabase 验证 reg.Init.Stop 会关闭内置数据库连接池。
+func TestRegisterStopReleasesDatabase(t *testing.T) {
+	reg := Register(context.Background(), nil,
+		xOption.WithDatabase(xOptDatabase.SQLite(":memory:")),
+		xOption.WithCache(xOptCache.WithMemory()),
+	)
+	db, ok := reg.Init.Ctx.Value(xCtx.DatabaseKey).(*gorm.DB)
+	if !ok {
+		t.Fatal("reg.Init.Ctx 未包含 *gorm.DB")
+	}
+
+	if err := reg.Init.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop 返回错误: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("获取 *sql.DB 失败: %v", err)
+	}
+	if err := sqlDB.Ping(); err == nil {
+		t.Fatal("Stop 之后数据库连接池仍可用")
+	}
+}
+
+// TestRegisterEReturnsNodeError 验证 RegisterE 在业务节点失败时返回结构化错误而非 panic，并释放已创建的日志器。
+func TestRegisterEReturnsNodeError(t *testing.T) {
+	failing := xRegNode.RegNodeList{
+		Key: xCtx.ContextKey("test_failing_node"),
+		Node: func(ctx context.Context) (any, error) {
+			return nil, errors.New("boom")
+		},
+	}
+
+	before := slog.Default()
+	reg, err := RegisterE(context.Background(), []xRegNode.RegNodeList{failing})
+	if reg != nil {
+		t.Fatal("失败时不应返回 reg")
+	}
+	if slog.Default() != before {
+		t.Error("失败时应恢复此前的全局 logger")
+	}
+	var nodeErr *xRegNode.NodeError
+	if !errors.As(err, &nodeErr) {
+		t.Fatalf("期望 *xRegNode.NodeError, 实际: %T %v", err, err)
+	}
+	if nodeErr.Key != failing.Key || nodeErr.Index != 1 {
+		t.Fatalf("NodeError 字段不正确: index=%d key=%v", nodeErr.Index, nodeErr.Key)
+	}
+}
+
+// TestRegisterELoggerError 验证日志目录不可用时 RegisterE 返回错误而非 panic，且不替换全局 logger。
+func TestRegisterELoggerError(t *testing.T) {
+	blocker := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(blocker, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	before := slog.Default()
+	reg, err := RegisterE(context.Background(), nil, xOption.WithLogger(xOptLogger.WithPath(filepath.Join(blocker, "logs"))))
+	if reg != nil || err == nil {
+		t.Fatalf("日志目录不可用时应返回错误: reg=%v err=%v", reg, err)
+	}
+	if slog.Default() != before {
+		t.Error("日志器创建失败时不应替换全局 logger")
+	}
+}
+
+// TestRegisterNamedEngine 验证附加引擎独立挂载路由，且与主引擎共享 reg.Init.Ctx。
+func TestRegisterNamedEngine(t *testing.T) {
+	reg := Register(context.Background(), nil,
+		xOption.WithCache(xOptCache.WithMemory()),
+		xOption.WithEngine("admin", xOptServer.WithAddr("127.0.0.1", "9090")),
+		xOption.WithEngineRouteGroup("admin", "/admin", func(rg *gin.RouterGroup) {
+			rg.GET("/ping", func(c *gin.Context) {
+				if c.Request.Context().Value(xCtx.RegNodeKey) == nil {
+					c.Status(http.StatusInternalServerError)
+					return
+				}
+				c.Status(http.StatusNoContent)
+			})
+		}),
+	)
+
+	servers := reg.NamedServers()
+	if len(servers) != 1 || servers[0].Name != "admin" || servers[0].Config.Addr() != "127.0.0.1:9090" {
+		t.Fatalf("附加服务不匹配: %+v", servers)
+	}
+	if reg.Engine("admin") == reg.Serve || reg.Engine("missing") != nil {
+		t.Fatal("附加引擎应独立于主引擎，未声明的名称应返回 nil")
+	}
+
+	recorder := httptest.NewRecorder()
+	reg.Engine("admin").ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/admin/ping", nil))
+	if recorder.Code != http.StatusNoContent {
+		t.Errorf("附加引擎路由应可访问且携带组件上下文: code=%d", recorder.Code)
+	}
+	recorder = httptest.NewRecorder()
+	reg.Serve.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/admin/ping", nil))
+	if recorder.Code != http.StatusNotFound {
+		t.Errorf("主引擎不应暴露附加引擎路由: code=%d", recorder.Code)
+	}
+}
+
+// TestRegisterEUndeclaredEngine 验证仅注册路由而未声明的附加引擎返回错误。
+func TestRegisterEUndeclaredEngine(t *testing.T) {
+	_, err := RegisterE(context.Background(), nil,
+		xOption.WithEngineRoute("admin", func(ctx context.Context, serve *gin.Engine) {}),
+	)
+	if err == nil {
+		t.Fatal("未声明的附加引擎应返回错误")
+	}
+}
+
+// TestRegisterEEngineListenTarget 验证未指定监听地址或与主服务、其他附加引擎地址相同的附加引擎返回错误。
+func TestRegisterEEngineListenTarget(t *testing.T) {
+	cases := map[string][]xOption.Option{
+		"未指定地址": {xOption.WithEngine("admin")},
+		"与主服务相同": {
+			xOption.WithServer(xOptServer.WithAddr("127.0.0.1", "8080")),
+			xOption.WithEngine("admin", xOptServer.WithAddr("0.0.0.0", "8080")),
+		},
+		"附加引擎之间相同": {
+			xOption.WithEngine("admin", xOptServer.WithAddr("127.0.0.1", "9090")),
+			xOption.WithEngine("debug", xOptServer.WithAddr("127.0.0.1", "9090")),
+		},
+	}
+	for name, opts := range cases {
+		if _, err := RegisterE(context.Background(), nil, opts...); err == nil {
+			t.Errorf("%s: 应返回错误", name)
+		}
+	}
+}
+
+// TestRegisterHealth 验证数据库、缓存与自定义检查汇总到 reg.Health()，并挂载存活、就绪与报告路由。
+func TestRegisterHealth(t *testing.T) {
+	downstream := errors.New("downstream unavailable")
+	reg := Register(context.Background(), nil,
+		xOption.WithDatabase(xOptDatabase.SQLite(":memory:")),
+		xOption.WithCache(xOptCache.WithMemory()),
+		xOption.WithHealth(
+			xOptHealth.WithCacheTTL(0),
+			xOptHealth.WithCheck("downstream", xHealth.CheckFunc(func(context.Context) error { return downstream }), xHealth.Optional()),
+		),
+	)
+	defer func() { _ = reg.Init.Stop(context.Background()) }()
+
+	names := reg.Health().Names()
+	if len(names) != 3 || names[2] != "downstream" {
+		t.Fatalf("健康检查项不匹配: %v", names)
+	}
+
+	serve := func(path string) int {
+		recorder := httptest.NewRecorder()
+		reg.Serve.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+		return recorder.Code
+	}
+	xLifecycle.SetReady(true)
+	defer xLifecycle.SetReady(false)
+	if code := serve("/readyz"); code != http.StatusOK {
+		t.Errorf("仅可选检查失败时应就绪: code=%d", code)
+	}
+	if code := serve("/health"); code != http.StatusOK {
+		t.Errorf("degraded 报告应返回 200: code=%d", code)
+	}
+
+	xLifecycle.SetReady(false)
+	if code := serve("/readyz"); code != http.StatusServiceUnavailable {
+		t.Errorf("进程未就绪时应返回 503: code=%d", code)
+	}
+	if code := serve("/healthz"); code != http.StatusOK {
+		t.Errorf("存活检查应始终返回 200: code=%d", code)
+	}
+}
+
+// TestRegisterMetrics 验证启用 WithMetrics 后在自定义路径导出 Prometheus 文本，且包含 HTTP 与数据库指标，
+// 非标准方法归并为 other。
+func TestRegisterMetrics(t *testing.T) {
+	reg := Register(context.Background(), nil,
+		xOption.WithDatabase(xOptDatabase.SQLite(":memory:")),
+		xOption.WithMetrics(xOptMetrics.WithPath("/internal/metrics")),
+		xOption.WithRoute(func(_ context.Context, r *gin.Engine) {
+			r.GET("/ping/:id", func(c *gin.Context) { c.String(http.StatusOK, "pong") })
+		}),
+	)
+	defer func() { _ = reg.Init.Stop(context.Background()) }()
+
+	reg.Serve.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/ping/1", nil))
+	reg.Serve.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("BREW", "/ping/1", nil))
+	recorder := httptest.NewRecorder()
+	reg.Serve.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/internal/metrics", nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("指标路由返回 %d", recorder.Code)
+	}
+	if ct := recorder.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain") {
+		t.Errorf("Content-Type 不匹配: %s", ct)
+	}
+	body := recorder.Body.String()
+	for _, want := range []string{
+		`http_requests_total{method="GET",route="/ping/:id",status="200"}`,
+		`gorm_pool_open_connections{system="sqlite",name="context_database"}`,
+		`http_requests_total{method="other",route="unmatched",status="404"}`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("缺少指标 %s", want)
+		}
+	}
+	if strings.Contains(body, "BREW") {
+		t.Error("非标准方法不应作为 method 标签值")
+	}
+
+	if _, err := RegisterE(context.Background(), nil, xOption.WithMetrics(xOptMetrics.WithEngine("admin"))); err == nil {
+		t.Error("挂载到未声明的附加引擎时应返回错误")
+	}
+}
+
+// TestRegisterCors 验证启用 WithCors 后主引擎处理预检请求，非法来源模式中断启动。
+func TestRegisterCors(t *testing.T) {
+	reg := Register(context.Background(), nil,
+		xOption.WithCors(xOptCors.WithAllowOrigins("https://*.example.com"), xOptCors.WithCredentials()),
+	)
+	defer func() { _ = reg.Init.Stop(context.Background()) }()
+
+	req := httptest.NewRequest(http.MethodOptions, "/api", nil)
+	req.Header.Set("Origin", "https://app.example.com")
+	req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+	recorder := httptest.NewRecorder()
+	reg.Serve.ServeHTTP(recorder, req)
+	if recorder.Code != http.StatusNoContent || recorder.Header().Get("Access-Control-Allow-Origin") != "https://app.example.com" {
+		t.Errorf("预检未被处理: code=%d origin=%q", recorder.Code, recorder.Header().Get("Access-Control-Allow-Origin"))
+	}
+
+	if _, err := RegisterE(context.Background(), nil, xOption.WithCors(xOptCors.WithAllowOrigins("example.com"))); err == nil {
+		t.Error("非法来源模式应返回错误")
+	}
+}
+
+// TestRegisterCorsReload 验证配置文件 cors 段变更后跨域中间件即时生效，无需重启。
+func TestRegisterCorsReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeCors := func(origin string) {
+		if err := os.WriteFile(path, []byte("cors:\n  allow_origins:\n    - "+origin+"\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeCors("https://a.example.com")
+	reg := Register(context.Background(), nil,
+		xOption.WithConfig(xConfig.New(xConfig.WithFile(path), xConfig.WithWatchInterval(10*time.Millisecond))),
+		xOption.WithCors(),
+	)
+	defer func() { _ = reg.Init.Stop(context.Background()) }()
+	allowOrigin := func(origin string) string {
+		req := httptest.NewRequest(http.MethodGet, "/api", nil)
+		req.Header.Set("Origin", origin)
+		recorder := httptest.NewRecorder()
+		reg.Serve.ServeHTTP(recorder, req)
+		return recorder.Header().Get("Access-Control-Allow-Origin")
+	}
+
+	if got := allowOrigin("https://a.example.com"); got != "https://a.example.com" {
+		t.Fatalf("初始配置未生效: %q", got)
+	}
+	writeCors("https://app.example.org")
+	deadline := time.Now().Add(2 * time.Second)
+	for allowOrigin("https://app.example.org") == "" {
+		if time.Now().After(deadline) {
+			t.Fatal("cors 段变更后未替换跨域配置")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if got := allowOrigin("https://a.example.com"); got != "" {
+		t.Errorf("旧来源应不再允许: %q", got)
+	}
+}
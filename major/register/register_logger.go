@@ -1,51 +1,115 @@
 package xReg
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"os"
 
 	xLog "github.com/bamboo-services/bamboo-base-go/common/log"
-	xCtxUtil "github.com/bamboo-services/bamboo-base-go/common/utility/context"
 	xMajorLog "github.com/bamboo-services/bamboo-base-go/major/log"
+	xOption "github.com/bamboo-services/bamboo-base-go/major/option"
 )
 
 // loggerInit 初始化并设置全局日志记录器。
 //
-// 该方法根据当前运行模式（调试/发布）配置日志级别，并创建一个支持控制台输出与文件切割归档的日志记录器。
-// 文件日志按日期归档，单个文件大小限制为 10MB。初始化失败会触发 panic。
-func (r *Reg) loggerInit() {
+// 日志级别、输出格式、采样策略、脱敏规则、额外输出目标、异步队列、目录、单文件大小、切割策略、保留天数/数量与归档方式均来自 lc，
+// 由 LOG_* 环境变量或 xOption.WithLogger 决定（见 [xOption.Config.Logger]）。
+// 创建一个支持控制台输出与文件切割归档的日志记录器；日志目录不可用或 syslog 无法连接时返回错误，
+// 此时不会替换全局 logger，也不会遗留已打开的日志文件。
+func (r *Reg) loggerInit(lc xOption.LoggerConfig) error {
 	// 创建日志切割写入器
 	rotator, err := xLog.NewRotatingWriter(xLog.RotatorConfig{
-		Dir:      ".logs",
-		BaseName: "log",
-		Ext:      ".log",
-		MaxSize:  10 * 1024 * 1024, // 10MB
+		Dir:             lc.Path(),
+		BaseName:        "log",
+		Ext:             ".log",
+		MaxSize:         int64(lc.MaxSize()) * 1024 * 1024,
+		MaxAge:          lc.MaxAge(),
+		MaxBackups:      lc.MaxBackups(),
+		MaxArchives:     lc.MaxArchives(),
+		DisableCompress: !lc.Compress(),
+		Policy:          lc.Rotation(),
+		Compression:     lc.Compression(),
+		OnArchive:       lc.OnArchive(),
 	})
 	if err != nil {
-		panic(fmt.Sprintf("日志写入器创建失败: %v", err))
+		return fmt.Errorf("日志写入器创建失败: %w", err)
 	}
 
-	// 确定日志级别
-	logLevel := slog.LevelInfo
-	debugMode := xCtxUtil.IsDebugMode()
-	if debugMode {
-		logLevel = slog.LevelDebug
+	// 额外输出目标
+	closers := []io.Closer{rotator}
+	sinks := lc.Sinks()
+	if network, addr := lc.Syslog(); addr != "" {
+		syslog, err := xLog.NewSyslogSink(xLog.SyslogConfig{
+			Network: network,
+			Addr:    addr,
+			Format:  lc.FileFormat(),
+		})
+		if err != nil {
+			_ = rotator.Close()
+			return fmt.Errorf("syslog 输出创建失败: %w", err)
+		}
+		sinks = append(sinks, syslog)
+		closers = append(closers, syslog)
 	}
 
+	r.logLevel = new(slog.LevelVar)
+	r.logLevel.Set(lc.Level())
+
+	// 按 logger 名称的级别注册表，运行期可通过 xLog.Levels() 调整
+	levels := xLog.NewLevelRegistry(r.logLevel)
+	for name, level := range lc.LoggerLevels() {
+		levels.Set(name, level)
+	}
+
+	// 全局脱敏器，LogHandler、HTTP 请求日志、xResult 与 xError 共用
+	xLog.SetRedactor(xLog.NewRedactor(lc.Redact()))
+
 	// 创建自定义 Handler
 	handler := xLog.NewLogHandler(xLog.HandlerConfig{
-		Console:     os.Stdout,
-		File:        rotator,
-		Level:       logLevel,
-		IsDebugMode: debugMode,
+		Console:  os.Stdout,
+		File:     rotator,
+		Levels:   levels,
+		Sampling: lc.Sampling(),
+
+		ConsoleFormat: lc.ConsoleFormat(),
+		FileFormat:    lc.FileFormat(),
+
+		Sinks: sinks,
+		Async: lc.Async(),
 	})
 
 	// 设置为全局默认 logger
+	previous := slog.Default()
 	logger := slog.New(handler)
 	slog.SetDefault(logger)
+	r.logClose = func(ctx context.Context) error {
+		slog.SetDefault(previous)
+		errs := []error{handler.(*xLog.LogHandler).Close(ctx)}
+		for _, closer := range closers {
+			errs = append(errs, closer.Close())
+		}
+		return errors.Join(errs...)
+	}
 
 	// 注册 Gin 日志 context 提取器
 	// 使 common/log 的 LogHandler 能从 gin.Context 中提取 trace ID，无需 common 层依赖 gin
 	xLog.SetLogContextExtractor(&xMajorLog.GinLogExtractor{})
+	return nil
+}
+
+// closeLogger 释放 loggerInit 创建的日志器，用于 RegisterE 在日志器就绪后失败的场景。
+//
+// 恢复此前的全局 logger，写出异步队列中尚未输出的日志并停止后台协程，随后关闭日志文件与 syslog 连接；
+// 调用方因此可以直接重试 RegisterE，而不会遗留文件句柄或写入协程。
+func (r *Reg) closeLogger(ctx context.Context) {
+	if r.logClose == nil {
+		return
+	}
+	if err := r.logClose(ctx); err != nil {
+		fmt.Fprintf(os.Stderr, "[LOG] 关闭日志器失败: %v\n", err)
+	}
+	r.logClose = nil
 }
@@ -0,0 +1,17 @@
+package xReg
+
+import (
+	xOption "github.com/bamboo-services/bamboo-base-go/major/option"
+	xRoute "github.com/bamboo-services/bamboo-base-go/major/route"
+)
+
+// metricsRoute 将指标导出路由挂载到主引擎或 xOptMetrics.WithEngine 指定的附加引擎。
+//
+// 附加引擎是否已声明由 RegisterE 在 Exec 之前校验。
+func (r *Reg) metricsRoute(mc xOption.MetricsConfig) {
+	serve := r.Serve
+	if name := mc.Engine(); name != "" {
+		serve = r.Engine(name)
+	}
+	xRoute.Metrics(serve, mc.Path(), mc.Registry())
+}
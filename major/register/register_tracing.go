@@ -0,0 +1,35 @@
+package xReg
+
+import (
+	"fmt"
+	"log/slog"
+
+	xLog "github.com/bamboo-services/bamboo-base-go/common/log"
+	xTrace "github.com/bamboo-services/bamboo-base-go/common/trace"
+	xOption "github.com/bamboo-services/bamboo-base-go/major/option"
+)
+
+// tracingInit 按配置创建 span 导出器并设置全局 Tracer。
+//
+// 导出器类型、收集器地址与批量参数来自 tc，由 TRACE_* 环境变量或 xOption.WithTracing 决定
+// （见 [xOption.Config.Tracing]）。未启用导出时清除全局 Tracer，span 仅用于日志关联。
+// 剩余 span 由 xMain.Runner 退出前通过 xTrace.Shutdown 导出。
+func (r *Reg) tracingInit(tc xOption.TracingConfig) error {
+	exporter, err := tc.NewExporter()
+	if err != nil {
+		return fmt.Errorf("链路导出器创建失败: %w", err)
+	}
+	if exporter == nil {
+		xTrace.SetTracer(nil)
+		return nil
+	}
+
+	config := tc.TracerConfig()
+	config.Exporter = exporter
+	xTrace.SetTracer(xTrace.NewTracer(config))
+
+	xLog.WithName(xLog.NamedINIT).Info(r.Init.Ctx, "链路追踪已启用",
+		slog.String("exporter", string(tc.ExporterType())),
+	)
+	return nil
+}
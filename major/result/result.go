@@ -39,12 +39,12 @@ func Success(ctx *gin.Context, message string) {
 // 参数说明:
 //   - ctx: 请求的 `gin.Context` 对象，用于提供上下文信息和响应能力。
 //   - message: 响应的描述性消息，通常用于说明操作结果。
-//   - data: 可选数据内容，用于携带额外的返回信息。
+//   - data: 可选数据内容，用于携带额外的返回信息。日志中的 data 按 xLog 的脱敏规则处理，响应体不受影响。
 //
 // 注意: 确保调用此函数前，业务上下文中正确设置必要的数据，例如日志记录器。
 func SuccessHasData(ctx *gin.Context, message string, data interface{}) {
 	xLog.WithName(xLog.NamedRESU).Info(ctx.Request.Context(), "[200]Success - "+message,
-		slog.Any("data", data),
+		slog.Any("data", xLog.Redacted(data)),
 	)
 	ctx.JSON(200, xBase.BaseResponse{
 		Context:  ctx.GetString(xConsts.RequestKey.String()),
@@ -64,7 +64,7 @@ func SuccessHasData(ctx *gin.Context, message string, data interface{}) {
 //   - ctx: `gin.Context` 对象，用于管理请求的上下文。
 //   - errorCode: 定义错误的代码和信息的结构化数据类型，标识错误的具体内容。
 //   - errorMessage: 自定义错误信息的字符串，用于补充或覆盖 `ErrorCodeKey` 中的默认错误描述。
-//   - data: 任意类型的数据，用于返回附加的上下文或调试信息。
+//   - data: 任意类型的数据，用于返回附加的上下文或调试信息。日志中的 data 按 xLog 的脱敏规则处理。
 //
 // 注意: 确保上下文中存在有效的日志记录器，否则可能影响日志记录功能。
 func Error(ctx *gin.Context, errorCode *xError.ErrorCode, errorMessage xError.ErrMessage, data interface{}) {
@@ -80,7 +80,7 @@ func Error(ctx *gin.Context, errorCode *xError.ErrorCode, errorMessage xError.Er
 	xLog.WithName(xLog.NamedRESU).Warn(ctx.Request.Context(), messageBuilder.String(),
 		slog.Uint64("code", uint64(errorCode.Code)),
 		slog.String("output", errorCode.GetOutput()),
-		slog.Any("data", data),
+		slog.Any("data", xLog.Redacted(data)),
 	)
 	ctx.Set(xConsts.ErrorCodeKey.String(), errorCode)
 	ctx.JSON(int(errorCode.Code/100), xBase.BaseResponse{
@@ -99,7 +99,7 @@ func Error(ctx *gin.Context, errorCode *xError.ErrorCode, errorMessage xError.Er
 // 参数 ctx 表示当前的请求上下文。
 // 参数 errorCode 表示错误码及相关信息，需为非空值。
 // 参数 errorMessage 提供额外的错误描述信息，便于调试和日志记录。
-// 参数 data 表示与错误相关的附加数据，若无数据可传入 nil。
+// 参数 data 表示与错误相关的附加数据，若无数据可传入 nil；日志中的 data 按 xLog 的脱敏规则处理。
 //
 // 此函数会记录 WARN 等级日志，包括错误码、输出标识及错误信息。
 // 请求的上下文错误码和响应数据会通过 `gin.Context` 的 Set 方法存储，
@@ -118,7 +118,7 @@ func AbortError(ctx *gin.Context, errorCode *xError.ErrorCode, errorMessage xErr
 	xLog.WithName(xLog.NamedRESU).Warn(ctx.Request.Context(), messageBuilder.String(),
 		slog.Uint64("code", uint64(errorCode.Code)),
 		slog.String("output", errorCode.GetOutput()),
-		slog.Any("data", data),
+		slog.Any("data", xLog.Redacted(data)),
 	)
 	ctx.Set(xConsts.ErrorCodeKey.String(), errorCode)
 	ctx.AbortWithStatusJSON(int(errorCode.Code/100), xBase.BaseResponse{
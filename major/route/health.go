@@ -0,0 +1,62 @@
+package xRoute
+
+import (
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xHealth "github.com/bamboo-services/bamboo-base-go/common/health"
+	xResult "github.com/bamboo-services/bamboo-base-go/major/result"
+	"github.com/gin-gonic/gin"
+)
+
+// HealthView 存活与就绪检查的简要视图。
+type HealthView struct {
+	Status xHealth.Status `json:"status"`
+	Ready  bool           `json:"ready"`
+}
+
+// Health 注册由 registry 驱动的健康检查路由，路径为空串的路由不注册。
+//
+// 由 Register 在启用 xOption.WithHealth 时调用，也可手动挂载到任意路由组：
+//   - liveness  存活检查，始终返回 200，不执行依赖检查，避免依赖故障导致实例被反复重启
+//   - readiness 就绪检查，进程就绪且必需检查全部通过时返回 200，否则 503
+//   - report    详细健康报告，存在必需检查失败时返回 503
+func Health(rg gin.IRoutes, registry *xHealth.Registry, liveness, readiness, report string) {
+	if liveness != "" {
+		rg.GET(liveness, getLiveness)
+	}
+	if readiness != "" {
+		rg.GET(readiness, getHealthReadiness(registry))
+	}
+	if report != "" {
+		rg.GET(report, getHealthReport(registry))
+	}
+}
+
+// getLiveness 返回存活状态。
+func getLiveness(ctx *gin.Context) {
+	xResult.SuccessHasData(ctx, "服务存活", HealthView{Status: xHealth.StatusUp, Ready: true})
+}
+
+// getHealthReadiness 返回就绪状态，未就绪时返回 503。
+func getHealthReadiness(registry *xHealth.Registry) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		report := registry.Check(ctx.Request.Context())
+		view := HealthView{Status: report.Status, Ready: report.Ready}
+		if !view.Ready {
+			xResult.Error(ctx, xError.ServiceUnavailable, "服务未就绪", view)
+			return
+		}
+		xResult.SuccessHasData(ctx, "服务已就绪", view)
+	}
+}
+
+// getHealthReport 返回详细健康报告，存在必需检查失败时返回 503。
+func getHealthReport(registry *xHealth.Registry) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		report := registry.Check(ctx.Request.Context())
+		if report.Status == xHealth.StatusDown {
+			xResult.Error(ctx, xError.ServiceUnavailable, "存在失败的健康检查", report)
+			return
+		}
+		xResult.SuccessHasData(ctx, "获取健康报告成功", report)
+	}
+}
@@ -0,0 +1,140 @@
+package xRoute
+
+import (
+	"log/slog"
+
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xLog "github.com/bamboo-services/bamboo-base-go/common/log"
+	xResult "github.com/bamboo-services/bamboo-base-go/major/result"
+	"github.com/gin-gonic/gin"
+)
+
+// LogLevelView 日志级别视图，Loggers 仅包含单独设置过级别的 logger。
+type LogLevelView struct {
+	Base    string            `json:"base"`
+	Loggers map[string]string `json:"loggers"`
+}
+
+// logLevelRequest 调整日志级别的请求体。
+type logLevelRequest struct {
+	Level string `json:"level" binding:"required"`
+}
+
+// LogLevel 注册日志级别管理路由，便于在生产环境排障时临时调整单个 logger 的级别。
+//
+// 注册的路由（相对于 rg）:
+//   - GET    /levels        查看基础级别与各 logger 的单独设置
+//   - PUT    /levels        调整基础级别，请求体 {"level":"debug"}
+//   - PUT    /levels/:name  单独调整某个 logger（如 REPO）的级别
+//   - DELETE /levels/:name  清除单独设置，恢复基础级别
+//
+// 该路由不自带鉴权，请挂载到受保护的路由组，例如：
+//
+//	xOption.WithRouteGroup("/admin/log", func(rg *gin.RouterGroup) {
+//	    rg.Use(adminAuth)
+//	    xRoute.LogLevel(rg)
+//	})
+func LogLevel(rg *gin.RouterGroup) {
+	rg.GET("/levels", getLogLevels)
+	rg.PUT("/levels", setBaseLogLevel)
+	rg.PUT("/levels/:name", setLoggerLevel)
+	rg.DELETE("/levels/:name", resetLoggerLevel)
+}
+
+// getLogLevels 返回当前日志级别视图。
+func getLogLevels(ctx *gin.Context) {
+	levels := logLevels(ctx)
+	if levels == nil {
+		return
+	}
+	xResult.SuccessHasData(ctx, "获取日志级别成功", newLogLevelView(levels))
+}
+
+// setBaseLogLevel 调整基础级别。
+func setBaseLogLevel(ctx *gin.Context) {
+	levels := logLevels(ctx)
+	if levels == nil {
+		return
+	}
+	level, ok := bindLogLevel(ctx)
+	if !ok {
+		return
+	}
+	if !levels.SetBase(level) {
+		xResult.Error(ctx, xError.UnsupportedOp, "基础日志级别不支持运行期调整", nil)
+		return
+	}
+	xLog.WithName(xLog.NamedCORE).Notice(ctx.Request.Context(), "基础日志级别已更新",
+		slog.String("level", xLog.LevelName(level)),
+	)
+	xResult.SuccessHasData(ctx, "调整日志级别成功", newLogLevelView(levels))
+}
+
+// setLoggerLevel 单独调整某个 logger 的级别。
+func setLoggerLevel(ctx *gin.Context) {
+	levels := logLevels(ctx)
+	if levels == nil {
+		return
+	}
+	level, ok := bindLogLevel(ctx)
+	if !ok {
+		return
+	}
+	name := ctx.Param("name")
+	levels.Set(name, level)
+	xLog.WithName(xLog.NamedCORE).Notice(ctx.Request.Context(), "日志级别已更新",
+		slog.String("logger", name),
+		slog.String("level", xLog.LevelName(level)),
+	)
+	xResult.SuccessHasData(ctx, "调整日志级别成功", newLogLevelView(levels))
+}
+
+// resetLoggerLevel 清除某个 logger 的单独设置。
+func resetLoggerLevel(ctx *gin.Context) {
+	levels := logLevels(ctx)
+	if levels == nil {
+		return
+	}
+	name := ctx.Param("name")
+	levels.Reset(name)
+	xLog.WithName(xLog.NamedCORE).Notice(ctx.Request.Context(), "日志级别已恢复为基础级别",
+		slog.String("logger", name),
+	)
+	xResult.SuccessHasData(ctx, "恢复日志级别成功", newLogLevelView(levels))
+}
+
+// logLevels 获取全局日志级别注册表，未使用框架日志 Handler 时返回错误响应。
+func logLevels(ctx *gin.Context) *xLog.LevelRegistry {
+	levels := xLog.Levels()
+	if levels == nil {
+		xResult.Error(ctx, xError.UnsupportedOp, "当前日志处理器不支持动态级别", nil)
+	}
+	return levels
+}
+
+// bindLogLevel 解析请求体中的日志级别，失败时写入错误响应。
+func bindLogLevel(ctx *gin.Context) (slog.Level, bool) {
+	var req logLevelRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		xResult.Error(ctx, xError.BodyError, xError.ErrMessage(err.Error()), nil)
+		return 0, false
+	}
+	level, ok := xLog.ParseLevel(req.Level)
+	if !ok {
+		xResult.Error(ctx, xError.ParameterError, "日志级别仅支持 debug/info/notice/warn/error", nil)
+		return 0, false
+	}
+	return level, true
+}
+
+// newLogLevelView 构建日志级别视图。
+func newLogLevelView(levels *xLog.LevelRegistry) LogLevelView {
+	view := LogLevelView{
+		Base:    xLog.LevelName(levels.Base()),
+		Loggers: make(map[string]string),
+	}
+	for name, level := range levels.Overrides() {
+		view.Loggers[name] = xLog.LevelName(level)
+	}
+	return view
+}
@@ -0,0 +1,14 @@
+package xRoute
+
+import (
+	xMetrics "github.com/bamboo-services/bamboo-base-go/common/metrics"
+	"github.com/gin-gonic/gin"
+)
+
+// Metrics 注册以 Prometheus 文本格式导出 registry 的路由。
+//
+// 由 Register 在启用 xOption.WithMetrics 时调用，也可手动挂载到任意路由组。
+// 响应为 Prometheus 抓取格式的纯文本，不经过 xResult 包装。
+func Metrics(rg gin.IRoutes, path string, registry *xMetrics.Registry) {
+	rg.GET(path, gin.WrapH(registry.Handler()))
+}
@@ -0,0 +1,35 @@
+package xRoute
+
+import (
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xLifecycle "github.com/bamboo-services/bamboo-base-go/common/lifecycle"
+	xResult "github.com/bamboo-services/bamboo-base-go/major/result"
+	"github.com/gin-gonic/gin"
+)
+
+// ReadinessView 就绪状态视图。
+type ReadinessView struct {
+	Ready bool `json:"ready"`
+}
+
+// Readiness 注册就绪检查路由 GET /readyz（相对于 rg），供负载均衡与 Kubernetes readinessProbe 使用。
+//
+// 全部 HTTP 服务开始监听后返回 200；xMain.Runner 收到退出信号后立即返回 503，
+// 配合 XLF_DRAIN_PERIOD 使实例在摘流期内被负载均衡摘除后再关闭服务。
+//
+//	xOption.WithRouteGroup("", func(rg *gin.RouterGroup) {
+//	    xRoute.Readiness(rg)
+//	})
+func Readiness(rg *gin.RouterGroup) {
+	rg.GET("/readyz", getReadiness)
+}
+
+// getReadiness 返回当前就绪状态。
+func getReadiness(ctx *gin.Context) {
+	view := ReadinessView{Ready: xLifecycle.Ready()}
+	if !view.Ready {
+		xResult.Error(ctx, xError.ServiceUnavailable, "服务未就绪", view)
+		return
+	}
+	xResult.SuccessHasData(ctx, "服务已就绪", view)
+}
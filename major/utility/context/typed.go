@@ -0,0 +1,37 @@
+package xCtxUtil
+
+import (
+	"context"
+
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xSnowflake "github.com/bamboo-services/bamboo-base-go/common/snowflake"
+	xCtx "github.com/bamboo-services/bamboo-base-go/defined/context"
+	xCache "github.com/bamboo-services/bamboo-base-go/major/cache"
+	xConfig "github.com/bamboo-services/bamboo-base-go/major/config"
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+)
+
+// 框架内置组件的带类型键，底层 ContextKey 与 xCtx 中的常量一致，
+// 可与 [GetDB] / [GetCacheManager] 等辅助函数混用。
+var (
+	DatabaseTypedKey      = xCtx.NewTypedKey[*gorm.DB](xCtx.DatabaseKey)
+	CacheManagerTypedKey  = xCtx.NewTypedKey[*xCache.Manager](xCtx.CacheManagerKey)
+	RedisClientTypedKey   = xCtx.NewTypedKey[*redis.Client](xCtx.RedisClientKey)
+	SnowflakeNodeTypedKey = xCtx.NewTypedKey[*xSnowflake.Node](xCtx.SnowflakeNodeKey)
+	ConfigTypedKey        = xCtx.NewTypedKey[*xConfig.Config](xCtx.ConfigKey)
+)
+
+// MustGetTyped 通过 [xCtx.TypedKey] 获取组件（panic 版本）。
+//
+// 与 [MustGet] 相同，但类型由 key 决定，无需显式指定类型参数。
+func MustGetTyped[T any](ctx context.Context, key xCtx.TypedKey[T]) T {
+	return MustGet[T](ctx, key.Key())
+}
+
+// GetTyped 通过 [xCtx.TypedKey] 获取组件（错误返回版本）。
+//
+// 与 [Get] 相同，但类型由 key 决定，无需显式指定类型参数。
+func GetTyped[T any](ctx context.Context, key xCtx.TypedKey[T]) (T, *xError.Error) {
+	return Get[T](ctx, key.Key())
+}
@@ -2,17 +2,35 @@ package xAsync
 
 import (
 	"context"
+	"fmt"
 	"runtime/debug"
+	"time"
 
+	xLifecycle "github.com/bamboo-services/bamboo-base-go/common/lifecycle"
 	xLog "github.com/bamboo-services/bamboo-base-go/common/log"
+	xMetrics "github.com/bamboo-services/bamboo-base-go/common/metrics"
+	xTrace "github.com/bamboo-services/bamboo-base-go/common/trace"
 	"github.com/gin-gonic/gin"
 )
 
+// 异步任务指标，name 标签为 WithName 设置的任务名称，未设置时为 "anonymous"
+var (
+	tasksStarted   = xMetrics.NewCounter("async_tasks_started_total", "已启动的异步任务数", "name")
+	tasksCompleted = xMetrics.NewCounter("async_tasks_completed_total", "已结束的异步任务数，status 为 ok / canceled / panic", "name", "status")
+	tasksRunning   = xMetrics.NewGauge("async_tasks_running", "正在执行的异步任务数").With()
+	taskDuration   = xMetrics.NewHistogram("async_task_duration_seconds", "异步任务执行耗时（秒）", nil, "name")
+)
+
 // Async 从父上下文中提取组件引用，创建独立的上下文后在新的 goroutine 中异步执行 fn。
 //
 // 异步任务的上下文不受父上下文取消的影响，可以通过 xCtxUtil 系列函数访问 DB、Redis 等组件。
+// 每个任务对应一个 span（父上下文链路的子 span），记录任务耗时，panic 时标记为失败。
 // 返回的 *Task 可通过 Cancel 强制终止或 Wait 等待完成。
 //
+// 任务的启动、结束（ok / canceled / panic）与耗时计入 async_tasks_* 指标。
+//
+// 任务加入 xLifecycle.PhaseAsync 关闭阶段：xMain.Runner 优雅关闭时会在该阶段的超时内等待在途任务完成。
+//
 // 不允许传入 *gin.Context，请使用 c.Request.Context() 获取标准 context.Context。
 //
 // 可选配置:
@@ -32,6 +50,7 @@ func Async(parentCtx context.Context, fn func(ctx context.Context), options ...O
 	}
 
 	ctx, cancel := detachContext(parentCtx)
+	ctx, span := xTrace.Start(ctx, spanName(config))
 	task := &Task{
 		ctx:    ctx,
 		cancel: cancel,
@@ -39,15 +58,31 @@ func Async(parentCtx context.Context, fn func(ctx context.Context), options ...O
 	}
 
 	log := resolveLogger(config)
+	member := xLifecycle.Join(xLifecycle.PhaseAsync)
+	name := metricName(config)
+	tasksStarted.Inc(name)
+	tasksRunning.Inc()
+	start := time.Now()
 
 	go func() {
+		defer member.Leave()
 		defer func() {
+			status := "ok"
+			if ctx.Err() != nil {
+				status = "canceled"
+			}
 			if r := recover(); r != nil {
+				status = "panic"
 				log.SugarError(ctx, "async task panicked",
 					"error", r,
 					"stack", string(debug.Stack()),
 				)
+				span.SetStatus(xTrace.StatusError, fmt.Sprintf("panic: %v", r))
 			}
+			span.End()
+			tasksRunning.Dec()
+			tasksCompleted.Inc(name, status)
+			taskDuration.Observe(time.Since(start).Seconds(), name)
 			if config.Debug {
 				log.SugarInfo(ctx, "异步任务执行完成")
 			}
@@ -63,6 +98,22 @@ func Async(parentCtx context.Context, fn func(ctx context.Context), options ...O
 	return task
 }
 
+// spanName 返回任务 span 名称，设置了任务名称时为 "async <name>"。
+func spanName(config Config) string {
+	if config.Name != "" {
+		return "async " + config.Name
+	}
+	return "async"
+}
+
+// metricName 返回任务指标的 name 标签值。
+func metricName(config Config) string {
+	if config.Name != "" {
+		return config.Name
+	}
+	return "anonymous"
+}
+
 // resolveLogger 根据配置解析日志器，优先使用自定义日志器，否则根据名称创建默认日志器。
 func resolveLogger(config Config) *xLog.LogNamedLogger {
 	if config.Logger != nil {
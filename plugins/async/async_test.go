@@ -7,6 +7,9 @@ import (
 	"testing"
 	"time"
 
+	xAuth "github.com/bamboo-services/bamboo-base-go/common/auth"
+	xMetrics "github.com/bamboo-services/bamboo-base-go/common/metrics"
+	xTrace "github.com/bamboo-services/bamboo-base-go/common/trace"
 	xCtx "github.com/bamboo-services/bamboo-base-go/defined/context"
 	"github.com/gin-gonic/gin"
 )
@@ -73,6 +76,20 @@ func TestAsync_ComponentAccess(t *testing.T) {
 	}
 }
 
+func TestAsync_PrincipalPropagation(t *testing.T) {
+	parentCtx := xAuth.WithPrincipal(context.Background(), &xAuth.Principal{Subject: "1001"})
+
+	var subject string
+	task := Async(parentCtx, func(ctx context.Context) {
+		subject = xAuth.Subject(ctx)
+	})
+
+	Wait(task)
+	if subject != "1001" {
+		t.Errorf("期望异步任务继承主体 1001，实际为 %q", subject)
+	}
+}
+
 func TestCancel(t *testing.T) {
 	task := Async(context.Background(), func(ctx context.Context) {
 		<-ctx.Done()
@@ -250,3 +267,48 @@ func TestAsync_ParentCancelDoesNotAffectDetachedRequestKey(t *testing.T) {
 		t.Errorf("期望 RequestKey = 'trace-123'，实际为 %q", gotTraceID)
 	}
 }
+
+func TestAsync_TraceContextChildSpan(t *testing.T) {
+	parent := xTrace.NewRoot()
+	parentCtx := xTrace.WithContext(context.Background(), parent)
+
+	var got xTrace.SpanContext
+	var ok bool
+	task := Async(parentCtx, func(ctx context.Context) {
+		got, ok = xTrace.FromContext(ctx)
+	})
+
+	Wait(task)
+	if !ok {
+		t.Fatal("期望异步任务上下文包含链路上下文")
+	}
+	if got.TraceID != parent.TraceID {
+		t.Errorf("期望 TraceID = %s，实际为 %s", parent.TraceID, got.TraceID)
+	}
+	if got.ParentID != parent.SpanID || got.SpanID == parent.SpanID {
+		t.Errorf("期望异步任务为父 span 的子 span，实际 parent=%s span=%s", got.ParentID, got.SpanID)
+	}
+}
+
+func TestAsync_Metrics(t *testing.T) {
+	Wait(Async(context.Background(), func(ctx context.Context) {}, WithName("metrics-ok")))
+	Wait(Async(context.Background(), func(ctx context.Context) { panic("boom") }, WithName("metrics-panic")))
+	task := Async(context.Background(), func(ctx context.Context) { <-ctx.Done() }, WithName("metrics-cancel"))
+	Cancel(task)
+	Wait(task)
+
+	var out strings.Builder
+	if err := xMetrics.Default().WriteText(&out); err != nil {
+		t.Fatalf("导出指标失败: %v", err)
+	}
+	for _, want := range []string{
+		`async_tasks_started_total{name="metrics-ok"} 1`,
+		`async_tasks_completed_total{name="metrics-ok",status="ok"} 1`,
+		`async_tasks_completed_total{name="metrics-panic",status="panic"} 1`,
+		`async_tasks_completed_total{name="metrics-cancel",status="canceled"} 1`,
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("缺少指标 %s", want)
+		}
+	}
+}
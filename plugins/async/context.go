@@ -3,13 +3,16 @@ package xAsync
 import (
 	"context"
 
+	xAuth "github.com/bamboo-services/bamboo-base-go/common/auth"
+	xTrace "github.com/bamboo-services/bamboo-base-go/common/trace"
 	xCtx "github.com/bamboo-services/bamboo-base-go/defined/context"
 )
 
 // detachContext 从父上下文中提取组件引用和请求级数据，注入到全新的独立上下文中。
 //
 // 新上下文基于 context.Background()，因此父上下文的取消不会影响异步任务。
-// 复制 RegNodeKey（组件引用）和 RequestKey（请求链路追踪 ID），
+// 复制 RegNodeKey（组件引用）、RequestKey（请求链路追踪 ID）、PrincipalKey（已认证主体）和 TraceKey（W3C 链路上下文），
+// 由 Async 在其下创建任务 span，使异步任务的日志与原始请求共享同一 trace id；
 // 不复制请求生命周期相关的临时数据（UserStartTimeKey、ErrorCodeKey 等）。
 //
 // 所有值的读取在同步阶段完成，确保 goroutine 启动时不依赖父上下文。
@@ -34,5 +37,15 @@ func detachContext(parentCtx context.Context) (context.Context, context.CancelFu
 		}
 	}
 
+	// 复制已认证主体，异步任务可继续按用户鉴权，日志附带 subject
+	if principal, ok := xAuth.FromContext(parentCtx); ok {
+		ctx = xAuth.WithPrincipal(ctx, principal)
+	}
+
+	// 复制链路上下文，异步任务与原始请求属于同一链路
+	if span, ok := xTrace.FromContext(parentCtx); ok {
+		ctx = xTrace.WithContext(ctx, span)
+	}
+
 	return context.WithCancel(ctx)
 }
@@ -4,6 +4,17 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"runtime"
+	"time"
+
+	xMetrics "github.com/bamboo-services/bamboo-base-go/common/metrics"
+	xTrace "github.com/bamboo-services/bamboo-base-go/common/trace"
+)
+
+// 定时任务指标，job 标签为任务函数的完整名称
+var (
+	jobRuns     = xMetrics.NewCounter("cron_job_runs_total", "定时任务执行次数，status 为 ok / panic", "job", "status")
+	jobDuration = xMetrics.NewHistogram("cron_job_duration_seconds", "定时任务执行耗时（秒）", nil, "job")
 )
 
 // Job 定义定时任务结构
@@ -42,6 +53,10 @@ type jobFunc func(ctx context.Context)
 // 支持的函数签名:
 //   - func()
 //   - func(context.Context)
+//
+// 每次执行都会为任务创建独立的 span（ctx 中已有链路时为其子 span，否则为新的根 span），
+// 记录执行耗时，panic 时标记为失败后继续向上抛出；任务内的日志与下游调用可按 trace id 关联到本次执行。
+// 每次执行同时计入 cron_job_runs_total{job,status} 与 cron_job_duration_seconds{job} 指标。
 func AdaptJob(fn any) (jobFunc, error) {
 	if fn == nil {
 		return nil, fmt.Errorf("cron job func 不能为 nil")
@@ -52,11 +67,19 @@ func AdaptJob(fn any) (jobFunc, error) {
 		return nil, fmt.Errorf("cron job func 必须是函数类型")
 	}
 
+	job := "unknown"
+	if f := runtime.FuncForPC(v.Pointer()); f != nil {
+		job = f.Name()
+	}
+	name := "cron " + job
+
 	t := v.Type()
 	switch t.NumIn() {
 	case 0:
 		// func() -> 包装为 func(context.Context)
 		return func(ctx context.Context) {
+			_, span := xTrace.Start(ctx, name)
+			defer endJobSpan(span, job, time.Now())
 			v.Call(nil)
 		}, nil
 	case 1:
@@ -66,9 +89,24 @@ func AdaptJob(fn any) (jobFunc, error) {
 			return nil, fmt.Errorf("参数类型必须是 context.Context")
 		}
 		return func(ctx context.Context) {
+			ctx, span := xTrace.Start(ctx, name)
+			defer endJobSpan(span, job, time.Now())
 			v.Call([]reflect.Value{reflect.ValueOf(ctx)})
 		}, nil
 	default:
 		return nil, fmt.Errorf("cron job func 最多接受一个 context.Context 参数")
 	}
 }
+
+// endJobSpan 结束任务 span 并记录指标，任务 panic 时标记为失败并继续向上抛出
+func endJobSpan(span *xTrace.Span, job string, start time.Time) {
+	jobDuration.Observe(time.Since(start).Seconds(), job)
+	if r := recover(); r != nil {
+		jobRuns.Inc(job, "panic")
+		span.SetStatus(xTrace.StatusError, fmt.Sprintf("panic: %v", r))
+		span.End()
+		panic(r)
+	}
+	jobRuns.Inc(job, "ok")
+	span.End()
+}
@@ -2,9 +2,13 @@ package xCron
 
 import (
 	"context"
+	"strings"
 	"sync/atomic"
 	"testing"
 	"time"
+
+	xMetrics "github.com/bamboo-services/bamboo-base-go/common/metrics"
+	xTrace "github.com/bamboo-services/bamboo-base-go/common/trace"
 )
 
 func TestNewJob(t *testing.T) {
@@ -157,3 +161,43 @@ func TestAdaptJob_ContextCancellation(t *testing.T) {
 		t.Error("函数执行超时")
 	}
 }
+
+func TestAdaptJob_TraceContextPerRun(t *testing.T) {
+	spans := make([]xTrace.SpanContext, 0, 2)
+	jobFn, err := AdaptJob(func(ctx context.Context) {
+		span, ok := xTrace.FromContext(ctx)
+		if !ok {
+			t.Error("期望任务上下文包含链路上下文")
+		}
+		spans = append(spans, span)
+	})
+	if err != nil {
+		t.Fatalf("AdaptJob 失败: %v", err)
+	}
+
+	jobFn(context.Background())
+	jobFn(context.Background())
+
+	if len(spans) != 2 || spans[0].TraceID == spans[1].TraceID {
+		t.Error("期望每次执行使用独立的 trace id")
+	}
+}
+
+func metricsJob() {}
+
+func TestAdaptJob_Metrics(t *testing.T) {
+	jobFn, err := AdaptJob(metricsJob)
+	if err != nil {
+		t.Fatalf("AdaptJob 失败: %v", err)
+	}
+	jobFn(context.Background())
+
+	var out strings.Builder
+	if err := xMetrics.Default().WriteText(&out); err != nil {
+		t.Fatalf("导出指标失败: %v", err)
+	}
+	want := `cron_job_runs_total{job="github.com/bamboo-services/bamboo-base-go/plugins/cron.metricsJob",status="ok"} 1`
+	if !strings.Contains(out.String(), want) {
+		t.Errorf("缺少指标 %s", want)
+	}
+}
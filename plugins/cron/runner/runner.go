@@ -6,6 +6,7 @@ import (
 	"sync"
 	"time"
 
+	xLifecycle "github.com/bamboo-services/bamboo-base-go/common/lifecycle"
 	xLog "github.com/bamboo-services/bamboo-base-go/common/log"
 	xCron "github.com/bamboo-services/bamboo-base-go/plugins/cron"
 	"github.com/robfig/cron/v3"
@@ -139,6 +140,10 @@ func run(ctx context.Context, config Config) {
 		log = xLog.WithName(xLog.NamedCRON)
 	}
 
+	// 加入 Cron 关闭阶段：xMain.Runner 在 gRPC 服务关闭后通知停止
+	member := xLifecycle.Join(xLifecycle.PhaseCron)
+	defer member.Leave()
+
 	// 构建 cron 选项
 	cronOpts := make([]cron.Option, 0)
 	if config.WithSeconds {
@@ -175,8 +180,11 @@ func run(ctx context.Context, config Config) {
 	c.Start()
 	log.Info(ctx, "Cron 服务已启动", slog.Int("jobs", registeredCount))
 
-	// 等待上下文取消
-	<-ctx.Done()
+	// 等待上下文取消或关闭阶段通知
+	select {
+	case <-ctx.Done():
+	case <-member.Stopping():
+	}
 
 	// 优雅关闭
 	log.Info(ctx, "Cron 服务正在关闭...")
@@ -194,14 +202,9 @@ func stopCron(c *cron.Cron, timeout time.Duration) {
 		return
 	}
 
-	done := make(chan struct{})
-	go func() {
-		c.Stop()
-		close(done)
-	}()
-
+	// Stop 立即停止调度，返回的上下文在运行中的任务全部结束后完成
 	select {
-	case <-done:
+	case <-c.Stop().Done():
 		return
 	case <-time.After(timeout):
 		return
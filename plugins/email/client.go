@@ -3,6 +3,7 @@ package xEmail
 import (
 	"context"
 	"fmt"
+	"net"
 
 	mail "github.com/wneessen/go-mail"
 	xLog "github.com/bamboo-services/bamboo-base-go/common/log"
@@ -236,3 +237,16 @@ func (c *EmailClient) AddTemplateDir(dir string) error {
 func (c *EmailClient) ListTemplates() []string {
 	return c.tmpl.ListTemplates()
 }
+
+// Check 检查 SMTP 服务器是否可达（建立 TCP 连接后立即关闭）
+//
+// 实现 xHealth.Checker，注册为节点后会自动登记为健康检查。
+// 仅探测连通性，不进行 SMTP 握手与认证，避免与并发的发送任务争用同一连接。
+func (c *EmailClient) Check(ctx context.Context) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", c.client.ServerAddr())
+	if err != nil {
+		return fmt.Errorf("SMTP 服务器不可达: %w", err)
+	}
+	return conn.Close()
+}
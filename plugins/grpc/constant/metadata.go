@@ -13,4 +13,19 @@ const (
 	MetadataAppAccessID  Metadata = "app-access-id"  // 定义用于传递应用访问标识符的元数据键，通常用于在中间件或拦截器中标识请求方的应用 ID。
 	MetadataAppSecretKey Metadata = "app-secret-key" // 定义用于传递应用密钥的元数据键，通常用于在中间件或拦截器中验证请求方的身份。
 	MetadataRequestUUID  Metadata = "x-request-uuid" // 定义用于传递请求唯一标识符的元数据键，通常用于在中间件或拦截器中标识请求的唯一性。
+	MetadataTraceParent  Metadata = "traceparent"    // 定义用于传递 W3C Trace Context 链路标识的元数据键。
+	MetadataTraceState   Metadata = "tracestate"     // 定义用于传递 W3C Trace Context 厂商扩展状态的元数据键。
+)
+
+// 认证元数据，由认证拦截器读取
+const (
+	MetadataAuthorization Metadata = "authorization" // 定义用于传递 Bearer 访问令牌的元数据键。
+)
+
+// 限流响应元数据，由限流拦截器写入响应 header
+const (
+	MetadataRateLimitLimit     Metadata = "x-ratelimit-limit"     // 限流窗口内允许的请求数
+	MetadataRateLimitRemaining Metadata = "x-ratelimit-remaining" // 限流窗口内剩余的请求数
+	MetadataRateLimitReset     Metadata = "x-ratelimit-reset"     // 限流额度完全恢复所需的秒数
+	MetadataRetryAfter         Metadata = "retry-after"           // 被限流后建议的重试等待秒数
 )
@@ -0,0 +1,26 @@
+package xGrpcIStream
+
+import (
+	xJwt "github.com/bamboo-services/bamboo-base-go/common/jwt"
+	xGrpcUtil "github.com/bamboo-services/bamboo-base-go/plugins/grpc/utility"
+	"google.golang.org/grpc"
+)
+
+// Auth 创建用于 gRPC 服务端的流式认证拦截器。
+//
+// 每个流在建立时校验一次令牌，其余行为与一元拦截器 xGrpcIUnary.Auth 一致。
+//
+// 参数说明:
+//   - manager: 令牌管理器，见 xJwt.New。
+//
+// 返回值:
+//   - `grpc.StreamServerInterceptor`: 返回配置好的 gRPC 流式拦截器实例，也可通过 xGrpcMiddle.UseStream 绑定到单个服务。
+func Auth(manager *xJwt.Manager) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		authCtx, err := xGrpcUtil.Authenticate(ss.Context(), manager)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &wrappedServerStream{ServerStream: ss, ctx: authCtx})
+	}
+}
@@ -0,0 +1,27 @@
+package xGrpcIStream
+
+import (
+	xAuthz "github.com/bamboo-services/bamboo-base-go/common/authz"
+	xGrpcUtil "github.com/bamboo-services/bamboo-base-go/plugins/grpc/utility"
+	"google.golang.org/grpc"
+)
+
+// Authorize 创建用于 gRPC 服务端的流式授权拦截器，需注册在 [Auth] 之后。
+//
+// 每个流在建立时判定一次，其余行为与一元拦截器 xGrpcIUnary.Authorize 一致。
+//
+// 参数说明:
+//   - enforcer: 授权判定器，见 xAuthz.New。
+//   - methods: 方法访问要求，未声明的方法直接放行。
+//
+// 返回值:
+//   - `grpc.StreamServerInterceptor`: 返回配置好的 gRPC 流式拦截器实例，也可通过 xGrpcMiddle.UseStream 绑定到单个服务。
+func Authorize(enforcer *xAuthz.Enforcer, methods xGrpcUtil.MethodRequirements) grpc.StreamServerInterceptor {
+	check := xGrpcUtil.NewAuthorizeCheck(enforcer, methods)
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := check(ss.Context(), info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
@@ -0,0 +1,25 @@
+package xGrpcIStream
+
+import (
+	xGrpcUtil "github.com/bamboo-services/bamboo-base-go/plugins/grpc/utility"
+	"google.golang.org/grpc"
+)
+
+// Metrics 创建用于 gRPC 服务端的流式指标拦截器。
+//
+// 向 xMetrics 默认注册表写入 grpc_server_handled_total{type,service,method,code}、
+// grpc_server_handling_seconds{type,service,method} 与 grpc_server_in_flight{type}，type 固定为 "stream"。
+// 耗时为整个流从建立到 handler 返回的时长。
+//
+// 返回值:
+//   - `grpc.StreamServerInterceptor`: 返回配置好的 gRPC 流式拦截器实例。
+//
+// 注意: 需放置在 Recover 之前，panic 被恢复为 Internal 错误后才能回到这里计数。
+func Metrics() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		done := xGrpcUtil.StartServerMetrics("stream", info.FullMethod)
+		err := handler(srv, ss)
+		done(err)
+		return err
+	}
+}
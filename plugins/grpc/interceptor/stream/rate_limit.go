@@ -0,0 +1,28 @@
+package xGrpcIStream
+
+import (
+	xRateLimit "github.com/bamboo-services/bamboo-base-go/common/ratelimit"
+	xGrpcUtil "github.com/bamboo-services/bamboo-base-go/plugins/grpc/utility"
+	"google.golang.org/grpc"
+)
+
+// RateLimit 创建用于 gRPC 服务端的流式限流拦截器。
+//
+// 每个流在建立时判定一次，流内的消息不再单独计数；其余行为与一元拦截器 xGrpcIUnary.RateLimit 一致。
+//
+// 参数说明:
+//   - limiter: 限流器，通常由 xCache.LimiterOf 构造。
+//   - key: 限流维度，可使用 xGrpcUtil.RateLimitByPeer / RateLimitByMethod / RateLimitByMetadata 或其组合。
+//   - opts: 可选配置，见 xGrpcUtil.WithRateLimitErrorCode、xGrpcUtil.WithRateLimitFailClosed。
+//
+// 返回值:
+//   - `grpc.StreamServerInterceptor`: 返回配置好的 gRPC 流式拦截器实例，也可通过 xGrpcMiddle.UseStream 绑定到单个服务。
+func RateLimit(limiter xRateLimit.Limiter, key xGrpcUtil.RateLimitKeyFunc, opts ...xGrpcUtil.RateLimitOption) grpc.StreamServerInterceptor {
+	check := xGrpcUtil.NewRateLimitCheck(limiter, key, opts...)
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := check(ss.Context(), info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
@@ -2,10 +2,13 @@ package xGrpcIStream
 
 import (
 	"context"
+	"errors"
+	"io"
 	"log/slog"
 	"time"
 
 	xLog "github.com/bamboo-services/bamboo-base-go/common/log"
+	xTrace "github.com/bamboo-services/bamboo-base-go/common/trace"
 	xCtx "github.com/bamboo-services/bamboo-base-go/defined/context"
 	xGrpcConst "github.com/bamboo-services/bamboo-base-go/plugins/grpc/constant"
 	xGrpcUtil "github.com/bamboo-services/bamboo-base-go/plugins/grpc/utility"
@@ -14,7 +17,8 @@ import (
 	"google.golang.org/grpc/metadata"
 )
 
-// Trace 返回一个 gRPC 流式拦截器，用于自动生成或复用请求追踪 UUID，记录请求开始时间，并设置响应元数据。
+// Trace 返回一个 gRPC 流式拦截器，用于自动生成或复用请求追踪 UUID，解析 W3C `traceparent` 创建覆盖整个流的 span，
+// 记录请求开始时间，并设置响应元数据。
 func Trace() grpc.StreamServerInterceptor {
 	log := xLog.WithName(xLog.NamedGRPC)
 
@@ -23,17 +27,22 @@ func Trace() grpc.StreamServerInterceptor {
 		if extractErr != nil {
 			requestUUID = uuid.NewString()
 		}
-		traceCtx := context.WithValue(ss.Context(), xCtx.RequestKey, requestUUID)
+		traceCtx, span := xGrpcUtil.StartServerSpan(ss.Context(), info.FullMethod)
+		traceCtx = context.WithValue(traceCtx, xCtx.RequestKey, requestUUID)
 		traceCtx = context.WithValue(traceCtx, xCtx.UserStartTimeKey, time.Now())
 
 		// 设置 header 和 trailer
-		md := metadata.Pairs(xGrpcConst.TrailerRequestUUID.String(), requestUUID)
+		md := metadata.Join(
+			metadata.Pairs(xGrpcConst.TrailerRequestUUID.String(), requestUUID),
+			xGrpcUtil.TraceMetadata(span.Context()),
+		)
 		if headerErr := ss.SetHeader(md); headerErr != nil {
 			log.Warn(traceCtx, "设置 gRPC 请求追踪头失败", slog.Any("error", headerErr))
 		}
 
 		wrapped := &wrappedServerStream{ServerStream: ss, ctx: traceCtx}
 		err := handler(srv, wrapped)
+		xGrpcUtil.EndSpan(span, err)
 
 		// 设置 trailer
 		ss.SetTrailer(md)
@@ -41,3 +50,35 @@ func Trace() grpc.StreamServerInterceptor {
 		return err
 	}
 }
+
+// TraceClient 返回一个 gRPC 客户端流式拦截器，为每个流创建 client span 并以 `traceparent`/`tracestate` 元数据透传给下游服务。
+//
+// span 在建流失败、RecvMsg 返回错误或 io.EOF 时结束。
+func TraceClient() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		spanCtx, span := xGrpcUtil.StartClientSpan(ctx, method)
+		cs, err := streamer(spanCtx, desc, cc, method, opts...)
+		if err != nil {
+			xGrpcUtil.EndSpan(span, err)
+			return nil, err
+		}
+		return &tracedClientStream{ClientStream: cs, span: span}, nil
+	}
+}
+
+// tracedClientStream 在流结束时结束 client span。
+type tracedClientStream struct {
+	grpc.ClientStream
+	span *xTrace.Span
+}
+
+// RecvMsg 接收消息，流结束（io.EOF）或出错时结束 span。
+func (s *tracedClientStream) RecvMsg(m interface{}) error {
+	err := s.ClientStream.RecvMsg(m)
+	if errors.Is(err, io.EOF) {
+		xGrpcUtil.EndSpan(s.span, nil)
+	} else if err != nil {
+		xGrpcUtil.EndSpan(s.span, err)
+	}
+	return err
+}
@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	xTrace "github.com/bamboo-services/bamboo-base-go/common/trace"
 	xCtxUtil "github.com/bamboo-services/bamboo-base-go/common/utility/context"
 	xCtx "github.com/bamboo-services/bamboo-base-go/defined/context"
 	"google.golang.org/grpc"
@@ -133,3 +134,28 @@ func TestInitContext(t *testing.T) {
 		t.Fatalf("init context interceptor should not return error: %v", err)
 	}
 }
+
+func TestTraceGenerateRootSpan(t *testing.T) {
+	ss := &mockServerStream{ctx: context.Background()}
+	info := &grpc.StreamServerInfo{FullMethod: "/x.Base/Test"}
+
+	interceptor := Trace()
+	err := interceptor(nil, ss, info, func(srv interface{}, stream grpc.ServerStream) error {
+		span, ok := xTrace.FromContext(stream.Context())
+		if !ok {
+			t.Fatalf("span context should be injected")
+		}
+		if span.ParentID.IsValid() {
+			t.Fatalf("root span should not have parent id")
+		}
+
+		headerValues := ss.header.Get("traceparent")
+		if len(headerValues) == 0 || headerValues[0] != span.TraceParent() {
+			t.Fatalf("header traceparent should equal current span")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("trace interceptor should not return error: %v", err)
+	}
+}
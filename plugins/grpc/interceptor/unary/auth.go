@@ -0,0 +1,29 @@
+package xGrpcIUnary
+
+import (
+	"context"
+
+	xJwt "github.com/bamboo-services/bamboo-base-go/common/jwt"
+	xGrpcUtil "github.com/bamboo-services/bamboo-base-go/plugins/grpc/utility"
+	"google.golang.org/grpc"
+)
+
+// Auth 创建用于 gRPC 服务端的一元认证拦截器。
+//
+// 校验 authorization 元数据中的 Bearer 访问令牌，失败时返回 Unauthenticated（服务端错误为 Internal）；
+// 通过后处理器可使用 xAuth.FromContext(ctx) 读取已认证主体，日志与 xAsync 任务会自动继承。
+//
+// 参数说明:
+//   - manager: 令牌管理器，见 xJwt.New。
+//
+// 返回值:
+//   - `grpc.UnaryServerInterceptor`: 返回配置好的 gRPC 一元拦截器实例，也可通过 xGrpcMiddle.UseUnary 绑定到单个服务。
+func Auth(manager *xJwt.Manager) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		authCtx, err := xGrpcUtil.Authenticate(ctx, manager)
+		if err != nil {
+			return nil, err
+		}
+		return handler(authCtx, req)
+	}
+}
@@ -0,0 +1,43 @@
+package xGrpcIUnary
+
+import (
+	"context"
+	"testing"
+
+	xAuth "github.com/bamboo-services/bamboo-base-go/common/auth"
+	xJwt "github.com/bamboo-services/bamboo-base-go/common/jwt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestAuthPropagatesPrincipal(t *testing.T) {
+	keys, err := xJwt.NewKeySet(xJwt.HMACKey("test", []byte("0123456789abcdef0123456789abcdef")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	manager := xJwt.New(keys)
+	token, err := manager.Issue(context.Background(), xJwt.Claims{Subject: "1001"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	interceptor := Auth(manager)
+	info := &grpc.UnaryServerInfo{FullMethod: "/x.Auth/Call"}
+	handler := func(ctx context.Context, _ interface{}) (interface{}, error) { return xAuth.Subject(ctx), nil }
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+	resp, err := interceptor(ctx, nil, info, handler)
+	if err != nil || resp != "1001" {
+		t.Fatalf("authenticated call = %v, %v", resp, err)
+	}
+
+	if _, err = interceptor(context.Background(), nil, info, handler); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("missing token should map to Unauthenticated, got %v", err)
+	}
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer bad"))
+	if _, err = interceptor(ctx, nil, info, handler); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("invalid token should map to Unauthenticated, got %v", err)
+	}
+}
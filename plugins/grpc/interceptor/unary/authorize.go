@@ -0,0 +1,37 @@
+package xGrpcIUnary
+
+import (
+	"context"
+
+	xAuthz "github.com/bamboo-services/bamboo-base-go/common/authz"
+	xGrpcUtil "github.com/bamboo-services/bamboo-base-go/plugins/grpc/utility"
+	"google.golang.org/grpc"
+)
+
+// Authorize 创建用于 gRPC 服务端的一元授权拦截器，需注册在 [Auth] 之后。
+//
+// 与 HTTP 侧的 xMiddle.Authorize 共用同一个 xAuthz.Enforcer，按方法声明的访问要求校验当前主体，
+// 拒绝时返回 Unauthenticated 或 PermissionDenied 并记录审计日志。
+//
+// 使用示例:
+//
+//	xGrpcIUnary.Authorize(enforcer, xGrpcUtil.MethodRequirements{
+//	    "/order.OrderService/*":      xAuthz.RequireRole("user"),
+//	    "/order.OrderService/Delete": xAuthz.Require("order:**", "delete"),
+//	})
+//
+// 参数说明:
+//   - enforcer: 授权判定器，见 xAuthz.New。
+//   - methods: 方法访问要求，未声明的方法直接放行。
+//
+// 返回值:
+//   - `grpc.UnaryServerInterceptor`: 返回配置好的 gRPC 一元拦截器实例，也可通过 xGrpcMiddle.UseUnary 绑定到单个服务。
+func Authorize(enforcer *xAuthz.Enforcer, methods xGrpcUtil.MethodRequirements) grpc.UnaryServerInterceptor {
+	check := xGrpcUtil.NewAuthorizeCheck(enforcer, methods)
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := check(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
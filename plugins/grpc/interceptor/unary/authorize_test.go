@@ -0,0 +1,46 @@
+package xGrpcIUnary
+
+import (
+	"context"
+	"testing"
+
+	xAuth "github.com/bamboo-services/bamboo-base-go/common/auth"
+	xAuthz "github.com/bamboo-services/bamboo-base-go/common/authz"
+	xGrpcUtil "github.com/bamboo-services/bamboo-base-go/plugins/grpc/utility"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestAuthorizeMethodRequirements(t *testing.T) {
+	policy := xAuthz.Policy{Roles: []xAuthz.Role{
+		{Name: "user", Rules: []xAuthz.Rule{{Resource: "order:**", Actions: []string{"read"}}}},
+	}}
+	enforcer, err := xAuthz.New(context.Background(), xAuthz.Static(policy))
+	if err != nil {
+		t.Fatal(err)
+	}
+	interceptor := Authorize(enforcer, xGrpcUtil.MethodRequirements{
+		"/x.Order/*":      xAuthz.RequireRole("user"),
+		"/x.Order/Delete": xAuthz.Require("order:**", "delete"),
+	})
+	handler := func(context.Context, interface{}) (interface{}, error) { return "ok", nil }
+	call := func(ctx context.Context, method string) error {
+		_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+		return err
+	}
+	user := xAuth.WithPrincipal(context.Background(), &xAuth.Principal{Subject: "7", Roles: []string{"user"}})
+
+	if err = call(user, "/x.Order/Get"); err != nil {
+		t.Fatalf("service wildcard should allow user: %v", err)
+	}
+	if err = call(user, "/x.Order/Delete"); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("exact method requirement should deny delete, got %v", err)
+	}
+	if err = call(context.Background(), "/x.Order/Get"); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("missing principal should map to Unauthenticated, got %v", err)
+	}
+	if err = call(context.Background(), "/x.Health/Check"); err != nil {
+		t.Fatalf("undeclared method should pass: %v", err)
+	}
+}
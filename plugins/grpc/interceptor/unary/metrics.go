@@ -0,0 +1,26 @@
+package xGrpcIUnary
+
+import (
+	"context"
+
+	xGrpcUtil "github.com/bamboo-services/bamboo-base-go/plugins/grpc/utility"
+	"google.golang.org/grpc"
+)
+
+// Metrics 创建用于 gRPC 服务端的一元指标拦截器。
+//
+// 向 xMetrics 默认注册表写入 grpc_server_handled_total{type,service,method,code}、
+// grpc_server_handling_seconds{type,service,method} 与 grpc_server_in_flight{type}，type 固定为 "unary"。
+//
+// 返回值:
+//   - `grpc.UnaryServerInterceptor`: 返回配置好的 gRPC 一元拦截器实例。
+//
+// 注意: 需放置在 Recover 之前，panic 被恢复为 Internal 错误后才能回到这里计数。
+func Metrics() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		done := xGrpcUtil.StartServerMetrics("unary", info.FullMethod)
+		resp, err := handler(ctx, req)
+		done(err)
+		return resp, err
+	}
+}
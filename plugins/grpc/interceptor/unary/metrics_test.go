@@ -0,0 +1,37 @@
+package xGrpcIUnary
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	xMetrics "github.com/bamboo-services/bamboo-base-go/common/metrics"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestMetricsRecordsStatusCode(t *testing.T) {
+	interceptor := Metrics()
+	info := &grpc.UnaryServerInfo{FullMethod: "/x.Metrics/Call"}
+	_, _ = interceptor(context.Background(), nil, info, func(context.Context, interface{}) (interface{}, error) {
+		return nil, nil
+	})
+	_, _ = interceptor(context.Background(), nil, info, func(context.Context, interface{}) (interface{}, error) {
+		return nil, status.Error(codes.NotFound, "missing")
+	})
+
+	var out strings.Builder
+	if err := xMetrics.Default().WriteText(&out); err != nil {
+		t.Fatalf("write metrics failed: %v", err)
+	}
+	for _, want := range []string{
+		`grpc_server_handled_total{type="unary",service="x.Metrics",method="Call",code="OK"} 1`,
+		`grpc_server_handled_total{type="unary",service="x.Metrics",method="Call",code="NotFound"} 1`,
+		`grpc_server_handling_seconds_count{type="unary",service="x.Metrics",method="Call"} 2`,
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Fatalf("metrics should contain %s", want)
+		}
+	}
+}
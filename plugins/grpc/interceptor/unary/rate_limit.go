@@ -0,0 +1,31 @@
+package xGrpcIUnary
+
+import (
+	"context"
+
+	xRateLimit "github.com/bamboo-services/bamboo-base-go/common/ratelimit"
+	xGrpcUtil "github.com/bamboo-services/bamboo-base-go/plugins/grpc/utility"
+	"google.golang.org/grpc"
+)
+
+// RateLimit 创建用于 gRPC 服务端的一元限流拦截器。
+//
+// 与 HTTP 侧的 xMiddle.RateLimit 共用同一个 [xRateLimit.Limiter]（通常由 xCache.LimiterOf 构造），
+// 超出限流时返回由错误码（默认 xError.LimitExceeded）映射的 gRPC status error，并在响应 header 中写入限流元数据。
+//
+// 参数说明:
+//   - limiter: 限流器。
+//   - key: 限流维度，可使用 xGrpcUtil.RateLimitByPeer / RateLimitByMethod / RateLimitByMetadata 或其组合。
+//   - opts: 可选配置，见 xGrpcUtil.WithRateLimitErrorCode、xGrpcUtil.WithRateLimitFailClosed。
+//
+// 返回值:
+//   - `grpc.UnaryServerInterceptor`: 返回配置好的 gRPC 一元拦截器实例，也可通过 xGrpcMiddle.UseUnary 绑定到单个服务。
+func RateLimit(limiter xRateLimit.Limiter, key xGrpcUtil.RateLimitKeyFunc, opts ...xGrpcUtil.RateLimitOption) grpc.UnaryServerInterceptor {
+	check := xGrpcUtil.NewRateLimitCheck(limiter, key, opts...)
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := check(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
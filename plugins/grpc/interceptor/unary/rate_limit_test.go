@@ -0,0 +1,51 @@
+package xGrpcIUnary
+
+import (
+	"context"
+	"testing"
+
+	xRateLimit "github.com/bamboo-services/bamboo-base-go/common/ratelimit"
+	xGrpcUtil "github.com/bamboo-services/bamboo-base-go/plugins/grpc/utility"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// countLimiter 按 key 计数的固定额度限流器
+type countLimiter struct {
+	limit int
+	seen  map[string]int
+}
+
+func (l *countLimiter) Allow(_ context.Context, key string) (xRateLimit.Result, error) {
+	l.seen[key]++
+	used := l.seen[key]
+	return xRateLimit.Result{Allowed: used <= l.limit, Limit: l.limit, Remaining: max(0, l.limit-used)}, nil
+}
+
+func TestRateLimitRejectsOverLimit(t *testing.T) {
+	limiter := &countLimiter{limit: 1, seen: map[string]int{}}
+	interceptor := RateLimit(limiter, xGrpcUtil.RateLimitKeys(
+		xGrpcUtil.RateLimitByMetadata("x-user-id"),
+		xGrpcUtil.RateLimitByMethod(),
+	))
+	info := &grpc.UnaryServerInfo{FullMethod: "/x.RateLimit/Call"}
+	handler := func(context.Context, interface{}) (interface{}, error) { return "ok", nil }
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-user-id", "42"))
+
+	if _, err := interceptor(ctx, nil, info, handler); err != nil {
+		t.Fatalf("first call should pass: %v", err)
+	}
+	_, err := interceptor(ctx, nil, info, handler)
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("LimitExceeded should map to InvalidArgument, got %v", err)
+	}
+	if _, ok := limiter.seen["x-user-id:42|route:/x.RateLimit/Call"]; !ok {
+		t.Fatalf("unexpected limiter keys: %v", limiter.seen)
+	}
+
+	if _, err = interceptor(context.Background(), nil, info, handler); err != nil {
+		t.Fatalf("call without user metadata should skip rate limiting: %v", err)
+	}
+}
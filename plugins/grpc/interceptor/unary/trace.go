@@ -20,8 +20,12 @@ import (
 // 若元数据中不存在该键值，则会自动生成一个新的 UUID 作为请求唯一标识。
 // 此标识会被注入到上下文中，用于后续的日志关联和业务逻辑追踪。
 //
-// 同时，该拦截器会在上下文中记录请求的开始时间，便于计算请求总耗时。
-// 在请求处理完成后，它会将 `x_request_uuid` 作为 Trailer 写回给客户端。
+// 同时，该拦截器会按 W3C Trace Context 解析元数据中的 `traceparent`/`tracestate`：
+// 有效时沿用上游 trace id 并创建子 span，否则创建新的根 span，注入到上下文中供日志与下游调用使用。
+// span 记录调用耗时与 gRPC 状态码，返回错误时标记为失败，结束后交由 xTrace 的全局导出器导出。
+//
+// 该拦截器还会在上下文中记录请求的开始时间，便于计算请求总耗时。
+// 在请求处理完成后，它会将 `x_request_uuid` 与当前 span 的 `traceparent` 作为 Trailer 写回给客户端。
 //
 // 参数说明:
 //   - 无参数。
@@ -31,7 +35,7 @@ import (
 //
 // 注意:
 //   - 如果设置 gRPC Trailer 失败，会记录一条警告日志，但不会中断请求流程。
-//   - 上下文中注入的 Key 分别为 `xCtx.RequestKey` 和 `xCtx.UserStartTimeKey`。
+//   - 上下文中注入的 Key 分别为 `xCtx.RequestKey`、`xCtx.TraceKey`、`xCtx.SpanKey` 和 `xCtx.UserStartTimeKey`。
 func Trace() grpc.UnaryServerInterceptor {
 	log := xLog.WithName(xLog.NamedGRPC)
 
@@ -40,16 +44,21 @@ func Trace() grpc.UnaryServerInterceptor {
 		if extractErr != nil {
 			requestUUID = uuid.NewString()
 		}
-		traceCtx := context.WithValue(ctx, xCtx.RequestKey, requestUUID)
+		traceCtx, span := xGrpcUtil.StartServerSpan(ctx, info.FullMethod)
+		traceCtx = context.WithValue(traceCtx, xCtx.RequestKey, requestUUID)
 		traceCtx = context.WithValue(traceCtx, xCtx.UserStartTimeKey, time.Now())
 
 		// 设置 header 和 trailer
-		md := metadata.Pairs(xGrpcConst.TrailerRequestUUID.String(), requestUUID)
+		md := metadata.Join(
+			metadata.Pairs(xGrpcConst.TrailerRequestUUID.String(), requestUUID),
+			xGrpcUtil.TraceMetadata(span.Context()),
+		)
 		if headerErr := grpc.SetHeader(traceCtx, md); headerErr != nil {
 			log.Warn(traceCtx, "设置 gRPC 请求追踪头失败", slog.Any("error", headerErr))
 		}
 
 		resp, err = handler(traceCtx, req)
+		xGrpcUtil.EndSpan(span, err)
 
 		if trailerErr := grpc.SetTrailer(traceCtx, md); trailerErr != nil {
 			log.Warn(traceCtx, "设置 gRPC 请求追踪尾失败", slog.Any("error", trailerErr))
@@ -58,3 +67,19 @@ func Trace() grpc.UnaryServerInterceptor {
 		return resp, err
 	}
 }
+
+// TraceClient 创建用于 gRPC 客户端的一元拦截器，为每次调用创建 client span 并将链路透传给下游服务。
+//
+// span 为调用上下文中当前 span 的子 span（不存在时为新的根 span），以 `traceparent`/`tracestate`
+// 元数据发送，使下游日志与当前请求共享同一 trace id；span 记录调用耗时与 gRPC 状态码。
+//
+// 返回值:
+//   - `grpc.UnaryClientInterceptor`: 返回配置好的 gRPC 客户端一元拦截器实例。
+func TraceClient() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		spanCtx, span := xGrpcUtil.StartClientSpan(ctx, method)
+		err := invoker(spanCtx, method, req, reply, cc, opts...)
+		xGrpcUtil.EndSpan(span, err)
+		return err
+	}
+}
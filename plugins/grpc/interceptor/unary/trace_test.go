@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	xTrace "github.com/bamboo-services/bamboo-base-go/common/trace"
 	xCtxUtil "github.com/bamboo-services/bamboo-base-go/common/utility/context"
 	xCtx "github.com/bamboo-services/bamboo-base-go/defined/context"
 	"google.golang.org/grpc"
@@ -98,3 +99,66 @@ func TestTraceReuseIncomingRequestUUID(t *testing.T) {
 		t.Fatalf("trace interceptor should not return error: %v", err)
 	}
 }
+
+func TestTraceContinueIncomingTraceParent(t *testing.T) {
+	parent := "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	stream := &mockServerTransportStream{method: "/x.Base/Test"}
+
+	incomingMD := metadata.Pairs("traceparent", parent, "tracestate", "vendor=abc")
+	ctx := metadata.NewIncomingContext(context.Background(), incomingMD)
+	ctx = grpc.NewContextWithServerTransportStream(ctx, stream)
+
+	var span xTrace.SpanContext
+	interceptor := Trace()
+	_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: stream.method}, func(handlerCtx context.Context, req interface{}) (interface{}, error) {
+		var ok bool
+		span, ok = xTrace.FromContext(handlerCtx)
+		if !ok {
+			t.Fatalf("span context should be injected")
+		}
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatalf("trace interceptor should not return error: %v", err)
+	}
+
+	if span.TraceID.String() != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Fatalf("trace id should continue incoming traceparent, got: %s", span.TraceID)
+	}
+	if span.ParentID.String() != "00f067aa0ba902b7" {
+		t.Fatalf("parent id should be incoming span id, got: %s", span.ParentID)
+	}
+	if span.TraceState != "vendor=abc" {
+		t.Fatalf("tracestate should be propagated, got: %s", span.TraceState)
+	}
+
+	headerValues := stream.header.Get("traceparent")
+	if len(headerValues) == 0 || headerValues[0] != span.TraceParent() {
+		t.Fatalf("header traceparent should equal current span")
+	}
+}
+
+func TestTraceClientInjectOutgoing(t *testing.T) {
+	span := xTrace.NewRoot()
+	ctx := xTrace.WithContext(context.Background(), span)
+
+	interceptor := TraceClient()
+	err := interceptor(ctx, "/x.Base/Test", nil, nil, nil, func(invokeCtx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		md, _ := metadata.FromOutgoingContext(invokeCtx)
+		values := md.Get("traceparent")
+		if len(values) != 1 {
+			t.Fatalf("outgoing traceparent should be set once, got: %v", values)
+		}
+		sent, ok := xTrace.ParseTraceParent(values[0])
+		if !ok || sent.TraceID != span.TraceID || sent.SpanID == span.SpanID {
+			t.Fatalf("outgoing traceparent should be a client span in the same trace, got: %s", values[0])
+		}
+		if client, _ := xTrace.FromContext(invokeCtx); client.ParentID != span.SpanID {
+			t.Fatalf("client span should be child of current span")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("trace client interceptor should not return error: %v", err)
+	}
+}
@@ -8,11 +8,15 @@ import (
 	"strconv"
 	"time"
 
+	xHealth "github.com/bamboo-services/bamboo-base-go/common/health"
+	xLifecycle "github.com/bamboo-services/bamboo-base-go/common/lifecycle"
 	xLog "github.com/bamboo-services/bamboo-base-go/common/log"
 	xEnv "github.com/bamboo-services/bamboo-base-go/defined/env"
 	xGrpcIStream "github.com/bamboo-services/bamboo-base-go/plugins/grpc/interceptor/stream"
 	xGrpcIUnary "github.com/bamboo-services/bamboo-base-go/plugins/grpc/interceptor/unary"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 )
 
@@ -32,6 +36,8 @@ type Config struct {
 	UnaryInterceptors   []grpc.UnaryServerInterceptor
 	StreamInterceptors  []grpc.StreamServerInterceptor
 	ServerOptions       []grpc.ServerOption
+	Health              *xHealth.Registry
+	HealthInterval      time.Duration
 }
 
 // New 返回一个可直接挂载到 xMain.Runner 附加协程中的 gRPC 启动函数。
@@ -66,6 +72,23 @@ func WithGracefulStopTimeout(timeout time.Duration) Option {
 	}
 }
 
+// WithHealth 注册 gRPC 标准健康服务（grpc.health.v1.Health），由 registry 的检查结果驱动。
+//
+// 每隔 interval（<= 0 时为 5s）执行一次 registry.Ready，同步更新整体（""）与各已注册服务的状态；
+// 进入关闭流程时先全部置为 NOT_SERVING，再优雅关闭服务。
+// 通常传入 reg.Health()，与 HTTP 的 /readyz 共用同一组检查。
+func WithHealth(registry *xHealth.Registry, interval time.Duration) Option {
+	return func(config *Config) {
+		if registry == nil {
+			return
+		}
+		config.Health = registry
+		if interval > 0 {
+			config.HealthInterval = interval
+		}
+	}
+}
+
 // WithLogger 设置 gRPC Runner 日志器。
 func WithLogger(logger *xLog.LogNamedLogger) Option {
 	return func(config *Config) {
@@ -125,6 +148,7 @@ func WithServerOptions(options ...grpc.ServerOption) Option {
 func defaultConfig() Config {
 	return Config{
 		GracefulStopTimeout: 30 * time.Second,
+		HealthInterval:      5 * time.Second,
 		Logger:              xLog.WithName(xLog.NamedGRPC),
 		RegisterServices:    make([]RegisterServiceFunc, 0),
 		UnaryInterceptors:   make([]grpc.UnaryServerInterceptor, 0),
@@ -168,6 +192,10 @@ func run(ctx context.Context, config Config) {
 		log = xLog.WithName(xLog.NamedGRPC)
 	}
 
+	// 加入 gRPC 关闭阶段：xMain.Runner 在 HTTP 服务关闭后、运行期上下文取消前通知停止
+	member := xLifecycle.Join(xLifecycle.PhaseGRPC)
+	defer member.Leave()
+
 	listener, err := net.Listen("tcp", address)
 	if err != nil {
 		log.Panic(ctx, "gRPC 服务监听失败",
@@ -184,8 +212,9 @@ func run(ctx context.Context, config Config) {
 	serverOptionList = append(serverOptionList, config.ServerOptions...)
 
 	// Unary 拦截器链
-	unaryInterceptorList := make([]grpc.UnaryServerInterceptor, 0, len(config.UnaryInterceptors)+4)
+	unaryInterceptorList := make([]grpc.UnaryServerInterceptor, 0, len(config.UnaryInterceptors)+5)
 	unaryInterceptorList = append(unaryInterceptorList, xGrpcIUnary.InitContext(ctx))
+	unaryInterceptorList = append(unaryInterceptorList, xGrpcIUnary.Metrics())
 	unaryInterceptorList = append(unaryInterceptorList, xGrpcIUnary.Recover())
 	unaryInterceptorList = append(unaryInterceptorList, xGrpcIUnary.Trace())
 	unaryInterceptorList = append(unaryInterceptorList, config.UnaryInterceptors...)
@@ -195,8 +224,9 @@ func run(ctx context.Context, config Config) {
 	}
 
 	// Stream 拦截器链
-	streamInterceptorList := make([]grpc.StreamServerInterceptor, 0, len(config.StreamInterceptors)+4)
+	streamInterceptorList := make([]grpc.StreamServerInterceptor, 0, len(config.StreamInterceptors)+5)
 	streamInterceptorList = append(streamInterceptorList, xGrpcIStream.InitContext(ctx))
+	streamInterceptorList = append(streamInterceptorList, xGrpcIStream.Metrics())
 	streamInterceptorList = append(streamInterceptorList, xGrpcIStream.Recover())
 	streamInterceptorList = append(streamInterceptorList, xGrpcIStream.Trace())
 	streamInterceptorList = append(streamInterceptorList, config.StreamInterceptors...)
@@ -215,6 +245,12 @@ func run(ctx context.Context, config Config) {
 	if reflectionEnabled {
 		reflection.Register(grpcServer)
 	}
+	var healthServer *health.Server
+	if config.Health != nil {
+		healthServer = health.NewServer()
+		healthpb.RegisterHealthServer(grpcServer, healthServer)
+		go watchHealth(ctx, member.Stopping(), grpcServer, healthServer, config.Health, config.HealthInterval)
+	}
 
 	errChan := make(chan error, 1)
 	go func() {
@@ -225,6 +261,14 @@ func run(ctx context.Context, config Config) {
 
 	select {
 	case <-ctx.Done():
+		shutdownHealth(healthServer)
+		gracefulStop(grpcServer, config.GracefulStopTimeout)
+		if serveErr := <-errChan; serveErr != nil && !errors.Is(serveErr, grpc.ErrServerStopped) {
+			log.Error(ctx, "gRPC 服务退出异常", slog.String("error", serveErr.Error()))
+		}
+		log.Info(ctx, "gRPC 服务已退出", slog.String("addr", address))
+	case <-member.Stopping():
+		shutdownHealth(healthServer)
 		gracefulStop(grpcServer, config.GracefulStopTimeout)
 		if serveErr := <-errChan; serveErr != nil && !errors.Is(serveErr, grpc.ErrServerStopped) {
 			log.Error(ctx, "gRPC 服务退出异常", slog.String("error", serveErr.Error()))
@@ -239,6 +283,42 @@ func run(ctx context.Context, config Config) {
 	}
 }
 
+// watchHealth 按 interval 执行健康检查并同步到 gRPC 健康服务，ctx 结束或进入关闭阶段时退出。
+func watchHealth(ctx context.Context, stopping <-chan struct{}, server *grpc.Server, healthServer *health.Server, registry *xHealth.Registry, interval time.Duration) {
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		status := healthpb.HealthCheckResponse_NOT_SERVING
+		if registry.Ready(ctx) {
+			status = healthpb.HealthCheckResponse_SERVING
+		}
+		healthServer.SetServingStatus("", status)
+		for name := range server.GetServiceInfo() {
+			if name != healthpb.Health_ServiceDesc.ServiceName {
+				healthServer.SetServingStatus(name, status)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-stopping:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// shutdownHealth 将 gRPC 健康服务的全部状态置为 NOT_SERVING，后续状态更新将被忽略
+func shutdownHealth(healthServer *health.Server) {
+	if healthServer != nil {
+		healthServer.Shutdown()
+	}
+}
+
 func resolveGrpcAddress() string {
 	port := xEnv.GetEnvInt(xEnv.GrpcPort, 1119)
 	if port <= 0 || port > 65535 {
@@ -0,0 +1,49 @@
+package xGrpcUtil
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+
+	xAuth "github.com/bamboo-services/bamboo-base-go/common/auth"
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xJwt "github.com/bamboo-services/bamboo-base-go/common/jwt"
+	xLog "github.com/bamboo-services/bamboo-base-go/common/log"
+	xGrpcConst "github.com/bamboo-services/bamboo-base-go/plugins/grpc/constant"
+	"google.golang.org/grpc/metadata"
+)
+
+// Authenticate 校验 authorization 元数据中的 Bearer 访问令牌，返回写入已认证主体的上下文。
+//
+// 一元与流式认证拦截器共用本函数，错误映射与 HTTP 侧的 xMiddle.Auth 一致：
+//   - 未携带令牌：xError.TokenMissing
+//   - 令牌过期：xError.TokenExpired
+//   - 签名、算法、签发方、受众错误或已吊销：xError.TokenInvalid
+//   - 吊销列表不可用：xError.ServerInternalError
+func Authenticate(ctx context.Context, manager *xJwt.Manager) (context.Context, error) {
+	var token string
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get(xGrpcConst.MetadataAuthorization.String()); len(values) > 0 {
+		token = strings.TrimSpace(values[0])
+		if len(token) > 7 && strings.EqualFold(token[:7], "Bearer ") {
+			token = strings.TrimSpace(token[7:])
+		}
+	}
+
+	claims, err := manager.Verify(ctx, token)
+	if err != nil {
+		switch {
+		case errors.Is(err, xJwt.ErrTokenMissing):
+			return ctx, statusError(ctx, xError.TokenMissing, xError.ErrMessage(err.Error()))
+		case errors.Is(err, xJwt.ErrTokenExpired):
+			return ctx, statusError(ctx, xError.TokenExpired, xError.ErrMessage(err.Error()))
+		case errors.Is(err, xJwt.ErrTokenInvalid):
+			return ctx, statusError(ctx, xError.TokenInvalid, xError.ErrMessage(err.Error()))
+		default:
+			xLog.WithName(xLog.NamedGRPC).Error(ctx, "令牌校验失败", slog.Any("error", err))
+			return ctx, statusError(ctx, xError.ServerInternalError, "令牌校验失败")
+		}
+	}
+	return xAuth.WithPrincipal(ctx, claims.Principal()), nil
+}
@@ -0,0 +1,49 @@
+package xGrpcUtil
+
+import (
+	"context"
+	"strings"
+
+	xAuth "github.com/bamboo-services/bamboo-base-go/common/auth"
+	xAuthz "github.com/bamboo-services/bamboo-base-go/common/authz"
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+)
+
+// MethodRequirements 服务方法声明的访问要求。
+//
+// 键为完整方法名（如 "/pkg.OrderService/Delete"）或服务通配（如 "/pkg.OrderService/*"），
+// 完整方法名优先；未声明的方法不做授权校验。
+type MethodRequirements map[string]xAuthz.Requirement
+
+// lookup 查找方法的访问要求，先精确匹配再按服务通配匹配
+func (m MethodRequirements) lookup(fullMethod string) (xAuthz.Requirement, bool) {
+	if need, ok := m[fullMethod]; ok {
+		return need, true
+	}
+	if i := strings.LastIndex(fullMethod, "/"); i > 0 {
+		need, ok := m[fullMethod[:i+1]+"*"]
+		return need, ok
+	}
+	return xAuthz.Requirement{}, false
+}
+
+// NewAuthorizeCheck 构造一元与流式授权拦截器共用的判定函数，放行时返回 nil。
+//
+// 请求属性仅包含 "method"（完整方法名），可用于策略条件；拒绝时返回由 xAuthz.ErrorCode 映射的 gRPC status error，
+// 未认证为 Unauthenticated，其余为 PermissionDenied。
+func NewAuthorizeCheck(enforcer *xAuthz.Enforcer, methods MethodRequirements) func(ctx context.Context, fullMethod string) error {
+	if enforcer == nil {
+		panic("xGrpcUtil: 授权拦截器的 enforcer 不能为 nil")
+	}
+	return func(ctx context.Context, fullMethod string) error {
+		need, ok := methods.lookup(fullMethod)
+		if !ok {
+			return nil
+		}
+		principal, _ := xAuth.FromContext(ctx)
+		if err := enforcer.Authorize(ctx, principal, need, map[string]string{"method": fullMethod}); err != nil {
+			return statusError(ctx, xAuthz.ErrorCode(err), xError.ErrMessage(err.Error()))
+		}
+		return nil
+	}
+}
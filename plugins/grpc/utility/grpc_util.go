@@ -6,8 +6,10 @@ import (
 	"strings"
 
 	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xGrpc "github.com/bamboo-services/bamboo-base-go/plugins/grpc"
 	xGrpcConst "github.com/bamboo-services/bamboo-base-go/plugins/grpc/constant"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 // ExtractMetadata 从 gRPC 传入上下文中提取指定键的元数据值
@@ -36,3 +38,9 @@ func ExtractMetadata(ctx context.Context, key xGrpcConst.Metadata) (string, *xEr
 	}
 	return "", xError.NewError(ctx, xError.NotExist, xError.ErrMessage(fmt.Sprintf("元数据中不存在有效值: %s", key.String())), false)
 }
+
+// statusError 以错误码构造 *xError.Error 并映射为 gRPC status error，供拦截器直接返回
+func statusError(ctx context.Context, code *xError.ErrorCode, message xError.ErrMessage) error {
+	xErr := xError.NewError(ctx, code, message, false)
+	return status.Error(xGrpc.ToGrpcStatusCode(code.Code), xErr.Error())
+}
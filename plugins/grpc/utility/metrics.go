@@ -0,0 +1,43 @@
+package xGrpcUtil
+
+import (
+	"strings"
+	"time"
+
+	xMetrics "github.com/bamboo-services/bamboo-base-go/common/metrics"
+	"google.golang.org/grpc/status"
+)
+
+// gRPC 服务端指标，一元与流式拦截器共用
+var (
+	grpcHandled  = xMetrics.NewCounter("grpc_server_handled_total", "gRPC 服务端处理完成的调用数", "type", "service", "method", "code")
+	grpcDuration = xMetrics.NewHistogram("grpc_server_handling_seconds", "gRPC 服务端调用耗时（秒）", nil, "type", "service", "method")
+	grpcInFlight = xMetrics.NewGauge("grpc_server_in_flight", "gRPC 服务端正在处理的调用数", "type")
+)
+
+// StartServerMetrics 记录一次服务端调用开始，返回在调用结束时传入错误的回调
+//
+// callType 为 "unary" 或 "stream"；fullMethod 形如 "/pkg.Service/Method"，拆分为 service 与 method 标签，
+// code 标签取 status.Code(err)。
+func StartServerMetrics(callType, fullMethod string) func(err error) {
+	start := time.Now()
+	inFlight := grpcInFlight.With(callType)
+	inFlight.Inc()
+	service, method := SplitMethod(fullMethod)
+	return func(err error) {
+		inFlight.Dec()
+		grpcHandled.Inc(callType, service, method, status.Code(err).String())
+		grpcDuration.Observe(time.Since(start).Seconds(), callType, service, method)
+	}
+}
+
+// SplitMethod 将 "/pkg.Service/Method" 形式的完整方法名拆分为服务名与方法名
+//
+// 格式不符时服务名为 "unknown"，方法名为去掉前导斜杠后的原值。
+func SplitMethod(fullMethod string) (service, method string) {
+	name := strings.TrimPrefix(fullMethod, "/")
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		return name[:i], name[i+1:]
+	}
+	return "unknown", name
+}
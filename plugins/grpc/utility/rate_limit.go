@@ -0,0 +1,143 @@
+package xGrpcUtil
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	xError "github.com/bamboo-services/bamboo-base-go/common/error"
+	xLog "github.com/bamboo-services/bamboo-base-go/common/log"
+	xRateLimit "github.com/bamboo-services/bamboo-base-go/common/ratelimit"
+	xGrpcConst "github.com/bamboo-services/bamboo-base-go/plugins/grpc/constant"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// RateLimitKeyFunc 从 gRPC 调用中提取限流维度，返回空字符串表示本次调用不参与限流。
+type RateLimitKeyFunc func(ctx context.Context, fullMethod string) string
+
+// RateLimitByPeer 按客户端地址（不含端口）限流。
+func RateLimitByPeer() RateLimitKeyFunc {
+	return func(ctx context.Context, _ string) string {
+		p, ok := peer.FromContext(ctx)
+		if !ok || p.Addr == nil {
+			return ""
+		}
+		addr := p.Addr.String()
+		if host, _, err := net.SplitHostPort(addr); err == nil {
+			addr = host
+		}
+		return "ip:" + addr
+	}
+}
+
+// RateLimitByMethod 按完整方法名（如 "/pkg.Service/Method"）限流。
+func RateLimitByMethod() RateLimitKeyFunc {
+	return func(_ context.Context, fullMethod string) string {
+		return "route:" + fullMethod
+	}
+}
+
+// RateLimitByMetadata 按请求元数据限流，如用户标识或 app-access-id；元数据缺失时不参与限流。
+func RateLimitByMetadata(key xGrpcConst.Metadata) RateLimitKeyFunc {
+	return func(ctx context.Context, _ string) string {
+		md, _ := metadata.FromIncomingContext(ctx)
+		for _, v := range md.Get(key.String()) {
+			if trimmed := strings.TrimSpace(v); trimmed != "" {
+				return key.String() + ":" + trimmed
+			}
+		}
+		return ""
+	}
+}
+
+// RateLimitKeys 组合多个维度，任一维度为空时本次调用不参与限流。
+func RateLimitKeys(keys ...RateLimitKeyFunc) RateLimitKeyFunc {
+	return func(ctx context.Context, fullMethod string) string {
+		parts := make([]string, 0, len(keys))
+		for _, key := range keys {
+			part := key(ctx, fullMethod)
+			if part == "" {
+				return ""
+			}
+			parts = append(parts, part)
+		}
+		return strings.Join(parts, "|")
+	}
+}
+
+// rateLimitConfig 限流拦截器配置
+type rateLimitConfig struct {
+	errorCode  *xError.ErrorCode
+	failClosed bool
+}
+
+// RateLimitOption 是限流拦截器的函数式选项。
+type RateLimitOption func(*rateLimitConfig)
+
+// WithRateLimitErrorCode 设置超出限流时返回的错误码，默认 xError.LimitExceeded。
+func WithRateLimitErrorCode(code *xError.ErrorCode) RateLimitOption {
+	return func(c *rateLimitConfig) { c.errorCode = code }
+}
+
+// WithRateLimitFailClosed 限流后端不可用时拒绝调用并返回 xError.AccessLimited，默认记录告警后放行。
+func WithRateLimitFailClosed() RateLimitOption {
+	return func(c *rateLimitConfig) { c.failClosed = true }
+}
+
+// NewRateLimitCheck 构造一元与流式限流拦截器共用的判定函数，放行时返回 nil。
+//
+// 参与限流的调用都会在响应 header 中写入 x-ratelimit-limit / x-ratelimit-remaining / x-ratelimit-reset，
+// 超限时追加 retry-after 并返回由错误码映射的 gRPC status error。
+func NewRateLimitCheck(limiter xRateLimit.Limiter, key RateLimitKeyFunc, opts ...RateLimitOption) func(ctx context.Context, fullMethod string) error {
+	if limiter == nil || key == nil {
+		panic("xGrpcUtil: 限流拦截器的 limiter 与 key 不能为 nil")
+	}
+	cfg := rateLimitConfig{errorCode: xError.LimitExceeded}
+	for _, o := range opts {
+		if o != nil {
+			o(&cfg)
+		}
+	}
+	log := xLog.WithName(xLog.NamedGRPC)
+
+	return func(ctx context.Context, fullMethod string) error {
+		k := key(ctx, fullMethod)
+		if k == "" {
+			return nil
+		}
+
+		result, err := limiter.Allow(ctx, k)
+		if err != nil {
+			if cfg.failClosed {
+				return statusError(ctx, xError.AccessLimited, "限流服务不可用")
+			}
+			log.Warn(ctx, "限流判定失败，已放行调用", slog.String("key", k), slog.String("method", fullMethod), slog.Any("error", err))
+			return nil
+		}
+
+		md := metadata.Pairs(
+			xGrpcConst.MetadataRateLimitLimit.String(), strconv.Itoa(result.Limit),
+			xGrpcConst.MetadataRateLimitRemaining.String(), strconv.Itoa(result.Remaining),
+			xGrpcConst.MetadataRateLimitReset.String(), strconv.Itoa(ceilSeconds(result.ResetAfter)),
+		)
+		if !result.Allowed {
+			md.Set(xGrpcConst.MetadataRetryAfter.String(), strconv.Itoa(max(1, ceilSeconds(result.RetryAfter))))
+		}
+		_ = grpc.SetHeader(ctx, md)
+		if !result.Allowed {
+			return statusError(ctx, cfg.errorCode, "请求过于频繁，请稍后再试")
+		}
+		return nil
+	}
+}
+
+// ceilSeconds 将时长向上取整为秒
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,94 @@
+package xGrpcUtil
+
+import (
+	"context"
+	"log/slog"
+
+	xTrace "github.com/bamboo-services/bamboo-base-go/common/trace"
+	xGrpcConst "github.com/bamboo-services/bamboo-base-go/plugins/grpc/constant"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// ExtractParentSpan 从 gRPC 传入元数据中解析上游的 W3C 链路上下文
+//
+// 元数据不含有效 `traceparent` 时返回 false，调用方应创建新的根 span。
+func ExtractParentSpan(ctx context.Context) (xTrace.SpanContext, bool) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	return xTrace.ExtractParent(
+		firstValue(md, xGrpcConst.MetadataTraceParent),
+		firstValue(md, xGrpcConst.MetadataTraceState),
+	)
+}
+
+// StartServerSpan 为 gRPC 服务端调用创建 span，沿用元数据中的上游链路
+//
+// span 名称为完整方法名，携带 rpc.system 与 rpc.method 属性，返回的 ctx 已写入新 span。
+func StartServerSpan(ctx context.Context, fullMethod string) (context.Context, *xTrace.Span) {
+	parent, _ := ExtractParentSpan(ctx)
+	return xTrace.Start(ctx, fullMethod,
+		xTrace.WithKind(xTrace.KindServer),
+		xTrace.WithParent(parent),
+		xTrace.WithAttrs(
+			slog.String("rpc.system", "grpc"),
+			slog.String("rpc.method", fullMethod),
+		),
+	)
+}
+
+// StartClientSpan 为 gRPC 客户端调用创建 span，并将其链路写入传出元数据
+func StartClientSpan(ctx context.Context, fullMethod string) (context.Context, *xTrace.Span) {
+	ctx, span := xTrace.Start(ctx, fullMethod,
+		xTrace.WithKind(xTrace.KindClient),
+		xTrace.WithAttrs(
+			slog.String("rpc.system", "grpc"),
+			slog.String("rpc.method", fullMethod),
+		),
+	)
+	return InjectOutgoing(ctx), span
+}
+
+// EndSpan 按 gRPC 状态码记录结果并结束 span
+func EndSpan(span *xTrace.Span, err error) {
+	code := status.Code(err)
+	span.SetAttrs(slog.String("rpc.grpc.status_code", code.String()))
+	if err != nil {
+		span.SetStatus(xTrace.StatusError, status.Convert(err).Message())
+	}
+	span.End()
+}
+
+// TraceMetadata 将链路上下文编码为 `traceparent`/`tracestate` 元数据
+func TraceMetadata(sc xTrace.SpanContext) metadata.MD {
+	md := metadata.MD{}
+	xTrace.Inject(sc, func(key, value string) {
+		md.Set(key, value)
+	})
+	return md
+}
+
+// InjectOutgoing 将 ctx 中的链路上下文追加到 gRPC 传出元数据，供调用下游服务时透传
+//
+// ctx 中不存在链路上下文或传出元数据已包含 `traceparent` 时原样返回。
+func InjectOutgoing(ctx context.Context) context.Context {
+	sc, ok := xTrace.FromContext(ctx)
+	if !ok {
+		return ctx
+	}
+	if md, exists := metadata.FromOutgoingContext(ctx); exists && len(md.Get(xGrpcConst.MetadataTraceParent.String())) > 0 {
+		return ctx
+	}
+	pairs := make([]string, 0, 4)
+	xTrace.Inject(sc, func(key, value string) {
+		pairs = append(pairs, key, value)
+	})
+	return metadata.AppendToOutgoingContext(ctx, pairs...)
+}
+
+// firstValue 返回元数据键的第一个值
+func firstValue(md metadata.MD, key xGrpcConst.Metadata) string {
+	if values := md.Get(key.String()); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}